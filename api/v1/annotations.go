@@ -10,6 +10,81 @@ const (
 	// Supported values: "mark" (default) - only mark the branch as stale;
 	// "auto" - delete the stale branch when it is not referenced by any CDPipeline/Stage.
 	BranchCleanupStrategyAnnotation = "app.edp.epam.com/branch-cleanup-strategy"
+
+	// BranchDiscoveryIncludeAnnotation is an annotation on a Codebase CR that opts it in to
+	// branch discovery: the operator creates a CodebaseBranch for every remote branch that
+	// matches one of these comma-separated glob patterns (path.Match syntax, e.g. "feature/*")
+	// and has no CodebaseBranch yet.
+	BranchDiscoveryIncludeAnnotation = "app.edp.epam.com/branch-discovery-include"
+
+	// BranchDiscoveryExcludeAnnotation is an annotation on a Codebase CR with comma-separated
+	// glob patterns of remote branches that branch discovery must skip even when they match
+	// BranchDiscoveryIncludeAnnotation.
+	BranchDiscoveryExcludeAnnotation = "app.edp.epam.com/branch-discovery-exclude"
+
+	// PreviewEnvironmentsAnnotation is an annotation on a Codebase CR that opts it in to preview
+	// environments: when set to "true", the operator creates a CodebaseBranch for the source
+	// branch of every opened pull request and removes it when the pull request is closed.
+	PreviewEnvironmentsAnnotation = "app.edp.epam.com/preview-environments"
+
+	// PreviewStageAnnotation is an annotation on a Codebase CR, copied to its preview
+	// CodebaseBranches, with the "<cdpipeline>/<stage>" the preview images are deployed to.
+	// The operator puts it on the CodebaseImageStream of the preview branch as an env label.
+	PreviewStageAnnotation = "app.edp.epam.com/preview-stage"
+
+	// PreviewTTLAnnotation is an annotation on a Codebase CR with the lifetime of its preview
+	// CodebaseBranches as a Go duration (e.g. "72h"). Previews whose pull request stays open
+	// longer are removed anyway. Defaults to 72h.
+	PreviewTTLAnnotation = "app.edp.epam.com/preview-ttl"
+
+	// PreviewExpiresAtAnnotation is an annotation on a preview CodebaseBranch with the time
+	// (RFC 3339) after which the operator removes it.
+	PreviewExpiresAtAnnotation = "app.edp.epam.com/preview-expires-at"
+
+	// VersionHistoryLimitAnnotation is an annotation on a Codebase CR with the number of the
+	// latest versions its CodebaseBranches keep in status.versionHistory; "0" keeps all of them.
+	// Defaults to 100.
+	VersionHistoryLimitAnnotation = "app.edp.epam.com/version-history-limit"
+
+	// BuildHistoryLimitAnnotation is an annotation on a Codebase CR with the number of the
+	// latest builds its CodebaseBranches keep in status.builds; "0" keeps all of them.
+	// Defaults to 20.
+	BuildHistoryLimitAnnotation = "app.edp.epam.com/build-history-limit"
+
+	// BuildReportAnnotationPrefix prefixes the annotations CI sets on a CodebaseBranch to report
+	// a build: the key is the prefix followed by the build number, the value is a JSON-encoded
+	// BuildRecord. The operator moves reports to status.builds and removes the annotations, so
+	// that concurrent builds never overwrite each other's reports.
+	BuildReportAnnotationPrefix = "build-report.app.edp.epam.com/"
+
+	// GitLabTokenRotationAnnotation is an annotation on a GitLab GitServer CR that enables rotation
+	// of the access token in its secret. The value is the lifetime of a rotated token, e.g. "720h",
+	// rounded up to whole days; the token is rotated when a third of its lifetime is left.
+	GitLabTokenRotationAnnotation = "app.edp.epam.com/gitlab-token-rotation"
+
+	// TokenExpiresAtAnnotation is an annotation on a GitServer secret with the time (RFC 3339)
+	// the access token the operator minted or rotated expires at.
+	TokenExpiresAtAnnotation = "app.edp.epam.com/token-expires-at"
+
+	// RotateWebhookSecretAnnotation is an annotation on a GitServer CR that requests a new
+	// webhook secret. The operator generates it, reconfigures the webhooks of all codebases
+	// of the GitServer and removes the annotation.
+	RotateWebhookSecretAnnotation = "app.edp.epam.com/rotate-webhook-secret"
+
+	// ApproveHostKeyAnnotation is an annotation on a GitServer CR that approves the SSH host key
+	// in status.pendingHostKey. The value must be its fingerprint. The operator adds the key to
+	// the managed known_hosts Secret and removes the annotation.
+	ApproveHostKeyAnnotation = "app.edp.epam.com/approve-host-key"
+
+	// ReleaseNotesAnnotation is an annotation on a CodebaseBranch CR that requests the release notes
+	// of the version in its value, e.g. the last version of a release branch. The operator generates
+	// them, also when they exist, and removes the annotation.
+	ReleaseNotesAnnotation = "app.edp.epam.com/release-notes"
+
+	// RegistryTagDiscoveryAnnotation is an annotation on a CodebaseImageStream CR that enables
+	// tag discovery: when "true", the operator lists the tags of spec.imageName in the registry
+	// and adds the ones missing in spec.tags.
+	RegistryTagDiscoveryAnnotation = "app.edp.epam.com/registry-tag-discovery"
 )
 
 const (
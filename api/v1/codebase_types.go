@@ -35,6 +35,13 @@ const (
 	VersioningTypeSemver VersioningType = "semver"
 )
 
+// Issue trackers of codebases.
+const (
+	IssueTrackerJira   = "jira"
+	IssueTrackerGitHub = "github"
+	IssueTrackerGitLab = "gitlab"
+)
+
 type Versioning struct {
 	Type VersioningType `json:"type"`
 
@@ -116,6 +123,25 @@ type CodebaseSpec struct {
 	// +optional
 	JiraIssueMetadataPayload *string `json:"jiraIssueMetadataPayload"`
 
+	// JiraTransitions move the Jira issues of deployed builds through the workflow.
+	// When set, they are used instead of the transitions of the JiraServer.
+	// +optional
+	JiraTransitions []JiraTransition `json:"jiraTransitions,omitempty"`
+
+	// IssueTracker is the tracker of the tickets of the codebase.
+	// Defaults to jira when jiraServer is set. github and gitlab use the issues of the codebase repository
+	// with the credentials of its GitServer. Tickets are then referenced as #123.
+	// +optional
+	// +kubebuilder:validation:Enum=jira;github;gitlab
+	IssueTracker string `json:"issueTracker,omitempty"`
+
+	// CommitPolicy enforces commitMessagePattern and ticketNamePattern on the commits of pushes
+	// and pull requests, and reports the result to the git provider as a commit status.
+	// Requires the branch events endpoint of the operator.
+	// +nullable
+	// +optional
+	CommitPolicy *CommitPolicy `json:"commitPolicy,omitempty"`
+
 	// A flag indicating how project should be provisioned. Default: false
 	EmptyProject bool `json:"emptyProject"`
 
@@ -139,6 +165,18 @@ type CodebaseSpec struct {
 	CloneRepositoryCredentials *CloneRepositoryCredentials `json:"cloneRepositoryCredentials,omitempty"`
 }
 
+// CommitPolicy is the policy the commit messages of a codebase follow.
+// Every commit message must match commitMessagePattern, if it is set.
+type CommitPolicy struct {
+	// RequireTicket requires every commit message to reference a ticket that matches ticketNamePattern.
+	// +optional
+	RequireTicket bool `json:"requireTicket,omitempty"`
+
+	// VerifyTickets checks that the referenced tickets exist in the issue tracker of the codebase.
+	// +optional
+	VerifyTickets bool `json:"verifyTickets,omitempty"`
+}
+
 type CloneRepositoryCredentials struct {
 	// SecretRef is a reference to secret that contains credentials for cloning repository.
 	// The secret must contain "username" and "password" keys.
@@ -157,6 +195,19 @@ func (in *CodebaseSpec) GetProjectID() string {
 	return strings.TrimPrefix(in.GitUrlPath, "/")
 }
 
+// GetIssueTracker returns the issue tracker of the codebase or an empty string if it has none.
+func (in *CodebaseSpec) GetIssueTracker() string {
+	if in.IssueTracker != "" {
+		return in.IssueTracker
+	}
+
+	if in.JiraServer != nil && *in.JiraServer != "" {
+		return IssueTrackerJira
+	}
+
+	return ""
+}
+
 func (in *CodebaseSpec) IsVersionTypeSemver() bool {
 	// For backward compatibility, we should consider VersioningTypeEDP as VersioningTypeSemver.
 	return in.Versioning.Type == VersioningTypeSemver || in.Versioning.Type == VersioningTypeEDP
@@ -185,6 +236,7 @@ const (
 	PutGitBranch                     ActionType = "put_git_branch"
 	PutCodebaseImageStream           ActionType = "put_codebase_image_stream"
 	CheckCommitHashExists            ActionType = "check_commit_hash_exists"
+	CheckGitServer                   ActionType = "check_git_server"
 )
 
 // Result describes how action were performed.
@@ -248,6 +300,56 @@ type CodebaseStatus struct {
 	// Stores GitWebUrl of codebase.
 	// +optional
 	GitWebUrl string `json:"gitWebUrl,omitempty"`
+
+	// WebHook is the configuration of the webhook in the git provider as the operator last set
+	// or checked it.
+	// +optional
+	WebHook *CodebaseWebHookStatus `json:"webHook,omitempty"`
+}
+
+// CodebaseWebHookStatus is the configuration of the webhook of a codebase in the git provider.
+type CodebaseWebHookStatus struct {
+	// URL is the URL the webhook delivers to.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// SecretHash is a hash of the webhook secret the webhook was last configured with.
+	// +optional
+	SecretHash string `json:"secretHash,omitempty"`
+
+	// Drift lists the webhook settings that differed from the desired ones at the last check
+	// and could not be repaired: missing, url, events, sslVerification, inactive or secret.
+	// +optional
+	Drift []string `json:"drift,omitempty"`
+
+	// LastCheckTime is the time the webhook was last compared with the desired configuration.
+	// +optional
+	LastCheckTime *metaV1.Time `json:"lastCheckTime,omitempty"`
+
+	// Deliveries summarizes the recent deliveries of the webhook as the git provider reports them.
+	// Not set for git providers that do not report deliveries.
+	// +optional
+	Deliveries *CodebaseWebHookDeliveries `json:"deliveries,omitempty"`
+}
+
+// CodebaseWebHookDeliveries summarizes the recent deliveries of the webhook of a codebase.
+type CodebaseWebHookDeliveries struct {
+	// LastDeliveryTime is the time of the most recent delivery.
+	// +optional
+	LastDeliveryTime *metaV1.Time `json:"lastDeliveryTime,omitempty"`
+
+	// LastSuccessTime is the time of the most recent delivery the receiver accepted.
+	// +optional
+	LastSuccessTime *metaV1.Time `json:"lastSuccessTime,omitempty"`
+
+	// ConsecutiveFailures is the number of the most recent deliveries that failed in a row.
+	// It is counted within the page of recent deliveries the git provider returns.
+	// +optional
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// LastFailure describes the most recent failed delivery: the event and the response status.
+	// +optional
+	LastFailure string `json:"lastFailure,omitempty"`
 }
 
 func (in *CodebaseStatus) GetWebHookRef() string {
@@ -13,8 +13,51 @@ const (
 
 	ReasonBranchNotFoundInGit = "BranchNotFoundInGit"
 	ReasonBranchFoundInGit    = "BranchFoundInGit"
+
+	// ConditionPipelinesResolved is a condition type indicating whether every
+	// spec.pipelines binding refers to an existing Tekton Pipeline.
+	ConditionPipelinesResolved = "PipelinesResolved"
+
+	ReasonPipelinesFound    = "PipelinesFound"
+	ReasonPipelinesNotFound = "PipelinesNotFound"
+)
+
+// BuildResult is the outcome of a CI build.
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+type BuildResult string
+
+const (
+	BuildResultRunning   BuildResult = "Running"
+	BuildResultSucceeded BuildResult = "Succeeded"
+	BuildResultFailed    BuildResult = "Failed"
 )
 
+// BuildRecord describes a single CI build of the branch.
+type BuildRecord struct {
+	// Number is the build number.
+	Number int64 `json:"number"`
+
+	// Commit is the SHA of the commit that was built.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// PipelineRun is the name of the PipelineRun that ran the build.
+	// +optional
+	PipelineRun string `json:"pipelineRun,omitempty"`
+
+	// Result is the outcome of the build.
+	Result BuildResult `json:"result"`
+
+	// Timestamp is the time the build was reported.
+	// +optional
+	Timestamp metaV1.Time `json:"timestamp,omitempty"`
+
+	// Version is the version of the branch the build was made for.
+	// Build numbers restart with every version.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
 // CodebaseBranchSpec defines the desired state of CodebaseBranch.
 type CodebaseBranchSpec struct {
 	// Name of Codebase associated with.
@@ -63,6 +106,13 @@ type CodebaseBranchStatus struct {
 	// +optional
 	Build *string `json:"build,omitempty"`
 
+	// Builds are the latest CI builds of the branch, oldest first. CI reports them through
+	// build report annotations; the operator keeps only the most recent ones.
+	// +nullable
+	// +optional
+	// +listType=atomic
+	Builds []BuildRecord `json:"builds,omitempty"`
+
 	// Specifies a current status of CodebaseBranch.
 	Status string `json:"status"`
 
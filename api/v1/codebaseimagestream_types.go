@@ -16,12 +16,77 @@ type CodebaseImageStreamSpec struct {
 	// +nullable
 	// +optional
 	Tags []Tag `json:"tags,omitempty"`
+
+	// Retention is the policy that prunes old tags.
+	// +nullable
+	// +optional
+	Retention *TagRetention `json:"retention,omitempty"`
+
+	// LatestTag selects the tag CD pipelines deploy as the latest one.
+	// By default, it is the tag created last.
+	// +nullable
+	// +optional
+	LatestTag *LatestTagSelection `json:"latestTag,omitempty"`
+}
+
+// LatestTagStrategy is how the latest tag of a CodebaseImageStream is selected.
+// +kubebuilder:validation:Enum=created;semver
+type LatestTagStrategy string
+
+const (
+	// LatestTagStrategyCreated selects the tag with the latest creation time.
+	LatestTagStrategyCreated LatestTagStrategy = "created"
+
+	// LatestTagStrategySemver selects the tag with the highest semantic version.
+	// Tags that are not semantic versions are not selected.
+	LatestTagStrategySemver LatestTagStrategy = "semver"
+)
+
+// LatestTagSelection selects the latest tag of a CodebaseImageStream among the tags that
+// match the pattern. Tags with the same version are ordered by their creation time.
+type LatestTagSelection struct {
+	// Strategy is how the tags are ordered.
+	// +kubebuilder:default=created
+	// +optional
+	Strategy LatestTagStrategy `json:"strategy,omitempty"`
+
+	// Pattern is a regular expression the tags must match to be selected, e.g. ^\d+\.\d+\.\d+$.
+	// With the semver strategy, the first capture group of the pattern is the version
+	// when the pattern has one, e.g. ^main-(\d+\.\d+\.\d+)-\d+$.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// TagRetention is the retention policy of the tags of a CodebaseImageStream. A tag is kept
+// when any rule keeps it. The latest tag and the tags of CDStageDeploys are always kept.
+// Without keepLast and keepNewerThan, all tags are kept.
+type TagRetention struct {
+	// KeepLast is the number of the latest tags to keep.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	KeepLast *int `json:"keepLast,omitempty"`
+
+	// KeepNewerThan keeps the tags created within this duration, e.g. "720h".
+	// +optional
+	KeepNewerThan *metaV1.Duration `json:"keepNewerThan,omitempty"`
+
+	// DryRun reports the tags the policy would prune in status.retention without pruning them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeleteImages deletes the images of pruned tags from the registry.
+	// +optional
+	DeleteImages bool `json:"deleteImages,omitempty"`
 }
 
 type Tag struct {
 	Name    string `json:"name"`
 	Created string `json:"created"`
 	Digest  string `json:"digest,omitempty"`
+
+	// Tickets are the Jira issues of the build, recorded from JiraIssueMetadata.
+	// +optional
+	Tickets []string `json:"tickets,omitempty"`
 }
 
 // CodebaseImageStreamStatus defines the observed state of CodebaseImageStream.
@@ -33,6 +98,49 @@ type CodebaseImageStreamStatus struct {
 
 	// Amount of times, operator fail to serve with existing CR.
 	FailureCount int64 `json:"failureCount"`
+
+	// Retention is the result of the last run of the retention policy.
+	// +optional
+	Retention *TagRetentionStatus `json:"retention,omitempty"`
+
+	// DeployedTags are the tags last deployed to the stages of CD pipelines, one per stage.
+	// The retention policy keeps them.
+	// +optional
+	DeployedTags []DeployedTag `json:"deployedTags,omitempty"`
+}
+
+// DeployedTag is the tag last deployed to a stage of a CD pipeline.
+type DeployedTag struct {
+	// Stage is the name of the Stage, e.g. "mypipeline-dev".
+	Stage string `json:"stage"`
+
+	// Tag is the name of the deployed tag.
+	Tag string `json:"tag"`
+}
+
+// TagRetentionStatus is the result of a run of the retention policy.
+type TagRetentionStatus struct {
+	// LastRunTime is the time of the run.
+	LastRunTime metaV1.Time `json:"lastRunTime"`
+
+	// DryRun is set when the tags were only reported.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// PrunedCount is the number of tags the run pruned, or would prune in a dry run.
+	PrunedCount int `json:"prunedCount"`
+
+	// PrunedTags are the names of the pruned tags, up to 100 of them.
+	// +optional
+	PrunedTags []string `json:"prunedTags,omitempty"`
+
+	// DeletedImages are the digests of the images deleted from the registry.
+	// +optional
+	DeletedImages []string `json:"deletedImages,omitempty"`
+
+	// Error is the error of the run. Tags whose image could not be deleted are kept.
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // +kubebuilder:object:root=true
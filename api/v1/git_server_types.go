@@ -1,6 +1,8 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -11,6 +13,53 @@ const (
 	GitProviderBitbucket = "bitbucket"
 )
 
+const (
+	// GitServerConditionTokenValid is a condition type indicating whether the git provider API
+	// accepts the GitServer access token.
+	GitServerConditionTokenValid = "TokenValid"
+
+	// GitServerConditionPermissionsSufficient is a condition type indicating whether the access
+	// token has the scopes the operator needs to create repositories, manage webhooks and set
+	// default branches.
+	GitServerConditionPermissionsSufficient = "PermissionsSufficient"
+
+	// GitServerConditionRateLimitAvailable is a condition type indicating whether the access
+	// token has enough API requests left in the current rate limit window.
+	GitServerConditionRateLimitAvailable = "RateLimitAvailable"
+
+	ReasonTokenAccepted        = "TokenAccepted"
+	ReasonTokenRejected        = "TokenRejected"
+	ReasonAPIProbeFailed       = "APIProbeFailed"
+	ReasonScopesGranted        = "ScopesGranted"
+	ReasonScopesMissing        = "ScopesMissing"
+	ReasonScopesNotReported    = "ScopesNotReported"
+	ReasonRateLimitAvailable   = "RateLimitAvailable"
+	ReasonRateLimitLow         = "RateLimitLow"
+	ReasonRateLimitNotReported = "RateLimitNotReported"
+
+	// GitServerConditionWebhookReachable is a condition type indicating whether the webhook
+	// endpoint of the EventListener accepts a self-test delivery through its public URL.
+	GitServerConditionWebhookReachable = "WebhookReachable"
+
+	ReasonWebhookDelivered   = "WebhookDelivered"
+	ReasonWebhookUnreachable = "WebhookUnreachable"
+)
+
+const (
+	// EventListenerExposureIngress exposes the EventListener with a networking.k8s.io Ingress.
+	EventListenerExposureIngress = "Ingress"
+
+	// EventListenerExposureHTTPRoute exposes the EventListener with a Gateway API HTTPRoute.
+	EventListenerExposureHTTPRoute = "HTTPRoute"
+
+	// EventListenerExposureRoute exposes the EventListener with an OpenShift Route.
+	EventListenerExposureRoute = "Route"
+
+	// WebhookExposureExternal means that the webhook endpoint is set in spec.webhookUrl
+	// and is not managed by the operator.
+	WebhookExposureExternal = "External"
+)
+
 // GitServerSpec defines the desired state of GitServer.
 type GitServerSpec struct {
 	GitHost string `json:"gitHost"`
@@ -31,6 +80,8 @@ type GitServerSpec struct {
 	//   - id_rsa: SSH private key for Git operations over SSH
 	//   - secretString: Webhook secret for validating webhook requests
 	//   - username: Git username to override the default GitUser
+	//   - githubAppId, githubAppInstallationId, githubAppPrivateKey: GitHub App credentials;
+	//     the operator then mints the token itself and refreshes it before it expires
 	// For Gerrit provider, only id_rsa key is required and used.
 	// When id_rsa is present the operator connects over SSH and verifies the server's
 	// host key against the operator's ssh-known-hosts ConfigMap; hosts other than
@@ -61,6 +112,105 @@ type GitServerSpec struct {
 	// is used instead of Tekton, or when webhook endpoints are managed externally.
 	// +optional
 	TektonDisabled bool `json:"tektonDisabled,omitempty"`
+
+	// Proxy is the HTTP(S) proxy used to reach the git server over HTTPS, both for git
+	// operations and for the git provider API. If not set, the proxy environment variables
+	// of the operator apply.
+	// +optional
+	Proxy *GitServerProxy `json:"proxy,omitempty"`
+
+	// TLS configures the HTTPS connections to the git server, both for git operations and
+	// for the git provider API.
+	// +optional
+	TLS *GitServerTLS `json:"tls,omitempty"`
+
+	// EventListener configures how the Tekton EventListener of the GitServer is exposed
+	// outside the cluster. If not set, the exposure is chosen by the platform of the operator.
+	// +optional
+	EventListener *GitServerEventListener `json:"eventListener,omitempty"`
+}
+
+// GitServerEventListener configures how the Tekton EventListener is exposed outside the cluster.
+type GitServerEventListener struct {
+	// Exposure is the kind of resource that exposes the EventListener: Ingress, HTTPRoute or Route.
+	// If not set, Route is used on OpenShift, HTTPRoute if the operator runs with
+	// INGRESS_CONTROLLER_TYPE=envoy and Ingress otherwise.
+	// +kubebuilder:validation:Enum=Ingress;HTTPRoute;Route
+	// +optional
+	Exposure string `json:"exposure,omitempty"`
+
+	// IngressClassName is the IngressClass of the Ingress. If not set, the default IngressClass
+	// of the cluster is used.
+	// +optional
+	// +kubebuilder:example:=nginx
+	IngressClassName string `json:"ingressClassName,omitempty"`
+
+	// Gateway is the Gateway the HTTPRoute attaches to. If not set, the Gateway from the
+	// GATEWAY_NAME and GATEWAY_NAMESPACE environment variables of the operator is used.
+	// +optional
+	Gateway *GitServerGatewayRef `json:"gateway,omitempty"`
+
+	// TLS configures TLS of the EventListener endpoint.
+	// +optional
+	TLS *GitServerEventListenerTLS `json:"tls,omitempty"`
+}
+
+// GitServerGatewayRef is a reference to the Gateway an HTTPRoute attaches to.
+type GitServerGatewayRef struct {
+	// Name is the name of the Gateway.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway. Defaults to the namespace of the GitServer.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener to attach to.
+	// If not set, the HTTPRoute attaches to all listeners that allow it.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// GitServerEventListenerTLS configures TLS of the EventListener endpoint.
+type GitServerEventListenerTLS struct {
+	// Disabled serves the endpoint over plain HTTP. The webhook URL then has the http scheme.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// SecretName is the name of a kubernetes.io/tls Secret with the certificate for the
+	// EventListener host. It is set in the Ingress tls section or as the external certificate
+	// of the Route. For an HTTPRoute TLS is terminated by the Gateway listener and the Secret
+	// is not used. If not set, the default certificate of the ingress controller is served.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// GitServerProxy is the HTTP(S) proxy used to reach the git server.
+type GitServerProxy struct {
+	// URL is the URL of the proxy.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +kubebuilder:example:=`http://proxy.example.com:3128`
+	// +required
+	URL string `json:"url"`
+
+	// NoProxy is a comma-separated list of hosts, domain suffixes and CIDRs that are
+	// reached directly, in the NO_PROXY format.
+	// +optional
+	// +kubebuilder:example:=`.internal.example.com,10.0.0.0/8`
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// GitServerTLS configures the HTTPS connections to the git server.
+type GitServerTLS struct {
+	// CABundleSecretRef is a reference to a Secret with PEM CA certificates in the ca.crt key,
+	// trusted in addition to the system ones. Use it for a git server with a private CA.
+	// +optional
+	CABundleSecretRef *corev1.LocalObjectReference `json:"caBundleSecretRef,omitempty"`
+
+	// ClientCertSecretRef is a reference to a kubernetes.io/tls Secret with the client
+	// certificate (tls.crt) and private key (tls.key) for git servers that require mutual TLS.
+	// +optional
+	ClientCertSecretRef *corev1.LocalObjectReference `json:"clientCertSecretRef,omitempty"`
 }
 
 // GitServerStatus defines the observed state of GitServer.
@@ -77,6 +227,89 @@ type GitServerStatus struct {
 	// Possible values are: ok, failed.
 	// +optional
 	Status string `json:"status,omitempty"`
+
+	// API is what the git provider API reported about the access token when it was last probed.
+	// +optional
+	API *GitServerAPIStatus `json:"api,omitempty"`
+
+	// Webhook is the endpoint the git provider delivers webhooks to.
+	// +optional
+	Webhook *GitServerWebhookStatus `json:"webhook,omitempty"`
+
+	// PendingHostKey is the SSH host key offered by a host that is not in known_hosts.
+	// It is trusted once approved with the app.edp.epam.com/approve-host-key annotation.
+	// +optional
+	PendingHostKey *GitServerHostKey `json:"pendingHostKey,omitempty"`
+
+	// Conditions represent the latest available observations of the git provider API
+	// (TokenValid, PermissionsSufficient, RateLimitAvailable) and of the webhook endpoint
+	// (WebhookReachable).
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metaV1.Condition `json:"conditions,omitempty"`
+}
+
+// GitServerAPIStatus is what the git provider API reported about the GitServer access token.
+type GitServerAPIStatus struct {
+	// Version is the version of the git provider or its API, e.g. "17.2.1" for GitLab.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Scopes are the scopes of the access token. Empty if the provider does not report them,
+	// e.g. for GitHub fine-grained and GitHub App tokens.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// RateLimit is the API rate limit of the access token.
+	// +optional
+	RateLimit *GitServerRateLimit `json:"rateLimit,omitempty"`
+
+	// TokenExpiresAt is the time the access token expires at, if it expires.
+	// +optional
+	TokenExpiresAt *metaV1.Time `json:"tokenExpiresAt,omitempty"`
+}
+
+// GitServerWebhookStatus is the endpoint the git provider delivers webhooks to.
+type GitServerWebhookStatus struct {
+	// URL is the effective webhook URL that is registered in the git provider.
+	URL string `json:"url"`
+
+	// Exposure is the kind of resource that exposes the EventListener: Ingress, HTTPRoute or Route,
+	// or External if the URL is set in spec.webhookUrl.
+	Exposure string `json:"exposure"`
+}
+
+// GitServerHostKey is an SSH host key offered by the git server.
+type GitServerHostKey struct {
+	// Host is the host:port address the key was offered for.
+	Host string `json:"host"`
+
+	// Type is the key type, e.g. ssh-ed25519.
+	Type string `json:"type"`
+
+	// Fingerprint is the SHA256 fingerprint of the key, as printed by ssh-keygen -l.
+	Fingerprint string `json:"fingerprint"`
+
+	// PublicKey is the key in the authorized_keys format.
+	PublicKey string `json:"publicKey"`
+
+	// FirstSeen is the time the key was offered first.
+	// +optional
+	FirstSeen *metaV1.Time `json:"firstSeen,omitempty"`
+}
+
+// GitServerRateLimit is the API rate limit of an access token.
+type GitServerRateLimit struct {
+	// Limit is the number of requests allowed in a rate limit window.
+	Limit int64 `json:"limit"`
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int64 `json:"remaining"`
+
+	// ResetAt is the time the current window ends at.
+	// +optional
+	ResetAt *metaV1.Time `json:"resetAt,omitempty"`
 }
 
 func (in *GitServerStatus) SetFailed(err string) {
@@ -93,6 +326,22 @@ func (in *GitServerStatus) IsSuccess() bool {
 	return in.Status == "ok"
 }
 
+// APIUnavailableReason returns why the git provider API cannot be used with the access token,
+// or an empty string if the last probe found no problem or the API has not been probed.
+func (in *GitServerStatus) APIUnavailableReason() string {
+	for _, conditionType := range []string{
+		GitServerConditionTokenValid,
+		GitServerConditionPermissionsSufficient,
+		GitServerConditionRateLimitAvailable,
+	} {
+		if meta.IsStatusConditionFalse(in.Conditions, conditionType) {
+			return meta.FindStatusCondition(in.Conditions, conditionType).Message
+		}
+	}
+
+	return ""
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=gs
@@ -22,6 +22,20 @@ type JiraIssueMetadataSpec struct {
 	// +nullable
 	// +optional
 	Tickets []string `json:"tickets,omitempty"`
+
+	// Tag is the CodebaseImageStream tag of the build the tickets belong to.
+	// The tickets are recorded on the tag, so that deployments of the tag can transition them.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// CodebaseBranch is the name of the CodebaseBranch the build belongs to.
+	// The tickets and commits are collected per version of the branch for the release notes.
+	// +optional
+	CodebaseBranch string `json:"codebaseBranch,omitempty"`
+
+	// Version of the build. Defaults to the current version of the CodebaseBranch.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // JiraIssueMetadataStatus defines the observed state of JiraIssueMetadata.
@@ -40,6 +54,11 @@ type JiraIssueMetadataStatus struct {
 	// Amount of times, operator fail to serve with existing CR.
 	FailureCount int64 `json:"failureCount"`
 
+	// PendingTickets are the tickets that failed to be updated.
+	// When set, only these tickets are updated on the next reconciliation.
+	// +optional
+	PendingTickets []string `json:"pendingTickets,omitempty"`
+
 	// ErrorStrings store the string values of the errors obtained during the reconciliation.
 	ErrorStrings []string `json:"-"`
 }
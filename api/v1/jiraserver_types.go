@@ -6,6 +6,38 @@ import (
 
 // NOTE: json tags are required. Any new fields you add must have json tags for the fields to be serialized.
 
+const (
+	// JiraAuthTypeBasic authenticates with the username and password keys of the credential secret.
+	JiraAuthTypeBasic = "basic"
+
+	// JiraAuthTypePAT authenticates to Jira Data Center with the personal access token in the token key.
+	JiraAuthTypePAT = "pat"
+
+	// JiraAuthTypeCloudToken authenticates to Jira Cloud with the account email in the username key
+	// and the API token in the token key.
+	JiraAuthTypeCloudToken = "cloudToken"
+
+	// JiraAuthTypeOAuth2 authenticates to Jira Cloud with an OAuth 2.0 (3LO) access token
+	// obtained with the clientId, clientSecret and refreshToken keys.
+	JiraAuthTypeOAuth2 = "oauth2"
+)
+
+const (
+	// JiraServerConditionConnected is a condition type indicating whether the operator connects
+	// to Jira with the credentials of the JiraServer.
+	JiraServerConditionConnected = "Connected"
+
+	// JiraServerConditionPermissionsSufficient is a condition type indicating whether the account
+	// has the permissions the operator needs in the Jira projects of the codebases.
+	JiraServerConditionPermissionsSufficient = "PermissionsSufficient"
+
+	ReasonConnectionSucceeded = "ConnectionSucceeded"
+	ReasonConnectionFailed    = "ConnectionFailed"
+	ReasonPermissionsGranted  = "PermissionsGranted"
+	ReasonPermissionsMissing  = "PermissionsMissing"
+	ReasonPermissionsNotKnown = "PermissionsNotKnown"
+)
+
 // JiraServerSpec defines the desired state of JiraServer.
 type JiraServerSpec struct {
 	ApiUrl string `json:"apiUrl"`
@@ -13,6 +45,53 @@ type JiraServerSpec struct {
 	RootUrl string `json:"rootUrl"`
 
 	CredentialName string `json:"credentialName"`
+
+	// AuthType is the authentication method used with the credentials from CredentialName.
+	// basic uses the username and password keys, pat the token key,
+	// cloudToken the username and token keys, oauth2 the clientId, clientSecret and refreshToken keys.
+	// +kubebuilder:validation:Enum=basic;pat;cloudToken;oauth2
+	// +kubebuilder:default=basic
+	// +optional
+	AuthType string `json:"authType,omitempty"`
+
+	// Transitions move the Jira issues of deployed builds through the workflow.
+	// Codebases can override them with their own jiraTransitions.
+	// +optional
+	Transitions []JiraTransition `json:"transitions,omitempty"`
+
+	// ReleaseFixVersions marks the fix version named after a version as released in Jira
+	// when the release notes of the version are generated.
+	// +optional
+	ReleaseFixVersions bool `json:"releaseFixVersions,omitempty"`
+}
+
+const (
+	// JiraTransitionEventDeployed is the event of a successful deployment to a stage.
+	JiraTransitionEventDeployed = "deployed"
+
+	// JiraTransitionEventFailed is the event of a failed deployment to a stage.
+	JiraTransitionEventFailed = "failed"
+)
+
+// JiraTransition maps a deployment event to the workflow transition of the Jira issues in the deployed build.
+type JiraTransition struct {
+	// Pipeline is the name of the CDPipeline. Matches every pipeline if empty.
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
+
+	// Stage is the name of the CDPipeline stage, e.g. qa. Matches every stage if empty.
+	// +optional
+	Stage string `json:"stage,omitempty"`
+
+	// Event is the outcome of the deployment that triggers the transition.
+	// +kubebuilder:validation:Enum=deployed;failed
+	// +kubebuilder:default=deployed
+	// +optional
+	Event string `json:"event,omitempty"`
+
+	// Transition is the name of the workflow transition or of its target status, e.g. Deployed to QA.
+	// +kubebuilder:validation:MinLength=1
+	Transition string `json:"transition"`
 }
 
 // JiraServerStatus defines the observed state of JiraServer.
@@ -30,6 +109,40 @@ type JiraServerStatus struct {
 	// which were performed
 	// +optional
 	DetailedMessage string `json:"detailed_message,omitempty"`
+
+	// Version is the version of Jira, e.g. 9.12.2.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// DeploymentType is Cloud for Jira Cloud and Server for Jira Server and Data Center.
+	// +optional
+	DeploymentType string `json:"deploymentType,omitempty"`
+
+	// Projects are the Jira projects of the codebases that use the JiraServer,
+	// with the permissions the account lacks in them.
+	// +optional
+	Projects []JiraProjectStatus `json:"projects,omitempty"`
+
+	// Conditions represent the latest available observations of the connection to Jira
+	// (Connected) and of the permissions of the account (PermissionsSufficient).
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metaV1.Condition `json:"conditions,omitempty"`
+}
+
+// JiraProjectStatus is a Jira project of the codebases that use the JiraServer.
+type JiraProjectStatus struct {
+	// Key is the key of the project, e.g. APP.
+	Key string `json:"key"`
+
+	// MissingPermissions are the project permissions the account lacks, e.g. EDIT_ISSUES.
+	// +optional
+	MissingPermissions []string `json:"missingPermissions,omitempty"`
+
+	// NotFound is true when the project does not exist or the account cannot see it.
+	// +optional
+	NotFound bool `json:"notFound,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -37,6 +150,7 @@ type JiraServerStatus struct {
 // +kubebuilder:resource:shortName=jrs
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Available",type="boolean",JSONPath=".status.available",description="Is resource available"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Jira version"
 
 // JiraServer is the Schema for the JiraServers API.
 type JiraServer struct {
@@ -41,4 +41,12 @@ const (
 	// selector; the condition remains the source of truth and the label is re-asserted by
 	// the operator on every staleness check.
 	StaleLabel = "app.edp.epam.com/stale"
+
+	// DiscoveredLabel marks a CodebaseBranch that the operator created for a branch found in
+	// the git repository by branch discovery, rather than one requested by a user.
+	DiscoveredLabel = "app.edp.epam.com/discovered"
+
+	// PreviewLabel marks a preview CodebaseBranch, created for a pull request rather than
+	// requested by a user. Its value is the pull request number.
+	PreviewLabel = "app.edp.epam.com/preview"
 )
@@ -5,10 +5,27 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRecord) DeepCopyInto(out *BuildRecord) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRecord.
+func (in *BuildRecord) DeepCopy() *BuildRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDStageDeploy) DeepCopyInto(out *CDStageDeploy) {
 	*out = *in
@@ -252,6 +269,13 @@ func (in *CodebaseBranchStatus) DeepCopyInto(out *CodebaseBranchStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Builds != nil {
+		in, out := &in.Builds, &out.Builds
+		*out = make([]BuildRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -277,7 +301,7 @@ func (in *CodebaseImageStream) DeepCopyInto(out *CodebaseImageStream) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodebaseImageStream.
@@ -336,7 +360,19 @@ func (in *CodebaseImageStreamSpec) DeepCopyInto(out *CodebaseImageStreamSpec) {
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]Tag, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(TagRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LatestTag != nil {
+		in, out := &in.LatestTag, &out.LatestTag
+		*out = new(LatestTagSelection)
+		**out = **in
 	}
 }
 
@@ -353,6 +389,16 @@ func (in *CodebaseImageStreamSpec) DeepCopy() *CodebaseImageStreamSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CodebaseImageStreamStatus) DeepCopyInto(out *CodebaseImageStreamStatus) {
 	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(TagRetentionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeployedTags != nil {
+		in, out := &in.DeployedTags, &out.DeployedTags
+		*out = make([]DeployedTag, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodebaseImageStreamStatus.
@@ -436,6 +482,16 @@ func (in *CodebaseSpec) DeepCopyInto(out *CodebaseSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.JiraTransitions != nil {
+		in, out := &in.JiraTransitions, &out.JiraTransitions
+		*out = make([]JiraTransition, len(*in))
+		copy(*out, *in)
+	}
+	if in.CommitPolicy != nil {
+		in, out := &in.CommitPolicy, &out.CommitPolicy
+		*out = new(CommitPolicy)
+		**out = **in
+	}
 	if in.CloneRepositoryCredentials != nil {
 		in, out := &in.CloneRepositoryCredentials, &out.CloneRepositoryCredentials
 		*out = new(CloneRepositoryCredentials)
@@ -457,6 +513,11 @@ func (in *CodebaseSpec) DeepCopy() *CodebaseSpec {
 func (in *CodebaseStatus) DeepCopyInto(out *CodebaseStatus) {
 	*out = *in
 	in.LastTimeUpdated.DeepCopyInto(&out.LastTimeUpdated)
+	if in.WebHook != nil {
+		in, out := &in.WebHook, &out.WebHook
+		*out = new(CodebaseWebHookStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodebaseStatus.
@@ -484,13 +545,95 @@ func (in *CodebaseTag) DeepCopy() *CodebaseTag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CodebaseWebHookDeliveries) DeepCopyInto(out *CodebaseWebHookDeliveries) {
+	*out = *in
+	if in.LastDeliveryTime != nil {
+		in, out := &in.LastDeliveryTime, &out.LastDeliveryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodebaseWebHookDeliveries.
+func (in *CodebaseWebHookDeliveries) DeepCopy() *CodebaseWebHookDeliveries {
+	if in == nil {
+		return nil
+	}
+	out := new(CodebaseWebHookDeliveries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CodebaseWebHookStatus) DeepCopyInto(out *CodebaseWebHookStatus) {
+	*out = *in
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Deliveries != nil {
+		in, out := &in.Deliveries, &out.Deliveries
+		*out = new(CodebaseWebHookDeliveries)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodebaseWebHookStatus.
+func (in *CodebaseWebHookStatus) DeepCopy() *CodebaseWebHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CodebaseWebHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitPolicy) DeepCopyInto(out *CommitPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitPolicy.
+func (in *CommitPolicy) DeepCopy() *CommitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeployedTag) DeepCopyInto(out *DeployedTag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeployedTag.
+func (in *DeployedTag) DeepCopy() *DeployedTag {
+	if in == nil {
+		return nil
+	}
+	out := new(DeployedTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitServer) DeepCopyInto(out *GitServer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServer.
@@ -511,6 +654,109 @@ func (in *GitServer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerAPIStatus) DeepCopyInto(out *GitServerAPIStatus) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(GitServerRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TokenExpiresAt != nil {
+		in, out := &in.TokenExpiresAt, &out.TokenExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerAPIStatus.
+func (in *GitServerAPIStatus) DeepCopy() *GitServerAPIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerAPIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerEventListener) DeepCopyInto(out *GitServerEventListener) {
+	*out = *in
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GitServerGatewayRef)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GitServerEventListenerTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerEventListener.
+func (in *GitServerEventListener) DeepCopy() *GitServerEventListener {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerEventListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerEventListenerTLS) DeepCopyInto(out *GitServerEventListenerTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerEventListenerTLS.
+func (in *GitServerEventListenerTLS) DeepCopy() *GitServerEventListenerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerEventListenerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerGatewayRef) DeepCopyInto(out *GitServerGatewayRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerGatewayRef.
+func (in *GitServerGatewayRef) DeepCopy() *GitServerGatewayRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerGatewayRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerHostKey) DeepCopyInto(out *GitServerHostKey) {
+	*out = *in
+	if in.FirstSeen != nil {
+		in, out := &in.FirstSeen, &out.FirstSeen
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerHostKey.
+func (in *GitServerHostKey) DeepCopy() *GitServerHostKey {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerHostKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitServerList) DeepCopyInto(out *GitServerList) {
 	*out = *in
@@ -543,9 +789,58 @@ func (in *GitServerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerProxy) DeepCopyInto(out *GitServerProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerProxy.
+func (in *GitServerProxy) DeepCopy() *GitServerProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerRateLimit) DeepCopyInto(out *GitServerRateLimit) {
+	*out = *in
+	if in.ResetAt != nil {
+		in, out := &in.ResetAt, &out.ResetAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerRateLimit.
+func (in *GitServerRateLimit) DeepCopy() *GitServerRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitServerSpec) DeepCopyInto(out *GitServerSpec) {
 	*out = *in
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(GitServerProxy)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GitServerTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventListener != nil {
+		in, out := &in.EventListener, &out.EventListener
+		*out = new(GitServerEventListener)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerSpec.
@@ -561,6 +856,28 @@ func (in *GitServerSpec) DeepCopy() *GitServerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitServerStatus) DeepCopyInto(out *GitServerStatus) {
 	*out = *in
+	if in.API != nil {
+		in, out := &in.API, &out.API
+		*out = new(GitServerAPIStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(GitServerWebhookStatus)
+		**out = **in
+	}
+	if in.PendingHostKey != nil {
+		in, out := &in.PendingHostKey, &out.PendingHostKey
+		*out = new(GitServerHostKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerStatus.
@@ -573,6 +890,46 @@ func (in *GitServerStatus) DeepCopy() *GitServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerTLS) DeepCopyInto(out *GitServerTLS) {
+	*out = *in
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerTLS.
+func (in *GitServerTLS) DeepCopy() *GitServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitServerWebhookStatus) DeepCopyInto(out *GitServerWebhookStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitServerWebhookStatus.
+func (in *GitServerWebhookStatus) DeepCopy() *GitServerWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitServerWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JiraIssueMetadata) DeepCopyInto(out *JiraIssueMetadata) {
 	*out = *in
@@ -661,6 +1018,11 @@ func (in *JiraIssueMetadataSpec) DeepCopy() *JiraIssueMetadataSpec {
 func (in *JiraIssueMetadataStatus) DeepCopyInto(out *JiraIssueMetadataStatus) {
 	*out = *in
 	in.LastTimeUpdated.DeepCopyInto(&out.LastTimeUpdated)
+	if in.PendingTickets != nil {
+		in, out := &in.PendingTickets, &out.PendingTickets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ErrorStrings != nil {
 		in, out := &in.ErrorStrings, &out.ErrorStrings
 		*out = make([]string, len(*in))
@@ -678,12 +1040,32 @@ func (in *JiraIssueMetadataStatus) DeepCopy() *JiraIssueMetadataStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JiraProjectStatus) DeepCopyInto(out *JiraProjectStatus) {
+	*out = *in
+	if in.MissingPermissions != nil {
+		in, out := &in.MissingPermissions, &out.MissingPermissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JiraProjectStatus.
+func (in *JiraProjectStatus) DeepCopy() *JiraProjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JiraProjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JiraServer) DeepCopyInto(out *JiraServer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -740,6 +1122,11 @@ func (in *JiraServerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JiraServerSpec) DeepCopyInto(out *JiraServerSpec) {
 	*out = *in
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]JiraTransition, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JiraServerSpec.
@@ -756,6 +1143,20 @@ func (in *JiraServerSpec) DeepCopy() *JiraServerSpec {
 func (in *JiraServerStatus) DeepCopyInto(out *JiraServerStatus) {
 	*out = *in
 	in.LastTimeUpdated.DeepCopyInto(&out.LastTimeUpdated)
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]JiraProjectStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JiraServerStatus.
@@ -768,6 +1169,36 @@ func (in *JiraServerStatus) DeepCopy() *JiraServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JiraTransition) DeepCopyInto(out *JiraTransition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JiraTransition.
+func (in *JiraTransition) DeepCopy() *JiraTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(JiraTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatestTagSelection) DeepCopyInto(out *LatestTagSelection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatestTagSelection.
+func (in *LatestTagSelection) DeepCopy() *LatestTagSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(LatestTagSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QuickLink) DeepCopyInto(out *QuickLink) {
 	*out = *in
@@ -875,6 +1306,11 @@ func (in *Repository) DeepCopy() *Repository {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Tag) DeepCopyInto(out *Tag) {
 	*out = *in
+	if in.Tickets != nil {
+		in, out := &in.Tickets, &out.Tickets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tag.
@@ -887,6 +1323,57 @@ func (in *Tag) DeepCopy() *Tag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagRetention) DeepCopyInto(out *TagRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int)
+		**out = **in
+	}
+	if in.KeepNewerThan != nil {
+		in, out := &in.KeepNewerThan, &out.KeepNewerThan
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagRetention.
+func (in *TagRetention) DeepCopy() *TagRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(TagRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TagRetentionStatus) DeepCopyInto(out *TagRetentionStatus) {
+	*out = *in
+	in.LastRunTime.DeepCopyInto(&out.LastRunTime)
+	if in.PrunedTags != nil {
+		in, out := &in.PrunedTags, &out.PrunedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeletedImages != nil {
+		in, out := &in.DeletedImages, &out.DeletedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagRetentionStatus.
+func (in *TagRetentionStatus) DeepCopy() *TagRetentionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TagRetentionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Versioning) DeepCopyInto(out *Versioning) {
 	*out = *in
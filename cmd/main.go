@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"flag"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -25,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -37,15 +39,22 @@ import (
 	"github.com/epam/edp-codebase-operator/v2/controllers/cdstagedeploy"
 	"github.com/epam/edp-codebase-operator/v2/controllers/cdstagedeploy/chain"
 	"github.com/epam/edp-codebase-operator/v2/controllers/codebase"
+	"github.com/epam/edp-codebase-operator/v2/controllers/codebase/webhookdrift"
 	"github.com/epam/edp-codebase-operator/v2/controllers/codebasebranch"
 	"github.com/epam/edp-codebase-operator/v2/controllers/codebasebranch/stalecheck"
 	"github.com/epam/edp-codebase-operator/v2/controllers/codebaseimagestream"
+	"github.com/epam/edp-codebase-operator/v2/controllers/codebaseimagestream/tagdiscovery"
+	"github.com/epam/edp-codebase-operator/v2/controllers/codebaseimagestream/tagretention"
 	"github.com/epam/edp-codebase-operator/v2/controllers/gitserver"
 	"github.com/epam/edp-codebase-operator/v2/controllers/integrationsecret"
 	"github.com/epam/edp-codebase-operator/v2/controllers/jiraissuemetadata"
 	"github.com/epam/edp-codebase-operator/v2/controllers/jiraserver"
+	"github.com/epam/edp-codebase-operator/v2/controllers/releasenotes"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/registry"
 	codebasePkg "github.com/epam/edp-codebase-operator/v2/pkg/codebase"
 	gitproviderv2 "github.com/epam/edp-codebase-operator/v2/pkg/git"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 	"github.com/epam/edp-codebase-operator/v2/pkg/telemetry"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 	"github.com/epam/edp-codebase-operator/v2/pkg/webhook"
@@ -65,6 +74,18 @@ const (
 	telemetryUrl                             = "https://telemetry.kuberocketci.io"
 	branchStaleCheckIntervalEnv              = "BRANCH_STALE_CHECK_INTERVAL"
 	branchStaleCheckDefaultInterval          = time.Hour * 24
+	previewExpiryCheckIntervalEnv            = "PREVIEW_EXPIRY_CHECK_INTERVAL"
+	previewExpiryCheckDefaultInterval        = time.Hour
+	webhookDriftCheckIntervalEnv             = "WEBHOOK_DRIFT_CHECK_INTERVAL"
+	webhookDriftCheckDefaultInterval         = time.Hour
+	registryTagDiscoveryIntervalEnv          = "REGISTRY_TAG_DISCOVERY_INTERVAL"
+	registryTagDiscoveryDefaultInterval      = time.Minute * 10
+	tagRetentionIntervalEnv                  = "TAG_RETENTION_INTERVAL"
+	tagRetentionDefaultInterval              = time.Hour
+	integrationSecretExpiryWarningDaysEnv    = "INTEGRATION_SECRET_EXPIRY_WARNING_DAYS"
+	integrationSecretExpiryWarningDefault    = 14
+	branchEventsBindAddressEnv               = "BRANCH_EVENTS_BIND_ADDRESS"
+	branchEventsReadHeaderTimeout            = time.Second * 10
 )
 
 func main() {
@@ -251,13 +272,25 @@ func main() {
 
 	ctrlLog := ctrl.Log.WithName("controllers")
 
-	cdStageDeployCtrl := cdstagedeploy.NewReconcileCDStageDeploy(mgr.GetClient(), ctrlLog, chain.CreateChain)
+	// Jira API calls made for a JiraServer share one throttled client and its cache, whichever
+	// controller makes them.
+	jiraClients := jira.NewClientPool()
+
+	cdStageDeployCtrl := cdstagedeploy.NewReconcileCDStageDeploy(
+		mgr.GetClient(),
+		ctrlLog,
+		chain.NewChainFactory(jiraClients),
+	)
 	if err = cdStageDeployCtrl.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "failed to create controller", "controller", "cd-stage-deploy")
 		os.Exit(1)
 	}
 
-	codebaseCtrl := codebase.NewReconcileCodebase(mgr.GetClient(), mgr.GetScheme(), ctrlLog)
+	// Git provider API calls made for a GitServer share one throttled HTTP client, so that
+	// the Codebase and GitServer controllers do not exhaust the provider rate limits together.
+	gitProviderHTTPClients := gitprovider.NewHTTPClientPool(mgr.GetClient())
+
+	codebaseCtrl := codebase.NewReconcileCodebase(mgr.GetClient(), mgr.GetScheme(), ctrlLog, gitProviderHTTPClients)
 	if err = codebaseCtrl.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "failed to create controller", "controller", "codebase")
 		os.Exit(1)
@@ -276,24 +309,49 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = gitserver.NewReconcileGitServer(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err = gitserver.NewReconcileGitServer(
+		mgr.GetClient(),
+		gitProviderHTTPClients,
+		mgr.GetEventRecorderFor("git-server-controller"),
+	).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, logFailCtrlCreateMessage, "controller", "git-server")
 		os.Exit(1)
 	}
 
-	jimCtrl := jiraissuemetadata.NewReconcileJiraIssueMetadata(mgr.GetClient(), mgr.GetScheme(), ctrlLog)
+	jimCtrl := jiraissuemetadata.NewReconcileJiraIssueMetadata(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		ctrlLog,
+		gitProviderHTTPClients,
+		jiraClients,
+	)
 	if err = jimCtrl.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, logFailCtrlCreateMessage, "controller", "jira-issue-metadata")
 		os.Exit(1)
 	}
 
-	jsCtrl := jiraserver.NewReconcileJiraServer(mgr.GetClient(), mgr.GetScheme(), ctrlLog)
+	jsCtrl := jiraserver.NewReconcileJiraServer(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		ctrlLog,
+		mgr.GetEventRecorderFor("jira-server-controller"),
+		jiraClients,
+	)
 	if err = jsCtrl.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, logFailCtrlCreateMessage, "controller", "jira-server")
 		os.Exit(1)
 	}
 
-	if err = integrationsecret.NewReconcileIntegrationSecret(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+	if err = releasenotes.NewReconcileReleaseNotes(mgr.GetClient(), jiraClients).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, logFailCtrlCreateMessage, "controller", "release-notes")
+		os.Exit(1)
+	}
+
+	if err = integrationsecret.NewReconcileIntegrationSecret(
+		mgr.GetClient(),
+		mgr.GetEventRecorderFor("integration-secret-controller"),
+		getIntegrationSecretExpiryWarning(),
+	).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, logFailCtrlCreateMessage, "controller", "integration-secret")
 		os.Exit(1)
 	}
@@ -335,19 +393,23 @@ func main() {
 		os.Exit(1)
 	}
 
-	if interval := getBranchStaleCheckInterval(); interval > 0 {
-		staleRecorder := mgr.GetEventRecorderFor("stale-branch-checker")
+	staleRecorder := mgr.GetEventRecorderFor("stale-branch-checker")
+	markAction := stalecheck.NewMarkAction(mgr.GetClient(), staleRecorder)
+	interval := getBranchStaleCheckInterval()
 
-		markAction := stalecheck.NewMarkAction(mgr.GetClient(), staleRecorder)
+	previewAction := stalecheck.NewPreviewAction(mgr.GetClient(), staleRecorder)
 
-		checker := stalecheck.NewChecker(
-			mgr.GetClient(),
-			ns,
-			interval,
-			gitproviderv2.DefaultGitProviderFactory,
-			markAction,
-			stalecheck.NewCleanupAction(mgr.GetClient(), staleRecorder, markAction),
-		)
+	checker := stalecheck.NewChecker(
+		mgr.GetClient(),
+		ns,
+		interval,
+		gitproviderv2.NewGitProviderFactory,
+		markAction,
+		stalecheck.NewCleanupAction(mgr.GetClient(), staleRecorder, markAction),
+		stalecheck.NewAdoptAction(mgr.GetClient(), staleRecorder),
+	)
+
+	if interval > 0 {
 		if err := mgr.Add(checker); err != nil {
 			setupLog.Error(err, "failed to add stale branch checker to manager")
 			os.Exit(1)
@@ -356,6 +418,86 @@ func main() {
 		setupLog.Info("Stale branch checker is disabled", "env", branchStaleCheckIntervalEnv)
 	}
 
+	if expiryInterval := getPreviewExpiryCheckInterval(); expiryInterval > 0 {
+		if err := mgr.Add(stalecheck.NewPreviewExpirer(mgr.GetClient(), ns, expiryInterval, previewAction)); err != nil {
+			setupLog.Error(err, "failed to add preview expirer to manager")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("Preview expirer is disabled", "env", previewExpiryCheckIntervalEnv)
+	}
+
+	if webhookInterval := getWebhookDriftCheckInterval(); webhookInterval > 0 {
+		if err := mgr.Add(webhookdrift.NewChecker(
+			mgr.GetClient(),
+			ns,
+			webhookInterval,
+			webhookdrift.NewGitProviderFactory(gitProviderHTTPClients),
+			mgr.GetEventRecorderFor("webhook-drift-checker"),
+		)); err != nil {
+			setupLog.Error(err, "failed to add webhook drift checker to manager")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("Webhook drift checker is disabled", "env", webhookDriftCheckIntervalEnv)
+	}
+
+	if discoveryInterval := getRegistryTagDiscoveryInterval(); discoveryInterval > 0 {
+		if err := mgr.Add(tagdiscovery.NewDiscoverer(
+			mgr.GetClient(),
+			ns,
+			discoveryInterval,
+			registry.NewRegistry,
+			mgr.GetEventRecorderFor("registry-tag-discoverer"),
+		)); err != nil {
+			setupLog.Error(err, "failed to add registry tag discoverer to manager")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("Registry tag discoverer is disabled", "env", registryTagDiscoveryIntervalEnv)
+	}
+
+	if retentionInterval := getTagRetentionInterval(); retentionInterval > 0 {
+		if err := mgr.Add(tagretention.NewPruner(
+			mgr.GetClient(),
+			ns,
+			retentionInterval,
+			registry.NewRegistry,
+			mgr.GetEventRecorderFor("tag-retention-pruner"),
+		)); err != nil {
+			setupLog.Error(err, "failed to add tag retention pruner to manager")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("Tag retention pruner is disabled", "env", tagRetentionIntervalEnv)
+	}
+
+	// The receiver is served by every replica rather than by the leader only: a git
+	// provider delivers to whichever pod the Service picks, and the actions it triggers
+	// are idempotent.
+	if addr := os.Getenv(branchEventsBindAddressEnv); addr != "" {
+		mux := http.NewServeMux()
+		mux.Handle(stalecheck.EventsPath, stalecheck.NewEventReceiver(
+			mgr.GetClient(),
+			ns,
+			checker,
+			previewAction,
+			stalecheck.NewCommitPolicyAction(mgr.GetClient(), gitProviderHTTPClients, jiraClients),
+		))
+
+		if err := mgr.Add(&manager.Server{
+			Name: "branch-events",
+			Server: &http.Server{
+				Addr:              addr,
+				Handler:           mux,
+				ReadHeaderTimeout: branchEventsReadHeaderTimeout,
+			},
+		}); err != nil {
+			setupLog.Error(err, "failed to add branch events receiver to manager")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
 
 	ctx := ctrl.SetupSignalHandler()
@@ -404,6 +546,100 @@ func getBranchStaleCheckInterval() time.Duration {
 	return d
 }
 
+// getPreviewExpiryCheckInterval accepts Go duration strings (e.g. "1h", "15m");
+// a zero or negative duration disables the expiry of previews.
+func getPreviewExpiryCheckInterval() time.Duration {
+	val, exists := os.LookupEnv(previewExpiryCheckIntervalEnv)
+	if !exists {
+		return previewExpiryCheckDefaultInterval
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		setupLog.Error(err, "Invalid preview expiry check interval, using default",
+			"env", previewExpiryCheckIntervalEnv, "value", val, "default", previewExpiryCheckDefaultInterval)
+
+		return previewExpiryCheckDefaultInterval
+	}
+
+	return d
+}
+
+// getWebhookDriftCheckInterval accepts Go duration strings (e.g. "1h", "30m");
+// a zero or negative duration disables the check.
+func getWebhookDriftCheckInterval() time.Duration {
+	val, exists := os.LookupEnv(webhookDriftCheckIntervalEnv)
+	if !exists {
+		return webhookDriftCheckDefaultInterval
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		setupLog.Error(err, "Invalid webhook drift check interval, using default",
+			"env", webhookDriftCheckIntervalEnv, "value", val, "default", webhookDriftCheckDefaultInterval)
+
+		return webhookDriftCheckDefaultInterval
+	}
+
+	return d
+}
+
+// getRegistryTagDiscoveryInterval accepts Go duration strings (e.g. "10m", "1h");
+// a zero or negative duration disables the discovery.
+func getRegistryTagDiscoveryInterval() time.Duration {
+	val, exists := os.LookupEnv(registryTagDiscoveryIntervalEnv)
+	if !exists {
+		return registryTagDiscoveryDefaultInterval
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		setupLog.Error(err, "Invalid registry tag discovery interval, using default",
+			"env", registryTagDiscoveryIntervalEnv, "value", val, "default", registryTagDiscoveryDefaultInterval)
+
+		return registryTagDiscoveryDefaultInterval
+	}
+
+	return d
+}
+
+// getTagRetentionInterval accepts Go duration strings (e.g. "1h", "30m");
+// "0" disables the tag retention policies.
+func getTagRetentionInterval() time.Duration {
+	val, exists := os.LookupEnv(tagRetentionIntervalEnv)
+	if !exists {
+		return tagRetentionDefaultInterval
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		setupLog.Error(err, "Invalid tag retention interval, using default",
+			"env", tagRetentionIntervalEnv, "value", val, "default", tagRetentionDefaultInterval)
+
+		return tagRetentionDefaultInterval
+	}
+
+	return d
+}
+
+// getIntegrationSecretExpiryWarning returns how long before integration credentials expire
+// the operator warns about it, configured in whole days.
+func getIntegrationSecretExpiryWarning() time.Duration {
+	days := integrationSecretExpiryWarningDefault
+
+	if val, exists := os.LookupEnv(integrationSecretExpiryWarningDaysEnv); exists {
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			setupLog.Error(err, "Invalid integration secret expiry warning, using default",
+				"env", integrationSecretExpiryWarningDaysEnv, "value", val, "default", days)
+		} else {
+			days = n
+		}
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
 func getTelemetryDelay() time.Duration {
 	val, exists := os.LookupEnv("TELEMETRY_DELAY")
 	if !exists {
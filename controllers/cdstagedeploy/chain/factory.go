@@ -5,19 +5,25 @@ import (
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/pkg/autodeploy"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
 	"github.com/epam/edp-codebase-operator/v2/pkg/tektoncd"
 )
 
 type CDStageDeployChain func(cl client.Client, stageDeploy *codebaseApi.CDStageDeploy) CDStageDeployHandler
 
-func CreateChain(cl client.Client, _ *codebaseApi.CDStageDeploy) CDStageDeployHandler {
-	c := chain{}
+// NewChainFactory creates the CDStageDeployChain whose handlers send Jira requests with the
+// clients of the pool.
+func NewChainFactory(jiraClients *jira.ClientPool) CDStageDeployChain {
+	return func(cl client.Client, _ *codebaseApi.CDStageDeploy) CDStageDeployHandler {
+		c := chain{}
 
-	c.Use(
-		NewResolveStatus(cl),
-		NewProcessTriggerTemplate(cl, tektoncd.NewTektonTriggerTemplateManager(cl), autodeploy.NewStrategyManager(cl)),
-		NewDeleteCDStageDeploy(cl),
-	)
+		c.Use(
+			NewResolveStatus(cl),
+			NewProcessTriggerTemplate(cl, tektoncd.NewTektonTriggerTemplateManager(cl), autodeploy.NewStrategyManager(cl)),
+			NewTransitionJiraIssues(cl, jiraClients),
+			NewDeleteCDStageDeploy(cl),
+		)
 
-	return &c
+		return &c
+	}
 }
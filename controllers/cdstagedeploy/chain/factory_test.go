@@ -9,9 +9,10 @@ import (
 	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
 )
 
-func TestCreateChain(t *testing.T) {
+func TestNewChainFactory(t *testing.T) {
 	t.Parallel()
 
 	cl := fake.NewClientBuilder().Build()
@@ -41,7 +42,7 @@ func TestCreateChain(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.NotNil(t, CreateChain(cl, tt.stageDeploy))
+			assert.NotNil(t, NewChainFactory(jira.NewClientPool())(cl, tt.stageDeploy))
 		})
 	}
 }
@@ -0,0 +1,220 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	tektonpipelineApi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebaseimagestream"
+)
+
+// conditionSucceeded is the type of the condition that reports the outcome of a PipelineRun.
+const conditionSucceeded = "Succeeded"
+
+// TransitionJiraIssues moves the Jira issues of the deployed builds through the workflow
+// when the CDStageDeploy is completed.
+// Jira errors are logged and do not fail the CDStageDeploy, so that the deployment is not retried because of them.
+type TransitionJiraIssues struct {
+	client        client.Client
+	newJiraClient func(ctx context.Context, js *codebaseApi.JiraServer) (jira.Client, error)
+}
+
+func NewTransitionJiraIssues(k8sClient client.Client, jiraClients *jira.ClientPool) *TransitionJiraIssues {
+	return &TransitionJiraIssues{
+		client: k8sClient,
+		newJiraClient: func(ctx context.Context, js *codebaseApi.JiraServer) (jira.Client, error) {
+			server, err := jira.ServerFromSecret(ctx, k8sClient, js)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Jira credentials: %w", err)
+			}
+
+			jc, err := jiraClients.Client(js, server)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create Jira client: %w", err)
+			}
+
+			return jc, nil
+		},
+	}
+}
+
+// ServeRequest transitions the Jira issues recorded on the deployed tags.
+func (h *TransitionJiraIssues) ServeRequest(ctx context.Context, stageDeploy *codebaseApi.CDStageDeploy) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !stageDeploy.IsCompleted() {
+		return nil
+	}
+
+	event, err := h.deploymentEvent(ctx, stageDeploy)
+	if err != nil {
+		log.Error(err, "Failed to get the outcome of the deployment. Skip transitioning Jira issues.")
+
+		return nil
+	}
+
+	for _, tag := range deployedTags(stageDeploy) {
+		if err := h.transitionTag(ctx, stageDeploy, tag, event); err != nil {
+			log.Error(err, "Failed to transition Jira issues", "codebase", tag.Codebase, "tag", tag.Tag)
+		}
+	}
+
+	return nil
+}
+
+// deploymentEvent returns the transition event of the deployment.
+// The deployment has failed if the latest PipelineRun started for the CDStageDeploy has not succeeded.
+// PipelineRuns are selected by the CDStageDeploy label, so that runs of other deployments of the stage are ignored.
+func (h *TransitionJiraIssues) deploymentEvent(
+	ctx context.Context,
+	stageDeploy *codebaseApi.CDStageDeploy,
+) (string, error) {
+	pipelineRuns := &tektonpipelineApi.PipelineRunList{}
+
+	if err := h.client.List(
+		ctx,
+		pipelineRuns,
+		client.InNamespace(stageDeploy.Namespace),
+		client.MatchingLabels{
+			codebaseApi.CdStageDeployLabel: stageDeploy.Name,
+		},
+	); err != nil {
+		return "", fmt.Errorf("failed to list PipelineRuns: %w", err)
+	}
+
+	var latest *tektonpipelineApi.PipelineRun
+
+	for i := range pipelineRuns.Items {
+		if latest == nil || latest.CreationTimestamp.Before(&pipelineRuns.Items[i].CreationTimestamp) {
+			latest = &pipelineRuns.Items[i]
+		}
+	}
+
+	if latest == nil {
+		return codebaseApi.JiraTransitionEventDeployed, nil
+	}
+
+	for _, c := range latest.Status.Conditions {
+		if string(c.Type) == conditionSucceeded && c.Status == coreV1.ConditionFalse {
+			return codebaseApi.JiraTransitionEventFailed, nil
+		}
+	}
+
+	return codebaseApi.JiraTransitionEventDeployed, nil
+}
+
+func (h *TransitionJiraIssues) transitionTag(
+	ctx context.Context,
+	stageDeploy *codebaseApi.CDStageDeploy,
+	tag codebaseApi.CodebaseTag,
+	event string,
+) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("codebase", tag.Codebase, "tag", tag.Tag)
+
+	codebase := &codebaseApi.Codebase{}
+	if err := h.client.Get(ctx, types.NamespacedName{
+		Namespace: stageDeploy.Namespace,
+		Name:      tag.Codebase,
+	}, codebase); err != nil {
+		return fmt.Errorf("failed to get Codebase: %w", err)
+	}
+
+	if codebase.Spec.JiraServer == nil || *codebase.Spec.JiraServer == "" {
+		return nil
+	}
+
+	js := &codebaseApi.JiraServer{}
+	if err := h.client.Get(ctx, types.NamespacedName{
+		Namespace: stageDeploy.Namespace,
+		Name:      *codebase.Spec.JiraServer,
+	}, js); err != nil {
+		return fmt.Errorf("failed to get JiraServer: %w", err)
+	}
+
+	rules := codebase.Spec.JiraTransitions
+	if len(rules) == 0 {
+		rules = js.Spec.Transitions
+	}
+
+	transition := matchTransition(rules, stageDeploy.Spec.Pipeline, stageDeploy.Spec.Stage, event)
+	if transition == "" {
+		return nil
+	}
+
+	tickets, err := codebaseimagestream.GetTagTickets(ctx, h.client, tag.Codebase, stageDeploy.Namespace, tag.Tag)
+	if err != nil {
+		return fmt.Errorf("failed to get tickets of the tag: %w", err)
+	}
+
+	if len(tickets) == 0 {
+		log.Info("No Jira issues are recorded on the deployed tag")
+
+		return nil
+	}
+
+	if !js.Status.Available {
+		return fmt.Errorf("JiraServer %s is not available", js.Name)
+	}
+
+	jc, err := h.newJiraClient(ctx, js)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, ticket := range tickets {
+		if err := jc.TransitionIssue(ctx, ticket, transition); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		log.Info("Jira issue has been transitioned", "issue", ticket, "transition", transition)
+	}
+
+	return errors.Join(errs...)
+}
+
+// matchTransition returns the transition of the first rule that matches the deployment.
+func matchTransition(rules []codebaseApi.JiraTransition, pipeline, stage, event string) string {
+	for _, r := range rules {
+		ruleEvent := r.Event
+		if ruleEvent == "" {
+			ruleEvent = codebaseApi.JiraTransitionEventDeployed
+		}
+
+		if (r.Pipeline == "" || r.Pipeline == pipeline) && (r.Stage == "" || r.Stage == stage) && ruleEvent == event {
+			return r.Transition
+		}
+	}
+
+	return ""
+}
+
+// deployedTags returns the tags of the CDStageDeploy, including the deprecated list of tags.
+func deployedTags(stageDeploy *codebaseApi.CDStageDeploy) []codebaseApi.CodebaseTag {
+	tags := make([]codebaseApi.CodebaseTag, 0, len(stageDeploy.Spec.Tags)+1)
+
+	for _, t := range append([]codebaseApi.CodebaseTag{stageDeploy.Spec.Tag}, stageDeploy.Spec.Tags...) {
+		if t.Codebase == "" || t.Tag == "" {
+			continue
+		}
+
+		if !slices.ContainsFunc(tags, func(existing codebaseApi.CodebaseTag) bool {
+			return existing.Codebase == t.Codebase && existing.Tag == t.Tag
+		}) {
+			tags = append(tags, t)
+		}
+	}
+
+	return tags
+}
@@ -0,0 +1,218 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	tektonpipelineApi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	jiraMocks "github.com/epam/edp-codebase-operator/v2/pkg/client/jira/mocks"
+)
+
+func TestTransitionJiraIssues_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, tektonpipelineApi.AddToScheme(scheme))
+
+	jiraServerName := "jira"
+	now := time.Now().Truncate(time.Second)
+
+	codebase := func(transitions ...codebaseApi.JiraTransition) *codebaseApi.Codebase {
+		return &codebaseApi.Codebase{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: codebaseApi.CodebaseSpec{
+				JiraServer:      &jiraServerName,
+				JiraTransitions: transitions,
+			},
+		}
+	}
+
+	jiraServer := &codebaseApi.JiraServer{
+		ObjectMeta: metav1.ObjectMeta{Name: jiraServerName, Namespace: "default"},
+		Spec: codebaseApi.JiraServerSpec{
+			Transitions: []codebaseApi.JiraTransition{
+				{Stage: "qa", Transition: "Deployed to QA"},
+				{Stage: "qa", Event: codebaseApi.JiraTransitionEventFailed, Transition: "QA Deployment Failed"},
+			},
+		},
+		Status: codebaseApi.JiraServerStatus{Available: true},
+	}
+
+	stream := &codebaseApi.CodebaseImageStream{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-main", Namespace: "default"},
+		Spec: codebaseApi.CodebaseImageStreamSpec{
+			Codebase: "app",
+			Tags: []codebaseApi.Tag{
+				{Name: "0.1.0-SNAPSHOT.2", Created: "2024-01-02T00:00:00Z", Tickets: []string{"APP-1", "APP-2"}},
+			},
+		},
+	}
+
+	pipelineRun := func(name, stageDeployName string, failed bool, created time.Time) *tektonpipelineApi.PipelineRun {
+		pr := &tektonpipelineApi.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					codebaseApi.CdPipelineLabel:    "mypipe",
+					codebaseApi.CdStageLabel:       "mypipe-qa",
+					codebaseApi.CdStageDeployLabel: stageDeployName,
+				},
+			},
+		}
+
+		if failed {
+			pr.Status.MarkFailed("Failed", "deploy failed")
+		} else {
+			pr.Status.MarkSucceeded("Succeeded", "deployed")
+		}
+
+		pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+
+		return pr
+	}
+
+	stageDeploy := func(status string) *codebaseApi.CDStageDeploy {
+		return &codebaseApi.CDStageDeploy{
+			ObjectMeta: metav1.ObjectMeta{Name: "mypipe-qa-x7k2p", Namespace: "default"},
+			Spec: codebaseApi.CDStageDeploySpec{
+				Pipeline: "mypipe",
+				Stage:    "qa",
+				Tag:      codebaseApi.CodebaseTag{Codebase: "app", Tag: "0.1.0-SNAPSHOT.2"},
+			},
+			Status: codebaseApi.CDStageDeployStatus{Status: status},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		stageDeploy *codebaseApi.CDStageDeploy
+		objects     []client.Object
+		jiraClient  func(t *testing.T) jira.Client
+	}{
+		{
+			name:        "should transition issues with the JiraServer rule",
+			stageDeploy: stageDeploy(codebaseApi.CDStageDeployStatusCompleted),
+			objects: []client.Object{
+				codebase(),
+				jiraServer,
+				stream,
+				pipelineRun("deploy-qa-1", "mypipe-qa-x7k2p", false, now),
+			},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("TransitionIssue", mock.Anything, "APP-1", "Deployed to QA").Return(nil)
+				m.On("TransitionIssue", mock.Anything, "APP-2", "Deployed to QA").Return(nil)
+
+				return m
+			},
+		},
+		{
+			name:        "should use the rules of the codebase",
+			stageDeploy: stageDeploy(codebaseApi.CDStageDeployStatusCompleted),
+			objects: []client.Object{
+				codebase(codebaseApi.JiraTransition{Pipeline: "mypipe", Transition: "Ready for Testing"}),
+				jiraServer,
+				stream,
+			},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("TransitionIssue", mock.Anything, "APP-1", "Ready for Testing").Return(nil)
+				m.On("TransitionIssue", mock.Anything, "APP-2", "Ready for Testing").
+					Return(errors.New("transition is not available"))
+
+				return m
+			},
+		},
+		{
+			name:        "should transition issues of a failed deployment",
+			stageDeploy: stageDeploy(codebaseApi.CDStageDeployStatusCompleted),
+			objects: []client.Object{
+				codebase(),
+				jiraServer,
+				stream,
+				pipelineRun("deploy-qa-1", "mypipe-qa-x7k2p", false, now.Add(-time.Minute)),
+				pipelineRun("deploy-qa-2", "mypipe-qa-x7k2p", true, now),
+			},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("TransitionIssue", mock.Anything, "APP-1", "QA Deployment Failed").Return(nil)
+				m.On("TransitionIssue", mock.Anything, "APP-2", "QA Deployment Failed").Return(nil)
+
+				return m
+			},
+		},
+		{
+			name:        "should ignore PipelineRuns of other deployments of the stage",
+			stageDeploy: stageDeploy(codebaseApi.CDStageDeployStatusCompleted),
+			objects: []client.Object{
+				codebase(),
+				jiraServer,
+				stream,
+				pipelineRun("deploy-qa-1", "mypipe-qa-x7k2p", false, now.Add(-time.Hour)),
+				pipelineRun("deploy-qa-2", "mypipe-qa-b4m9z", true, now),
+			},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("TransitionIssue", mock.Anything, "APP-1", "Deployed to QA").Return(nil)
+				m.On("TransitionIssue", mock.Anything, "APP-2", "Deployed to QA").Return(nil)
+
+				return m
+			},
+		},
+		{
+			name:        "should skip a stage without rules",
+			stageDeploy: stageDeploy(codebaseApi.CDStageDeployStatusCompleted),
+			objects: []client.Object{
+				codebase(codebaseApi.JiraTransition{Stage: "prod", Transition: "Released"}),
+				jiraServer,
+				stream,
+			},
+		},
+		{
+			name:        "should skip a running deployment",
+			stageDeploy: stageDeploy(codebaseApi.CDStageDeployStatusRunning),
+			objects:     []client.Object{codebase(), jiraServer, stream},
+		},
+		{
+			name:        "should not fail if the codebase is missing",
+			stageDeploy: stageDeploy(codebaseApi.CDStageDeployStatusCompleted),
+			objects:     []client.Object{jiraServer, stream},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &TransitionJiraIssues{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				newJiraClient: func(context.Context, *codebaseApi.JiraServer) (jira.Client, error) {
+					if tt.jiraClient == nil {
+						t.Fatal("Jira client should not be created")
+					}
+
+					return tt.jiraClient(t), nil
+				},
+			}
+
+			err := h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.stageDeploy)
+			require.NoError(t, err)
+		})
+	}
+}
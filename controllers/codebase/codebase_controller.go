@@ -22,6 +22,8 @@ import (
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/codebase/service/chain"
 	cHand "github.com/epam/edp-codebase-operator/v2/controllers/codebase/service/chain/handler"
+	"github.com/epam/edp-codebase-operator/v2/controllers/codebase/webhookdrift"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 	"github.com/epam/edp-codebase-operator/v2/pkg/objectmodifier"
 	codebasepredicate "github.com/epam/edp-codebase-operator/v2/pkg/predicate"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
@@ -30,12 +32,18 @@ import (
 
 const codebaseOperatorFinalizerName = "codebase.operator.finalizer.name"
 
-func NewReconcileCodebase(c client.Client, scheme *runtime.Scheme, log logr.Logger) *ReconcileCodebase {
+func NewReconcileCodebase(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	httpClients *gitprovider.HTTPClientPool,
+) *ReconcileCodebase {
 	return &ReconcileCodebase{
-		client:   c,
-		scheme:   scheme,
-		log:      log.WithName("codebase"),
-		modifier: objectmodifier.NewCodebaseModifier(c),
+		client:      c,
+		scheme:      scheme,
+		log:         log.WithName("codebase"),
+		modifier:    objectmodifier.NewCodebaseModifier(c),
+		httpClients: httpClients,
 	}
 }
 
@@ -45,6 +53,7 @@ type ReconcileCodebase struct {
 	log         logr.Logger
 	chainGetter func(cr *codebaseApi.Codebase) (cHand.CodebaseHandler, error)
 	modifier    *objectmodifier.CodebaseModifier
+	httpClients *gitprovider.HTTPClientPool
 }
 
 func (r *ReconcileCodebase) SetupWithManager(mgr ctrl.Manager) error {
@@ -183,6 +192,7 @@ func (r *ReconcileCodebase) updateFinishStatus(ctx context.Context, c *codebaseA
 		WebHookID:       c.Status.WebHookID,
 		WebHookRef:      webHookRef,
 		GitWebUrl:       c.Status.GitWebUrl,
+		WebHook:         c.Status.WebHook,
 	}
 
 	if err := r.client.Status().Update(ctx, c); err != nil {
@@ -213,7 +223,7 @@ func (r *ReconcileCodebase) getChain(
 ) (cHand.CodebaseHandler, error) {
 	if r.chainGetter == nil {
 		r.chainGetter = func(cr *codebaseApi.Codebase) (cHand.CodebaseHandler, error) {
-			return chain.MakeChain(ctx, r.client), nil
+			return chain.MakeChain(ctx, r.client, r.httpClients), nil
 		}
 	}
 
@@ -236,11 +246,14 @@ func (r *ReconcileCodebase) tryToDeleteCodebase(
 		return nil, err
 	}
 
-	if err := chain.MakeDeletionChain(ctx, r.client).ServeRequest(ctx, codebase); err != nil {
+	if err := chain.MakeDeletionChain(ctx, r.client, r.httpClients).ServeRequest(ctx, codebase); err != nil {
 		return nil, fmt.Errorf("failed to make deletion chain: %w", err)
 	}
 
 	log.Info("Codebase deletion chain has been finished successfully")
+
+	webhookdrift.DeleteMetrics(codebase.Namespace, codebase.Name)
+
 	log.Info("Removing finalizer from Codebase", "finalizer", codebaseOperatorFinalizerName)
 
 	controllerutil.RemoveFinalizer(codebase, codebaseOperatorFinalizerName)
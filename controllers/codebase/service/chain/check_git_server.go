@@ -0,0 +1,54 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+// CheckGitServer is a chain element that stops the provisioning of a codebase when its
+// GitServer reports that the git provider API cannot be used with the access token, so that
+// the reason shows up in the codebase status instead of a failure halfway through.
+type CheckGitServer struct {
+	client client.Client
+}
+
+// NewCheckGitServer creates a new CheckGitServer chain element.
+func NewCheckGitServer(k8sClient client.Client) *CheckGitServer {
+	return &CheckGitServer{client: k8sClient}
+}
+
+// ServeRequest checks the git provider API conditions of the codebase GitServer.
+func (s *CheckGitServer) ServeRequest(ctx context.Context, codebase *codebaseApi.Codebase) error {
+	// Provisioned codebases keep working when, e.g., the rate limit runs low for a while.
+	if codebase.Status.Available {
+		return nil
+	}
+
+	gitServer := &codebaseApi.GitServer{}
+	if err := s.client.Get(
+		ctx,
+		client.ObjectKey{Name: codebase.Spec.GitServer, Namespace: codebase.Namespace},
+		gitServer,
+	); err != nil {
+		err = fmt.Errorf("failed to get git server %s: %w", codebase.Spec.GitServer, err)
+		setFailedFields(codebase, codebaseApi.CheckGitServer, err.Error())
+
+		return err
+	}
+
+	if reason := gitServer.Status.APIUnavailableReason(); reason != "" {
+		err := fmt.Errorf("git server %s cannot be used: %s", gitServer.Name, reason)
+		setFailedFields(codebase, codebaseApi.CheckGitServer, err.Error())
+
+		return err
+	}
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Git server API is available", "gitServer", gitServer.Name)
+
+	return nil
+}
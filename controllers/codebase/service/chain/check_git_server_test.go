@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+func TestCheckGitServer_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	const namespace = "test-ns"
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	newGitServer := func(conditions ...metaV1.Condition) *codebaseApi.GitServer {
+		return &codebaseApi.GitServer{
+			ObjectMeta: metaV1.ObjectMeta{Name: "git-server", Namespace: namespace},
+			Status:     codebaseApi.GitServerStatus{Conditions: conditions},
+		}
+	}
+
+	missingScopes := metaV1.Condition{
+		Type:    codebaseApi.GitServerConditionPermissionsSufficient,
+		Status:  metaV1.ConditionFalse,
+		Reason:  codebaseApi.ReasonScopesMissing,
+		Message: "The access token lacks scopes: api",
+	}
+
+	tests := []struct {
+		name        string
+		gitServer   *codebaseApi.GitServer
+		available   bool
+		wantErr     require.ErrorAssertionFunc
+		errContains string
+	}{
+		{
+			name:      "not probed git server",
+			gitServer: newGitServer(),
+			wantErr:   require.NoError,
+		},
+		{
+			name: "unknown permissions",
+			gitServer: newGitServer(metaV1.Condition{
+				Type:   codebaseApi.GitServerConditionPermissionsSufficient,
+				Status: metaV1.ConditionUnknown,
+				Reason: codebaseApi.ReasonScopesNotReported,
+			}),
+			wantErr: require.NoError,
+		},
+		{
+			name:        "missing scopes",
+			gitServer:   newGitServer(missingScopes),
+			wantErr:     require.Error,
+			errContains: "git server git-server cannot be used: The access token lacks scopes: api",
+		},
+		{
+			name:      "available codebase",
+			gitServer: newGitServer(missingScopes),
+			available: true,
+			wantErr:   require.NoError,
+		},
+		{
+			name:        "git server not found",
+			wantErr:     require.Error,
+			errContains: "failed to get git server git-server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			codebase := &codebaseApi.Codebase{
+				ObjectMeta: metaV1.ObjectMeta{Name: "test", Namespace: namespace},
+				Spec:       codebaseApi.CodebaseSpec{GitServer: "git-server"},
+				Status:     codebaseApi.CodebaseStatus{Available: tt.available},
+			}
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.gitServer != nil {
+				builder = builder.WithObjects(tt.gitServer)
+			}
+
+			err := NewCheckGitServer(builder.Build()).ServeRequest(context.Background(), codebase)
+
+			tt.wantErr(t, err)
+
+			if tt.errContains != "" {
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Equal(t, util.StatusFailed, codebase.Status.Status)
+				assert.Equal(t, codebaseApi.CheckGitServer, codebase.Status.Action)
+			}
+		})
+	}
+}
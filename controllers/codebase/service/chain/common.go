@@ -12,6 +12,7 @@ import (
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	gitproviderv2 "github.com/epam/edp-codebase-operator/v2/pkg/git"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitconnection"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
 
@@ -26,6 +27,17 @@ type GitRepositoryContext struct {
 	Token           string
 	RepoGitUrl      string
 	WorkDir         string
+
+	// Connection holds the proxy and TLS settings of the GitServer.
+	Connection gitconnection.Settings
+}
+
+// GitConfig returns the git provider configuration of the GitServer.
+func (c *GitRepositoryContext) GitConfig() gitproviderv2.Config {
+	cfg := gitproviderv2.NewConfigFromGitServerAndSecret(c.GitServer, c.GitServerSecret)
+	cfg.Connection = c.Connection
+
+	return cfg
 }
 
 func setIntermediateSuccessFields(
@@ -53,6 +65,7 @@ func setIntermediateSuccessFields(
 		WebHookID:       cb.Status.WebHookID,
 		WebHookRef:      webHookRef,
 		GitWebUrl:       cb.Status.GitWebUrl,
+		WebHook:         cb.Status.WebHook,
 	}
 
 	if err := c.Status().Update(ctx, cb); err != nil {
@@ -119,12 +132,7 @@ func PrepareGitRepository(
 		return nil, err
 	}
 
-	gitProvider := gitProviderFactory(
-		gitproviderv2.NewConfigFromGitServerAndSecret(
-			gitRepoCtx.GitServer,
-			gitRepoCtx.GitServerSecret,
-		),
-	)
+	gitProvider := gitProviderFactory(gitRepoCtx.GitConfig())
 
 	if !util.DoesDirectoryExist(gitRepoCtx.WorkDir) || util.IsDirectoryEmpty(gitRepoCtx.WorkDir) {
 		log.Info("Start cloning repository", "url", gitRepoCtx.RepoGitUrl)
@@ -171,6 +179,11 @@ func GetGitRepositoryContext(
 		return nil, fmt.Errorf("failed to get GitServer secret: %w", err)
 	}
 
+	conn, err := gitconnection.Load(ctx, c, gitServer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GitServer connection settings: %w", err)
+	}
+
 	return &GitRepositoryContext{
 		GitServer:       gitServer,
 		GitServerSecret: gitServerSecret,
@@ -179,5 +192,6 @@ func GetGitRepositoryContext(
 		Token:           string(gitServerSecret.Data[util.GitServerSecretTokenField]),
 		RepoGitUrl:      util.GetProjectGitUrl(gitServer, gitServerSecret, codebase.Spec.GetProjectID()),
 		WorkDir:         util.GetWorkDir(codebase.Name, codebase.Namespace),
+		Connection:      conn,
 	}, nil
 }
@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
-	"github.com/go-resty/resty/v2"
 	coreV1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,13 +18,17 @@ import (
 // DeleteWebHook is a chain element to delete webhook.
 type DeleteWebHook struct {
 	client      client.Client
-	restyClient *resty.Client
+	httpClients *gitprovider.HTTPClientPool
 	log         logr.Logger
 }
 
 // NewDeleteWebHook creates DeleteWebHook instance.
-func NewDeleteWebHook(k8sClient client.Client, restyClient *resty.Client, log logr.Logger) *DeleteWebHook {
-	return &DeleteWebHook{client: k8sClient, restyClient: restyClient, log: log}
+func NewDeleteWebHook(
+	k8sClient client.Client,
+	httpClients *gitprovider.HTTPClientPool,
+	log logr.Logger,
+) *DeleteWebHook {
+	return &DeleteWebHook{client: k8sClient, httpClients: httpClients, log: log}
 }
 
 // ServeRequest deletes webhook.
@@ -63,9 +66,16 @@ func (s *DeleteWebHook) ServeRequest(ctx context.Context, codebase *codebaseApi.
 		return nil
 	}
 
+	restyClient, err := s.httpClients.RestyClient(ctx, gitServer)
+	if err != nil {
+		log.Error(err, "Failed to delete webhook: unable to create HTTP client")
+
+		return nil
+	}
+
 	gitProvider, err := gitprovider.NewProvider(
 		gitServer,
-		s.restyClient,
+		restyClient,
 		string(secret.Data[util.GitServerSecretTokenField]),
 	)
 	if err != nil {
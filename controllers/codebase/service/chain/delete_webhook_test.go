@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,13 +17,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 	"github.com/epam/edp-codebase-operator/v2/pkg/platform"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
 
 func TestDeleteWebHook_ServeRequest(t *testing.T) {
-	restyClient := resty.New()
-	httpmock.ActivateNonDefault(restyClient.GetClient())
+	httpClients := gitprovider.NewHTTPClientPool(
+		nil,
+		gitprovider.WithTransport(httpmock.DefaultTransport),
+		gitprovider.WithRetries(0, 0),
+	)
 
 	defer httpmock.DeactivateAndReset()
 
@@ -381,7 +384,7 @@ func TestDeleteWebHook_ServeRequest(t *testing.T) {
 			loggerSink, ok := logger.GetSink().(*platform.LoggerMock)
 			require.True(t, ok)
 
-			s := NewDeleteWebHook(k8sClient, restyClient, logger)
+			s := NewDeleteWebHook(k8sClient, httpClients, logger)
 
 			assert.NoError(t, s.ServeRequest(ctrl.LoggerInto(context.Background(), logger), tt.codebase))
 			assert.Equalf(
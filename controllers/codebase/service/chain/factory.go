@@ -3,7 +3,6 @@ package chain
 import (
 	"context"
 
-	"github.com/go-resty/resty/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,7 +13,7 @@ import (
 	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 )
 
-func MakeChain(ctx context.Context, c client.Client) handler.CodebaseHandler {
+func MakeChain(ctx context.Context, c client.Client, httpClients *gitprovider.HTTPClientPool) handler.CodebaseHandler {
 	log := ctrl.LoggerFrom(ctx)
 
 	log.Info("Default chain is selected")
@@ -23,14 +22,15 @@ func MakeChain(ctx context.Context, c client.Client) handler.CodebaseHandler {
 	gitlabCIManager := gitlabci.NewManager(c)
 
 	ch.Use(
+		NewCheckGitServer(c),
 		NewPutGitWebRepoUrl(c),
 		NewPutProject(
 			c,
 			&gerrit.SSHGerritClient{},
-			gitprovider.NewGitProjectProvider,
+			httpClients.NewGitProjectProvider,
 			gitproviderv2.NewGitProviderFactory,
 		),
-		NewPutWebHook(c, resty.New()),
+		NewPutWebHook(c, httpClients),
 		NewPutGitLabCIConfig(c, gitlabCIManager, gitproviderv2.NewGitProviderFactory),
 		NewPutDeployConfigs(c, gitproviderv2.NewGitProviderFactory),
 		NewPutDefaultCodeBaseBranch(c),
@@ -40,7 +40,11 @@ func MakeChain(ctx context.Context, c client.Client) handler.CodebaseHandler {
 	return ch
 }
 
-func MakeDeletionChain(ctx context.Context, c client.Client) handler.CodebaseHandler {
+func MakeDeletionChain(
+	ctx context.Context,
+	c client.Client,
+	httpClients *gitprovider.HTTPClientPool,
+) handler.CodebaseHandler {
 	log := ctrl.LoggerFrom(ctx)
 
 	log.Info("Deletion chain is selected")
@@ -48,7 +52,7 @@ func MakeDeletionChain(ctx context.Context, c client.Client) handler.CodebaseHan
 	ch := &chain{}
 
 	ch.Use(
-		NewDeleteWebHook(c, resty.New(), log),
+		NewDeleteWebHook(c, httpClients, log),
 		NewCleaner(c),
 	)
 
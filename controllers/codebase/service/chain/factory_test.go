@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 )
 
 func TestMakeChain(t *testing.T) {
@@ -14,6 +16,7 @@ func TestMakeChain(t *testing.T) {
 	c := MakeChain(
 		context.Background(),
 		fake.NewClientBuilder().Build(),
+		gitprovider.NewHTTPClientPool(nil),
 	)
 
 	assert.NotNil(t, c)
@@ -25,6 +28,7 @@ func TestMakeDeletionChain(t *testing.T) {
 	c := MakeDeletionChain(
 		context.Background(),
 		fake.NewClientBuilder().Build(),
+		gitprovider.NewHTTPClientPool(nil),
 	)
 
 	assert.NotNil(t, c)
@@ -67,7 +67,7 @@ func (h *PutDeployConfigs) tryToPushConfigs(ctx context.Context, codebase *codeb
 	}
 
 	// Create git provider using factory
-	g := h.gitProviderFactory(gitproviderv2.NewConfigFromGitServerAndSecret(gitCtx.GitServer, gitCtx.GitServerSecret))
+	g := h.gitProviderFactory(gitCtx.GitConfig())
 
 	// Add Gerrit-specific commit hooks if needed
 	if gitCtx.GitServer.Spec.GitProvider == codebaseApi.GitProviderGerrit {
@@ -104,7 +104,7 @@ func (h *PutGitLabCIConfig) tryToPushGitLabCIConfig(ctx context.Context, codebas
 	}
 
 	// Create git provider using factory
-	g := h.gitProviderFactory(gitproviderv2.NewConfigFromGitServerAndSecret(gitCtx.GitServer, gitCtx.GitServerSecret))
+	g := h.gitProviderFactory(gitCtx.GitConfig())
 
 	// Inject GitLab CI configuration
 	log.Info("Start injecting GitLab CI config")
@@ -25,7 +25,7 @@ import (
 type PutProject struct {
 	k8sClient             client.Client
 	gerritClient          gerrit.Client
-	gitApiProjectProvider func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error)
+	gitApiProjectProvider func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error)
 	gitProviderFactory    gitproviderv2.GitProviderFactory
 	gitProviderNoAuth     gitproviderv2.Git
 }
@@ -42,7 +42,7 @@ var (
 func NewPutProject(
 	c client.Client,
 	gerritProvider gerrit.Client,
-	gitApiProjectProvider func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error),
+	gitApiProjectProvider func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error),
 	gitProviderFactory gitproviderv2.GitProviderFactory,
 ) *PutProject {
 	return &PutProject{
@@ -211,12 +211,7 @@ func (h *PutProject) remoteDefaultBranchAbsent(
 	codebase *codebaseApi.Codebase,
 	repoContext *GitRepositoryContext,
 ) (bool, error) {
-	gitProvider := h.gitProviderFactory(
-		gitproviderv2.NewConfigFromGitServerAndSecret(
-			repoContext.GitServer,
-			repoContext.GitServerSecret,
-		),
-	)
+	gitProvider := h.gitProviderFactory(repoContext.GitConfig())
 
 	repoURL := util.GetProjectGitUrl(repoContext.GitServer, repoContext.GitServerSecret, codebase.Spec.GetProjectID())
 
@@ -320,12 +315,7 @@ func (h *PutProject) pushProject(ctx context.Context, projectName string, repoCo
 	log.Info("Start pushing project")
 	log.Info("Start adding remote link")
 
-	gitProvider := h.gitProviderFactory(
-		gitproviderv2.NewConfigFromGitServerAndSecret(
-			repoContext.GitServer,
-			repoContext.GitServerSecret,
-		),
-	)
+	gitProvider := h.gitProviderFactory(repoContext.GitConfig())
 
 	if err := gitProvider.AddRemoteLink(
 		ctx,
@@ -407,12 +397,7 @@ func (h *PutProject) checkoutBranch(
 		codebase.Spec.BranchToCopyInDefaultBranch,
 	)
 
-	gitProvider := h.gitProviderFactory(
-		gitproviderv2.NewConfigFromGitServerAndSecret(
-			repoContext.GitServer,
-			repoContext.GitServerSecret,
-		),
-	)
+	gitProvider := h.gitProviderFactory(repoContext.GitConfig())
 
 	if codebase.Spec.BranchToCopyInDefaultBranch != "" &&
 		codebase.Spec.DefaultBranch != codebase.Spec.BranchToCopyInDefaultBranch {
@@ -490,7 +475,7 @@ func (h *PutProject) createGitThirdPartyProject(
 
 	log.Info("Start creating project in git provider")
 
-	gitProvider, err := h.gitApiProjectProvider(gitServer, gitProviderToken)
+	gitProvider, err := h.gitApiProjectProvider(ctx, gitServer, gitProviderToken)
 	if err != nil {
 		return fmt.Errorf("failed to create git provider: %w", err)
 	}
@@ -569,7 +554,7 @@ func (h *PutProject) setDefaultBranch(
 
 	log.Info("Set default branch in git provider")
 
-	gitProvider, err := h.gitApiProjectProvider(gitServer, gitProviderToken)
+	gitProvider, err := h.gitApiProjectProvider(ctx, gitServer, gitProviderToken)
 	if err != nil {
 		return fmt.Errorf("failed to create git provider: %w", err)
 	}
@@ -753,5 +738,6 @@ func setFailedFields(c *codebaseApi.Codebase, a codebaseApi.ActionType, message
 		WebHookID:       c.Status.WebHookID,
 		WebHookRef:      webHookRef,
 		GitWebUrl:       c.Status.GitWebUrl,
+		WebHook:         c.Status.WebHook,
 	}
 }
@@ -41,7 +41,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 		gitProviderFactory func(t *testing.T) gitproviderv2.GitProviderFactory
 		gitProvider        func(
 			t *testing.T,
-		) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error)
+		) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error)
 		wantErr                 require.ErrorAssertionFunc
 		wantStatus              func(t *testing.T, status codebaseApi.CodebaseStatus)
 		wantCodebaseErrorStatus func(t *testing.T, codebase *codebaseApi.Codebase)
@@ -64,7 +64,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				return nil
 			},
 			wantErr: require.NoError,
@@ -93,7 +93,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				return nil
 			},
 			wantErr: require.NoError,
@@ -122,7 +122,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				return nil
 			},
 			wantErr: require.NoError,
@@ -151,7 +151,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				return nil
 			},
 			wantErr: require.NoError,
@@ -214,7 +214,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
@@ -223,7 +223,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -287,14 +287,14 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(true, nil)
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -362,7 +362,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
@@ -371,7 +371,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -433,12 +433,12 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, errors.New("API connection failed"))
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -501,14 +501,14 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
 				mock.EXPECT().CreateProject(testify.Anything, testify.Anything, testify.Anything, "test-app", testify.Anything).
 					Return(errors.New("permission denied"))
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -574,14 +574,14 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
 				mock.EXPECT().CreateProject(testify.Anything, testify.Anything, testify.Anything, "test-app", testify.Anything).
 					Return(nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -646,7 +646,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
@@ -655,7 +655,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(errors.New("branch does not exist"))
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -720,7 +720,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
@@ -729,7 +729,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(gitprovider.ErrApiNotSupported)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -790,8 +790,8 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mocks.NewMockGitProjectProvider(t), nil
 				}
 			},
@@ -849,8 +849,8 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mocks.NewMockGitProjectProvider(t), nil
 				}
 			},
@@ -916,7 +916,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
@@ -925,7 +925,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -989,7 +989,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
@@ -998,7 +998,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "master").
 					Return(nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -1061,12 +1061,12 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(nil).Once()
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -1134,7 +1134,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(false, nil)
@@ -1143,7 +1143,7 @@ func TestPutProject_ServeRequest(t *testing.T) {
 				mock.EXPECT().SetDefaultBranch(testify.Anything, testify.Anything, testify.Anything, "test-app", "main").
 					Return(nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -1208,12 +1208,12 @@ func TestPutProject_ServeRequest(t *testing.T) {
 			},
 			gitProvider: func(
 				t *testing.T,
-			) func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+			) func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 				mock := mocks.NewMockGitProjectProvider(t)
 				mock.EXPECT().ProjectExists(testify.Anything, testify.Anything, testify.Anything, "test-app").
 					Return(true, nil)
 
-				return func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mock, nil
 				}
 			},
@@ -1725,7 +1725,7 @@ func TestPutProject_ServeRequest_Gerrit(t *testing.T) {
 			h := NewPutProject(
 				k8sClient,
 				tt.gerritClient(t),
-				func(gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
+				func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (gitprovider.GitProjectProvider, error) {
 					return mocks.NewMockGitProjectProvider(t), nil
 				},
 				tt.gitProviderFactory(t),
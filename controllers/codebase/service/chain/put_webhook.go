@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/go-resty/resty/v2"
 	routeApi "github.com/openshift/api/route/v1"
 	coreV1 "k8s.io/api/core/v1"
 	networkingV1 "k8s.io/api/networking/v1"
@@ -16,7 +15,6 @@ import (
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/gitserver"
 	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
-	"github.com/epam/edp-codebase-operator/v2/pkg/platform"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
 
@@ -27,12 +25,12 @@ const (
 // PutWebHook is a chain element to create webhook.
 type PutWebHook struct {
 	client      client.Client
-	restyClient *resty.Client
+	httpClients *gitprovider.HTTPClientPool
 }
 
 // NewPutWebHook creates PutWebHook instance.
-func NewPutWebHook(k8sClient client.Client, restyClient *resty.Client) *PutWebHook {
-	return &PutWebHook{client: k8sClient, restyClient: restyClient}
+func NewPutWebHook(k8sClient client.Client, httpClients *gitprovider.HTTPClientPool) *PutWebHook {
+	return &PutWebHook{client: k8sClient, httpClients: httpClients}
 }
 
 // ServeRequest creates webhook.
@@ -70,9 +68,14 @@ func (s *PutWebHook) ServeRequest(ctx context.Context, codebase *codebaseApi.Cod
 		return s.processCodebaseError(codebase, err)
 	}
 
+	restyClient, err := s.httpClients.RestyClient(ctx, gitServer)
+	if err != nil {
+		return s.processCodebaseError(codebase, err)
+	}
+
 	gitProvider, err := gitprovider.NewProvider(
 		gitServer,
-		s.restyClient,
+		restyClient,
 		string(secret.Data[util.GitServerSecretTokenField]),
 	)
 	if err != nil {
@@ -120,6 +123,10 @@ func (s *PutWebHook) ServeRequest(ctx context.Context, codebase *codebaseApi.Cod
 	}
 
 	codebase.Status.WebHookRef = webHook.ID
+	codebase.Status.WebHook = &codebaseApi.CodebaseWebHookStatus{
+		URL:        webHookURL,
+		SecretHash: gitprovider.WebHookSecretHash(string(secret.Data[util.GitServerSecretWebhookSecretField])),
+	}
 
 	if err = setIntermediateSuccessFields(ctx, s.client, codebase, codebaseApi.PutWebHook); err != nil {
 		return fmt.Errorf("failed to update codebase %s status: %w", codebase.Name, err)
@@ -161,15 +168,19 @@ func (s *PutWebHook) getWebHookUrl(ctx context.Context, gitServer *codebaseApi.G
 		return gitServer.Spec.WebhookUrl, nil
 	}
 
-	if !platform.IsK8S() {
-		return s.getWebhookRouteUrl(ctx, gitServer.Name, gitServer.Namespace)
+	// The GitServer controller reports the effective URL once the EventListener is exposed.
+	if gitServer.Status.Webhook != nil && gitServer.Status.Webhook.URL != "" {
+		return gitServer.Status.Webhook.URL, nil
 	}
 
-	if platform.IsEnvoy() {
+	switch gitserver.EventListenerExposure(gitServer) {
+	case codebaseApi.EventListenerExposureRoute:
+		return s.getWebhookRouteUrl(ctx, gitServer.Name, gitServer.Namespace)
+	case codebaseApi.EventListenerExposureHTTPRoute:
 		return s.getWebhookHTTPRouteUrl(ctx, gitServer.Name, gitServer.Namespace)
+	default:
+		return s.getWebhookIngressUrl(ctx, gitServer.Name, gitServer.Namespace)
 	}
-
-	return s.getWebhookIngressUrl(ctx, gitServer.Name, gitServer.Namespace)
 }
 
 func (*PutWebHook) processCodebaseError(codebase *codebaseApi.Codebase, err error) error {
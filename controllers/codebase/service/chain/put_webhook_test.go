@@ -8,7 +8,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/jarcoal/httpmock"
 	routeApi "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +22,7 @@ import (
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/gitserver"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 	"github.com/epam/edp-codebase-operator/v2/pkg/platform"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
@@ -32,8 +32,11 @@ const (
 )
 
 func TestPutWebHook_ServeRequest(t *testing.T) {
-	restyClient := resty.New()
-	httpmock.ActivateNonDefault(restyClient.GetClient())
+	httpClients := gitprovider.NewHTTPClientPool(
+		nil,
+		gitprovider.WithTransport(httpmock.DefaultTransport),
+		gitprovider.WithRetries(0, 0),
+	)
 
 	defer httpmock.DeactivateAndReset()
 
@@ -56,6 +59,7 @@ func TestPutWebHook_ServeRequest(t *testing.T) {
 		responder   func(t *testing.T)
 		wantErr     require.ErrorAssertionFunc
 		errContains string
+		wantWebHook *codebaseApi.CodebaseWebHookStatus
 	}{
 		{
 			name: "success gitlab",
@@ -174,6 +178,10 @@ func TestPutWebHook_ServeRequest(t *testing.T) {
 				httpmock.RegisterRegexpResponder(http.MethodGet, fakeUrlRegexp, GETResponder)
 			},
 			wantErr: require.NoError,
+			wantWebHook: &codebaseApi.CodebaseWebHookStatus{
+				URL:        "https://fake.gitlab.com/webhook",
+				SecretHash: gitprovider.WebHookSecretHash("test-webhook-secret"),
+			},
 		},
 		{
 			name: "success gitlab with route",
@@ -1057,7 +1065,7 @@ func TestPutWebHook_ServeRequest(t *testing.T) {
 				WithObjects(tt.k8sObjects...).
 				WithStatusSubresource(tt.k8sObjects...).
 				Build()
-			s := NewPutWebHook(k8sClient, restyClient)
+			s := NewPutWebHook(k8sClient, httpClients)
 
 			gotErr := s.ServeRequest(context.Background(), tt.codebase)
 			tt.wantErr(t, gotErr)
@@ -1065,6 +1073,10 @@ func TestPutWebHook_ServeRequest(t *testing.T) {
 			if tt.errContains != "" {
 				assert.Contains(t, gotErr.Error(), tt.errContains)
 			}
+
+			if tt.wantWebHook != nil {
+				assert.Equal(t, tt.wantWebHook, tt.codebase.Status.WebHook)
+			}
 		})
 	}
 }
@@ -1080,13 +1092,54 @@ func TestPutWebHook_getWebhookHTTPRouteUrl(t *testing.T) {
 		WithObjects(fakeHTTPRoute(gitserver.GenerateIngressName("test-git-server"), host)).
 		Build()
 
-	s := NewPutWebHook(k8sClient, resty.New())
+	s := NewPutWebHook(k8sClient, gitprovider.NewHTTPClientPool(nil))
 
 	got, err := s.getWebhookHTTPRouteUrl(context.Background(), "test-git-server", namespace)
 	require.NoError(t, err)
 	assert.Equal(t, util.GetHostWithProtocol(host), got)
 }
 
+func TestPutWebHook_getWebHookUrl(t *testing.T) {
+	schema := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(schema))
+	require.NoError(t, networkingV1.AddToScheme(schema))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(schema).
+		WithObjects(
+			fakeHTTPRoute(gitserver.GenerateIngressName("test-git-server"), "el-route.example.com"),
+			fakeIngress(gitserver.GenerateIngressName("test-git-server"), "el-ingress.example.com"),
+		).
+		Build()
+
+	s := NewPutWebHook(k8sClient, gitprovider.NewHTTPClientPool(nil))
+
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "test-git-server",
+			Namespace: namespace,
+		},
+		Spec: codebaseApi.GitServerSpec{
+			EventListener: &codebaseApi.GitServerEventListener{
+				Exposure: codebaseApi.EventListenerExposureHTTPRoute,
+			},
+		},
+	}
+
+	got, err := s.getWebHookUrl(context.Background(), gitServer)
+	require.NoError(t, err)
+	assert.Equal(t, "https://el-route.example.com", got)
+
+	gitServer.Status.Webhook = &codebaseApi.GitServerWebhookStatus{
+		URL:      "http://el-status.example.com",
+		Exposure: codebaseApi.EventListenerExposureHTTPRoute,
+	}
+
+	got, err = s.getWebHookUrl(context.Background(), gitServer)
+	require.NoError(t, err)
+	assert.Equal(t, "http://el-status.example.com", got)
+}
+
 func fakeHTTPRoute(name, host string) *gatewayv1.HTTPRoute {
 	return &gatewayv1.HTTPRoute{
 		ObjectMeta: metaV1.ObjectMeta{
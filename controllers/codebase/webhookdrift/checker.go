@@ -0,0 +1,412 @@
+package webhookdrift
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+const (
+	EventReasonWebhookDriftDetected  = "WebhookDriftDetected"
+	EventReasonWebhookRepaired       = "WebhookRepaired"
+	EventReasonWebhookRepairFailed   = "WebhookRepairFailed"
+	EventReasonWebhookSecretRotated  = "WebhookSecretRotated"
+	EventReasonWebhookRotationFailed = "WebhookSecretRotationFailed"
+
+	webhookSecretLength = 20
+)
+
+// GitProviderFactory is the injection seam for tests;
+// production wiring uses NewGitProviderFactory.
+type GitProviderFactory func(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	token string,
+) (gitprovider.GitWebHookProvider, error)
+
+// NewGitProviderFactory creates a GitProviderFactory that uses the shared HTTP clients of the GitServers.
+func NewGitProviderFactory(httpClients *gitprovider.HTTPClientPool) GitProviderFactory {
+	return func(
+		ctx context.Context,
+		gitServer *codebaseApi.GitServer,
+		token string,
+	) (gitprovider.GitWebHookProvider, error) {
+		restyClient, err := httpClients.RestyClient(ctx, gitServer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get git provider HTTP client: %w", err)
+		}
+
+		provider, err := gitprovider.NewProvider(gitServer, restyClient, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create git provider: %w", err)
+		}
+
+		return provider, nil
+	}
+}
+
+// Checker periodically compares the webhook of every Codebase in the git provider with the
+// configuration the operator sets up: URL, events, SSL verification and secret.
+// It recreates missing webhooks, reconfigures drifted ones and rotates the webhook secret
+// of a GitServer on request.
+//
+// It runs as a manager Runnable (leader-only) rather than a watch-driven controller
+// because the webhook configuration is external state that no Kubernetes event reports.
+type Checker struct {
+	client          client.Client
+	namespace       string
+	interval        time.Duration
+	providerFactory GitProviderFactory
+	recorder        record.EventRecorder
+}
+
+func NewChecker(
+	k8sClient client.Client,
+	namespace string,
+	interval time.Duration,
+	providerFactory GitProviderFactory,
+	recorder record.EventRecorder,
+) *Checker {
+	return &Checker{
+		client:          k8sClient,
+		namespace:       namespace,
+		interval:        interval,
+		providerFactory: providerFactory,
+		recorder:        recorder,
+	}
+}
+
+// Start implements manager.Runnable. It sweeps once on startup and then on every tick.
+func (c *Checker) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("webhook-drift-checker")
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	log.Info("Starting webhook drift checker", "interval", c.interval)
+
+	c.sweep(ctx)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Stopping webhook drift checker")
+			return nil
+		case <-ticker.C:
+			c.sweep(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection ensures only the elected leader talks to git servers.
+func (c *Checker) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *Checker) sweep(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Checking codebase webhooks")
+
+	gitServers := &codebaseApi.GitServerList{}
+	if err := c.client.List(ctx, gitServers, client.InNamespace(c.namespace)); err != nil {
+		log.Error(err, "Failed to list git servers")
+		return
+	}
+
+	// Secrets are rotated before the codebases are checked, so that the webhooks get
+	// the new secret in the same sweep.
+	for i := range gitServers.Items {
+		gitServer := &gitServers.Items[i]
+
+		if _, ok := gitServer.Annotations[codebaseApi.RotateWebhookSecretAnnotation]; !ok {
+			continue
+		}
+
+		if err := c.rotateSecret(ctx, gitServer); err != nil {
+			log.Error(err, "Failed to rotate webhook secret", "gitserver", gitServer.Name)
+			c.recorder.Eventf(gitServer, corev1.EventTypeWarning, EventReasonWebhookRotationFailed,
+				"Failed to rotate webhook secret: %s", err.Error())
+		}
+	}
+
+	codebases := &codebaseApi.CodebaseList{}
+	if err := c.client.List(ctx, codebases, client.InNamespace(c.namespace)); err != nil {
+		log.Error(err, "Failed to list codebases")
+		return
+	}
+
+	checked := 0
+
+	for i := range codebases.Items {
+		codebase := &codebases.Items[i]
+		if !eligibleForCheck(codebase) {
+			continue
+		}
+
+		gitServer := findGitServer(gitServers.Items, codebase.Spec.GitServer)
+		if gitServer == nil || !gitServerSupported(gitServer) {
+			continue
+		}
+
+		if err := c.checkCodebase(ctx, codebase, gitServer); err != nil {
+			log.Error(err, "Failed to check webhook", "codebase", codebase.Name)
+			continue
+		}
+
+		checked++
+	}
+
+	log.Info("Codebase webhooks check finished", "codebases", checked)
+}
+
+// rotateSecret generates a new webhook secret for the GitServer and removes the rotation annotation.
+// The annotation is kept if the secret cannot be updated, so that the rotation is retried.
+func (c *Checker) rotateSecret(ctx context.Context, gitServer *codebaseApi.GitServer) error {
+	secret := &corev1.Secret{}
+	if err := c.client.Get(
+		ctx, client.ObjectKey{Namespace: c.namespace, Name: gitServer.Spec.NameSshKeySecret}, secret,
+	); err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", gitServer.Spec.NameSshKeySecret, err)
+	}
+
+	webhookSecret, err := util.GenerateRandomString(webhookSecretLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	secret.Data[util.GitServerSecretWebhookSecretField] = []byte(webhookSecret)
+
+	if err = c.client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", secret.Name, err)
+	}
+
+	patch := client.MergeFrom(gitServer.DeepCopy())
+	delete(gitServer.Annotations, codebaseApi.RotateWebhookSecretAnnotation)
+
+	if err = c.client.Patch(ctx, gitServer, patch); err != nil {
+		return fmt.Errorf("failed to remove %s annotation: %w", codebaseApi.RotateWebhookSecretAnnotation, err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Webhook secret has been rotated", "gitserver", gitServer.Name)
+	c.recorder.Event(gitServer, corev1.EventTypeNormal, EventReasonWebhookSecretRotated,
+		"Webhook secret has been rotated, the webhooks of the codebases are being reconfigured")
+
+	return nil
+}
+
+func (c *Checker) checkCodebase(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	gitServer *codebaseApi.GitServer,
+) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("codebase", codebase.Name)
+
+	webHookURL := desiredURL(gitServer)
+	if webHookURL == "" {
+		log.Info("Webhook URL of the git server is not known yet, skipping webhook check")
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.client.Get(
+		ctx, client.ObjectKey{Namespace: c.namespace, Name: gitServer.Spec.NameSshKeySecret}, secret,
+	); err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", gitServer.Spec.NameSshKeySecret, err)
+	}
+
+	token := string(secret.Data[util.GitServerSecretTokenField])
+	webHookSecret := string(secret.Data[util.GitServerSecretWebhookSecretField])
+	skipTLS := gitServer.Spec.SkipWebhookSSLVerification
+	gitHost := gitprovider.GetGitProviderAPIURL(gitServer)
+	projectID := codebase.Spec.GetProjectID()
+
+	provider, err := c.providerFactory(ctx, gitServer, token)
+	if err != nil {
+		return err
+	}
+
+	// A failed listing means the webhook state is unknown; nothing is repaired on API errors.
+	webHooks, err := provider.GetWebHooks(ctx, gitHost, token, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	webHook := findWebHook(webHooks, codebase.Status.GetWebHookRef())
+	drift := gitprovider.WebHookDrift(gitServer.Spec.GitProvider, webHook, webHookURL, skipTLS)
+
+	// Git providers never return the secret, so a change is detected by the stored hash.
+	// Webhooks created before the hash was recorded are reconfigured once.
+	secretHash := gitprovider.WebHookSecretHash(webHookSecret)
+	if webHook != nil && (codebase.Status.WebHook == nil || codebase.Status.WebHook.SecretHash != secretHash) {
+		drift = append(drift, gitprovider.WebHookDriftSecret)
+	}
+
+	status := &codebaseApi.CodebaseWebHookStatus{
+		URL:        webHookURL,
+		SecretHash: secretHash,
+	}
+	webHookRef := codebase.Status.GetWebHookRef()
+	exists := webHook != nil
+
+	if len(drift) > 0 {
+		log.Info("Webhook configuration has drifted", "drift", drift)
+		c.recorder.Eventf(codebase, corev1.EventTypeWarning, EventReasonWebhookDriftDetected,
+			"Webhook %s differs from the desired configuration: %s", webHookRef, strings.Join(drift, ", "))
+
+		repaired, repairErr := c.repair(ctx, provider, webHook, gitHost, token, projectID, webHookRef,
+			webHookSecret, webHookURL, skipTLS)
+		if repairErr != nil {
+			log.Error(repairErr, "Failed to repair webhook")
+			c.recorder.Eventf(codebase, corev1.EventTypeWarning, EventReasonWebhookRepairFailed,
+				"Failed to repair webhook: %s", repairErr.Error())
+
+			status = codebase.Status.WebHook.DeepCopy()
+			if status == nil {
+				status = &codebaseApi.CodebaseWebHookStatus{}
+			}
+
+			status.Drift = drift
+		} else {
+			webHookRef = repaired.ID
+			exists = true
+
+			log.Info("Webhook has been repaired", "webhook", webHookRef)
+			c.recorder.Eventf(codebase, corev1.EventTypeNormal, EventReasonWebhookRepaired,
+				"Webhook %s has been reconfigured: %s", webHookRef, strings.Join(drift, ", "))
+		}
+	}
+
+	if exists {
+		c.checkDeliveries(ctx, codebase, provider, gitHost, token, projectID, webHookRef, status)
+	}
+
+	now := metav1.Now()
+	status.LastCheckTime = &now
+
+	return c.updateStatus(ctx, codebase, status, webHookRef)
+}
+
+// repair recreates a missing webhook or reconfigures an existing one.
+func (*Checker) repair(
+	ctx context.Context,
+	provider gitprovider.GitWebHookProvider,
+	webHook *gitprovider.WebHook,
+	gitHost, token, projectID, webHookRef, webHookSecret, webHookURL string,
+	skipTLS bool,
+) (*gitprovider.WebHook, error) {
+	if webHook == nil {
+		created, err := provider.CreateWebHookIfNotExists(ctx, gitHost, token, projectID, webHookSecret, webHookURL, skipTLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webhook: %w", err)
+		}
+
+		return created, nil
+	}
+
+	updated, err := provider.UpdateWebHook(ctx, gitHost, token, projectID, webHookRef, webHookSecret, webHookURL, skipTLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (c *Checker) updateStatus(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	status *codebaseApi.CodebaseWebHookStatus,
+	webHookRef string,
+) error {
+	patch := client.MergeFrom(codebase.DeepCopy())
+
+	codebase.Status.WebHook = status
+
+	if webHookRef != codebase.Status.GetWebHookRef() {
+		codebase.Status.WebHookRef = webHookRef
+		codebase.Status.WebHookID = 0
+	}
+
+	if err := c.client.Status().Patch(ctx, codebase, patch); err != nil {
+		return fmt.Errorf("failed to update webhook status: %w", err)
+	}
+
+	return nil
+}
+
+// eligibleForCheck filters out codebases whose webhook is not managed by the operator
+// or not created yet, and codebases being deleted.
+func eligibleForCheck(codebase *codebaseApi.Codebase) bool {
+	if codebase.DeletionTimestamp != nil {
+		return false
+	}
+
+	if codebase.Spec.CiTool != util.CITekton {
+		return false
+	}
+
+	return codebase.Status.GetWebHookRef() != ""
+}
+
+// gitServerSupported reports whether the operator manages webhooks of the GitServer
+// and can currently use its API.
+func gitServerSupported(gitServer *codebaseApi.GitServer) bool {
+	if !slices.Contains([]string{
+		codebaseApi.GitProviderGithub,
+		codebaseApi.GitProviderGitlab,
+		codebaseApi.GitProviderBitbucket,
+	}, gitServer.Spec.GitProvider) {
+		return false
+	}
+
+	return gitServer.Status.APIUnavailableReason() == ""
+}
+
+// desiredURL returns the URL webhooks of the GitServer deliver to, as PutWebHook sets it up.
+func desiredURL(gitServer *codebaseApi.GitServer) string {
+	if gitServer.Spec.WebhookUrl != "" {
+		return gitServer.Spec.WebhookUrl
+	}
+
+	if gitServer.Status.Webhook != nil {
+		return gitServer.Status.Webhook.URL
+	}
+
+	return ""
+}
+
+func findGitServer(gitServers []codebaseApi.GitServer, name string) *codebaseApi.GitServer {
+	for i := range gitServers {
+		if gitServers[i].Name == name {
+			return &gitServers[i]
+		}
+	}
+
+	return nil
+}
+
+func findWebHook(webHooks []*gitprovider.WebHook, ref string) *gitprovider.WebHook {
+	for _, webHook := range webHooks {
+		if webHook.ID == ref {
+			return webHook
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,345 @@
+package webhookdrift
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	gitprovidermocks "github.com/epam/edp-codebase-operator/v2/pkg/gitprovider/mocks"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+const (
+	testNamespace  = "default"
+	testWebHookURL = "https://el-gitlab-default.example.com"
+	testSecret     = "webhook-secret"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func newCodebase() *codebaseApi.Codebase {
+	return &codebaseApi.Codebase{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: testNamespace,
+		},
+		Spec: codebaseApi.CodebaseSpec{
+			GitServer:  "gitlab",
+			GitUrlPath: "/owner/app",
+			CiTool:     util.CITekton,
+		},
+		Status: codebaseApi.CodebaseStatus{
+			WebHookRef: "1",
+			WebHook: &codebaseApi.CodebaseWebHookStatus{
+				URL:        testWebHookURL,
+				SecretHash: gitprovider.WebHookSecretHash(testSecret),
+			},
+		},
+	}
+}
+
+func newGitServerWithSecret() (*codebaseApi.GitServer, *corev1.Secret) {
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab", Namespace: testNamespace},
+		Spec: codebaseApi.GitServerSpec{
+			GitHost:          "gitlab.example.com",
+			GitProvider:      codebaseApi.GitProviderGitlab,
+			NameSshKeySecret: "gitlab-secret",
+		},
+		Status: codebaseApi.GitServerStatus{
+			Webhook: &codebaseApi.GitServerWebhookStatus{
+				URL:      testWebHookURL,
+				Exposure: codebaseApi.EventListenerExposureIngress,
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitlab-secret", Namespace: testNamespace},
+		Data: map[string][]byte{
+			util.GitServerSecretTokenField:         []byte("token"),
+			util.GitServerSecretWebhookSecretField: []byte(testSecret),
+		},
+	}
+
+	return gitServer, secret
+}
+
+func newChecker(
+	k8sClient client.Client,
+	provider gitprovider.GitWebHookProvider,
+	recorder record.EventRecorder,
+) *Checker {
+	factory := func(context.Context, *codebaseApi.GitServer, string) (gitprovider.GitWebHookProvider, error) {
+		return provider, nil
+	}
+
+	return NewChecker(k8sClient, testNamespace, 0, factory, recorder)
+}
+
+func syncedWebHook(id string) *gitprovider.WebHook {
+	return &gitprovider.WebHook{
+		ID:     id,
+		URL:    testWebHookURL,
+		Events: gitprovider.WebHookEvents(codebaseApi.GitProviderGitlab),
+		Active: true,
+	}
+}
+
+func expectNoDeliveries(provider *gitprovidermocks.MockGitWebHookProvider) {
+	provider.On("GetWebHookDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, gitprovider.ErrWebHookDeliveriesNotSupported)
+}
+
+func getCodebase(t *testing.T, k8sClient client.Client) *codebaseApi.Codebase {
+	t.Helper()
+
+	codebase := &codebaseApi.Codebase{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Namespace: testNamespace, Name: "app"}, codebase))
+
+	return codebase
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestChecker_InSync(t *testing.T) {
+	codebase := newCodebase()
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		WithStatusSubresource(codebase).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+	expectNoDeliveries(provider)
+	provider.On("GetWebHooks", mock.Anything, "https://gitlab.example.com", "token", "owner/app").
+		Return([]*gitprovider.WebHook{syncedWebHook("1")}, nil)
+
+	recorder := record.NewFakeRecorder(10)
+
+	newChecker(k8sClient, provider, recorder).sweep(context.Background())
+
+	updated := getCodebase(t, k8sClient)
+	require.NotNil(t, updated.Status.WebHook)
+	assert.Empty(t, updated.Status.WebHook.Drift)
+	assert.NotNil(t, updated.Status.WebHook.LastCheckTime)
+	assert.Empty(t, drainEvents(recorder))
+}
+
+func TestChecker_RepairsDriftedWebHook(t *testing.T) {
+	codebase := newCodebase()
+	gitServer, secret := newGitServerWithSecret()
+	gitServer.Spec.WebhookUrl = "https://hooks.example.com"
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		WithStatusSubresource(codebase).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+	expectNoDeliveries(provider)
+	provider.On("GetWebHooks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*gitprovider.WebHook{syncedWebHook("1")}, nil)
+	provider.On("UpdateWebHook", mock.Anything, "https://gitlab.example.com", "token", "owner/app", "1",
+		testSecret, "https://hooks.example.com", false).
+		Return(&gitprovider.WebHook{ID: "1", URL: "https://hooks.example.com"}, nil)
+
+	recorder := record.NewFakeRecorder(10)
+
+	newChecker(k8sClient, provider, recorder).sweep(context.Background())
+
+	updated := getCodebase(t, k8sClient)
+	require.NotNil(t, updated.Status.WebHook)
+	assert.Equal(t, "https://hooks.example.com", updated.Status.WebHook.URL)
+	assert.Empty(t, updated.Status.WebHook.Drift)
+
+	events := drainEvents(recorder)
+	require.Len(t, events, 2)
+	assert.Contains(t, events[0], EventReasonWebhookDriftDetected)
+	assert.Contains(t, events[0], gitprovider.WebHookDriftURL)
+	assert.Contains(t, events[1], EventReasonWebhookRepaired)
+}
+
+func TestChecker_RecreatesMissingWebHook(t *testing.T) {
+	codebase := newCodebase()
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		WithStatusSubresource(codebase).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+	expectNoDeliveries(provider)
+	provider.On("GetWebHooks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*gitprovider.WebHook{}, nil)
+	provider.On("CreateWebHookIfNotExists", mock.Anything, mock.Anything, "token", "owner/app",
+		testSecret, testWebHookURL, false).
+		Return(syncedWebHook("2"), nil)
+
+	recorder := record.NewFakeRecorder(10)
+
+	newChecker(k8sClient, provider, recorder).sweep(context.Background())
+
+	updated := getCodebase(t, k8sClient)
+	assert.Equal(t, "2", updated.Status.WebHookRef)
+	assert.Empty(t, updated.Status.WebHook.Drift)
+
+	events := drainEvents(recorder)
+	require.Len(t, events, 2)
+	assert.Contains(t, events[0], gitprovider.WebHookDriftMissing)
+	assert.Contains(t, events[1], EventReasonWebhookRepaired)
+}
+
+func TestChecker_ReportsFailedRepair(t *testing.T) {
+	codebase := newCodebase()
+	codebase.Status.WebHook.SecretHash = ""
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		WithStatusSubresource(codebase).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+	expectNoDeliveries(provider)
+	provider.On("GetWebHooks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*gitprovider.WebHook{syncedWebHook("1")}, nil)
+	provider.On("UpdateWebHook", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("forbidden"))
+
+	recorder := record.NewFakeRecorder(10)
+
+	newChecker(k8sClient, provider, recorder).sweep(context.Background())
+
+	updated := getCodebase(t, k8sClient)
+	require.NotNil(t, updated.Status.WebHook)
+	assert.Equal(t, []string{gitprovider.WebHookDriftSecret}, updated.Status.WebHook.Drift)
+	assert.Empty(t, updated.Status.WebHook.SecretHash, "the hash of an unapplied secret must not be recorded")
+
+	events := drainEvents(recorder)
+	require.Len(t, events, 2)
+	assert.Contains(t, events[1], EventReasonWebhookRepairFailed)
+}
+
+func TestChecker_SkipsOnListError(t *testing.T) {
+	codebase := newCodebase()
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		WithStatusSubresource(codebase).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+	provider.On("GetWebHooks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("connection refused"))
+
+	recorder := record.NewFakeRecorder(10)
+
+	newChecker(k8sClient, provider, recorder).sweep(context.Background())
+
+	updated := getCodebase(t, k8sClient)
+	assert.Nil(t, updated.Status.WebHook.LastCheckTime)
+	assert.Empty(t, drainEvents(recorder))
+}
+
+func TestChecker_RotatesSecret(t *testing.T) {
+	codebase := newCodebase()
+	gitServer, secret := newGitServerWithSecret()
+	gitServer.Annotations = map[string]string{codebaseApi.RotateWebhookSecretAnnotation: "true"}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		WithStatusSubresource(codebase).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+	expectNoDeliveries(provider)
+	provider.On("GetWebHooks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*gitprovider.WebHook{syncedWebHook("1")}, nil)
+	provider.On("UpdateWebHook", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "1",
+		mock.MatchedBy(func(s string) bool { return s != testSecret }), testWebHookURL, false).
+		Return(syncedWebHook("1"), nil)
+
+	recorder := record.NewFakeRecorder(10)
+
+	newChecker(k8sClient, provider, recorder).sweep(context.Background())
+
+	updatedSecret := &corev1.Secret{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), updatedSecret))
+
+	rotated := string(updatedSecret.Data[util.GitServerSecretWebhookSecretField])
+	assert.NotEqual(t, testSecret, rotated)
+
+	updatedGitServer := &codebaseApi.GitServer{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(gitServer), updatedGitServer))
+	assert.NotContains(t, updatedGitServer.Annotations, codebaseApi.RotateWebhookSecretAnnotation)
+
+	updated := getCodebase(t, k8sClient)
+	assert.Equal(t, gitprovider.WebHookSecretHash(rotated), updated.Status.WebHook.SecretHash)
+
+	events := drainEvents(recorder)
+	require.Len(t, events, 3)
+	assert.Contains(t, events[0], EventReasonWebhookSecretRotated)
+	assert.Contains(t, events[1], gitprovider.WebHookDriftSecret)
+	assert.Contains(t, events[2], EventReasonWebhookRepaired)
+}
+
+func TestChecker_SkipsIneligibleCodebases(t *testing.T) {
+	notTekton := newCodebase()
+	notTekton.Spec.CiTool = "gitlab"
+
+	noWebHook := newCodebase()
+	noWebHook.Name = "no-webhook"
+	noWebHook.Status.WebHookRef = ""
+
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(notTekton, noWebHook, gitServer, secret).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+
+	newChecker(k8sClient, provider, record.NewFakeRecorder(10)).sweep(context.Background())
+}
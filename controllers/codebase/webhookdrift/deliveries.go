@@ -0,0 +1,136 @@
+package webhookdrift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+)
+
+const (
+	EventReasonWebhookDeliveriesFailing   = "WebhookDeliveriesFailing"
+	EventReasonWebhookDeliveriesRecovered = "WebhookDeliveriesRecovered"
+)
+
+var deliveryConsecutiveFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "codebase_operator_webhook_delivery_consecutive_failures",
+	Help: "Number of the most recent webhook deliveries of a Codebase that failed in a row.",
+}, []string{"namespace", "codebase"})
+
+func init() {
+	metrics.Registry.MustRegister(deliveryConsecutiveFailures)
+}
+
+// DeleteMetrics removes the metrics of a deleted Codebase.
+func DeleteMetrics(namespace, codebase string) {
+	deliveryConsecutiveFailures.DeleteLabelValues(namespace, codebase)
+}
+
+// checkDeliveries summarizes the recent deliveries of the webhook into the status and reports
+// the start and the end of a series of failed deliveries. If the deliveries cannot be read,
+// the previous summary is kept.
+func (c *Checker) checkDeliveries(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	provider gitprovider.GitWebHookProvider,
+	gitHost, token, projectID, webHookRef string,
+	status *codebaseApi.CodebaseWebHookStatus,
+) {
+	var previous *codebaseApi.CodebaseWebHookDeliveries
+	if codebase.Status.WebHook != nil {
+		previous = codebase.Status.WebHook.Deliveries
+	}
+
+	deliveries, err := provider.GetWebHookDeliveries(ctx, gitHost, token, projectID, webHookRef)
+	if err != nil {
+		if !errors.Is(err, gitprovider.ErrWebHookDeliveriesNotSupported) {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to get webhook deliveries", "codebase", codebase.Name)
+
+			status.Deliveries = previous.DeepCopy()
+		}
+
+		return
+	}
+
+	summary := summarizeDeliveries(deliveries, previous)
+	status.Deliveries = summary
+
+	deliveryConsecutiveFailures.WithLabelValues(codebase.Namespace, codebase.Name).
+		Set(float64(summary.ConsecutiveFailures))
+
+	wasFailing := previous != nil && previous.ConsecutiveFailures > 0
+
+	switch {
+	case summary.ConsecutiveFailures > 0 && !wasFailing:
+		c.recorder.Eventf(codebase, corev1.EventTypeWarning, EventReasonWebhookDeliveriesFailing,
+			"The last %d webhook deliveries failed, the latest: %s",
+			summary.ConsecutiveFailures, summary.LastFailure)
+	case summary.ConsecutiveFailures == 0 && wasFailing:
+		c.recorder.Event(codebase, corev1.EventTypeNormal, EventReasonWebhookDeliveriesRecovered,
+			"Webhook deliveries succeed again")
+	}
+}
+
+// summarizeDeliveries summarizes deliveries ordered newest first. The time of the last success
+// is carried over from the previous summary when no delivery in the page succeeded.
+func summarizeDeliveries(
+	deliveries []gitprovider.WebHookDelivery,
+	previous *codebaseApi.CodebaseWebHookDeliveries,
+) *codebaseApi.CodebaseWebHookDeliveries {
+	summary := &codebaseApi.CodebaseWebHookDeliveries{}
+
+	if previous != nil {
+		summary.LastSuccessTime = previous.LastSuccessTime.DeepCopy()
+	}
+
+	if len(deliveries) > 0 {
+		summary.LastDeliveryTime = deliveryTime(&deliveries[0])
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		if delivery.Succeeded() {
+			if t := deliveryTime(delivery); t != nil {
+				summary.LastSuccessTime = t
+			}
+
+			break
+		}
+
+		if summary.ConsecutiveFailures == 0 {
+			summary.LastFailure = describeFailure(delivery)
+		}
+
+		summary.ConsecutiveFailures++
+	}
+
+	return summary
+}
+
+func deliveryTime(delivery *gitprovider.WebHookDelivery) *metav1.Time {
+	if delivery.DeliveredAt.IsZero() {
+		return nil
+	}
+
+	t := metav1.NewTime(delivery.DeliveredAt)
+
+	return &t
+}
+
+func describeFailure(delivery *gitprovider.WebHookDelivery) string {
+	result := delivery.Status
+	if result == "" {
+		result = fmt.Sprintf("status %d", delivery.StatusCode)
+	}
+
+	return fmt.Sprintf("%s: %s", delivery.Event, result)
+}
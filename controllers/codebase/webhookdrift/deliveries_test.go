@@ -0,0 +1,151 @@
+package webhookdrift
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	gitprovidermocks "github.com/epam/edp-codebase-operator/v2/pkg/gitprovider/mocks"
+)
+
+func Test_summarizeDeliveries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	earlier := metav1.NewTime(now.Add(-24 * time.Hour))
+
+	tests := []struct {
+		name       string
+		deliveries []gitprovider.WebHookDelivery
+		previous   *codebaseApi.CodebaseWebHookDeliveries
+		want       *codebaseApi.CodebaseWebHookDeliveries
+	}{
+		{
+			name:       "no deliveries",
+			deliveries: nil,
+			want:       &codebaseApi.CodebaseWebHookDeliveries{},
+		},
+		{
+			name: "latest delivery succeeded",
+			deliveries: []gitprovider.WebHookDelivery{
+				{Event: "push", StatusCode: 202, DeliveredAt: now},
+				{Event: "push", StatusCode: 502, DeliveredAt: now.Add(-time.Hour)},
+			},
+			want: &codebaseApi.CodebaseWebHookDeliveries{
+				LastDeliveryTime: ptrTime(now),
+				LastSuccessTime:  ptrTime(now),
+			},
+		},
+		{
+			name: "failures after a success",
+			deliveries: []gitprovider.WebHookDelivery{
+				{Event: "pull_request.opened", StatusCode: 502, Status: "Invalid HTTP Response: 502", DeliveredAt: now},
+				{Event: "push", StatusCode: 0, DeliveredAt: now.Add(-time.Minute)},
+				{Event: "push", StatusCode: 200, DeliveredAt: now.Add(-time.Hour)},
+			},
+			want: &codebaseApi.CodebaseWebHookDeliveries{
+				LastDeliveryTime:    ptrTime(now),
+				LastSuccessTime:     ptrTime(now.Add(-time.Hour)),
+				ConsecutiveFailures: 2,
+				LastFailure:         "pull_request.opened: Invalid HTTP Response: 502",
+			},
+		},
+		{
+			name: "only failures keep the previous success time",
+			deliveries: []gitprovider.WebHookDelivery{
+				{Event: "merge_request_hooks", Status: "internal error"},
+			},
+			previous: &codebaseApi.CodebaseWebHookDeliveries{LastSuccessTime: &earlier},
+			want: &codebaseApi.CodebaseWebHookDeliveries{
+				LastSuccessTime:     &earlier,
+				ConsecutiveFailures: 1,
+				LastFailure:         "merge_request_hooks: internal error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, summarizeDeliveries(tt.deliveries, tt.previous))
+		})
+	}
+}
+
+func TestChecker_ReportsFailingDeliveries(t *testing.T) {
+	codebase := newCodebase()
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		WithStatusSubresource(codebase).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitWebHookProvider(t)
+	provider.On("GetWebHooks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*gitprovider.WebHook{syncedWebHook("1")}, nil)
+	provider.On("GetWebHookDeliveries", mock.Anything, "https://gitlab.example.com", "token", "owner/app", "1").
+		Return([]gitprovider.WebHookDelivery{
+			{Event: "merge_request_hooks", StatusCode: 503},
+			{Event: "merge_request_hooks", StatusCode: 200},
+		}, nil).Once()
+
+	recorder := record.NewFakeRecorder(10)
+	checker := newChecker(k8sClient, provider, recorder)
+
+	checker.sweep(context.Background())
+
+	updated := getCodebase(t, k8sClient)
+	require.NotNil(t, updated.Status.WebHook.Deliveries)
+	assert.Equal(t, 1, updated.Status.WebHook.Deliveries.ConsecutiveFailures)
+	assert.Equal(t, "merge_request_hooks: status 503", updated.Status.WebHook.Deliveries.LastFailure)
+
+	events := drainEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], EventReasonWebhookDeliveriesFailing)
+
+	// A failed listing keeps the summary.
+	provider.On("GetWebHookDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("forbidden")).Once()
+
+	checker.sweep(context.Background())
+
+	updated = getCodebase(t, k8sClient)
+	require.NotNil(t, updated.Status.WebHook.Deliveries)
+	assert.Equal(t, 1, updated.Status.WebHook.Deliveries.ConsecutiveFailures)
+	assert.Empty(t, drainEvents(recorder))
+
+	provider.On("GetWebHookDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]gitprovider.WebHookDelivery{{Event: "merge_request_hooks", StatusCode: 200}}, nil).Once()
+
+	checker.sweep(context.Background())
+
+	updated = getCodebase(t, k8sClient)
+	assert.Zero(t, updated.Status.WebHook.Deliveries.ConsecutiveFailures)
+
+	events = drainEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], EventReasonWebhookDeliveriesRecovered)
+
+	DeleteMetrics(codebase.Namespace, codebase.Name)
+	assert.False(t, deliveryConsecutiveFailures.DeleteLabelValues(codebase.Namespace, codebase.Name),
+		"the metric of a deleted codebase must be removed")
+}
+
+func ptrTime(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+
+	return &mt
+}
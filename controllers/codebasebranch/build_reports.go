@@ -0,0 +1,79 @@
+package codebasebranch
+
+import (
+	"context"
+	"fmt"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
+)
+
+// processBuildReports moves the builds CI reported through annotations to status.builds and
+// removes the annotations. The status is written first: if removing the annotations fails,
+// the next reconciliation records the same builds again, which replaces them in place.
+func (r *ReconcileCodebaseBranch) processBuildReports(
+	ctx context.Context,
+	cb *codebaseApi.CodebaseBranch,
+	codebase *codebaseApi.Codebase,
+) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	reports, invalid := codebasebranch.GetBuildReports(cb)
+	if len(reports) == 0 && len(invalid) == 0 {
+		return nil
+	}
+
+	for key, err := range invalid {
+		log.Error(err, "Dropping malformed build report", "annotation", key)
+	}
+
+	retention, err := codebasebranch.NewHistoryRetention(codebase)
+	if err != nil {
+		return fmt.Errorf("failed to get history retention: %w", err)
+	}
+
+	if len(reports) > 0 {
+		version := ptr.Deref(cb.Spec.Version, "")
+
+		for _, report := range reports {
+			build := report.Build
+			if build.Timestamp.IsZero() {
+				build.Timestamp = metaV1.Now()
+			}
+
+			// Reports that do not name a version are for the current version of the branch.
+			if build.Version == "" {
+				build.Version = version
+			}
+
+			retention.AddBuild(&cb.Status, version, build)
+		}
+
+		if err = r.client.Status().Update(ctx, cb); err != nil {
+			return fmt.Errorf("failed to update CodebaseBranch builds: %w", err)
+		}
+
+		log.Info("Recorded reported builds", "count", len(reports))
+	}
+
+	original := cb.DeepCopy()
+
+	for _, report := range reports {
+		delete(cb.Annotations, report.Annotation)
+	}
+
+	for key := range invalid {
+		delete(cb.Annotations, key)
+	}
+
+	if err = r.client.Patch(ctx, cb, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to remove build report annotations: %w", err)
+	}
+
+	return nil
+}
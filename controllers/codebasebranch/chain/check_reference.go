@@ -75,7 +75,12 @@ func (c CheckReferenceExists) ServeRequest(ctx context.Context, codebaseBranch *
 		return c.processErr(codebaseBranch, fmt.Errorf("failed to get secret %s: %w", gitServer.Spec.NameSshKeySecret, err))
 	}
 
-	g := c.GitProviderFactory(gitproviderv2.NewConfigFromGitServerAndSecret(gitServer, secret))
+	gitConfig, err := gitproviderv2.NewConfig(ctx, c.Client, gitServer, secret)
+	if err != nil {
+		return c.processErr(codebaseBranch, err)
+	}
+
+	g := c.GitProviderFactory(gitConfig)
 
 	repoGitUrl := util.GetProjectGitUrl(gitServer, secret, codebase.Spec.GetProjectID())
 
@@ -121,6 +126,7 @@ func (CheckReferenceExists) setFailedFields(
 		VersionHistory:      codebaseBranch.Status.VersionHistory,
 		LastSuccessfulBuild: codebaseBranch.Status.LastSuccessfulBuild,
 		Build:               codebaseBranch.Status.Build,
+		Builds:              codebaseBranch.Status.Builds,
 		FailureCount:        codebaseBranch.Status.FailureCount,
 		Git:                 codebaseBranch.Status.Git,
 		Conditions:          codebaseBranch.Status.Conditions,
@@ -10,6 +10,7 @@ import (
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/codebasebranch/chain/handler"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
 )
 
 type ProcessNewVersion struct {
@@ -72,6 +73,13 @@ func (h ProcessNewVersion) processNewVersion(
 	codebaseBranch.Status.LastSuccessfulBuild = nil
 	codebaseBranch.Status.VersionHistory = append(codebaseBranch.Status.VersionHistory, *codebaseBranch.Spec.Version)
 
+	retention, err := codebasebranch.NewHistoryRetention(codebase)
+	if err != nil {
+		return fmt.Errorf("failed to get history retention: %w", err)
+	}
+
+	retention.TrimVersionHistory(&codebaseBranch.Status)
+
 	if err = h.Client.Status().Update(ctx, codebaseBranch); err != nil {
 		return fmt.Errorf("failed to update CodebaseBranch status: %w", err)
 	}
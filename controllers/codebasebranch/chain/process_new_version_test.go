@@ -74,6 +74,52 @@ func TestProcessNewVersion_ServeRequest(t *testing.T) {
 				require.Equal(t, []string{"1.0.0"}, cb.Status.VersionHistory)
 			},
 		},
+		{
+			name: "version history is trimmed to the codebase limit",
+			codebaseBranch: &codebaseApi.CodebaseBranch{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-branch",
+					Namespace: "default",
+				},
+				Spec: codebaseApi.CodebaseBranchSpec{
+					CodebaseName: "test-codebase",
+					Version:      util.GetStringP("1.2.0"),
+				},
+				Status: codebaseApi.CodebaseBranchStatus{
+					VersionHistory: []string{"1.0.0", "1.1.0"},
+				},
+			},
+			client: func(t *testing.T, cb *codebaseApi.CodebaseBranch) client.Client {
+				s := runtime.NewScheme()
+				require.NoError(t, codebaseApi.AddToScheme(s))
+
+				return fake.NewClientBuilder().
+					WithScheme(s).
+					WithObjects(
+						cb,
+						&codebaseApi.Codebase{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-codebase",
+								Namespace: "default",
+								Annotations: map[string]string{
+									codebaseApi.VersionHistoryLimitAnnotation: "2",
+								},
+							},
+							Spec: codebaseApi.CodebaseSpec{
+								Versioning: codebaseApi.Versioning{
+									Type: codebaseApi.VersioningTypeSemver,
+								},
+							},
+						},
+					).
+					WithStatusSubresource(cb).
+					Build()
+			},
+			wantErr: require.NoError,
+			wantCodebaseBranch: func(t *testing.T, cb *codebaseApi.CodebaseBranch) {
+				require.Equal(t, []string{"1.1.0", "1.2.0"}, cb.Status.VersionHistory)
+			},
+		},
 		{
 			name: "skip processing new version because of version already exists",
 			codebaseBranch: &codebaseApi.CodebaseBranch{
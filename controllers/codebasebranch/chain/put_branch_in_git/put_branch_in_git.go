@@ -87,7 +87,14 @@ func (h PutBranchInGit) ServeRequest(ctx context.Context, branch *codebaseApi.Co
 		return err
 	}
 
-	gitProvider := h.GitProviderFactory(gitproviderv2.NewConfigFromGitServerAndSecret(gitServer, secret))
+	gitConfig, err := gitproviderv2.NewConfig(ctx, h.Client, gitServer, secret)
+	if err != nil {
+		putGitBranchSetFailedFields(branch, err.Error())
+
+		return err //nolint:wrapcheck // NewConfig errors are descriptive
+	}
+
+	gitProvider := h.GitProviderFactory(gitConfig)
 
 	repoGitUrl := util.GetProjectGitUrl(gitServer, secret, codebase.Spec.GetProjectID())
 
@@ -97,7 +104,7 @@ func (h PutBranchInGit) ServeRequest(ctx context.Context, branch *codebaseApi.Co
 		fromRef = codebase.Spec.DefaultBranch
 	}
 
-	err := gitProvider.CreateRemoteBranchViaRefUpdate(ctx, repoGitUrl, branch.Spec.BranchName, fromRef)
+	err = gitProvider.CreateRemoteBranchViaRefUpdate(ctx, repoGitUrl, branch.Spec.BranchName, fromRef)
 	if err != nil {
 		putGitBranchSetFailedFields(branch, err.Error())
 
@@ -137,6 +144,7 @@ func (h PutBranchInGit) setIntermediateSuccessFields(
 		VersionHistory:      cb.Status.VersionHistory,
 		LastSuccessfulBuild: cb.Status.LastSuccessfulBuild,
 		Build:               cb.Status.Build,
+		Builds:              cb.Status.Builds,
 		FailureCount:        cb.Status.FailureCount,
 		Git:                 cb.Status.Git,
 		Conditions:          cb.Status.Conditions,
@@ -162,6 +170,7 @@ func putGitBranchSetFailedFields(cb *codebaseApi.CodebaseBranch, message string)
 		VersionHistory:      cb.Status.VersionHistory,
 		LastSuccessfulBuild: cb.Status.LastSuccessfulBuild,
 		Build:               cb.Status.Build,
+		Builds:              cb.Status.Builds,
 		FailureCount:        cb.Status.FailureCount,
 		Git:                 cb.Status.Git,
 		Conditions:          cb.Status.Conditions,
@@ -151,6 +151,12 @@ func (h PutCodebaseImageStream) createCodebaseImageStreamIfNotExists(
 		},
 	}
 
+	// A preview branch deploys to its stage through the env label, which PutCDStageDeploy
+	// turns into a CDStageDeploy for every new tag.
+	if stage := codebaseBranch.Annotations[codebaseApi.PreviewStageAnnotation]; stage != "" {
+		cis.Labels[stage] = ""
+	}
+
 	if err = controllerutil.SetControllerReference(codebaseBranch, cis, h.Client.Scheme()); err != nil {
 		return fmt.Errorf("failed to set controller reference for CodebaseImageStream: %w", err)
 	}
@@ -211,6 +217,7 @@ func setFailedFields(cb *codebaseApi.CodebaseBranch, a codebaseApi.ActionType, m
 		Git:             cb.Status.Git,
 		VersionHistory:  cb.Status.VersionHistory,
 		Build:           cb.Status.Build,
+		Builds:          cb.Status.Builds,
 		Conditions:      cb.Status.Conditions,
 	}
 }
@@ -230,6 +237,7 @@ func (h PutCodebaseImageStream) setIntermediateSuccessFields(
 		VersionHistory:      cb.Status.VersionHistory,
 		LastSuccessfulBuild: cb.Status.LastSuccessfulBuild,
 		Build:               cb.Status.Build,
+		Builds:              cb.Status.Builds,
 		Git:                 cb.Status.Git,
 		Conditions:          cb.Status.Conditions,
 	}
@@ -73,6 +73,52 @@ func TestPutCodebaseImageStream_ServeRequest(t *testing.T) {
 				require.Equal(t, cis.Labels[codebaseApi.CodebaseBranchLabel], "test-branch")
 			},
 		},
+		{
+			name: "preview branch image stream gets the preview stage env label",
+			codebaseBranch: &codebaseApi.CodebaseBranch{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-branch",
+					Namespace: "default",
+					Annotations: map[string]string{
+						codebaseApi.PreviewStageAnnotation: "pipeline/preview",
+					},
+				},
+				Spec: codebaseApi.CodebaseBranchSpec{
+					CodebaseName: "test-codebase",
+					BranchName:   "feature",
+				},
+			},
+			objects: []client.Object{
+				&codebaseApi.Codebase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-codebase",
+						Namespace: "default",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      platform.KrciConfigMap,
+						Namespace: "default",
+					},
+					Data: map[string]string{
+						platform.KrciConfigContainerRegistryHost:  "test-registry",
+						platform.KrciConfigContainerRegistrySpace: "test-space",
+					},
+				},
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, k8sCl client.Client) {
+				cis, err := codebaseimagestream.GetCodebaseImageStreamByCodebaseBaseBranchName(
+					context.Background(),
+					k8sCl,
+					"test-branch",
+					"default",
+				)
+				require.NoError(t, err)
+
+				require.Contains(t, cis.Labels, "pipeline/preview")
+			},
+		},
 		{
 			name: "codebase image stream already exists",
 			codebaseBranch: &codebaseApi.CodebaseBranch{
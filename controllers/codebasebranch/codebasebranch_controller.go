@@ -73,6 +73,10 @@ func (r *ReconcileCodebaseBranch) SetupWithManager(mgr ctrl.Manager, maxConcurre
 				return true
 			}
 
+			if codebasebranch.HasBuildReports(no) {
+				return true
+			}
+
 			if no.DeletionTimestamp != nil {
 				return true
 			}
@@ -172,6 +176,15 @@ func (r *ReconcileCodebaseBranch) Reconcile(ctx context.Context, request reconci
 		return *result, nil
 	}
 
+	if err = r.processBuildReports(ctx, cb, c); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to process build reports: %w", err)
+	}
+
+	pipelinesResolved, err := r.checkPipelines(ctx, cb, c)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// this is a case where we want to init build number
 	// a default build number is a "0"
 	// later will be incremented during CI/CD stages
@@ -207,6 +220,12 @@ func (r *ReconcileCodebaseBranch) Reconcile(ctx context.Context, request reconci
 
 	log.Info("Reconciling CodebaseBranch has been finished")
 
+	if !pipelinesResolved {
+		const pipelinesRecheckInterval = 5 * time.Minute
+
+		return reconcile.Result{RequeueAfter: pipelinesRecheckInterval}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
@@ -225,6 +244,7 @@ func (r *ReconcileCodebaseBranch) setSuccessStatus(
 		VersionHistory:      cb.Status.VersionHistory,
 		LastSuccessfulBuild: cb.Status.LastSuccessfulBuild,
 		Build:               cb.Status.Build,
+		Builds:              cb.Status.Builds,
 		Git:                 cb.Status.Git,
 		Conditions:          cb.Status.Conditions,
 	}
@@ -240,8 +260,8 @@ func (r *ReconcileCodebaseBranch) updateStatus(ctx context.Context, cb *codebase
 		return fmt.Errorf("failed to get CodebaseBranch: %w", err)
 	}
 
-	// Conditions are owned by the stale branch checker, which may have patched them
-	// after cb was read; the freshly fetched value is always the authoritative one.
+	// Conditions are patched on their own by the stale branch checker and the pipeline
+	// bindings check; the freshly fetched value is always the authoritative one.
 	conditions := cbbranch.Status.Conditions
 	cbbranch.Status = cb.Status
 	cbbranch.Status.Conditions = conditions
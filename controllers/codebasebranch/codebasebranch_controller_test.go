@@ -9,11 +9,14 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	tektonpipelineApi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	coreV1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -830,3 +833,141 @@ func TestReconcileCodebaseBranch_setSuccessStatus_PreservesStaleCondition(t *tes
 	assert.Equal(t, codebaseApi.ConditionStale, updated.Status.Conditions[0].Type)
 	assert.Equal(t, metaV1.ConditionTrue, updated.Status.Conditions[0].Status)
 }
+
+func TestReconcileCodebaseBranch_processBuildReports(t *testing.T) {
+	cb := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "app-main",
+			Namespace: "namespace",
+			Annotations: map[string]string{
+				codebaseApi.BuildReportAnnotationPrefix + "3": `{"commit":"abc","pipelineRun":"app-main-build-3","result":"Succeeded"}`,
+				codebaseApi.BuildReportAnnotationPrefix + "4": `not json`,
+				"keep": "me",
+			},
+		},
+		Spec: codebaseApi.CodebaseBranchSpec{
+			CodebaseName: "app",
+			BranchName:   "main",
+			Version:      ptr.To("0.2.0"),
+		},
+		Status: codebaseApi.CodebaseBranchStatus{
+			Builds: []codebaseApi.BuildRecord{
+				{Version: "0.2.0", Number: 1, Result: codebaseApi.BuildResultSucceeded},
+				{Version: "0.2.0", Number: 2, Result: codebaseApi.BuildResultFailed},
+			},
+		},
+	}
+	codebase := &codebaseApi.Codebase{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "namespace",
+			Annotations: map[string]string{codebaseApi.BuildHistoryLimitAnnotation: "2"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	fakeCl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(cb, codebase).
+		WithStatusSubresource(cb).
+		Build()
+
+	r := ReconcileCodebaseBranch{
+		client: fakeCl,
+		log:    logr.Discard(),
+		scheme: scheme,
+	}
+
+	require.NoError(t, r.processBuildReports(context.TODO(), cb.DeepCopy(), codebase))
+
+	updated := &codebaseApi.CodebaseBranch{}
+	require.NoError(t, fakeCl.Get(context.TODO(),
+		types.NamespacedName{Name: "app-main", Namespace: "namespace"}, updated))
+
+	require.Len(t, updated.Status.Builds, 2)
+	assert.Equal(t, int64(3), updated.Status.Builds[1].Number)
+	assert.Equal(t, "app-main-build-3", updated.Status.Builds[1].PipelineRun)
+	assert.Equal(t, "0.2.0", updated.Status.Builds[1].Version, "the report must default to the branch version")
+	assert.False(t, updated.Status.Builds[1].Timestamp.IsZero())
+	assert.Equal(t, "3", *updated.Status.LastSuccessfulBuild)
+
+	assert.Equal(t, map[string]string{"keep": "me"}, updated.Annotations,
+		"valid and malformed reports must be removed")
+}
+
+func TestReconcileCodebaseBranch_checkPipelines(t *testing.T) {
+	codebase := &codebaseApi.Codebase{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "namespace"},
+		Spec:       codebaseApi.CodebaseSpec{CiTool: util.CITekton},
+	}
+	cb := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app-main", Namespace: "namespace"},
+		Spec: codebaseApi.CodebaseBranchSpec{
+			CodebaseName: "app",
+			BranchName:   "main",
+			Pipelines: map[string]string{
+				"build":  "github-go-gin-app-build-semver",
+				"review": "github-go-gin-app-review",
+			},
+		},
+	}
+	build := &tektonpipelineApi.Pipeline{
+		ObjectMeta: metaV1.ObjectMeta{Name: "github-go-gin-app-build-semver", Namespace: "namespace"},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, tektonpipelineApi.AddToScheme(scheme))
+
+	fakeCl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cb, build).
+		WithStatusSubresource(cb).
+		Build()
+
+	r := NewReconcileCodebaseBranch(fakeCl, scheme, logr.Discard())
+
+	resolved, err := r.checkPipelines(context.Background(), cb, codebase)
+	require.NoError(t, err)
+	assert.False(t, resolved)
+
+	updated := &codebaseApi.CodebaseBranch{}
+	require.NoError(t, fakeCl.Get(context.Background(), types.NamespacedName{Name: "app-main", Namespace: "namespace"}, updated))
+
+	condition := meta.FindStatusCondition(updated.Status.Conditions, codebaseApi.ConditionPipelinesResolved)
+	require.NotNil(t, condition)
+	assert.Equal(t, metaV1.ConditionFalse, condition.Status)
+	assert.Equal(t, codebaseApi.ReasonPipelinesNotFound, condition.Reason)
+	assert.Contains(t, condition.Message, "review: github-go-gin-app-review")
+
+	require.NoError(t, fakeCl.Create(context.Background(), &tektonpipelineApi.Pipeline{
+		ObjectMeta: metaV1.ObjectMeta{Name: "github-go-gin-app-review", Namespace: "namespace"},
+	}))
+
+	resolved, err = r.checkPipelines(context.Background(), updated, codebase)
+	require.NoError(t, err)
+	assert.True(t, resolved)
+	assert.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, codebaseApi.ConditionPipelinesResolved))
+}
+
+func TestReconcileCodebaseBranch_checkPipelines_SkipsWithoutTekton(t *testing.T) {
+	cb := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app-main", Namespace: "namespace"},
+		Spec: codebaseApi.CodebaseBranchSpec{
+			CodebaseName: "app",
+			Pipelines:    map[string]string{"build": "missing"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	r := NewReconcileCodebaseBranch(fake.NewClientBuilder().WithScheme(scheme).WithObjects(cb).Build(), scheme, logr.Discard())
+
+	resolved, err := r.checkPipelines(context.Background(), cb, &codebaseApi.Codebase{})
+	require.NoError(t, err)
+	assert.True(t, resolved, "bindings cannot be checked when the Tekton API is not installed")
+	assert.Empty(t, cb.Status.Conditions)
+}
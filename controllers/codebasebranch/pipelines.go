@@ -0,0 +1,65 @@
+package codebasebranch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
+)
+
+// +kubebuilder:rbac:groups=tekton.dev,namespace=placeholder,resources=pipelines,verbs=get;list;watch
+
+// checkPipelines sets the PipelinesResolved condition of the branch and reports whether
+// every pipeline binding refers to an existing Tekton Pipeline. A missing pipeline does
+// not stop the reconciliation: it may be installed later, e.g. by the next GitOps sync.
+func (r *ReconcileCodebaseBranch) checkPipelines(
+	ctx context.Context,
+	cb *codebaseApi.CodebaseBranch,
+	codebase *codebaseApi.Codebase,
+) (bool, error) {
+	if len(cb.Spec.Pipelines) == 0 || !codebasebranch.UsesTektonPipelines(codebase) {
+		return true, nil
+	}
+
+	missing, err := codebasebranch.FindMissingPipelines(ctx, r.client, cb.Namespace, cb.Spec.Pipelines)
+	if err != nil {
+		if errors.Is(err, codebasebranch.ErrPipelineAPIUnavailable) {
+			ctrl.LoggerFrom(ctx).V(1).Info("Skipping pipeline bindings check, Tekton is not installed")
+
+			return true, nil
+		}
+
+		return false, fmt.Errorf("failed to check pipeline bindings: %w", err)
+	}
+
+	condition := metaV1.Condition{
+		Type:    codebaseApi.ConditionPipelinesResolved,
+		Status:  metaV1.ConditionTrue,
+		Reason:  codebaseApi.ReasonPipelinesFound,
+		Message: "All pipeline bindings refer to existing Tekton Pipelines",
+	}
+
+	if len(missing) > 0 {
+		condition.Status = metaV1.ConditionFalse
+		condition.Reason = codebaseApi.ReasonPipelinesNotFound
+		condition.Message = "Tekton Pipelines do not exist: " + strings.Join(missing, ", ")
+	}
+
+	original := cb.DeepCopy()
+
+	if meta.SetStatusCondition(&cb.Status.Conditions, condition) {
+		if err = r.client.Status().Patch(ctx, cb, client.MergeFrom(original)); err != nil {
+			return false, fmt.Errorf("failed to set %s condition: %w", codebaseApi.ConditionPipelinesResolved, err)
+		}
+	}
+
+	return len(missing) == 0, nil
+}
@@ -0,0 +1,169 @@
+package stalecheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
+	gitproviderv2 "github.com/epam/edp-codebase-operator/v2/pkg/git"
+)
+
+const (
+	EventReasonBranchAdopted = "BranchAdopted"
+
+	// maxAdoptionsPerCheck bounds the reference lookups a single check sends to the git
+	// server; the remaining branches are adopted by the following checks.
+	maxAdoptionsPerCheck = 50
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// AdoptAction implements branch discovery, the reverse of the staleness check: it
+// creates CodebaseBranches for remote branches that match the codebase's discovery
+// patterns and have none yet. The CodebaseBranch controller then finds the branch
+// already in git and only completes the registration.
+type AdoptAction struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+func NewAdoptAction(k8sClient client.Client, recorder record.EventRecorder) *AdoptAction {
+	return &AdoptAction{client: k8sClient, recorder: recorder}
+}
+
+// Apply adopts the remote branches of the codebase that no CodebaseBranch in branches
+// tracks. branches must hold every CodebaseBranch of the codebase, whatever its state,
+// or a branch would be adopted twice.
+func (a *AdoptAction) Apply(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	gitClient gitproviderv2.Git,
+	repoURL string,
+	remoteBranches []string,
+	branches []*codebaseApi.CodebaseBranch,
+) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	patterns, err := codebasebranch.NewDiscoveryPatterns(codebase)
+	if err != nil {
+		return fmt.Errorf("failed to read branch discovery patterns: %w", err)
+	}
+
+	// A codebase that is not provisioned yet has no branches worth adopting, and the
+	// CodebaseBranch controller would only postpone them.
+	if patterns == nil || !codebase.Status.Available {
+		return nil
+	}
+
+	tracked := make(map[string]struct{}, len(branches))
+	for _, branch := range branches {
+		tracked[branch.Spec.BranchName] = struct{}{}
+	}
+
+	var errs []error
+
+	adopted := 0
+
+	for _, name := range remoteBranches {
+		if _, ok := tracked[name]; ok || !patterns.Match(name) {
+			continue
+		}
+
+		if adopted == maxAdoptionsPerCheck {
+			log.Info("Reached the limit of branches adopted in one check, the rest are left for the next one",
+				"limit", maxAdoptionsPerCheck)
+
+			break
+		}
+
+		if err = a.adopt(ctx, codebase, gitClient, repoURL, name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		adopted++
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a *AdoptAction) adopt(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	gitClient gitproviderv2.Git,
+	repoURL, branchName string,
+) error {
+	// Pinning FromCommit records where the adopted branch stood when it was found.
+	hash, err := gitClient.ResolveRemoteReference(ctx, repoURL, branchName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve branch %s: %w", branchName, err)
+	}
+
+	branch := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      adoptedBranchName(codebase.Name, branchName),
+			Namespace: codebase.Namespace,
+			Labels: map[string]string{
+				codebaseApi.CodebaseLabel:   codebase.Name,
+				codebaseApi.BranchHashLabel: codebasebranch.MakeGitBranchHash(branchName),
+				codebaseApi.DiscoveredLabel: "true",
+			},
+		},
+		Spec: codebaseApi.CodebaseBranchSpec{
+			CodebaseName: codebase.Name,
+			BranchName:   branchName,
+			FromCommit:   hash,
+		},
+	}
+
+	if codebase.Spec.IsVersionTypeSemver() {
+		branch.Spec.Version = codebase.Spec.Versioning.StartFrom
+	}
+
+	if err = a.client.Create(ctx, branch); err != nil {
+		// Branch names that differ only in characters a resource name cannot hold map to
+		// the same name; the first one keeps it and the others need a manual CodebaseBranch.
+		if apierrors.IsAlreadyExists(err) {
+			ctrl.LoggerFrom(ctx).Info("Skipping branch adoption, the CodebaseBranch name is taken",
+				"branch", branchName, "codebasebranch", branch.Name)
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to create CodebaseBranch for branch %s: %w", branchName, err)
+	}
+
+	if a.recorder != nil {
+		a.recorder.Eventf(codebase, corev1.EventTypeNormal, EventReasonBranchAdopted,
+			"CodebaseBranch %s was created for branch %s found in the git repository", branch.Name, branchName)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Adopted git branch", "branch", branchName, "codebasebranch", branch.Name)
+
+	return nil
+}
+
+// adoptedBranchName follows the naming of the default CodebaseBranch ("<codebase>-<branch>"
+// with slashes replaced), additionally lowercasing and replacing every character a resource
+// name cannot hold, because git branches are not created through the portal's validation.
+func adoptedBranchName(codebaseName, branchName string) string {
+	name := codebaseName + "-" + invalidNameChars.ReplaceAllString(strings.ToLower(branchName), "-")
+
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		name = name[:validation.DNS1123SubdomainMaxLength]
+	}
+
+	return strings.TrimRight(name, ".-")
+}
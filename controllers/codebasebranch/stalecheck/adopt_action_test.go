@@ -0,0 +1,121 @@
+package stalecheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
+	gitmocks "github.com/epam/edp-codebase-operator/v2/pkg/git/mocks"
+)
+
+func discoveryCodebase() *codebaseApi.Codebase {
+	codebase := newCodebase()
+	codebase.Annotations = map[string]string{
+		codebaseApi.BranchDiscoveryIncludeAnnotation: "release/*, hotfix-*",
+		codebaseApi.BranchDiscoveryExcludeAnnotation: "release/old-*",
+	}
+	codebase.Status.Available = true
+
+	return codebase
+}
+
+func TestChecker_AdoptsMatchingRemoteBranches(t *testing.T) {
+	codebase := discoveryCodebase()
+	codebase.Spec.Versioning = codebaseApi.Versioning{Type: codebaseApi.VersioningTypeSemver, StartFrom: ptr.To("0.1.0")}
+	tracked := newBranch("app-release-1.0", "release/1.0", codebaseApi.CodebaseBranchGitStatusBranchCreated)
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, tracked, gitServer, secret).
+		WithStatusSubresource(tracked).
+		Build()
+
+	gitClient := gitmocks.NewMockGit(t)
+	gitClient.On("ListRemoteBranches", mock.Anything, mock.Anything).
+		Return([]string{"main", "release/1.0", "release/2.0", "release/old-1", "feature", "hotfix-X"}, nil).Once()
+	gitClient.On("ResolveRemoteReference", mock.Anything, mock.Anything, "release/2.0").Return("abc", nil).Once()
+	gitClient.On("ResolveRemoteReference", mock.Anything, mock.Anything, "hotfix-X").Return("def", nil).Once()
+
+	recorder := record.NewFakeRecorder(10)
+	newChecker(t, k8sClient, gitClient, recorder).sweep(context.Background())
+
+	adopted := getBranch(t, k8sClient, "app-release-2.0")
+	assert.Equal(t, "release/2.0", adopted.Spec.BranchName)
+	assert.Equal(t, "app", adopted.Spec.CodebaseName)
+	assert.Equal(t, "abc", adopted.Spec.FromCommit)
+	assert.Equal(t, ptr.To("0.1.0"), adopted.Spec.Version)
+	assert.Equal(t, "true", adopted.Labels[codebaseApi.DiscoveredLabel])
+	assert.Equal(t, codebasebranch.MakeGitBranchHash("release/2.0"), adopted.Labels[codebaseApi.BranchHashLabel])
+
+	assert.Equal(t, "def", getBranch(t, k8sClient, "app-hotfix-x").Spec.FromCommit)
+
+	list := &codebaseApi.CodebaseBranchList{}
+	require.NoError(t, k8sClient.List(context.Background(), list))
+	assert.Len(t, list.Items, 3, "only matching untracked branches must be adopted")
+	assert.Len(t, recorder.Events, 2)
+}
+
+func TestChecker_AdoptsBranchesOfCodebaseWithoutBranches(t *testing.T) {
+	codebase := discoveryCodebase()
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		Build()
+
+	gitClient := gitmocks.NewMockGit(t)
+	gitClient.On("ListRemoteBranches", mock.Anything, mock.Anything).Return([]string{"release/1.0"}, nil).Once()
+	gitClient.On("ResolveRemoteReference", mock.Anything, mock.Anything, "release/1.0").Return("abc", nil).Once()
+
+	newChecker(t, k8sClient, gitClient, record.NewFakeRecorder(10)).sweep(context.Background())
+
+	assert.Equal(t, "release/1.0", getBranch(t, k8sClient, "app-release-1.0").Spec.BranchName)
+}
+
+func TestChecker_SkipsDiscoveryForUnavailableCodebase(t *testing.T) {
+	codebase := discoveryCodebase()
+	codebase.Status.Available = false
+	gitServer, secret := newGitServerWithSecret()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		Build()
+
+	gitClient := gitmocks.NewMockGit(t)
+	gitClient.On("ListRemoteBranches", mock.Anything, mock.Anything).Return([]string{"release/1.0"}, nil).Once()
+
+	newChecker(t, k8sClient, gitClient, record.NewFakeRecorder(10)).sweep(context.Background())
+
+	list := &codebaseApi.CodebaseBranchList{}
+	require.NoError(t, k8sClient.List(context.Background(), list, client.InNamespace(testNamespace)))
+	assert.Empty(t, list.Items)
+}
+
+func TestAdoptedBranchName(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "feature/JIRA-1_fix", want: "app-feature-jira-1-fix"},
+		{branch: "release/1.0", want: "app-release-1.0"},
+		{branch: "fix-", want: "app-fix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			assert.Equal(t, tt.want, adoptedBranchName("app", tt.branch))
+		})
+	}
+}
@@ -17,11 +17,13 @@ import (
 )
 
 // GitClientFactory is the injection seam for tests;
-// production wiring uses gitproviderv2.DefaultGitProviderFactory.
-type GitClientFactory func(gitServer *codebaseApi.GitServer, secret *corev1.Secret) gitproviderv2.Git
+// production wiring uses gitproviderv2.NewGitProviderFactory.
+type GitClientFactory func(cfg gitproviderv2.Config) gitproviderv2.Git
 
 // Checker periodically verifies that every CodebaseBranch still has a corresponding
 // branch in the real git repository and applies the configured cleanup strategy.
+// With an AdoptAction it also adopts the remote branches of codebases that opted in
+// to branch discovery.
 //
 // It runs as a manager Runnable (leader-only) rather than a watch-driven controller
 // because staleness is external state that no Kubernetes event reports.
@@ -32,6 +34,7 @@ type Checker struct {
 	gitClientFactory GitClientFactory
 	markAction       StaleBranchAction
 	cleanupAction    StaleBranchAction
+	adoptAction      *AdoptAction
 }
 
 func NewChecker(
@@ -41,6 +44,7 @@ func NewChecker(
 	gitClientFactory GitClientFactory,
 	markAction StaleBranchAction,
 	cleanupAction StaleBranchAction,
+	adoptAction *AdoptAction,
 ) *Checker {
 	return &Checker{
 		client:           k8sClient,
@@ -49,6 +53,7 @@ func NewChecker(
 		gitClientFactory: gitClientFactory,
 		markAction:       markAction,
 		cleanupAction:    cleanupAction,
+		adoptAction:      adoptAction,
 	}
 }
 
@@ -104,11 +109,18 @@ func (c *Checker) sweep(ctx context.Context) {
 
 	for i := range branches.Items {
 		branch := &branches.Items[i]
+
 		branchesByCodebase[branch.Spec.CodebaseName] = append(branchesByCodebase[branch.Spec.CodebaseName], branch)
 	}
 
+	if c.adoptAction != nil {
+		if err = c.addDiscoveryCodebases(ctx, branchesByCodebase); err != nil {
+			log.Error(err, "Failed to list codebases, skipping branch discovery for codebases without branches")
+		}
+	}
+
 	for codebaseName, codebaseBranches := range branchesByCodebase {
-		if err := c.checkCodebaseBranches(ctx, codebaseName, codebaseBranches, usage); err != nil {
+		if err := c.checkCodebaseBranches(ctx, codebaseName, codebaseBranches, usage, true); err != nil {
 			log.Error(err, "Failed to check branches staleness", "codebase", codebaseName)
 		}
 	}
@@ -116,11 +128,81 @@ func (c *Checker) sweep(ctx context.Context) {
 	log.Info("Codebase branches staleness check finished", "codebases", len(branchesByCodebase))
 }
 
+// CheckBranches runs the staleness check outside the periodic sweep for the
+// CodebaseBranches of one codebase that track the given git branches. It serves git
+// events reporting a branch deletion: the verdict still comes from a remote listing,
+// so a delayed or replayed event can only trigger an early check, never a wrong one.
+func (c *Checker) CheckBranches(ctx context.Context, codebaseName string, gitBranches []string) error {
+	branches := &codebaseApi.CodebaseBranchList{}
+	if err := c.client.List(ctx, branches, client.InNamespace(c.namespace)); err != nil {
+		return fmt.Errorf("failed to list codebase branches: %w", err)
+	}
+
+	wanted := make(map[string]struct{}, len(gitBranches))
+	for _, name := range gitBranches {
+		wanted[name] = struct{}{}
+	}
+
+	var affected []*codebaseApi.CodebaseBranch
+
+	for i := range branches.Items {
+		branch := &branches.Items[i]
+		if branch.Spec.CodebaseName != codebaseName {
+			continue
+		}
+
+		if _, ok := wanted[branch.Spec.BranchName]; ok {
+			affected = append(affected, branch)
+		}
+	}
+
+	if len(affected) == 0 {
+		return nil
+	}
+
+	// Unlike the sweep, a single check cannot share a snapshot, so the usage is read
+	// on every call.
+	usage, err := codebasebranch.NewBranchUsageIndex(ctx, c.client, c.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to index deployment usage: %w", err)
+	}
+
+	// The branch list is filtered, so discovery is off: every other remote branch would
+	// look untracked.
+	return c.checkCodebaseBranches(ctx, codebaseName, affected, usage, false)
+}
+
+// addDiscoveryCodebases adds the codebases that opted in to branch discovery but have
+// no CodebaseBranches yet, so that the sweep lists their remote branches too.
+func (c *Checker) addDiscoveryCodebases(
+	ctx context.Context,
+	branchesByCodebase map[string][]*codebaseApi.CodebaseBranch,
+) error {
+	codebases := &codebaseApi.CodebaseList{}
+	if err := c.client.List(ctx, codebases, client.InNamespace(c.namespace)); err != nil {
+		return fmt.Errorf("failed to list codebases: %w", err)
+	}
+
+	for i := range codebases.Items {
+		codebase := &codebases.Items[i]
+		if codebase.Annotations[codebaseApi.BranchDiscoveryIncludeAnnotation] == "" {
+			continue
+		}
+
+		if _, ok := branchesByCodebase[codebase.Name]; !ok {
+			branchesByCodebase[codebase.Name] = nil
+		}
+	}
+
+	return nil
+}
+
 func (c *Checker) checkCodebaseBranches(
 	ctx context.Context,
 	codebaseName string,
 	branches []*codebaseApi.CodebaseBranch,
 	usage *codebasebranch.BranchUsageIndex,
+	discover bool,
 ) error {
 	log := ctrl.LoggerFrom(ctx).WithValues("codebase", codebaseName)
 
@@ -155,7 +237,14 @@ func (c *Checker) checkCodebaseBranches(
 
 	// A failed listing means the repository state is unknown; branches are marked stale
 	// only on a successful listing that lacks them, never on connectivity/auth errors.
-	remoteBranches, err := c.gitClientFactory(gitServer, secret).ListRemoteBranches(ctx, repoURL)
+	gitConfig, err := gitproviderv2.NewConfig(ctx, c.client, gitServer, secret)
+	if err != nil {
+		return err //nolint:wrapcheck // NewConfig errors are descriptive
+	}
+
+	gitClient := c.gitClientFactory(gitConfig)
+
+	remoteBranches, err := gitClient.ListRemoteBranches(ctx, repoURL)
 	if err != nil {
 		return fmt.Errorf("failed to list remote branches for %s, skipping staleness check: %w", repoURL, err)
 	}
@@ -185,6 +274,12 @@ func (c *Checker) checkCodebaseBranches(
 		}
 	}
 
+	if discover && c.adoptAction != nil {
+		if err = c.adoptAction.Apply(ctx, codebase, gitClient, repoURL, remoteBranches, branches); err != nil {
+			return fmt.Errorf("failed to adopt remote branches: %w", err)
+		}
+	}
+
 	return nil
 }
 
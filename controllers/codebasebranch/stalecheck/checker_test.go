@@ -93,13 +93,21 @@ func newChecker(
 ) *Checker {
 	t.Helper()
 
-	factory := func(_ *codebaseApi.GitServer, _ *corev1.Secret) gitproviderv2.Git {
+	factory := func(_ gitproviderv2.Config) gitproviderv2.Git {
 		return gitClient
 	}
 
 	mark := NewMarkAction(k8sClient, recorder)
 
-	return NewChecker(k8sClient, testNamespace, 0, factory, mark, NewCleanupAction(k8sClient, recorder, mark))
+	return NewChecker(
+		k8sClient,
+		testNamespace,
+		0,
+		factory,
+		mark,
+		NewCleanupAction(k8sClient, recorder, mark),
+		NewAdoptAction(k8sClient, recorder),
+	)
 }
 
 func getBranch(t *testing.T, k8sClient client.Client, name string) *codebaseApi.CodebaseBranch {
@@ -0,0 +1,681 @@
+package stalecheck
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util/gitpathlabel"
+)
+
+const (
+	// EventsPath is the path the EventReceiver is served on.
+	EventsPath = "/branch-events"
+
+	// maxEventBodySize matches the largest payload GitHub delivers; GitLab and Bitbucket
+	// payloads are smaller.
+	maxEventBodySize = 25 << 20
+
+	// eventCheckTimeout bounds the check triggered by a single event. Providers give up
+	// waiting for a response after about ten seconds, and a check cut short by that would
+	// be lost until the next sweep, so it runs detached from the request.
+	eventCheckTimeout = 2 * time.Minute
+
+	zeroCommitHash = "0000000000000000000000000000000000000000"
+
+	// maxGitHubPushCommits is the number of commits GitHub reports in a push event at most.
+	maxGitHubPushCommits = 2048
+)
+
+var errUnsupportedEvent = errors.New("unsupported git event")
+
+// gitEvent is the provider-independent content of an event the receiver acts on: it
+// reports either deleted branches and pushed commits, or a pull request that was opened,
+// updated or closed.
+type gitEvent struct {
+	gitProvider string
+
+	// repoPath is the repository path as the provider reports it, e.g. "owner/app".
+	repoPath string
+
+	deletedBranches []string
+
+	pushes []push
+
+	pullRequest *pullRequest
+}
+
+// EventReceiver receives git events from GitHub, GitLab and Bitbucket. Branch deletions
+// run the staleness check for just the affected branches, so that the periodic sweep can
+// run rarely; pull requests open and close preview environments when a PreviewAction is set.
+// When a CommitPolicyAction is set, the commits of pushes and open pull requests are checked
+// against the commit policy of the codebase.
+//
+// Events are authenticated with the webhook secret of the codebase's GitServer (the
+// secretString key of its credentials secret), the same secret the operator registers
+// the Tekton webhooks with.
+type EventReceiver struct {
+	client    client.Client
+	namespace string
+	checker   *Checker
+	previews  *PreviewAction
+	policy    *CommitPolicyAction
+}
+
+func NewEventReceiver(
+	k8sClient client.Client,
+	namespace string,
+	checker *Checker,
+	previews *PreviewAction,
+	policy *CommitPolicyAction,
+) *EventReceiver {
+	return &EventReceiver{
+		client:    k8sClient,
+		namespace: namespace,
+		checker:   checker,
+		previews:  previews,
+		policy:    policy,
+	}
+}
+
+func (r *EventReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	log := ctrl.Log.WithName("branch-event-receiver")
+
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxEventBodySize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := parseGitEvent(req.Header, body)
+	if err != nil {
+		log.Info("Rejected git event", "reason", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if event == nil || !r.handles(event) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	log = log.WithValues("repository", event.repoPath)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), eventCheckTimeout)
+
+	defer cancel()
+
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	codebases, err := r.findVerifiedCodebases(ctx, event, req.Header, body)
+	if err != nil {
+		log.Error(err, "Failed to authenticate git event")
+		http.Error(w, "failed to authenticate event", http.StatusInternalServerError)
+
+		return
+	}
+
+	// An unknown repository and a wrong signature get the same answer, so that the
+	// endpoint does not reveal which repositories the platform manages.
+	if len(codebases) == 0 {
+		log.Info("Git event does not match any codebase or has an invalid signature")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+		return
+	}
+
+	for _, codebase := range codebases {
+		if err = r.handleEvent(ctx, codebase, event); err != nil {
+			log.Error(err, "Failed to handle git event", "codebase", codebase.Name)
+			http.Error(w, "failed to handle event", http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handles reports whether the receiver has an action for the event.
+func (r *EventReceiver) handles(event *gitEvent) bool {
+	if pr := event.pullRequest; pr != nil {
+		return r.previews != nil || (r.policy != nil && !pr.closed)
+	}
+
+	return len(event.deletedBranches) > 0 || (len(event.pushes) > 0 && r.policy != nil)
+}
+
+func (r *EventReceiver) handleEvent(ctx context.Context, codebase *codebaseApi.Codebase, event *gitEvent) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("codebase", codebase.Name)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	pr := event.pullRequest
+	if pr != nil {
+		return r.handlePullRequest(ctx, codebase, pr)
+	}
+
+	if len(event.deletedBranches) > 0 {
+		log.Info("Checking branches reported deleted by git event", "branches", event.deletedBranches)
+
+		if err := r.checker.CheckBranches(ctx, codebase.Name, event.deletedBranches); err != nil {
+			return err
+		}
+	}
+
+	if r.policy == nil {
+		return nil
+	}
+
+	for i := range event.pushes {
+		if err := r.policy.CheckPush(ctx, codebase, &event.pushes[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *EventReceiver) handlePullRequest(ctx context.Context, codebase *codebaseApi.Codebase, pr *pullRequest) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if r.previews != nil {
+		if pr.closed {
+			log.Info("Closing preview of pull request", "pullRequest", pr.number)
+
+			return r.previews.Close(ctx, codebase, pr)
+		}
+
+		log.Info("Opening preview of pull request", "pullRequest", pr.number, "branch", pr.branch)
+
+		if err := r.previews.Open(ctx, codebase, pr); err != nil {
+			return err
+		}
+	}
+
+	if r.policy == nil {
+		return nil
+	}
+
+	return r.policy.CheckPullRequest(ctx, codebase, pr)
+}
+
+// findVerifiedCodebases returns the codebases of the event's repository whose GitServer
+// webhook secret signs the event. Several codebases may share a repository path on
+// different GitServers, so each candidate is verified against its own secret.
+func (r *EventReceiver) findVerifiedCodebases(
+	ctx context.Context,
+	event *gitEvent,
+	header http.Header,
+	body []byte,
+) ([]*codebaseApi.Codebase, error) {
+	candidates := &codebaseApi.CodebaseList{}
+	if err := r.client.List(
+		ctx,
+		candidates,
+		client.InNamespace(r.namespace),
+		client.MatchingLabels{codebaseApi.GitUrlPathHashLabel: gitpathlabel.Hash(event.repoPath)},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list codebases: %w", err)
+	}
+
+	var verified []*codebaseApi.Codebase
+
+	for i := range candidates.Items {
+		codebase := &candidates.Items[i]
+
+		// The label only narrows the selection; the path itself is the identity.
+		if !strings.EqualFold(codebase.Spec.GetProjectID(), strings.TrimPrefix(event.repoPath, "/")) {
+			continue
+		}
+
+		secret, err := r.webhookSecret(ctx, codebase, event.gitProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		if verifyEventSignature(event.gitProvider, header, body, secret) {
+			verified = append(verified, codebase)
+		}
+	}
+
+	return verified, nil
+}
+
+// webhookSecret returns the webhook secret of the codebase's GitServer, or nil when the
+// GitServer is of another provider than the event or has no secret yet.
+func (r *EventReceiver) webhookSecret(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	gitProvider string,
+) ([]byte, error) {
+	gitServer := &codebaseApi.GitServer{}
+	if err := r.client.Get(
+		ctx, client.ObjectKey{Namespace: r.namespace, Name: codebase.Spec.GitServer}, gitServer,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get git server %s: %w", codebase.Spec.GitServer, err)
+	}
+
+	if gitServer.Spec.GitProvider != gitProvider {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(
+		ctx, client.ObjectKey{Namespace: r.namespace, Name: gitServer.Spec.NameSshKeySecret}, secret,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", gitServer.Spec.NameSshKeySecret, err)
+	}
+
+	return secret.Data[util.GitServerSecretWebhookSecretField], nil
+}
+
+// verifyEventSignature checks the event against the webhook secret the way each
+// provider signs it: GitHub and Bitbucket send an HMAC-SHA256 of the body, GitLab
+// sends the secret itself. An empty secret never verifies.
+func verifyEventSignature(gitProvider string, header http.Header, body, secret []byte) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	switch gitProvider {
+	case codebaseApi.GitProviderGithub:
+		return verifyHMACSHA256(header.Get("X-Hub-Signature-256"), body, secret)
+	case codebaseApi.GitProviderBitbucket:
+		return verifyHMACSHA256(header.Get("X-Hub-Signature"), body, secret)
+	case codebaseApi.GitProviderGitlab:
+		return subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), secret) == 1
+	default:
+		return false
+	}
+}
+
+func verifyHMACSHA256(signature string, body, secret []byte) bool {
+	hexSignature, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+
+	got, err := hex.DecodeString(hexSignature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// parseGitEvent extracts the deleted branches and pushes, or the pull request, from a
+// provider event. It returns nil without an error for supported events the receiver does
+// not act on, such as tag pushes and pings.
+func parseGitEvent(header http.Header, body []byte) (*gitEvent, error) {
+	switch {
+	case header.Get("X-GitHub-Event") != "":
+		return parseGitHubEvent(header.Get("X-GitHub-Event"), body)
+	case header.Get("X-Gitlab-Event") != "":
+		return parseGitLabEvent(header.Get("X-Gitlab-Event"), body)
+	case header.Get("X-Event-Key") != "":
+		return parseBitbucketEvent(header.Get("X-Event-Key"), body)
+	default:
+		return nil, errUnsupportedEvent
+	}
+}
+
+func parseGitHubEvent(eventType string, body []byte) (*gitEvent, error) {
+	payload := struct {
+		Ref     string `json:"ref"`
+		RefType string `json:"ref_type"`
+		Deleted bool   `json:"deleted"`
+		Before  string `json:"before"`
+		After   string `json:"after"`
+		Commits []struct {
+			ID      string `json:"id"`
+			Message string `json:"message"`
+		} `json:"commits"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}{}
+
+	var branch string
+
+	switch eventType {
+	case "delete":
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode GitHub event: %w", err)
+		}
+
+		if payload.RefType != "branch" {
+			return nil, nil
+		}
+
+		branch = payload.Ref
+	case "push":
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode GitHub event: %w", err)
+		}
+
+		name, isBranch := strings.CutPrefix(payload.Ref, "refs/heads/")
+		if !isBranch {
+			return nil, nil
+		}
+
+		if !payload.Deleted {
+			p := push{branch: name, baseSHA: commitSHA(payload.Before), headSHA: payload.After}
+			for _, commit := range payload.Commits {
+				p.commits = append(p.commits, gitprovider.Commit{SHA: commit.ID, Message: commit.Message})
+			}
+
+			p.truncated = len(p.commits) >= maxGitHubPushCommits
+
+			return newPushEvent(codebaseApi.GitProviderGithub, payload.Repository.FullName, nil, commitPushes(p))
+		}
+
+		branch = name
+	case "pull_request":
+		return parseGitHubPullRequestEvent(body)
+	default:
+		return nil, nil
+	}
+
+	return newPushEvent(codebaseApi.GitProviderGithub, payload.Repository.FullName, []string{branch}, nil)
+}
+
+func parseGitLabEvent(eventType string, body []byte) (*gitEvent, error) {
+	switch eventType {
+	case "Push Hook":
+	case "Merge Request Hook":
+		return parseGitLabMergeRequestEvent(body)
+	default:
+		return nil, nil
+	}
+
+	payload := struct {
+		Ref               string `json:"ref"`
+		Before            string `json:"before"`
+		After             string `json:"after"`
+		TotalCommitsCount int    `json:"total_commits_count"`
+		Commits           []struct {
+			ID      string `json:"id"`
+			Message string `json:"message"`
+		} `json:"commits"`
+		Project struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"project"`
+	}{}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode GitLab event: %w", err)
+	}
+
+	branch, isBranch := strings.CutPrefix(payload.Ref, "refs/heads/")
+	if !isBranch {
+		return nil, nil
+	}
+
+	if payload.After == zeroCommitHash {
+		return newPushEvent(codebaseApi.GitProviderGitlab, payload.Project.PathWithNamespace, []string{branch}, nil)
+	}
+
+	p := push{branch: branch, baseSHA: commitSHA(payload.Before), headSHA: payload.After}
+	for _, commit := range payload.Commits {
+		p.commits = append(p.commits, gitprovider.Commit{SHA: commit.ID, Message: commit.Message})
+	}
+
+	p.truncated = payload.TotalCommitsCount > len(p.commits)
+
+	return newPushEvent(codebaseApi.GitProviderGitlab, payload.Project.PathWithNamespace, nil, commitPushes(p))
+}
+
+func parseBitbucketEvent(eventType string, body []byte) (*gitEvent, error) {
+	switch eventType {
+	case "repo:push":
+	case "pullrequest:created", "pullrequest:updated":
+		return parseBitbucketPullRequestEvent(body, false)
+	case "pullrequest:fulfilled", "pullrequest:rejected":
+		return parseBitbucketPullRequestEvent(body, true)
+	default:
+		return nil, nil
+	}
+
+	type bitbucketRef struct {
+		Type   string `json:"type"`
+		Name   string `json:"name"`
+		Target struct {
+			Hash string `json:"hash"`
+		} `json:"target"`
+	}
+
+	payload := struct {
+		Push struct {
+			Changes []struct {
+				Old     *bitbucketRef `json:"old"`
+				New     *bitbucketRef `json:"new"`
+				Commits []struct {
+					Hash    string `json:"hash"`
+					Message string `json:"message"`
+				} `json:"commits"`
+				Truncated bool `json:"truncated"`
+			} `json:"changes"`
+		} `json:"push"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}{}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode Bitbucket event: %w", err)
+	}
+
+	var (
+		branches []string
+		pushes   []push
+	)
+
+	for _, change := range payload.Push.Changes {
+		switch {
+		case change.New == nil && change.Old != nil && change.Old.Type == "branch":
+			branches = append(branches, change.Old.Name)
+		case change.New != nil && change.New.Type == "branch":
+			p := push{branch: change.New.Name, headSHA: change.New.Target.Hash, truncated: change.Truncated}
+			if change.Old != nil {
+				p.baseSHA = change.Old.Target.Hash
+			}
+
+			for _, commit := range change.Commits {
+				p.commits = append(p.commits, gitprovider.Commit{SHA: commit.Hash, Message: commit.Message})
+			}
+
+			pushes = append(pushes, commitPushes(p)...)
+		}
+	}
+
+	return newPushEvent(codebaseApi.GitProviderBitbucket, payload.Repository.FullName, branches, pushes)
+}
+
+func parseGitHubPullRequestEvent(body []byte) (*gitEvent, error) {
+	payload := struct {
+		Action      string `json:"action"`
+		Number      int    `json:"number"`
+		PullRequest struct {
+			Head struct {
+				Ref  string `json:"ref"`
+				SHA  string `json:"sha"`
+				Repo *struct {
+					FullName string `json:"full_name"`
+				} `json:"repo"`
+			} `json:"head"`
+		} `json:"pull_request"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}{}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode GitHub event: %w", err)
+	}
+
+	pr := &pullRequest{
+		number:  payload.Number,
+		branch:  payload.PullRequest.Head.Ref,
+		headSHA: payload.PullRequest.Head.SHA,
+	}
+
+	switch payload.Action {
+	case "opened", "reopened", "synchronize":
+	case "closed":
+		pr.closed = true
+	default:
+		return nil, nil
+	}
+
+	// The head repository is null when the fork was deleted.
+	head := payload.PullRequest.Head.Repo
+	pr.fromFork = head == nil || !strings.EqualFold(head.FullName, payload.Repository.FullName)
+
+	return newPullRequestEvent(codebaseApi.GitProviderGithub, payload.Repository.FullName, pr)
+}
+
+func parseGitLabMergeRequestEvent(body []byte) (*gitEvent, error) {
+	payload := struct {
+		Project struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"project"`
+		ObjectAttributes struct {
+			IID             int    `json:"iid"`
+			Action          string `json:"action"`
+			SourceBranch    string `json:"source_branch"`
+			SourceProjectID int    `json:"source_project_id"`
+			TargetProjectID int    `json:"target_project_id"`
+			LastCommit      struct {
+				ID string `json:"id"`
+			} `json:"last_commit"`
+		} `json:"object_attributes"`
+	}{}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode GitLab event: %w", err)
+	}
+
+	attrs := payload.ObjectAttributes
+	pr := &pullRequest{
+		number:   attrs.IID,
+		branch:   attrs.SourceBranch,
+		headSHA:  attrs.LastCommit.ID,
+		fromFork: attrs.SourceProjectID != attrs.TargetProjectID,
+	}
+
+	switch attrs.Action {
+	case "open", "reopen", "update":
+	case "close", "merge":
+		pr.closed = true
+	default:
+		return nil, nil
+	}
+
+	return newPullRequestEvent(codebaseApi.GitProviderGitlab, payload.Project.PathWithNamespace, pr)
+}
+
+func parseBitbucketPullRequestEvent(body []byte, closed bool) (*gitEvent, error) {
+	type bitbucketRepository struct {
+		FullName string `json:"full_name"`
+	}
+
+	payload := struct {
+		PullRequest struct {
+			ID     int `json:"id"`
+			Source struct {
+				Branch struct {
+					Name string `json:"name"`
+				} `json:"branch"`
+				Commit struct {
+					Hash string `json:"hash"`
+				} `json:"commit"`
+				Repository bitbucketRepository `json:"repository"`
+			} `json:"source"`
+		} `json:"pullrequest"`
+		Repository bitbucketRepository `json:"repository"`
+	}{}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode Bitbucket event: %w", err)
+	}
+
+	source := payload.PullRequest.Source
+	pr := &pullRequest{
+		number:   payload.PullRequest.ID,
+		branch:   source.Branch.Name,
+		headSHA:  source.Commit.Hash,
+		closed:   closed,
+		fromFork: !strings.EqualFold(source.Repository.FullName, payload.Repository.FullName),
+	}
+
+	return newPullRequestEvent(codebaseApi.GitProviderBitbucket, payload.Repository.FullName, pr)
+}
+
+func newPushEvent(gitProvider, repoPath string, deletedBranches []string, pushes []push) (*gitEvent, error) {
+	if len(deletedBranches) == 0 && len(pushes) == 0 {
+		return nil, nil
+	}
+
+	if repoPath == "" {
+		return nil, fmt.Errorf("%s event has no repository: %w", gitProvider, errUnsupportedEvent)
+	}
+
+	return &gitEvent{
+		gitProvider:     gitProvider,
+		repoPath:        repoPath,
+		deletedBranches: deletedBranches,
+		pushes:          pushes,
+	}, nil
+}
+
+// commitPushes returns the push when it has commits: a branch created at an existing
+// commit has nothing to check.
+func commitPushes(p push) []push {
+	if len(p.commits) == 0 && !p.truncated {
+		return nil
+	}
+
+	return []push{p}
+}
+
+// commitSHA returns the commit hash of a push payload, empty for the zero hash of a new branch.
+func commitSHA(sha string) string {
+	if sha == zeroCommitHash {
+		return ""
+	}
+
+	return sha
+}
+
+func newPullRequestEvent(gitProvider, repoPath string, pr *pullRequest) (*gitEvent, error) {
+	if repoPath == "" || pr.number == 0 || pr.branch == "" {
+		return nil, fmt.Errorf("%s pull request event is incomplete: %w", gitProvider, errUnsupportedEvent)
+	}
+
+	return &gitEvent{gitProvider: gitProvider, repoPath: repoPath, pullRequest: pr}, nil
+}
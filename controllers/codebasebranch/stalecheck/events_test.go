@@ -0,0 +1,209 @@
+package stalecheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	gitmocks "github.com/epam/edp-codebase-operator/v2/pkg/git/mocks"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util/gitpathlabel"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+func newLabeledCodebase() *codebaseApi.Codebase {
+	codebase := newCodebase()
+	codebase.Labels = map[string]string{codebaseApi.GitUrlPathHashLabel: gitpathlabel.Hash(codebase.Spec.GitUrlPath)}
+
+	return codebase
+}
+
+func newEventReceiver(t *testing.T, k8sClient client.Client, gitClient *gitmocks.MockGit) *EventReceiver {
+	t.Helper()
+
+	recorder := record.NewFakeRecorder(10)
+
+	return NewEventReceiver(
+		k8sClient,
+		testNamespace,
+		newChecker(t, k8sClient, gitClient, recorder),
+		NewPreviewAction(k8sClient, recorder),
+		nil,
+	)
+}
+
+func sendEvent(t *testing.T, receiver http.Handler, body string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, EventsPath, bytes.NewBufferString(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	receiver.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(body))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestEventReceiver_MarksBranchDeletedInGitLab(t *testing.T) {
+	codebase := newLabeledCodebase()
+	featureBranch := newBranch("app-feature", "feature", codebaseApi.CodebaseBranchGitStatusBranchCreated)
+	otherBranch := newBranch("app-other", "other", codebaseApi.CodebaseBranchGitStatusBranchCreated)
+	gitServer, secret := newGitServerWithSecret()
+	secret.Data[util.GitServerSecretWebhookSecretField] = []byte(testWebhookSecret)
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, featureBranch, otherBranch, gitServer, secret).
+		WithStatusSubresource(featureBranch, otherBranch).
+		Build()
+
+	gitClient := gitmocks.NewMockGit(t)
+	gitClient.On("ListRemoteBranches", mock.Anything, mock.Anything).Return([]string{"main"}, nil).Once()
+
+	rec := sendEvent(t, newEventReceiver(t, k8sClient, gitClient),
+		`{"object_kind":"push","ref":"refs/heads/feature","after":"0000000000000000000000000000000000000000",`+
+			`"project":{"path_with_namespace":"owner/app"}}`,
+		map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": testWebhookSecret},
+	)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	stale := getBranch(t, k8sClient, "app-feature")
+	assert.True(t, meta.IsStatusConditionTrue(stale.Status.Conditions, codebaseApi.ConditionStale))
+
+	// Only the branch named in the event is checked, even though "other" is missing too.
+	untouched := getBranch(t, k8sClient, "app-other")
+	assert.Empty(t, untouched.Status.Conditions)
+}
+
+func TestEventReceiver_DeletesBranchDeletedInGitHubUnderAutoStrategy(t *testing.T) {
+	codebase := autoCleanupCodebase()
+	codebase.Labels = map[string]string{codebaseApi.GitUrlPathHashLabel: gitpathlabel.Hash(codebase.Spec.GitUrlPath)}
+	featureBranch := newBranch("app-feature", "feature", codebaseApi.CodebaseBranchGitStatusBranchCreated)
+	gitServer, secret := newGitServerWithSecret()
+	gitServer.Spec.GitProvider = codebaseApi.GitProviderGithub
+	secret.Data[util.GitServerSecretWebhookSecretField] = []byte(testWebhookSecret)
+
+	scheme := newScheme(t)
+	require.NoError(t, pipelineApi.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(codebase, featureBranch, gitServer, secret).
+		WithStatusSubresource(featureBranch).
+		Build()
+
+	gitClient := gitmocks.NewMockGit(t)
+	gitClient.On("ListRemoteBranches", mock.Anything, mock.Anything).Return([]string{"main"}, nil).Once()
+
+	body := `{"ref":"feature","ref_type":"branch","repository":{"full_name":"Owner/App"}}`
+	rec := sendEvent(t, newEventReceiver(t, k8sClient, gitClient), body,
+		map[string]string{"X-GitHub-Event": "delete", "X-Hub-Signature-256": sign(body)},
+	)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(featureBranch), &codebaseApi.CodebaseBranch{})
+	assert.True(t, apierrors.IsNotFound(err), "unused branch must be deleted")
+}
+
+func TestEventReceiver_RejectsInvalidSignature(t *testing.T) {
+	codebase := newLabeledCodebase()
+	featureBranch := newBranch("app-feature", "feature", codebaseApi.CodebaseBranchGitStatusBranchCreated)
+	gitServer, secret := newGitServerWithSecret()
+	gitServer.Spec.GitProvider = codebaseApi.GitProviderBitbucket
+	secret.Data[util.GitServerSecretWebhookSecretField] = []byte(testWebhookSecret)
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, featureBranch, gitServer, secret).
+		WithStatusSubresource(featureBranch).
+		Build()
+
+	// No git call is expected: an unauthenticated event must not trigger a check.
+	gitClient := gitmocks.NewMockGit(t)
+
+	body := `{"push":{"changes":[{"old":{"type":"branch","name":"feature"},"new":null}]},` +
+		`"repository":{"full_name":"owner/app"}}`
+	rec := sendEvent(t, newEventReceiver(t, k8sClient, gitClient), body,
+		map[string]string{"X-Event-Key": "repo:push", "X-Hub-Signature": "sha256=00"},
+	)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, getBranch(t, k8sClient, "app-feature").Status.Conditions)
+}
+
+func TestEventReceiver_IgnoresEventsWithoutBranchDeletion(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+	receiver := newEventReceiver(t, k8sClient, gitmocks.NewMockGit(t))
+
+	tests := []struct {
+		name   string
+		body   string
+		header map[string]string
+		want   int
+	}{
+		{
+			name:   "GitHub push of new commits",
+			body:   `{"ref":"refs/heads/feature","deleted":false,"repository":{"full_name":"owner/app"}}`,
+			header: map[string]string{"X-GitHub-Event": "push"},
+			want:   http.StatusNoContent,
+		},
+		{
+			name:   "GitHub tag deletion",
+			body:   `{"ref":"v1.0.0","ref_type":"tag","repository":{"full_name":"owner/app"}}`,
+			header: map[string]string{"X-GitHub-Event": "delete"},
+			want:   http.StatusNoContent,
+		},
+		{
+			name:   "GitLab merge request",
+			body:   `{"object_kind":"merge_request"}`,
+			header: map[string]string{"X-Gitlab-Event": "Merge Request Hook"},
+			want:   http.StatusNoContent,
+		},
+		{
+			name:   "Bitbucket branch creation",
+			body:   `{"push":{"changes":[{"old":null,"new":{"type":"branch","name":"feature"}}]}}`,
+			header: map[string]string{"X-Event-Key": "repo:push"},
+			want:   http.StatusNoContent,
+		},
+		{
+			name: "unknown provider",
+			body: `{}`,
+			want: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sendEvent(t, receiver, tt.body, tt.header).Code)
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package stalecheck
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/issuetracker"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+// CommitPolicyStatusContext is the name of the commit status the CommitPolicyAction reports.
+// Branch protection rules can require it.
+const CommitPolicyStatusContext = "edp/commit-policy"
+
+// shortSHALength is the length of the commit hashes in status descriptions.
+const shortSHALength = 7
+
+// push is the provider-independent content of a push of commits to a branch.
+type push struct {
+	branch string
+
+	// baseSHA is the commit the branch pointed to before the push, empty for a new branch.
+	baseSHA string
+
+	// headSHA is the commit the branch points to after the push.
+	headSHA string
+
+	// commits are the pushed commits the webhook reports: GitHub reports up to 2048 of
+	// them, GitLab the latest 20 and Bitbucket the latest 5. truncated is set when the push
+	// has more commits.
+	commits   []gitprovider.Commit
+	truncated bool
+}
+
+// commitList is the list of the commits a check covers. partial is set when they are only
+// part of the commits to check, err when they could not be listed.
+type commitList struct {
+	commits []gitprovider.Commit
+	partial bool
+	err     error
+}
+
+// gitRepository is the repository of a codebase in the API of its git provider.
+type gitRepository struct {
+	provider  gitprovider.GitCommitProvider
+	apiURL    string
+	token     string
+	projectID string
+}
+
+// CommitPolicyAction enforces the commit policy of codebases: the commit messages of pushes
+// and pull requests must match the commitMessagePattern of the codebase and reference tickets
+// of its ticketNamePattern that exist in its issue tracker. The result is reported to the git
+// provider as a commit status of the pushed commit or of the head of the pull request.
+type CommitPolicyAction struct {
+	client        client.Client
+	httpClients   *gitprovider.HTTPClientPool
+	newTracker    func(ctx context.Context, codebase *codebaseApi.Codebase) (issuetracker.Tracker, error)
+	newRepository func(ctx context.Context, codebase *codebaseApi.Codebase) (*gitRepository, error)
+}
+
+func NewCommitPolicyAction(
+	k8sClient client.Client,
+	httpClients *gitprovider.HTTPClientPool,
+	jiraClients *jira.ClientPool,
+) *CommitPolicyAction {
+	a := &CommitPolicyAction{
+		client:      k8sClient,
+		httpClients: httpClients,
+		newTracker:  issuetracker.NewFactory(k8sClient, httpClients, jiraClients).New,
+	}
+
+	a.newRepository = a.gitRepository
+
+	return a
+}
+
+// CheckPush checks the pushed commits and reports the result on the head of the branch.
+func (a *CommitPolicyAction) CheckPush(ctx context.Context, codebase *codebaseApi.Codebase, p *push) error {
+	if codebase.Spec.CommitPolicy == nil || p.headSHA == "" {
+		return nil
+	}
+
+	repo, err := a.newRepository(ctx, codebase)
+	if err != nil {
+		return err
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Checking commit policy of push", "branch", p.branch, "commit", p.headSHA)
+
+	return a.check(ctx, codebase, repo, p.headSHA, pushCommits(ctx, codebase, repo, p))
+}
+
+// pushCommits lists the pushed commits with the API of the git provider, as webhooks report
+// only part of the commits of large pushes. The commits of a new branch are the commits that
+// are not on the default branch. The commits a webhook reports are used only for the first
+// push of the default branch, which has no branch to compare with.
+func pushCommits(ctx context.Context, codebase *codebaseApi.Codebase, repo *gitRepository, p *push) commitList {
+	base := p.baseSHA
+	if base == "" && p.branch != codebase.Spec.DefaultBranch {
+		base = codebase.Spec.DefaultBranch
+	}
+
+	if base == "" {
+		return commitList{commits: p.commits, partial: p.truncated}
+	}
+
+	commits, err := repo.provider.GetCommits(ctx, repo.apiURL, repo.token, repo.projectID, base, p.headSHA)
+	if err != nil {
+		err = fmt.Errorf("failed to get commits from %s to %s: %w", base, p.headSHA, err)
+	}
+
+	return commitList{commits: commits, err: err}
+}
+
+// CheckPullRequest checks the commits of an open pull request and reports the result on its head.
+func (a *CommitPolicyAction) CheckPullRequest(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	pr *pullRequest,
+) error {
+	if codebase.Spec.CommitPolicy == nil || pr.closed || pr.headSHA == "" {
+		return nil
+	}
+
+	repo, err := a.newRepository(ctx, codebase)
+	if err != nil {
+		return err
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Checking commit policy of pull request", "pullRequest", pr.number)
+
+	commits, err := repo.provider.GetPullRequestCommits(ctx, repo.apiURL, repo.token, repo.projectID, pr.number)
+	if err != nil {
+		err = fmt.Errorf("failed to get commits of pull request %d: %w", pr.number, err)
+	}
+
+	return a.check(ctx, codebase, repo, pr.headSHA, commitList{commits: commits, err: err})
+}
+
+// check reports the result of the policy check of the commits as the status of the commit sha.
+// Violations are reported as a failure. A check that could not be completed, e.g. because the
+// issue tracker is unavailable, is reported as an error, and its error returned. A check of
+// part of the commits that found no violations is reported as pending.
+func (a *CommitPolicyAction) check(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	repo *gitRepository,
+	sha string,
+	list commitList,
+) error {
+	status := gitprovider.CommitStatus{
+		State:   gitprovider.CommitStateSuccess,
+		Context: CommitPolicyStatusContext,
+	}
+
+	var (
+		violations []string
+		checked    int
+	)
+
+	checkErr := list.err
+	if checkErr == nil {
+		violations, checked, checkErr = a.commitViolations(ctx, codebase, list.commits)
+	}
+
+	switch {
+	case checkErr != nil:
+		status.State = gitprovider.CommitStateError
+		status.Description = "Commit policy check failed: " + checkErr.Error()
+	case len(violations) > 0:
+		status.State = gitprovider.CommitStateFailure
+		status.Description = fmt.Sprintf("%d of %d commits violate the commit policy. %s",
+			len(violations), checked, strings.Join(violations, ". "))
+	case list.partial:
+		status.State = gitprovider.CommitStatePending
+		status.Description = fmt.Sprintf("%d commits follow the commit policy, the push has more commits than reported",
+			checked)
+	default:
+		status.Description = fmt.Sprintf("%d commits follow the commit policy", checked)
+	}
+
+	if err := repo.provider.SetCommitStatus(ctx, repo.apiURL, repo.token, repo.projectID, sha, status); err != nil {
+		return fmt.Errorf("failed to set commit status of %s: %w", sha, err)
+	}
+
+	if checkErr != nil {
+		return fmt.Errorf("failed to check commit policy: %w", checkErr)
+	}
+
+	return nil
+}
+
+// commitViolations returns how the commits violate the commit policy of the codebase, one
+// description per commit, and the number of commits checked. commitMessagePattern is matched
+// against the first line of the commit message, tickets are searched for in the whole message.
+// Merge commits, which have more than one parent, are skipped.
+func (a *CommitPolicyAction) commitViolations(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+	commits []gitprovider.Commit,
+) ([]string, int, error) {
+	policy := codebase.Spec.CommitPolicy
+
+	var messageRe *regexp.Regexp
+
+	if codebase.Spec.CommitMessagePattern != nil && *codebase.Spec.CommitMessagePattern != "" {
+		re, err := regexp.Compile(*codebase.Spec.CommitMessagePattern)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid commit message pattern: %w", err)
+		}
+
+		messageRe = re
+	}
+
+	tickets := &ticketVerifier{action: a, codebase: codebase, known: make(map[string]bool)}
+
+	var (
+		violations []string
+		checked    int
+	)
+
+	for _, commit := range commits {
+		if commit.Parents > 1 {
+			continue
+		}
+
+		checked++
+
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		sha := commit.SHA[:min(len(commit.SHA), shortSHALength)]
+
+		if messageRe != nil && !messageRe.MatchString(subject) {
+			violations = append(violations, fmt.Sprintf("Commit %s does not match the commit message pattern", sha))
+			continue
+		}
+
+		found, err := issuetracker.FindTickets(issuetracker.TicketNamePattern(codebase), commit.Message)
+		if err != nil {
+			return nil, 0, err //nolint:wrapcheck // FindTickets errors are descriptive
+		}
+
+		if policy.RequireTicket && len(found) == 0 {
+			violations = append(violations, fmt.Sprintf("Commit %s does not reference a ticket", sha))
+			continue
+		}
+
+		if !policy.VerifyTickets {
+			continue
+		}
+
+		missing, err := tickets.missing(ctx, found)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(missing) > 0 {
+			violations = append(violations, fmt.Sprintf("Commit %s references unknown tickets %s",
+				sha, strings.Join(missing, ", ")))
+		}
+	}
+
+	return violations, checked, nil
+}
+
+// ticketVerifier checks that tickets exist in the issue tracker of a codebase, asking the
+// tracker once per ticket.
+type ticketVerifier struct {
+	action   *CommitPolicyAction
+	codebase *codebaseApi.Codebase
+	tracker  issuetracker.Tracker
+	known    map[string]bool
+}
+
+func (v *ticketVerifier) missing(ctx context.Context, tickets []string) ([]string, error) {
+	var missing []string
+
+	for _, ticket := range tickets {
+		exists, ok := v.known[ticket]
+		if !ok {
+			if v.tracker == nil {
+				tracker, err := v.action.newTracker(ctx, v.codebase)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create issue tracker: %w", err)
+				}
+
+				v.tracker = tracker
+			}
+
+			var err error
+
+			if exists, err = v.tracker.IssueExists(ctx, ticket); err != nil {
+				return nil, fmt.Errorf("failed to check ticket %s: %w", ticket, err)
+			}
+
+			v.known[ticket] = exists
+		}
+
+		if !exists {
+			missing = append(missing, ticket)
+		}
+	}
+
+	return missing, nil
+}
+
+// gitRepository returns the repository of the codebase, accessed with the token of its GitServer.
+func (a *CommitPolicyAction) gitRepository(
+	ctx context.Context,
+	codebase *codebaseApi.Codebase,
+) (*gitRepository, error) {
+	gitServer := &codebaseApi.GitServer{}
+	if err := a.client.Get(
+		ctx, client.ObjectKey{Namespace: codebase.Namespace, Name: codebase.Spec.GitServer}, gitServer,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get git server %s: %w", codebase.Spec.GitServer, err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := a.client.Get(
+		ctx, client.ObjectKey{Namespace: codebase.Namespace, Name: gitServer.Spec.NameSshKeySecret}, secret,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", gitServer.Spec.NameSshKeySecret, err)
+	}
+
+	token := string(secret.Data[util.GitServerSecretTokenField])
+	if token == "" {
+		return nil, fmt.Errorf("no %s key in secret %s", util.GitServerSecretTokenField, secret.Name)
+	}
+
+	restyClient, err := a.httpClients.RestyClient(ctx, gitServer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git provider HTTP client: %w", err)
+	}
+
+	provider, err := gitprovider.NewGitCommitProvider(gitServer, restyClient, token)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // NewGitCommitProvider errors are descriptive
+	}
+
+	return &gitRepository{
+		provider:  provider,
+		apiURL:    gitprovider.GetGitProviderAPIURL(gitServer),
+		token:     token,
+		projectID: codebase.Spec.GetProjectID(),
+	}, nil
+}
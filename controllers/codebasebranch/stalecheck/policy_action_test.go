@@ -0,0 +1,390 @@
+package stalecheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	gitmocks "github.com/epam/edp-codebase-operator/v2/pkg/git/mocks"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	gitprovidermocks "github.com/epam/edp-codebase-operator/v2/pkg/gitprovider/mocks"
+	"github.com/epam/edp-codebase-operator/v2/pkg/issuetracker"
+	trackermocks "github.com/epam/edp-codebase-operator/v2/pkg/issuetracker/mocks"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+func newPolicyAction(
+	provider gitprovider.GitCommitProvider,
+	tracker issuetracker.Tracker,
+) *CommitPolicyAction {
+	return &CommitPolicyAction{
+		newTracker: func(context.Context, *codebaseApi.Codebase) (issuetracker.Tracker, error) {
+			return tracker, nil
+		},
+		newRepository: func(context.Context, *codebaseApi.Codebase) (*gitRepository, error) {
+			return &gitRepository{
+				provider:  provider,
+				apiURL:    "https://api.github.com",
+				token:     "token",
+				projectID: "owner/app",
+			}, nil
+		},
+	}
+}
+
+func newPolicyCodebase(policy *codebaseApi.CommitPolicy) *codebaseApi.Codebase {
+	codebase := newCodebase()
+	codebase.Spec.CommitMessagePattern = ptr.To(`^\[APP-\d+\] .+`)
+	codebase.Spec.TicketNamePattern = ptr.To(`APP-\d+`)
+	codebase.Spec.CommitPolicy = policy
+
+	return codebase
+}
+
+func TestCommitPolicyAction_CheckPush(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      *codebaseApi.CommitPolicy
+		commits     []gitprovider.Commit
+		listErr     error
+		tracker     func(t *testing.T) issuetracker.Tracker
+		wantState   string
+		wantMessage string
+		wantErr     require.ErrorAssertionFunc
+	}{
+		{
+			name:   "all commits follow the policy",
+			policy: &codebaseApi.CommitPolicy{RequireTicket: true, VerifyTickets: true},
+			commits: []gitprovider.Commit{
+				{SHA: "1111111aaaa", Message: "[APP-1] Add feature\n\nRefs APP-2"},
+				{SHA: "2222222bbbb", Message: "[APP-1] Fix feature"},
+				{SHA: "3333333cccc", Message: "Sync with main", Parents: 2},
+			},
+			tracker: func(t *testing.T) issuetracker.Tracker {
+				m := trackermocks.NewMockTracker(t)
+				m.On("IssueExists", mock.Anything, "APP-1").Return(true, nil).Once()
+				m.On("IssueExists", mock.Anything, "APP-2").Return(true, nil).Once()
+
+				return m
+			},
+			wantState:   gitprovider.CommitStateSuccess,
+			wantMessage: "2 commits follow the commit policy",
+			wantErr:     require.NoError,
+		},
+		{
+			name:   "commit does not match the message pattern",
+			policy: &codebaseApi.CommitPolicy{},
+			commits: []gitprovider.Commit{
+				{SHA: "1111111aaaa", Message: "[APP-1] Add feature"},
+				{SHA: "2222222bbbb", Message: "fix typo"},
+				{SHA: "3333333cccc", Message: "Merge branch 'main'", Parents: 2},
+			},
+			tracker: func(t *testing.T) issuetracker.Tracker {
+				return trackermocks.NewMockTracker(t)
+			},
+			wantState:   gitprovider.CommitStateFailure,
+			wantMessage: "1 of 2 commits violate the commit policy. Commit 2222222 does not match",
+			wantErr:     require.NoError,
+		},
+		{
+			name:    "commit references an unknown ticket",
+			policy:  &codebaseApi.CommitPolicy{VerifyTickets: true},
+			commits: []gitprovider.Commit{{SHA: "1111111aaaa", Message: "[APP-404] Add feature"}},
+			tracker: func(t *testing.T) issuetracker.Tracker {
+				m := trackermocks.NewMockTracker(t)
+				m.On("IssueExists", mock.Anything, "APP-404").Return(false, nil)
+
+				return m
+			},
+			wantState:   gitprovider.CommitStateFailure,
+			wantMessage: "Commit 1111111 references unknown tickets APP-404",
+			wantErr:     require.NoError,
+		},
+		{
+			name:    "commits cannot be listed",
+			policy:  &codebaseApi.CommitPolicy{},
+			listErr: errors.New("not found"),
+			tracker: func(t *testing.T) issuetracker.Tracker {
+				return trackermocks.NewMockTracker(t)
+			},
+			wantState:   gitprovider.CommitStateError,
+			wantMessage: "Commit policy check failed: failed to get commits from base to head: not found",
+			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
+				require.ErrorContains(t, err, "not found")
+			},
+		},
+		{
+			name:    "issue tracker fails",
+			policy:  &codebaseApi.CommitPolicy{VerifyTickets: true},
+			commits: []gitprovider.Commit{{SHA: "1111111aaaa", Message: "[APP-1] Add feature"}},
+			tracker: func(t *testing.T) issuetracker.Tracker {
+				m := trackermocks.NewMockTracker(t)
+				m.On("IssueExists", mock.Anything, "APP-1").Return(false, errors.New("unauthorized"))
+
+				return m
+			},
+			wantState:   gitprovider.CommitStateError,
+			wantMessage: "Commit policy check failed",
+			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
+				require.ErrorContains(t, err, "unauthorized")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := gitprovidermocks.NewMockGitCommitProvider(t)
+			provider.On("GetCommits", mock.Anything, "https://api.github.com", "token", "owner/app", "base", "head").
+				Return(tt.commits, tt.listErr).Once()
+			provider.On("SetCommitStatus", mock.Anything, "https://api.github.com", "token", "owner/app", "head",
+				mock.MatchedBy(func(status gitprovider.CommitStatus) bool {
+					return status.Context == CommitPolicyStatusContext && status.State == tt.wantState &&
+						assert.Contains(t, status.Description, tt.wantMessage)
+				}),
+			).Return(nil).Once()
+
+			// The commits the webhook reports are ignored in favour of the commits the provider lists.
+			err := newPolicyAction(provider, tt.tracker(t)).CheckPush(
+				context.Background(),
+				newPolicyCodebase(tt.policy),
+				&push{branch: "feature", baseSHA: "base", headSHA: "head", truncated: true},
+			)
+
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestCommitPolicyAction_RequireTicket(t *testing.T) {
+	t.Parallel()
+
+	codebase := newPolicyCodebase(&codebaseApi.CommitPolicy{RequireTicket: true})
+	codebase.Spec.CommitMessagePattern = nil
+
+	provider := gitprovidermocks.NewMockGitCommitProvider(t)
+	provider.On("SetCommitStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "head",
+		mock.MatchedBy(func(status gitprovider.CommitStatus) bool {
+			return status.State == gitprovider.CommitStateFailure &&
+				status.Description == "1 of 1 commits violate the commit policy. Commit 1111111 does not reference a ticket"
+		}),
+	).Return(nil).Once()
+
+	// The first push of the default branch has nothing to compare with and is checked with
+	// the commits the webhook reports.
+	err := newPolicyAction(provider, nil).CheckPush(context.Background(), codebase, &push{
+		branch:  codebase.Spec.DefaultBranch,
+		headSHA: "head",
+		commits: []gitprovider.Commit{{SHA: "1111111aaaa", Message: "Add feature"}},
+	})
+
+	require.NoError(t, err)
+}
+
+func TestCommitPolicyAction_CheckPushOfNewBranch(t *testing.T) {
+	t.Parallel()
+
+	codebase := newPolicyCodebase(&codebaseApi.CommitPolicy{})
+
+	provider := gitprovidermocks.NewMockGitCommitProvider(t)
+	provider.On("GetCommits", mock.Anything, mock.Anything, mock.Anything, "owner/app",
+		codebase.Spec.DefaultBranch, "head").
+		Return([]gitprovider.Commit{{SHA: "1111111aaaa", Message: "[APP-1] Add feature"}}, nil).Once()
+	provider.On("SetCommitStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "head",
+		mock.MatchedBy(func(status gitprovider.CommitStatus) bool {
+			return status.State == gitprovider.CommitStateSuccess
+		}),
+	).Return(nil).Once()
+
+	err := newPolicyAction(provider, nil).CheckPush(context.Background(), codebase, &push{
+		branch:  "feature",
+		headSHA: "head",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestCommitPolicyAction_CheckTruncatedPush(t *testing.T) {
+	t.Parallel()
+
+	codebase := newPolicyCodebase(&codebaseApi.CommitPolicy{})
+
+	provider := gitprovidermocks.NewMockGitCommitProvider(t)
+	provider.On("SetCommitStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "head",
+		mock.MatchedBy(func(status gitprovider.CommitStatus) bool {
+			return status.State == gitprovider.CommitStatePending &&
+				status.Description == "1 commits follow the commit policy, the push has more commits than reported"
+		}),
+	).Return(nil).Once()
+
+	err := newPolicyAction(provider, nil).CheckPush(context.Background(), codebase, &push{
+		branch:    codebase.Spec.DefaultBranch,
+		headSHA:   "head",
+		commits:   []gitprovider.Commit{{SHA: "1111111aaaa", Message: "[APP-1] Add feature"}},
+		truncated: true,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestCommitPolicyAction_CheckPullRequest(t *testing.T) {
+	t.Parallel()
+
+	provider := gitprovidermocks.NewMockGitCommitProvider(t)
+	provider.On("GetPullRequestCommits", mock.Anything, mock.Anything, mock.Anything, "owner/app", 7).
+		Return([]gitprovider.Commit{{SHA: "1111111aaaa", Message: "[APP-1] Add feature"}}, nil).Once()
+	provider.On("SetCommitStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "head",
+		mock.MatchedBy(func(status gitprovider.CommitStatus) bool {
+			return status.State == gitprovider.CommitStateSuccess
+		}),
+	).Return(nil).Once()
+
+	action := newPolicyAction(provider, nil)
+	codebase := newPolicyCodebase(&codebaseApi.CommitPolicy{})
+
+	require.NoError(t, action.CheckPullRequest(context.Background(), codebase,
+		&pullRequest{number: 7, branch: "feature", headSHA: "head"}))
+
+	// Closed pull requests and codebases without a policy are not checked.
+	require.NoError(t, action.CheckPullRequest(context.Background(), codebase,
+		&pullRequest{number: 7, branch: "feature", headSHA: "head", closed: true}))
+	require.NoError(t, action.CheckPullRequest(context.Background(), newCodebase(),
+		&pullRequest{number: 7, branch: "feature", headSHA: "head"}))
+}
+
+func TestEventReceiver_ChecksCommitPolicyOfPush(t *testing.T) {
+	codebase := newLabeledCodebase()
+	codebase.Spec.CommitMessagePattern = ptr.To(`^\[APP-\d+\] .+`)
+	codebase.Spec.CommitPolicy = &codebaseApi.CommitPolicy{}
+	gitServer, secret := newGitServerWithSecret()
+	secret.Data[util.GitServerSecretWebhookSecretField] = []byte(testWebhookSecret)
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		Build()
+
+	provider := gitprovidermocks.NewMockGitCommitProvider(t)
+	provider.On("GetCommits", mock.Anything, mock.Anything, mock.Anything, "owner/app",
+		"1111111111111111111111111111111111111111", "2222222222222222222222222222222222222222").
+		Return([]gitprovider.Commit{{SHA: "2222222222222222222222222222222222222222", Message: "wip\n"}}, nil).Once()
+	provider.On("SetCommitStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+		"2222222222222222222222222222222222222222",
+		mock.MatchedBy(func(status gitprovider.CommitStatus) bool {
+			return status.State == gitprovider.CommitStateFailure
+		}),
+	).Return(nil).Once()
+
+	receiver := newEventReceiver(t, k8sClient, gitmocks.NewMockGit(t))
+	receiver.policy = newPolicyAction(provider, nil)
+
+	rec := sendEvent(t, receiver,
+		`{"object_kind":"push","ref":"refs/heads/feature","before":"1111111111111111111111111111111111111111",`+
+			`"after":"2222222222222222222222222222222222222222",`+
+			`"commits":[{"id":"2222222222222222222222222222222222222222","message":"wip\n"}],`+
+			`"project":{"path_with_namespace":"owner/app"}}`,
+		map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": testWebhookSecret},
+	)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestParseGitEvent_PushesAndHeadCommits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		header          string
+		eventType       string
+		body            string
+		wantPushes      []push
+		wantDeleted     []string
+		wantPullRequest *pullRequest
+	}{
+		{
+			name:      "GitHub push",
+			header:    "X-GitHub-Event",
+			eventType: "push",
+			body: `{"ref":"refs/heads/feature","before":"000","after":"bbb","repository":{"full_name":"owner/app"},` +
+				`"commits":[{"id":"aaa","message":"first"},{"id":"bbb","message":"second"}]}`,
+			wantPushes: []push{{branch: "feature", baseSHA: "000", headSHA: "bbb", commits: []gitprovider.Commit{
+				{SHA: "aaa", Message: "first"}, {SHA: "bbb", Message: "second"},
+			}}},
+		},
+		{
+			name:      "GitLab push of a new branch with more commits than reported",
+			header:    "X-Gitlab-Event",
+			eventType: "Push Hook",
+			body: `{"ref":"refs/heads/feature","before":"` + zeroCommitHash + `","after":"bbb",` +
+				`"total_commits_count":30,"project":{"path_with_namespace":"owner/app"},` +
+				`"commits":[{"id":"bbb","message":"second"}]}`,
+			wantPushes: []push{{branch: "feature", headSHA: "bbb", truncated: true, commits: []gitprovider.Commit{
+				{SHA: "bbb", Message: "second"},
+			}}},
+		},
+		{
+			name:      "Bitbucket push and deletion",
+			header:    "X-Event-Key",
+			eventType: "repo:push",
+			body: `{"repository":{"full_name":"owner/app"},"push":{"changes":[` +
+				`{"old":{"type":"branch","name":"old"},"new":null},` +
+				`{"old":{"type":"branch","name":"feature","target":{"hash":"aaa"}},` +
+				`"new":{"type":"branch","name":"feature","target":{"hash":"bbb"}},` +
+				`"commits":[{"hash":"bbb","message":"second"}]}]}}`,
+			wantPushes: []push{{branch: "feature", baseSHA: "aaa", headSHA: "bbb", commits: []gitprovider.Commit{
+				{SHA: "bbb", Message: "second"},
+			}}},
+			wantDeleted: []string{"old"},
+		},
+		{
+			name:      "GitHub pull request",
+			header:    "X-GitHub-Event",
+			eventType: "pull_request",
+			body: `{"action":"synchronize","number":3,"repository":{"full_name":"owner/app"},` +
+				`"pull_request":{"head":{"ref":"feature","sha":"bbb","repo":{"full_name":"owner/app"}}}}`,
+			wantPullRequest: &pullRequest{number: 3, branch: "feature", headSHA: "bbb"},
+		},
+		{
+			name:      "GitLab merge request",
+			header:    "X-Gitlab-Event",
+			eventType: "Merge Request Hook",
+			body: `{"project":{"path_with_namespace":"owner/app"},"object_attributes":{"iid":3,"action":"update",` +
+				`"source_branch":"feature","source_project_id":1,"target_project_id":1,"last_commit":{"id":"bbb"}}}`,
+			wantPullRequest: &pullRequest{number: 3, branch: "feature", headSHA: "bbb"},
+		},
+		{
+			name:      "Bitbucket pull request",
+			header:    "X-Event-Key",
+			eventType: "pullrequest:updated",
+			body: `{"repository":{"full_name":"owner/app"},"pullrequest":{"id":3,"source":{` +
+				`"branch":{"name":"feature"},"commit":{"hash":"bbb"},"repository":{"full_name":"owner/app"}}}}`,
+			wantPullRequest: &pullRequest{number: 3, branch: "feature", headSHA: "bbb"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := parseGitEvent(http.Header{http.CanonicalHeaderKey(tt.header): {tt.eventType}}, []byte(tt.body))
+			require.NoError(t, err)
+			require.NotNil(t, event)
+
+			assert.Equal(t, "owner/app", event.repoPath)
+			assert.Equal(t, tt.wantPushes, event.pushes)
+			assert.Equal(t, tt.wantDeleted, event.deletedBranches)
+			assert.Equal(t, tt.wantPullRequest, event.pullRequest)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package stalecheck
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
+	"github.com/epam/edp-codebase-operator/v2/pkg/deploymentusage"
+)
+
+const (
+	EventReasonPreviewCreated  = "PreviewCreated"
+	EventReasonPreviewDeleted  = "PreviewDeleted"
+	EventReasonPreviewRetained = "PreviewRetained"
+)
+
+// pullRequest is the provider-independent content of a pull request event.
+type pullRequest struct {
+	number int
+
+	// branch is the source branch of the pull request.
+	branch string
+
+	// headSHA is the last commit of the source branch.
+	headSHA string
+
+	closed bool
+
+	// fromFork is set for pull requests whose source branch lives in another repository,
+	// where the operator can neither build nor list it.
+	fromFork bool
+}
+
+// PreviewAction manages preview environments: short-lived CodebaseBranches created for
+// the source branch of an open pull request. The CodebaseBranch controller builds them
+// like any other branch, so each gets a CodebaseImageStream and, with a preview stage,
+// a CDStageDeploy for every new image.
+//
+// A preview is removed when its pull request is closed or its TTL passes, but never while
+// a CDPipeline or Stage uses it, the same rule the CodebaseBranch deletion webhook enforces.
+type PreviewAction struct {
+	client   client.Client
+	recorder record.EventRecorder
+	now      func() time.Time
+}
+
+func NewPreviewAction(k8sClient client.Client, recorder record.EventRecorder) *PreviewAction {
+	return &PreviewAction{client: k8sClient, recorder: recorder, now: time.Now}
+}
+
+// Open creates the preview CodebaseBranch of an opened pull request. Pull requests whose
+// source branch already has a CodebaseBranch, preview or not, get no other.
+func (a *PreviewAction) Open(ctx context.Context, codebase *codebaseApi.Codebase, pr *pullRequest) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("pullRequest", pr.number)
+
+	settings, err := codebasebranch.NewPreviewSettings(codebase)
+	if err != nil {
+		return fmt.Errorf("failed to read preview settings: %w", err)
+	}
+
+	if settings == nil || !codebase.Status.Available {
+		return nil
+	}
+
+	if pr.fromFork {
+		log.Info("Skipping preview for pull request from a fork")
+		return nil
+	}
+
+	tracked := &codebaseApi.CodebaseBranchList{}
+	if err = a.client.List(
+		ctx,
+		tracked,
+		client.InNamespace(codebase.Namespace),
+		client.MatchingLabels{
+			codebaseApi.CodebaseLabel:   codebase.Name,
+			codebaseApi.BranchHashLabel: codebasebranch.MakeGitBranchHash(pr.branch),
+		},
+	); err != nil {
+		return fmt.Errorf("failed to list codebase branches: %w", err)
+	}
+
+	if len(tracked.Items) > 0 {
+		return nil
+	}
+
+	annotations := map[string]string{
+		codebaseApi.PreviewExpiresAtAnnotation: a.now().Add(settings.TTL).UTC().Format(time.RFC3339),
+	}
+
+	if settings.Stage != "" {
+		annotations[codebaseApi.PreviewStageAnnotation] = settings.Stage
+	}
+
+	branch := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-pr-%d", codebase.Name, pr.number),
+			Namespace:   codebase.Namespace,
+			Annotations: annotations,
+			Labels: map[string]string{
+				codebaseApi.CodebaseLabel:   codebase.Name,
+				codebaseApi.BranchHashLabel: codebasebranch.MakeGitBranchHash(pr.branch),
+				codebaseApi.PreviewLabel:    strconv.Itoa(pr.number),
+			},
+		},
+		Spec: codebaseApi.CodebaseBranchSpec{
+			CodebaseName: codebase.Name,
+			BranchName:   pr.branch,
+		},
+	}
+
+	if codebase.Spec.IsVersionTypeSemver() {
+		branch.Spec.Version = codebase.Spec.Versioning.StartFrom
+	}
+
+	if err = a.client.Create(ctx, branch); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to create preview CodebaseBranch: %w", err)
+	}
+
+	if a.recorder != nil {
+		a.recorder.Eventf(codebase, corev1.EventTypeNormal, EventReasonPreviewCreated,
+			"Preview CodebaseBranch %s was created for pull request #%d", branch.Name, pr.number)
+	}
+
+	log.Info("Created preview codebase branch", "codebasebranch", branch.Name)
+
+	return nil
+}
+
+// Close removes the preview CodebaseBranch of a closed pull request. A preview still in use
+// is kept and expires at once, so that the sweep removes it as soon as it is released.
+func (a *PreviewAction) Close(ctx context.Context, codebase *codebaseApi.Codebase, pr *pullRequest) error {
+	previews := &codebaseApi.CodebaseBranchList{}
+	if err := a.client.List(
+		ctx,
+		previews,
+		client.InNamespace(codebase.Namespace),
+		client.MatchingLabels{
+			codebaseApi.CodebaseLabel: codebase.Name,
+			codebaseApi.PreviewLabel:  strconv.Itoa(pr.number),
+		},
+	); err != nil {
+		return fmt.Errorf("failed to list preview codebase branches: %w", err)
+	}
+
+	for i := range previews.Items {
+		branch := &previews.Items[i]
+
+		refs, err := codebasebranch.FindBranchUsage(ctx, a.client, branch)
+		if err != nil {
+			return fmt.Errorf("failed to check CodebaseBranch usage: %w", err)
+		}
+
+		removed, err := a.remove(ctx, branch, refs)
+		if err != nil {
+			return err
+		}
+
+		if removed {
+			continue
+		}
+
+		original := branch.DeepCopy()
+
+		if branch.Annotations == nil {
+			branch.Annotations = make(map[string]string, 1)
+		}
+
+		branch.Annotations[codebaseApi.PreviewExpiresAtAnnotation] = a.now().UTC().Format(time.RFC3339)
+
+		if err = a.client.Patch(ctx, branch, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to expire preview CodebaseBranch %s: %w", branch.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Expire removes the preview branch once its expiry time passes. It reports whether the
+// branch was deleted, so that the sweep does not check it afterwards.
+func (a *PreviewAction) Expire(
+	ctx context.Context,
+	branch *codebaseApi.CodebaseBranch,
+	usage *codebasebranch.BranchUsageIndex,
+) (bool, error) {
+	if _, ok := branch.Labels[codebaseApi.PreviewLabel]; !ok || branch.DeletionTimestamp != nil {
+		return false, nil
+	}
+
+	// A preview without a readable expiry time was edited by hand; it is left alone rather
+	// than removed early.
+	expiresAt, err := time.Parse(time.RFC3339, branch.Annotations[codebaseApi.PreviewExpiresAtAnnotation])
+	if err != nil || a.now().Before(expiresAt) {
+		return false, nil
+	}
+
+	return a.remove(ctx, branch, usage.Find(branch))
+}
+
+func (a *PreviewAction) remove(
+	ctx context.Context,
+	branch *codebaseApi.CodebaseBranch,
+	refs []deploymentusage.Reference,
+) (bool, error) {
+	if len(refs) > 0 {
+		if a.recorder != nil {
+			a.recorder.Eventf(branch, corev1.EventTypeWarning, EventReasonPreviewRetained,
+				"Preview is retained because it is used by %s", deploymentusage.Join(refs))
+		}
+
+		return false, nil
+	}
+
+	if err := a.client.Delete(ctx, branch); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("failed to delete preview CodebaseBranch %s: %w", branch.Name, err)
+	}
+
+	if a.recorder != nil {
+		a.recorder.Eventf(branch, corev1.EventTypeNormal, EventReasonPreviewDeleted,
+			"Preview of branch %s was deleted", branch.Spec.BranchName)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Deleted preview codebase branch",
+		"codebasebranch", branch.Name, "branch", branch.Spec.BranchName)
+
+	return true, nil
+}
@@ -0,0 +1,169 @@
+package stalecheck
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	pipelineApi "github.com/epam/edp-cd-pipeline-operator/v2/api/v1"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
+	gitmocks "github.com/epam/edp-codebase-operator/v2/pkg/git/mocks"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+func previewCodebase() *codebaseApi.Codebase {
+	codebase := newLabeledCodebase()
+	codebase.Annotations = map[string]string{
+		codebaseApi.PreviewEnvironmentsAnnotation: "true",
+		codebaseApi.PreviewStageAnnotation:        "app-pipeline/preview",
+		codebaseApi.PreviewTTLAnnotation:          "24h",
+	}
+	codebase.Status.Available = true
+
+	return codebase
+}
+
+func newPreviewBranch(expiresAt time.Time) *codebaseApi.CodebaseBranch {
+	branch := newBranch("app-pr-7", "feature", codebaseApi.CodebaseBranchGitStatusBranchCreated)
+	branch.Labels = map[string]string{
+		codebaseApi.CodebaseLabel:   "app",
+		codebaseApi.BranchHashLabel: codebasebranch.MakeGitBranchHash("feature"),
+		codebaseApi.PreviewLabel:    "7",
+	}
+	branch.Annotations = map[string]string{
+		codebaseApi.PreviewExpiresAtAnnotation: expiresAt.UTC().Format(time.RFC3339),
+	}
+
+	return branch
+}
+
+func TestEventReceiver_OpensPreviewForGitHubPullRequest(t *testing.T) {
+	codebase := previewCodebase()
+	gitServer, secret := newGitServerWithSecret()
+	gitServer.Spec.GitProvider = codebaseApi.GitProviderGithub
+	secret.Data[util.GitServerSecretWebhookSecretField] = []byte(testWebhookSecret)
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, gitServer, secret).
+		Build()
+
+	body := `{"action":"opened","number":7,"pull_request":{"head":{"ref":"feature","repo":{"full_name":"owner/app"}}},` +
+		`"repository":{"full_name":"owner/app"}}`
+	rec := sendEvent(t, newEventReceiver(t, k8sClient, gitmocks.NewMockGit(t)), body,
+		map[string]string{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": sign(body)},
+	)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	preview := getBranch(t, k8sClient, "app-pr-7")
+	assert.Equal(t, "feature", preview.Spec.BranchName)
+	assert.Equal(t, "7", preview.Labels[codebaseApi.PreviewLabel])
+	assert.Equal(t, "app-pipeline/preview", preview.Annotations[codebaseApi.PreviewStageAnnotation])
+
+	expiresAt, err := time.Parse(time.RFC3339, preview.Annotations[codebaseApi.PreviewExpiresAtAnnotation])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt, time.Minute)
+}
+
+func TestPreviewAction_Open_SkipsForksAndTrackedBranches(t *testing.T) {
+	codebase := previewCodebase()
+	tracked := newBranch("app-main", "main", codebaseApi.CodebaseBranchGitStatusBranchCreated)
+	tracked.Labels = map[string]string{
+		codebaseApi.CodebaseLabel:   "app",
+		codebaseApi.BranchHashLabel: codebasebranch.MakeGitBranchHash("main"),
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, tracked).
+		Build()
+
+	action := NewPreviewAction(k8sClient, record.NewFakeRecorder(10))
+
+	require.NoError(t, action.Open(context.Background(), codebase, &pullRequest{number: 1, branch: "main"}))
+	require.NoError(t, action.Open(context.Background(), codebase, &pullRequest{number: 2, branch: "fix", fromFork: true}))
+
+	list := &codebaseApi.CodebaseBranchList{}
+	require.NoError(t, k8sClient.List(context.Background(), list))
+	assert.Len(t, list.Items, 1)
+}
+
+func TestEventReceiver_ClosesPreviewForMergedGitLabMergeRequest(t *testing.T) {
+	codebase := previewCodebase()
+	preview := newPreviewBranch(time.Now().Add(time.Hour))
+	gitServer, secret := newGitServerWithSecret()
+	secret.Data[util.GitServerSecretWebhookSecretField] = []byte(testWebhookSecret)
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, preview, gitServer, secret).
+		Build()
+
+	rec := sendEvent(t, newEventReceiver(t, k8sClient, gitmocks.NewMockGit(t)),
+		`{"object_kind":"merge_request","project":{"path_with_namespace":"owner/app"},`+
+			`"object_attributes":{"iid":7,"action":"merge","source_branch":"feature",`+
+			`"source_project_id":1,"target_project_id":1}}`,
+		map[string]string{"X-Gitlab-Event": "Merge Request Hook", "X-Gitlab-Token": testWebhookSecret},
+	)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(preview), &codebaseApi.CodebaseBranch{})
+	assert.True(t, apierrors.IsNotFound(err), "preview must be deleted")
+}
+
+func TestPreviewAction_Close_RetainsUsedPreview(t *testing.T) {
+	codebase := previewCodebase()
+	preview := newPreviewBranch(time.Now().Add(time.Hour))
+	pipeline := &pipelineApi.CDPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-pipeline", Namespace: testNamespace},
+		Spec:       pipelineApi.CDPipelineSpec{InputDockerStreams: []string{"app-pr-7"}},
+	}
+
+	scheme := newScheme(t)
+	require.NoError(t, pipelineApi.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(codebase, preview, pipeline).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	action := NewPreviewAction(k8sClient, recorder)
+
+	require.NoError(t, action.Close(context.Background(), codebase, &pullRequest{number: 7, branch: "feature", closed: true}))
+
+	retained := getBranch(t, k8sClient, "app-pr-7")
+	expiresAt, err := time.Parse(time.RFC3339, retained.Annotations[codebaseApi.PreviewExpiresAtAnnotation])
+	require.NoError(t, err)
+	assert.False(t, expiresAt.After(time.Now()), "retained preview must expire at once")
+	assert.Contains(t, <-recorder.Events, EventReasonPreviewRetained)
+}
+
+func TestPreviewExpirer_DeletesExpiredPreview(t *testing.T) {
+	codebase := previewCodebase()
+	expired := newPreviewBranch(time.Now().Add(-time.Minute))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(codebase, expired).
+		Build()
+
+	NewPreviewExpirer(k8sClient, testNamespace, time.Hour, NewPreviewAction(k8sClient, record.NewFakeRecorder(10))).
+		sweep(context.Background())
+
+	err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(expired), &codebaseApi.CodebaseBranch{})
+	assert.True(t, apierrors.IsNotFound(err), "expired preview must be deleted")
+}
@@ -0,0 +1,94 @@
+package stalecheck
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
+)
+
+// PreviewExpirer periodically removes the preview CodebaseBranches whose TTL passed.
+//
+// It runs on its own interval rather than with the Checker, so that previews expire even
+// when the stale branch check is disabled, and close to their expiry time with the daily
+// stale branch check.
+type PreviewExpirer struct {
+	client    client.Client
+	namespace string
+	interval  time.Duration
+	action    *PreviewAction
+}
+
+func NewPreviewExpirer(
+	k8sClient client.Client,
+	namespace string,
+	interval time.Duration,
+	action *PreviewAction,
+) *PreviewExpirer {
+	return &PreviewExpirer{
+		client:    k8sClient,
+		namespace: namespace,
+		interval:  interval,
+		action:    action,
+	}
+}
+
+// Start implements manager.Runnable. It sweeps once on startup and then on every tick.
+func (e *PreviewExpirer) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("preview-expirer")
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	log.Info("Starting preview expirer", "interval", e.interval)
+
+	e.sweep(ctx)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Stopping preview expirer")
+			return nil
+		case <-ticker.C:
+			e.sweep(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection ensures only the elected leader deletes previews.
+func (e *PreviewExpirer) NeedLeaderElection() bool {
+	return true
+}
+
+func (e *PreviewExpirer) sweep(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+
+	branches := &codebaseApi.CodebaseBranchList{}
+	if err := e.client.List(ctx, branches, client.InNamespace(e.namespace), client.HasLabels{
+		codebaseApi.PreviewLabel,
+	}); err != nil {
+		log.Error(err, "Failed to list preview codebase branches")
+		return
+	}
+
+	if len(branches.Items) == 0 {
+		return
+	}
+
+	usage, err := codebasebranch.NewBranchUsageIndex(ctx, e.client, e.namespace)
+	if err != nil {
+		log.Error(err, "Failed to index deployment usage, skipping preview expiry")
+		return
+	}
+
+	for i := range branches.Items {
+		if _, err := e.action.Expire(ctx, &branches.Items[i], usage); err != nil {
+			log.Error(err, "Failed to expire preview branch", "branch", branches.Items[i].Name)
+		}
+	}
+}
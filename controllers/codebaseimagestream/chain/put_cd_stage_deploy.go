@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -66,7 +68,7 @@ func (h PutCDStageDeploy) handleCodebaseImageStreamEnvLabels(
 	for envLabel, val := range imageStream.Labels {
 		// pipeline lable should be in format cdpipeline/stage-name: ""
 		if labelValueRegexp.MatchString(envLabel) && val == "" {
-			if err := h.putCDStageDeploy(ctx, envLabel, imageStream.Namespace, imageStream.Spec); err != nil {
+			if err := h.putCDStageDeploy(ctx, envLabel, imageStream); err != nil {
 				return err
 			}
 
@@ -95,11 +97,11 @@ func validateCbis(imageStream *codebaseApi.CodebaseImageStream) []string {
 
 func (h PutCDStageDeploy) putCDStageDeploy(
 	ctx context.Context,
-	envLabel,
-	namespace string,
-	spec codebaseApi.CodebaseImageStreamSpec,
+	envLabel string,
+	imageStream *codebaseApi.CodebaseImageStream,
 ) error {
 	l := ctrl.LoggerFrom(ctx)
+	namespace, spec := imageStream.Namespace, imageStream.Spec
 	// use name for CDStageDeploy, it is converted from envLabel and cdpipeline/stage now is cdpipeline-stage
 	name := strings.ReplaceAll(envLabel, "/", "-")
 	env := strings.Split(envLabel, "/")
@@ -130,19 +132,30 @@ func (h PutCDStageDeploy) putCDStageDeploy(
 		return nil
 	}
 
-	cdsd, err := getCreateCommand(
-		ctx,
+	tag, deploy, err := latestTag(ctx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to construct command to create %v cd stage deploy: %w", name, err)
+	}
+
+	if !deploy {
+		l.Info("The tag added last is not the latest tag. Skip CDStageDeploy creation.")
+
+		return nil
+	}
+
+	if err = h.recordDeployedTag(ctx, imageStream, stageCrName, tag.Name); err != nil {
+		return fmt.Errorf("failed to record the tag deployed to %v stage: %w", stageCrName, err)
+	}
+
+	cdsd := getCreateCommand(
 		pipeline,
 		stage,
 		name,
 		namespace,
 		spec.Codebase,
 		stageCr.Spec.TriggerType,
-		spec.Tags,
+		tag,
 	)
-	if err != nil {
-		return fmt.Errorf("failed to construct command to create %v cd stage deploy: %w", name, err)
-	}
 
 	if err = h.create(ctx, cdsd, stageCr); err != nil {
 		return fmt.Errorf("failed to create %v cd stage deploy: %w", name, err)
@@ -151,6 +164,42 @@ func (h PutCDStageDeploy) putCDStageDeploy(
 	return nil
 }
 
+// recordDeployedTag records the tag deployed to the stage in the status of the CodebaseImageStream,
+// so that the retention policy keeps it after the CDStageDeploy is deleted. The tag is recorded
+// before the CDStageDeploy is created, so that a deployed tag is never left unrecorded.
+func (h PutCDStageDeploy) recordDeployedTag(
+	ctx context.Context,
+	imageStream *codebaseApi.CodebaseImageStream,
+	stage, tag string,
+) error {
+	deployed := codebaseApi.DeployedTag{Stage: stage, Tag: tag}
+	stream := imageStream.DeepCopy()
+	refetch := false
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error { //nolint:wrapcheck // the caller wraps the error
+		if refetch {
+			if err := h.client.Get(ctx, client.ObjectKeyFromObject(stream), stream); err != nil {
+				return err //nolint:wrapcheck // RetryOnConflict needs the original error
+			}
+		}
+
+		refetch = true
+
+		if slices.Contains(stream.Status.DeployedTags, deployed) {
+			return nil
+		}
+
+		patch := client.MergeFromWithOptions(stream.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+		stream.Status.DeployedTags = slices.DeleteFunc(stream.Status.DeployedTags, func(d codebaseApi.DeployedTag) bool {
+			return d.Stage == stage
+		})
+		stream.Status.DeployedTags = append(stream.Status.DeployedTags, deployed)
+
+		return h.client.Status().Patch(ctx, stream, patch) //nolint:wrapcheck // RetryOnConflict needs the original error
+	})
+}
+
 func (h PutCDStageDeploy) skipCDStageDeployCreation(
 	ctx context.Context,
 	pipeline,
@@ -192,16 +241,32 @@ func (h PutCDStageDeploy) skipCDStageDeployCreation(
 	}
 }
 
-func getCreateCommand(
-	ctx context.Context,
-	pipeline, stage, name, namespace, codebase, triggerType string,
-	tags []codebaseApi.Tag,
-) (*cdStageDeployCommand, error) {
-	lastTag, err := codebaseimagestream.GetLastTag(tags, ctrl.LoggerFrom(ctx))
+// latestTag returns the tag to deploy and whether to deploy it. With a latest tag selection,
+// a CDStageDeploy is created only when the tag added last is the one the selection picks,
+// so that adding a tag it does not pick, e.g. a snapshot to a stream that deploys releases,
+// does not deploy the latest tag again.
+func latestTag(ctx context.Context, spec codebaseApi.CodebaseImageStreamSpec) (codebaseApi.Tag, bool, error) {
+	tag, err := codebaseimagestream.GetLatestTag(spec.Tags, spec.LatestTag, ctrl.LoggerFrom(ctx))
+
+	if spec.LatestTag != nil && errors.Is(err, codebaseimagestream.ErrLatestTagNotFound) {
+		return codebaseApi.Tag{}, false, nil
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last tag: %w", err)
+		return codebaseApi.Tag{}, false, fmt.Errorf("failed to get last tag: %w", err)
+	}
+
+	if spec.LatestTag != nil && tag.Name != spec.Tags[len(spec.Tags)-1].Name {
+		return codebaseApi.Tag{}, false, nil
 	}
 
+	return tag, true, nil
+}
+
+func getCreateCommand(
+	pipeline, stage, name, namespace, codebase, triggerType string,
+	lastTag codebaseApi.Tag,
+) *cdStageDeployCommand {
 	return &cdStageDeployCommand{
 		Name:        name,
 		Namespace:   namespace,
@@ -213,7 +278,7 @@ func getCreateCommand(
 			Tag:      lastTag.Name,
 			Digest:   lastTag.Digest,
 		},
-	}, nil
+	}
 }
 
 func (h PutCDStageDeploy) create(ctx context.Context, command *cdStageDeployCommand, stage *pipelineApi.Stage) error {
@@ -53,6 +53,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects(
 						&pipelineAPi.Stage{
 							ObjectMeta: metaV1.ObjectMeta{
@@ -88,6 +89,15 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 							codebaseApi.CdStageLabel:    "ci-dev",
 						}))
 				require.Len(t, cdStageDeploys.Items, 1)
+
+				imageStream := &codebaseApi.CodebaseImageStream{}
+				require.NoError(t, k8scl.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      "test-image-stream",
+				}, imageStream))
+				require.Equal(t,
+					[]codebaseApi.DeployedTag{{Stage: "ci-dev", Tag: "latest"}},
+					imageStream.Status.DeployedTags)
 			},
 		},
 		{
@@ -109,6 +119,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects(
 						&pipelineAPi.Stage{
 							ObjectMeta: metaV1.ObjectMeta{
@@ -165,6 +176,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects(
 						&pipelineAPi.Stage{
 							ObjectMeta: metaV1.ObjectMeta{
@@ -231,6 +243,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects(
 						&pipelineAPi.Stage{
 							ObjectMeta: metaV1.ObjectMeta{
@@ -297,6 +310,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects().
 					Build()
 			},
@@ -325,6 +339,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects(
 						&pipelineAPi.Stage{
 							ObjectMeta: metaV1.ObjectMeta{
@@ -363,6 +378,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects().
 					Build()
 			},
@@ -387,6 +403,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects().
 					Build()
 			},
@@ -414,6 +431,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects().
 					Build()
 			},
@@ -439,6 +457,7 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			client: func(t *testing.T) client.Client {
 				return fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
 					WithObjects(
 						&pipelineAPi.Stage{
 							ObjectMeta: metaV1.ObjectMeta{
@@ -473,6 +492,131 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 				require.Len(t, cdStageDeploys.Items, 0)
 			},
 		},
+		{
+			name: "create CDStageDeploy for the tag the latest tag selection picks",
+			imageStream: &codebaseApi.CodebaseImageStream{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "test-image-stream",
+					Namespace: "default",
+					Labels: map[string]string{
+						"ci/prod": "",
+					},
+				},
+				Spec: codebaseApi.CodebaseImageStreamSpec{
+					Codebase:  "app",
+					ImageName: "app",
+					LatestTag: &codebaseApi.LatestTagSelection{
+						Strategy: codebaseApi.LatestTagStrategySemver,
+						Pattern:  `^\d+\.\d+\.\d+$`,
+					},
+					Tags: []codebaseApi.Tag{
+						{Name: "1.0.0", Created: "2026-10-01T12:00:00Z"},
+						{Name: "1.1.0", Created: "2026-10-02T12:00:00Z"},
+					},
+				},
+				Status: codebaseApi.CodebaseImageStreamStatus{
+					DeployedTags: []codebaseApi.DeployedTag{
+						{Stage: "ci-dev", Tag: "1.1.0"},
+						{Stage: "ci-prod", Tag: "1.0.0"},
+					},
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
+					WithObjects(
+						&pipelineAPi.Stage{
+							ObjectMeta: metaV1.ObjectMeta{
+								Name:      "ci-prod",
+								Namespace: "default",
+							},
+							Spec: pipelineAPi.StageSpec{
+								TriggerType: pipelineAPi.TriggerTypeAutoDeploy,
+							},
+						},
+					).Build()
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, k8scl client.Client) {
+				cdStageDeploys := &codebaseApi.CDStageDeployList{}
+				require.NoError(t,
+					k8scl.List(
+						context.Background(),
+						cdStageDeploys,
+						client.InNamespace("default"),
+						client.MatchingLabels{
+							codebaseApi.CdPipelineLabel: "ci",
+							codebaseApi.CdStageLabel:    "ci-prod",
+						}))
+				require.Len(t, cdStageDeploys.Items, 1)
+				require.Equal(t, "1.1.0", cdStageDeploys.Items[0].Spec.Tag.Tag)
+
+				imageStream := &codebaseApi.CodebaseImageStream{}
+				require.NoError(t, k8scl.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      "test-image-stream",
+				}, imageStream))
+				require.Equal(t, []codebaseApi.DeployedTag{
+					{Stage: "ci-dev", Tag: "1.1.0"},
+					{Stage: "ci-prod", Tag: "1.1.0"},
+				}, imageStream.Status.DeployedTags, "the tag deployed to the stage must replace the previous one")
+			},
+		},
+		{
+			name: "skip CDStageDeploy creation if the added tag is not the latest tag",
+			imageStream: &codebaseApi.CodebaseImageStream{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      "test-image-stream",
+					Namespace: "default",
+					Labels: map[string]string{
+						"ci/prod": "",
+					},
+				},
+				Spec: codebaseApi.CodebaseImageStreamSpec{
+					Codebase:  "app",
+					ImageName: "app",
+					LatestTag: &codebaseApi.LatestTagSelection{
+						Strategy: codebaseApi.LatestTagStrategySemver,
+						Pattern:  `^\d+\.\d+\.\d+$`,
+					},
+					Tags: []codebaseApi.Tag{
+						{Name: "1.0.0", Created: "2026-10-01T12:00:00Z"},
+						{Name: "1.1.0-SNAPSHOT.1", Created: "2026-10-02T12:00:00Z"},
+					},
+				},
+			},
+			client: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
+					WithObjects(
+						&pipelineAPi.Stage{
+							ObjectMeta: metaV1.ObjectMeta{
+								Name:      "ci-prod",
+								Namespace: "default",
+							},
+							Spec: pipelineAPi.StageSpec{
+								TriggerType: pipelineAPi.TriggerTypeAutoDeploy,
+							},
+						},
+					).Build()
+			},
+			wantErr: require.NoError,
+			want: func(t *testing.T, k8scl client.Client) {
+				cdStageDeploys := &codebaseApi.CDStageDeployList{}
+				require.NoError(t,
+					k8scl.List(
+						context.Background(),
+						cdStageDeploys,
+						client.InNamespace("default"),
+						client.MatchingLabels{
+							codebaseApi.CdPipelineLabel: "ci",
+							codebaseApi.CdStageLabel:    "ci-prod",
+						}))
+				require.Empty(t, cdStageDeploys.Items)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -482,7 +626,11 @@ func TestPutCDStageDeploy_ServeRequest(t *testing.T) {
 			h := PutCDStageDeploy{
 				client: tt.client(t),
 			}
-			tt.wantErr(t, h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), tt.imageStream))
+
+			imageStream := tt.imageStream.DeepCopy()
+			require.NoError(t, h.client.Create(context.Background(), imageStream))
+
+			tt.wantErr(t, h.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), imageStream))
 			tt.want(t, h.client)
 		})
 	}
@@ -2,6 +2,7 @@ package codebaseimagestream
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -16,6 +17,7 @@ import (
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/codebaseimagestream/chain"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebaseimagestream"
 	codebasepredicate "github.com/epam/edp-codebase-operator/v2/pkg/predicate"
 )
 
@@ -51,7 +53,8 @@ func (r *ReconcileCodebaseImageStream) SetupWithManager(mgr ctrl.Manager) error
 				return true
 			}
 
-			if !reflect.DeepEqual(oo.Spec.Tags, on.Spec.Tags) && on.Labels != nil {
+			if !reflect.DeepEqual(oo.Spec.Tags, on.Spec.Tags) && on.Labels != nil &&
+				!tagsRemoved(oo.Spec.Tags, on.Spec.Tags) && latestTagChanged(oo.Spec, on.Spec) {
 				return true
 			}
 
@@ -71,6 +74,41 @@ func (r *ReconcileCodebaseImageStream) SetupWithManager(mgr ctrl.Manager) error
 	return nil
 }
 
+// tagsRemoved reports whether the new tags are the old ones with some of them removed, as the
+// tag retention policy does. Removing tags does not change what to deploy.
+func tagsRemoved(oldTags, newTags []codebaseApi.Tag) bool {
+	if len(newTags) >= len(oldTags) {
+		return false
+	}
+
+	i := 0
+
+	for _, tag := range oldTags {
+		if i < len(newTags) && reflect.DeepEqual(tag, newTags[i]) {
+			i++
+		}
+	}
+
+	return i == len(newTags)
+}
+
+// latestTagChanged reports whether the update changes the latest tag of the stream. Backfilling
+// the digests of the tags or adding tags older than the latest one does not change what to deploy.
+func latestTagChanged(oldSpec, newSpec codebaseApi.CodebaseImageStreamSpec) bool {
+	newTag, err := codebaseimagestream.GetLatestTag(newSpec.Tags, newSpec.LatestTag, logr.Discard())
+	if err != nil {
+		// Let the reconciliation report an invalid selection.
+		return !errors.Is(err, codebaseimagestream.ErrLatestTagNotFound)
+	}
+
+	oldTag, err := codebaseimagestream.GetLatestTag(oldSpec.Tags, oldSpec.LatestTag, logr.Discard())
+	if err != nil {
+		return true
+	}
+
+	return oldTag.Name != newTag.Name
+}
+
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=codebaseimagestreams,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=codebaseimagestreams/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=codebaseimagestreams/finalizers,verbs=update
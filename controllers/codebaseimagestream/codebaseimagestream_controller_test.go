@@ -139,3 +139,110 @@ func TestReconcileCodebaseImageStream_Reconcile_ShouldFail(t *testing.T) {
 		t.Fatalf("wrong error returned: %s", err.Error())
 	}
 }
+
+func TestTagsRemoved(t *testing.T) {
+	t.Parallel()
+
+	one := codebaseApi.Tag{Name: "0.0.1", Created: "2026-10-01T10:00:00Z"}
+	two := codebaseApi.Tag{Name: "0.0.2", Created: "2026-10-02T10:00:00Z"}
+	three := codebaseApi.Tag{Name: "0.0.3", Created: "2026-10-03T10:00:00Z"}
+
+	tests := []struct {
+		name    string
+		oldTags []codebaseApi.Tag
+		newTags []codebaseApi.Tag
+		want    bool
+	}{
+		{
+			name:    "oldest tags removed",
+			oldTags: []codebaseApi.Tag{one, two, three},
+			newTags: []codebaseApi.Tag{three},
+			want:    true,
+		},
+		{
+			name:    "middle tag removed",
+			oldTags: []codebaseApi.Tag{one, two, three},
+			newTags: []codebaseApi.Tag{one, three},
+			want:    true,
+		},
+		{name: "all tags removed", oldTags: []codebaseApi.Tag{one}, newTags: nil, want: true},
+		{name: "tag added", oldTags: []codebaseApi.Tag{one}, newTags: []codebaseApi.Tag{one, two}, want: false},
+		{name: "tag replaced", oldTags: []codebaseApi.Tag{one, two}, newTags: []codebaseApi.Tag{three}, want: false},
+		{name: "unchanged", oldTags: []codebaseApi.Tag{one}, newTags: []codebaseApi.Tag{one}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tagsRemoved(tt.oldTags, tt.newTags))
+		})
+	}
+}
+
+func TestLatestTagChanged(t *testing.T) {
+	t.Parallel()
+
+	one := codebaseApi.Tag{Name: "0.0.1", Created: "2026-10-01T10:00:00Z"}
+	two := codebaseApi.Tag{Name: "0.0.2", Created: "2026-10-02T10:00:00Z"}
+	twoWithDigest := codebaseApi.Tag{Name: "0.0.2", Created: "2026-10-02T10:00:00Z", Digest: "sha256:abc"}
+	semver := &codebaseApi.LatestTagSelection{Strategy: codebaseApi.LatestTagStrategySemver}
+
+	tests := []struct {
+		name    string
+		oldSpec codebaseApi.CodebaseImageStreamSpec
+		newSpec codebaseApi.CodebaseImageStreamSpec
+		want    bool
+	}{
+		{
+			name:    "newer tag added",
+			oldSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{one}},
+			newSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{one, two}},
+			want:    true,
+		},
+		{
+			name:    "first tag added",
+			oldSpec: codebaseApi.CodebaseImageStreamSpec{},
+			newSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{one}},
+			want:    true,
+		},
+		{
+			name:    "digest backfilled",
+			oldSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{one, two}},
+			newSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{one, twoWithDigest}},
+			want:    false,
+		},
+		{
+			name:    "older tag appended",
+			oldSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{two}},
+			newSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{two, one}},
+			want:    false,
+		},
+		{
+			name:    "older version appended with the semver strategy",
+			oldSpec: codebaseApi.CodebaseImageStreamSpec{Tags: []codebaseApi.Tag{two}, LatestTag: semver},
+			newSpec: codebaseApi.CodebaseImageStreamSpec{
+				Tags:      []codebaseApi.Tag{two, {Name: "0.0.1-hotfix", Created: "2026-10-03T10:00:00Z"}},
+				LatestTag: semver,
+			},
+			want: false,
+		},
+		{
+			name:    "no tag selected",
+			oldSpec: codebaseApi.CodebaseImageStreamSpec{LatestTag: semver},
+			newSpec: codebaseApi.CodebaseImageStreamSpec{
+				Tags:      []codebaseApi.Tag{{Name: "latest", Created: "2026-10-03T10:00:00Z"}},
+				LatestTag: semver,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, latestTagChanged(tt.oldSpec, tt.newSpec))
+		})
+	}
+}
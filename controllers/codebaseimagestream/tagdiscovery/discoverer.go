@@ -0,0 +1,249 @@
+package tagdiscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/registry"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebaseimagestream"
+)
+
+const (
+	EventReasonTagsDiscovered     = "RegistryTagsDiscovered"
+	EventReasonTagDiscoveryFailed = "RegistryTagDiscoveryFailed"
+
+	// maxImagesPerStream bounds the images read per CodebaseImageStream in one sweep; each
+	// takes up to three registry requests.
+	maxImagesPerStream = 50
+
+	maxTagsInEventMessage = 10
+)
+
+// signatureTagPattern matches the tags cosign stores signatures, attestations and SBOMs of
+// images under; they are not images to deploy.
+var signatureTagPattern = regexp.MustCompile(`^sha256-[0-9a-f]{64}\.(sig|att|sbom)$`)
+
+// Discoverer periodically adds the tags of the registry to the CodebaseImageStreams that opt
+// in with the RegistryTagDiscoveryAnnotation, so that images pushed by a pipeline that failed
+// to update the CodebaseImageStream, or pushed by hand, are deployed like any other.
+// The tags get the digest and the creation time of their image, so that an old image found
+// late does not become the latest tag.
+//
+// It runs as a manager Runnable (leader-only) rather than a watch-driven controller
+// because the tags of the registry are external state that no Kubernetes event reports.
+type Discoverer struct {
+	*codebaseimagestream.Sweeper
+
+	client      client.Client
+	namespace   string
+	newRegistry registry.Factory
+	recorder    record.EventRecorder
+	now         func() time.Time
+}
+
+func NewDiscoverer(
+	k8sClient client.Client,
+	namespace string,
+	interval time.Duration,
+	newRegistry registry.Factory,
+	recorder record.EventRecorder,
+) *Discoverer {
+	d := &Discoverer{
+		client:      k8sClient,
+		namespace:   namespace,
+		newRegistry: newRegistry,
+		recorder:    recorder,
+		now:         time.Now,
+	}
+	d.Sweeper = codebaseimagestream.NewSweeper("registry-tag-discoverer", interval, d.sweep)
+
+	return d
+}
+
+func (d *Discoverer) sweep(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+
+	streams := &codebaseApi.CodebaseImageStreamList{}
+	if err := d.client.List(ctx, streams, client.InNamespace(d.namespace)); err != nil {
+		log.Error(err, "Failed to list CodebaseImageStreams")
+		return
+	}
+
+	for i := range streams.Items {
+		stream := &streams.Items[i]
+
+		if stream.Annotations[codebaseApi.RegistryTagDiscoveryAnnotation] != "true" {
+			continue
+		}
+
+		if err := d.discover(ctx, stream); err != nil {
+			log.Error(err, "Failed to discover registry tags", "codebaseimagestream", stream.Name)
+			d.recorder.Event(stream, corev1.EventTypeWarning, EventReasonTagDiscoveryFailed, err.Error())
+		}
+	}
+}
+
+// discover adds the tags of the registry missing in the CodebaseImageStream, and the digests
+// missing in its tags. Up to maxImagesPerStream images are read per sweep; the rest follow
+// in the next sweeps.
+func (d *Discoverer) discover(ctx context.Context, stream *codebaseApi.CodebaseImageStream) error {
+	host, repository := registry.ParseImageName(stream.Spec.ImageName)
+
+	auth, err := registry.CredentialsFor(ctx, d.client, d.namespace, host)
+	if err != nil {
+		return err //nolint:wrapcheck // CredentialsFor errors are descriptive
+	}
+
+	reg := d.newRegistry(host, auth)
+
+	tags, err := reg.Tags(ctx, repository)
+	if err != nil {
+		return fmt.Errorf("failed to list tags of %s: %w", stream.Spec.ImageName, err)
+	}
+
+	known := make(map[string]int, len(stream.Spec.Tags))
+	for i, tag := range stream.Spec.Tags {
+		known[tag.Name] = i
+	}
+
+	var found []codebaseApi.Tag
+
+	for _, name := range tags {
+		if len(found) == maxImagesPerStream {
+			break
+		}
+
+		i, exists := known[name]
+		if signatureTagPattern.MatchString(name) || (exists && stream.Spec.Tags[i].Digest != "") {
+			continue
+		}
+
+		image, err := reg.Image(ctx, repository, name)
+		if errors.Is(err, registry.ErrNotFound) {
+			// The tag was deleted after the tags were listed.
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to get image %s:%s: %w", stream.Spec.ImageName, name, err)
+		}
+
+		created := image.Created
+		if created.IsZero() {
+			created = d.now()
+		}
+
+		found = append(found, codebaseApi.Tag{
+			Name:    name,
+			Created: created.UTC().Format(time.RFC3339),
+			Digest:  image.Digest,
+		})
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	// Tags are kept in the order they were created in, as CI appends them.
+	slices.SortStableFunc(found, func(a, b codebaseApi.Tag) int {
+		return strings.Compare(a.Created, b.Created)
+	})
+
+	discovered, err := d.addTags(ctx, stream, found)
+	if err != nil {
+		return fmt.Errorf("failed to add registry tags: %w", err)
+	}
+
+	if len(discovered) > 0 {
+		d.recorder.Eventf(stream, corev1.EventTypeNormal, EventReasonTagsDiscovered,
+			"Added %d tags found in the registry: %s", len(discovered), tagNames(discovered))
+	}
+
+	return nil
+}
+
+// addTags adds the tags found in the registry to the CodebaseImageStream, or their digests
+// to its tags that have none, and returns the tags added. CI appends tags while the registry
+// is read, so the tags are patched with an optimistic lock and added again to the current
+// CodebaseImageStream on conflict.
+func (d *Discoverer) addTags(
+	ctx context.Context,
+	stream *codebaseApi.CodebaseImageStream,
+	found []codebaseApi.Tag,
+) ([]codebaseApi.Tag, error) {
+	digests := make(map[string]string, len(found))
+	for _, tag := range found {
+		digests[tag.Name] = tag.Digest
+	}
+
+	var added []codebaseApi.Tag
+
+	refetch := false
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refetch {
+			if err := d.client.Get(ctx, client.ObjectKeyFromObject(stream), stream); err != nil {
+				return err //nolint:wrapcheck // RetryOnConflict needs the original error
+			}
+		}
+
+		refetch = true
+
+		patch := client.MergeFromWithOptions(stream.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		updated := false
+		known := make(map[string]bool, len(stream.Spec.Tags))
+
+		for i := range stream.Spec.Tags {
+			tag := &stream.Spec.Tags[i]
+			known[tag.Name] = true
+
+			if digest, ok := digests[tag.Name]; ok && tag.Digest == "" {
+				tag.Digest = digest
+				updated = true
+			}
+		}
+
+		added = added[:0]
+
+		for _, tag := range found {
+			if !known[tag.Name] {
+				added = append(added, tag)
+			}
+		}
+
+		if len(added) == 0 && !updated {
+			return nil
+		}
+
+		stream.Spec.Tags = append(stream.Spec.Tags, added...)
+
+		return d.client.Patch(ctx, stream, patch) //nolint:wrapcheck // RetryOnConflict needs the original error
+	})
+
+	return added, err //nolint:wrapcheck // the caller wraps the error
+}
+
+func tagNames(tags []codebaseApi.Tag) string {
+	names := make([]string, 0, min(len(tags), maxTagsInEventMessage))
+	for _, tag := range tags[:min(len(tags), maxTagsInEventMessage)] {
+		names = append(names, tag.Name)
+	}
+
+	if len(tags) > maxTagsInEventMessage {
+		names = append(names, "...")
+	}
+
+	return strings.Join(names, ", ")
+}
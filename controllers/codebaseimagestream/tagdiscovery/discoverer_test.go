@@ -0,0 +1,240 @@
+package tagdiscovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/registry"
+)
+
+const testNamespace = "default"
+
+type fakeRegistry struct {
+	registry.Registry
+
+	tags    []string
+	images  map[string]registry.Image
+	tagsErr error
+}
+
+func (f *fakeRegistry) Tags(context.Context, string) ([]string, error) {
+	return f.tags, f.tagsErr
+}
+
+func (f *fakeRegistry) Image(_ context.Context, _, tag string) (registry.Image, error) {
+	image, ok := f.images[tag]
+	if !ok {
+		return registry.Image{}, registry.ErrNotFound
+	}
+
+	return image, nil
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func newStream(name string, annotated bool, tags ...codebaseApi.Tag) *codebaseApi.CodebaseImageStream {
+	stream := &codebaseApi.CodebaseImageStream{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: codebaseApi.CodebaseImageStreamSpec{
+			Codebase:  "app",
+			ImageName: "harbor.example.com/team/app",
+			Tags:      tags,
+		},
+	}
+
+	if annotated {
+		stream.Annotations = map[string]string{codebaseApi.RegistryTagDiscoveryAnnotation: "true"}
+	}
+
+	return stream
+}
+
+func newRegistrySecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kaniko-docker-config",
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				"app.edp.epam.com/integration-secret": "true",
+				"app.edp.epam.com/secret-type":        "registry",
+			},
+		},
+		Data: map[string][]byte{
+			".dockerconfigjson": []byte(`{"auths":{"harbor.example.com":{"username":"robot","password":"secret"}}}`),
+		},
+	}
+}
+
+func TestDiscoverer_sweep(t *testing.T) {
+	t.Parallel()
+
+	stream := newStream("app-main", true,
+		codebaseApi.Tag{Name: "main-0.0.1", Created: "2026-10-01T10:00:00Z"},
+		codebaseApi.Tag{Name: "main-0.0.2", Created: "2026-10-02T10:00:00Z", Digest: "sha256:two"},
+	)
+	other := newStream("other", false)
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(stream, other, newRegistrySecret()).
+		Build()
+
+	reg := &fakeRegistry{
+		tags: []string{
+			"main-0.0.1",
+			"main-0.0.2",
+			"main-0.0.4",
+			"main-0.0.3",
+			"sha256-2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae.sig",
+			"manual",
+		},
+		images: map[string]registry.Image{
+			"main-0.0.1": {Digest: "sha256:one"},
+			"main-0.0.3": {Digest: "sha256:three", Created: time.Date(2026, 10, 3, 10, 0, 0, 0, time.UTC)},
+			"main-0.0.4": {Digest: "sha256:four", Created: time.Date(2026, 10, 4, 10, 0, 0, 0, time.UTC)},
+			"manual":     {Digest: "sha256:manual"},
+		},
+	}
+
+	var gotHost string
+
+	var gotAuth registry.Auth
+
+	recorder := record.NewFakeRecorder(10)
+	d := NewDiscoverer(k8sClient, testNamespace, time.Minute, func(host string, auth registry.Auth) registry.Registry {
+		gotHost, gotAuth = host, auth
+
+		return reg
+	}, recorder)
+	d.now = func() time.Time { return time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC) }
+
+	d.sweep(context.Background())
+
+	assert.Equal(t, "harbor.example.com", gotHost)
+	assert.Equal(t, registry.Auth{Username: "robot", Password: "secret"}, gotAuth)
+
+	got := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+
+	assert.Equal(t, []codebaseApi.Tag{
+		{Name: "main-0.0.1", Created: "2026-10-01T10:00:00Z", Digest: "sha256:one"},
+		{Name: "main-0.0.2", Created: "2026-10-02T10:00:00Z", Digest: "sha256:two"},
+		{Name: "main-0.0.3", Created: "2026-10-03T10:00:00Z", Digest: "sha256:three"},
+		{Name: "main-0.0.4", Created: "2026-10-04T10:00:00Z", Digest: "sha256:four"},
+		// Images without a creation time get the time they were found.
+		{Name: "manual", Created: "2026-10-19T08:00:00Z", Digest: "sha256:manual"},
+	}, got.Spec.Tags)
+
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Added 3 tags found in the registry: main-0.0.3, main-0.0.4, manual")
+
+	untouched := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(other), untouched))
+	assert.Empty(t, untouched.Spec.Tags)
+
+	// A second sweep finds nothing new.
+	d.sweep(context.Background())
+	assert.Empty(t, recorder.Events)
+}
+
+func TestDiscoverer_discoverConcurrentUpdate(t *testing.T) {
+	t.Parallel()
+
+	stream := newStream("app-main", true,
+		codebaseApi.Tag{Name: "main-0.0.1", Created: "2026-10-01T10:00:00Z"},
+	)
+
+	patches := 0
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(stream, newRegistrySecret()).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(
+				ctx context.Context,
+				c client.WithWatch,
+				obj client.Object,
+				patch client.Patch,
+				opts ...client.PatchOption,
+			) error {
+				patches++
+
+				if patches == 1 {
+					// CI appends a tag while the registry is read.
+					current := &codebaseApi.CodebaseImageStream{}
+					require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), current))
+
+					current.Spec.Tags = append(current.Spec.Tags,
+						codebaseApi.Tag{Name: "main-0.0.2", Created: "2026-10-02T10:00:00Z"})
+					require.NoError(t, c.Update(ctx, current))
+				}
+
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	reg := &fakeRegistry{
+		tags: []string{"main-0.0.1", "main-0.0.2"},
+		images: map[string]registry.Image{
+			"main-0.0.1": {Digest: "sha256:one"},
+			"main-0.0.2": {Digest: "sha256:two", Created: time.Date(2026, 10, 2, 10, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	d := NewDiscoverer(k8sClient, testNamespace, time.Minute, func(string, registry.Auth) registry.Registry {
+		return reg
+	}, recorder)
+
+	got := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+	require.NoError(t, d.discover(context.Background(), got))
+
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+	assert.Equal(t, 2, patches, "the conflicting patch must be retried")
+	assert.Equal(t, []codebaseApi.Tag{
+		{Name: "main-0.0.1", Created: "2026-10-01T10:00:00Z", Digest: "sha256:one"},
+		// The tag CI appended is kept, and gets the digest found in the registry.
+		{Name: "main-0.0.2", Created: "2026-10-02T10:00:00Z", Digest: "sha256:two"},
+	}, got.Spec.Tags)
+	assert.Empty(t, recorder.Events, "no tag was added by the discoverer")
+}
+
+func TestDiscoverer_discoverFailure(t *testing.T) {
+	t.Parallel()
+
+	stream := newStream("app-main", true)
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(stream).Build()
+
+	recorder := record.NewFakeRecorder(10)
+	d := NewDiscoverer(k8sClient, testNamespace, time.Minute, func(string, registry.Auth) registry.Registry {
+		return &fakeRegistry{tagsErr: errors.New("registry returned 403 Forbidden")}
+	}, recorder)
+
+	d.sweep(context.Background())
+
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, EventReasonTagDiscoveryFailed)
+}
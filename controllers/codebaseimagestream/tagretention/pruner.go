@@ -0,0 +1,372 @@
+package tagretention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/registry"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebaseimagestream"
+)
+
+const (
+	EventReasonTagsPruned     = "TagsPruned"
+	EventReasonTagPruneFailed = "TagPruneFailed"
+
+	// maxReportedTags bounds status.retention.prunedTags, which must not grow the CR the
+	// policy keeps small.
+	maxReportedTags = 100
+)
+
+// Pruner periodically applies the retention policy of CodebaseImageStreams: it removes the
+// tags the policy does not keep from spec.tags and, when the policy asks for it, deletes
+// their images from the registry. The result is reported in status.retention.
+//
+// It runs as a manager Runnable (leader-only) rather than a watch-driven controller
+// because tags age out with time, not with a change of the CodebaseImageStream.
+type Pruner struct {
+	*codebaseimagestream.Sweeper
+
+	client      client.Client
+	namespace   string
+	newRegistry registry.Factory
+	recorder    record.EventRecorder
+	now         func() time.Time
+}
+
+func NewPruner(
+	k8sClient client.Client,
+	namespace string,
+	interval time.Duration,
+	newRegistry registry.Factory,
+	recorder record.EventRecorder,
+) *Pruner {
+	p := &Pruner{
+		client:      k8sClient,
+		namespace:   namespace,
+		newRegistry: newRegistry,
+		recorder:    recorder,
+		now:         time.Now,
+	}
+	p.Sweeper = codebaseimagestream.NewSweeper("tag-retention-pruner", interval, p.sweep)
+
+	return p
+}
+
+func (p *Pruner) sweep(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+
+	streams := &codebaseApi.CodebaseImageStreamList{}
+	if err := p.client.List(ctx, streams, client.InNamespace(p.namespace)); err != nil {
+		log.Error(err, "Failed to list CodebaseImageStreams")
+		return
+	}
+
+	var deployed map[string][]string
+
+	for i := range streams.Items {
+		stream := &streams.Items[i]
+
+		if stream.Spec.Retention == nil {
+			continue
+		}
+
+		// The CDStageDeploys are listed once per sweep, and only when a stream has a policy.
+		if deployed == nil {
+			var err error
+
+			if deployed, err = p.deployedTags(ctx); err != nil {
+				log.Error(err, "Failed to list CDStageDeploys")
+				return
+			}
+		}
+
+		if err := p.prune(ctx, stream, usageOf(stream, streams.Items, deployed)); err != nil {
+			log.Error(err, "Failed to apply tag retention policy", "codebaseimagestream", stream.Name)
+			p.recorder.Event(stream, corev1.EventTypeWarning, EventReasonTagPruneFailed, err.Error())
+		}
+	}
+}
+
+// imageUsage is how the images of a CodebaseImageStream are used outside of it: every
+// branch of a codebase has a CodebaseImageStream of the same image.
+type imageUsage struct {
+	// deployed are the tags of the image that CDStageDeploys reference or that were last
+	// deployed to a stage, as the CodebaseImageStreams of the image record.
+	deployed []string
+	// digests are the digests of the tags of the other CodebaseImageStreams of the image.
+	digests map[string]bool
+}
+
+// usageOf returns the usage of the images of the CodebaseImageStream by the CodebaseImageStreams
+// of the same image, by the CDStageDeploys of their codebases and by the stages they were
+// deployed to. CDStageDeploys are deleted once processed, so the deployed tags recorded in
+// status.deployedTags are what keeps the tags running in the stages.
+func usageOf(
+	stream *codebaseApi.CodebaseImageStream,
+	streams []codebaseApi.CodebaseImageStream,
+	deployed map[string][]string,
+) imageUsage {
+	usage := imageUsage{digests: make(map[string]bool)}
+	host, repository := registry.ParseImageName(stream.Spec.ImageName)
+
+	var codebases []string
+
+	for i := range streams {
+		other := &streams[i]
+
+		if otherHost, otherRepository := registry.ParseImageName(other.Spec.ImageName); otherHost != host ||
+			otherRepository != repository {
+			continue
+		}
+
+		if !slices.Contains(codebases, other.Spec.Codebase) {
+			codebases = append(codebases, other.Spec.Codebase)
+			usage.deployed = append(usage.deployed, deployed[other.Spec.Codebase]...)
+		}
+
+		for _, d := range other.Status.DeployedTags {
+			usage.deployed = append(usage.deployed, d.Tag)
+		}
+
+		if other.Name == stream.Name {
+			continue
+		}
+
+		for _, tag := range other.Spec.Tags {
+			if tag.Digest != "" {
+				usage.digests[tag.Digest] = true
+			}
+		}
+	}
+
+	return usage
+}
+
+// prune applies the retention policy of the CodebaseImageStream. Tags whose image could not
+// be deleted from the registry are kept, so that the deletion is retried in the next sweep.
+func (p *Pruner) prune(ctx context.Context, stream *codebaseApi.CodebaseImageStream, usage imageUsage) error {
+	policy := stream.Spec.Retention
+
+	kept, pruned, err := p.partition(ctx, &stream.Spec, usage.deployed)
+	if err != nil {
+		return err
+	}
+
+	result := &codebaseApi.TagRetentionStatus{
+		LastRunTime: metav1.NewTime(p.now()),
+		DryRun:      policy.DryRun,
+	}
+
+	var deleteErr error
+
+	if !policy.DryRun && policy.DeleteImages && len(pruned) > 0 {
+		var failed []codebaseApi.Tag
+
+		pruned, failed, result.DeletedImages, deleteErr = p.deleteImages(ctx, stream, kept, pruned, usage.digests)
+		kept = append(kept, failed...)
+	}
+
+	result.PrunedCount = len(pruned)
+
+	for _, tag := range pruned[:min(len(pruned), maxReportedTags)] {
+		result.PrunedTags = append(result.PrunedTags, tag.Name)
+	}
+
+	if deleteErr != nil {
+		result.Error = deleteErr.Error()
+	}
+
+	if !policy.DryRun && len(pruned) > 0 {
+		if err := p.removeTags(ctx, stream, pruned); err != nil {
+			return fmt.Errorf("failed to prune tags: %w", err)
+		}
+
+		p.recorder.Eventf(stream, corev1.EventTypeNormal, EventReasonTagsPruned,
+			"Pruned %d tags, %d images deleted from the registry", len(pruned), len(result.DeletedImages))
+	}
+
+	statusPatch := client.MergeFrom(stream.DeepCopy())
+	stream.Status.Retention = result
+
+	if err := p.client.Status().Patch(ctx, stream, statusPatch); err != nil {
+		return fmt.Errorf("failed to update retention status: %w", err)
+	}
+
+	return deleteErr
+}
+
+// removeTags removes the pruned tags from spec.tags, keeping its order. CI appends tags while
+// the policy is applied, so the tags are patched with an optimistic lock and removed again
+// from the current CodebaseImageStream on conflict.
+func (p *Pruner) removeTags(
+	ctx context.Context,
+	stream *codebaseApi.CodebaseImageStream,
+	pruned []codebaseApi.Tag,
+) error {
+	refetch := false
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error { //nolint:wrapcheck // the caller wraps the error
+		if refetch {
+			if err := p.client.Get(ctx, client.ObjectKeyFromObject(stream), stream); err != nil {
+				return err //nolint:wrapcheck // RetryOnConflict needs the original error
+			}
+		}
+
+		refetch = true
+
+		patch := client.MergeFromWithOptions(stream.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+		stream.Spec.Tags = slices.DeleteFunc(stream.Spec.Tags, func(tag codebaseApi.Tag) bool {
+			return slices.ContainsFunc(pruned, func(p codebaseApi.Tag) bool { return p.Name == tag.Name })
+		})
+
+		return p.client.Patch(ctx, stream, patch) //nolint:wrapcheck // RetryOnConflict needs the original error
+	})
+}
+
+// partition splits the tags into the ones the retention policy keeps and the ones it prunes.
+// The latest tag, as spec.latestTag selects it, the deployed tags and the tags with a creation
+// time that cannot be parsed are kept.
+func (p *Pruner) partition(
+	ctx context.Context,
+	spec *codebaseApi.CodebaseImageStreamSpec,
+	deployed []string,
+) (kept, pruned []codebaseApi.Tag, err error) {
+	tags, policy := spec.Tags, spec.Retention
+
+	if policy.KeepLast == nil && policy.KeepNewerThan == nil {
+		return tags, nil, nil
+	}
+
+	latest, err := codebaseimagestream.GetLatestTag(tags, spec.LatestTag, ctrl.LoggerFrom(ctx))
+	if err != nil && !errors.Is(err, codebaseimagestream.ErrLatestTagNotFound) {
+		return nil, nil, fmt.Errorf("failed to get latest tag: %w", err)
+	}
+
+	type datedTag struct {
+		tag     codebaseApi.Tag
+		created time.Time
+	}
+
+	dated := make([]datedTag, 0, len(tags))
+
+	for _, tag := range tags {
+		created, err := time.Parse(time.RFC3339, tag.Created)
+		if err != nil || tag.Name == latest.Name || slices.Contains(deployed, tag.Name) {
+			kept = append(kept, tag)
+			continue
+		}
+
+		dated = append(dated, datedTag{tag: tag, created: created})
+	}
+
+	// Newest first, so that the first keepLast tags are kept. The latest tag counts too.
+	slices.SortStableFunc(dated, func(a, b datedTag) int {
+		return b.created.Compare(a.created)
+	})
+
+	keepLast := len(dated)
+	if policy.KeepLast != nil {
+		keepLast = max(*policy.KeepLast-1, 0)
+	}
+
+	for i, d := range dated {
+		switch {
+		case policy.KeepLast != nil && i < keepLast,
+			policy.KeepNewerThan != nil && p.now().Sub(d.created) < policy.KeepNewerThan.Duration:
+			kept = append(kept, d.tag)
+		default:
+			pruned = append(pruned, d.tag)
+		}
+	}
+
+	return kept, pruned, nil
+}
+
+// deleteImages deletes the images of the pruned tags from the registry. An image is deleted
+// only when no kept tag, and no tag of another CodebaseImageStream of the image, has its
+// digest, since deleting it removes every tag that points to it. Pruned tags without a
+// digest are pruned from spec.tags only.
+func (p *Pruner) deleteImages(
+	ctx context.Context,
+	stream *codebaseApi.CodebaseImageStream,
+	kept, pruned []codebaseApi.Tag,
+	inUse map[string]bool,
+) (deleted, failed []codebaseApi.Tag, digests []string, err error) {
+	host, repository := registry.ParseImageName(stream.Spec.ImageName)
+
+	auth, err := registry.CredentialsFor(ctx, p.client, p.namespace, host)
+	if err != nil {
+		return nil, pruned, nil, err //nolint:wrapcheck // CredentialsFor errors are descriptive
+	}
+
+	reg := p.newRegistry(host, auth)
+	failedDigests := make(map[string]bool)
+
+	var errs []error
+
+	for _, tag := range pruned {
+		shared := inUse[tag.Digest] ||
+			slices.ContainsFunc(kept, func(k codebaseApi.Tag) bool { return k.Digest == tag.Digest })
+
+		switch {
+		case tag.Digest == "" || shared || slices.Contains(digests, tag.Digest):
+			deleted = append(deleted, tag)
+		case failedDigests[tag.Digest]:
+			failed = append(failed, tag)
+		default:
+			deleteErr := reg.DeleteImage(ctx, repository, tag.Digest)
+
+			// An image that is already gone needs no deletion.
+			if deleteErr != nil && !errors.Is(deleteErr, registry.ErrNotFound) {
+				failedDigests[tag.Digest] = true
+				failed = append(failed, tag)
+				errs = append(errs, deleteErr)
+
+				continue
+			}
+
+			deleted = append(deleted, tag)
+			digests = append(digests, tag.Digest)
+		}
+	}
+
+	if len(errs) > 0 {
+		err = fmt.Errorf("failed to delete %d images from the registry: %w", len(errs), errs[0])
+	}
+
+	return deleted, failed, digests, err
+}
+
+// deployedTags returns the tags of the CDStageDeploys by codebase.
+func (p *Pruner) deployedTags(ctx context.Context) (map[string][]string, error) {
+	list := &codebaseApi.CDStageDeployList{}
+	if err := p.client.List(ctx, list, client.InNamespace(p.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list CDStageDeploys: %w", err)
+	}
+
+	deployed := make(map[string][]string)
+
+	for i := range list.Items {
+		spec := &list.Items[i].Spec
+
+		for _, tag := range append([]codebaseApi.CodebaseTag{spec.Tag}, spec.Tags...) {
+			if tag.Codebase != "" && tag.Tag != "" {
+				deployed[tag.Codebase] = append(deployed[tag.Codebase], tag.Tag)
+			}
+		}
+	}
+
+	return deployed, nil
+}
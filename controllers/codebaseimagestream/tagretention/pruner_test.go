@@ -0,0 +1,297 @@
+package tagretention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/registry"
+)
+
+const testNamespace = "default"
+
+type fakeRegistry struct {
+	registry.Registry
+
+	failing map[string]bool
+	deleted []string
+}
+
+func (f *fakeRegistry) DeleteImage(_ context.Context, _, digest string) error {
+	if f.failing[digest] {
+		return errors.New("registry returned 405 Method Not Allowed")
+	}
+
+	f.deleted = append(f.deleted, digest)
+
+	return nil
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func newStream(name string, retention *codebaseApi.TagRetention) *codebaseApi.CodebaseImageStream {
+	return &codebaseApi.CodebaseImageStream{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: codebaseApi.CodebaseImageStreamSpec{
+			Codebase:  "app",
+			ImageName: "harbor.example.com/team/app",
+			Retention: retention,
+			Tags: []codebaseApi.Tag{
+				{Name: "main-1", Created: "2026-10-01T10:00:00Z", Digest: "sha256:one"},
+				{Name: "main-2", Created: "2026-10-02T10:00:00Z", Digest: "sha256:two"},
+				{Name: "main-3", Created: "2026-10-03T10:00:00Z", Digest: "sha256:three"},
+				{Name: "main-4", Created: "2026-10-04T10:00:00Z", Digest: "sha256:six"},
+				{Name: "main-5", Created: "2026-10-05T10:00:00Z"},
+				{Name: "main-6", Created: "2026-10-06T10:00:00Z", Digest: "sha256:six"},
+				{Name: "main-7", Created: "2026-10-07T10:00:00Z", Digest: "sha256:seven"},
+			},
+		},
+	}
+}
+
+func newStageDeploy() *codebaseApi.CDStageDeploy {
+	return &codebaseApi.CDStageDeploy{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipeline-prod", Namespace: testNamespace},
+		Spec: codebaseApi.CDStageDeploySpec{
+			Pipeline: "pipeline",
+			Stage:    "prod",
+			Tag:      codebaseApi.CodebaseTag{Codebase: "app", Tag: "main-1"},
+			Tags:     []codebaseApi.CodebaseTag{{Codebase: "other", Tag: "main-2"}},
+		},
+	}
+}
+
+func newPruner(
+	t *testing.T,
+	reg *fakeRegistry,
+	objects ...client.Object,
+) (*Pruner, client.Client, *record.FakeRecorder) {
+	t.Helper()
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(objects...).
+		WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	p := NewPruner(k8sClient, testNamespace, time.Hour, func(string, registry.Auth) registry.Registry {
+		return reg
+	}, recorder)
+	p.now = func() time.Time { return time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC) }
+
+	return p, k8sClient, recorder
+}
+
+func tagNames(tags []codebaseApi.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
+
+func TestPruner_sweep(t *testing.T) {
+	t.Parallel()
+
+	keepLast := 2
+	stream := newStream("app-main", &codebaseApi.TagRetention{KeepLast: &keepLast, DeleteImages: true})
+	other := newStream("other", nil)
+	other.Spec.ImageName = "harbor.example.com/team/other"
+	reg := &fakeRegistry{failing: map[string]bool{"sha256:three": true}}
+
+	p, k8sClient, recorder := newPruner(t, reg, stream, other, newStageDeploy())
+
+	p.sweep(context.Background())
+
+	got := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+
+	// main-1 is deployed, main-3 failed to be deleted, main-6 and main-7 are the last two.
+	assert.Equal(t, []string{"main-1", "main-3", "main-6", "main-7"}, tagNames(got.Spec.Tags))
+	// main-4 shares its image with main-6, main-5 has no digest.
+	assert.Equal(t, []string{"sha256:two"}, reg.deleted)
+
+	require.NotNil(t, got.Status.Retention)
+	assert.False(t, got.Status.Retention.DryRun)
+	assert.Equal(t, 3, got.Status.Retention.PrunedCount)
+	assert.ElementsMatch(t, []string{"main-2", "main-4", "main-5"}, got.Status.Retention.PrunedTags)
+	assert.Equal(t, []string{"sha256:two"}, got.Status.Retention.DeletedImages)
+	assert.Contains(t, got.Status.Retention.Error, "failed to delete 1 images from the registry")
+
+	require.Len(t, recorder.Events, 2)
+	assert.Contains(t, <-recorder.Events, "Pruned 3 tags, 1 images deleted from the registry")
+	assert.Contains(t, <-recorder.Events, EventReasonTagPruneFailed)
+
+	untouched := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(other), untouched))
+	assert.Len(t, untouched.Spec.Tags, 7)
+	assert.Nil(t, untouched.Status.Retention)
+}
+
+func TestPruner_sweepSharedImage(t *testing.T) {
+	t.Parallel()
+
+	keepLast := 2
+	stream := newStream("app-main", &codebaseApi.TagRetention{KeepLast: &keepLast, DeleteImages: true})
+
+	// The feature branch of the codebase has the image of main-3 under another tag.
+	feature := newStream("app-feature", nil)
+	feature.Spec.Tags = []codebaseApi.Tag{{Name: "feature-1", Created: "2026-10-03T11:00:00Z", Digest: "sha256:three"}}
+
+	// Another codebase of the same image has main-2 deployed.
+	other := newStream("other-main", nil)
+	other.Spec.Codebase = "other"
+	other.Spec.Tags = nil
+
+	reg := &fakeRegistry{}
+
+	p, k8sClient, _ := newPruner(t, reg, stream, feature, other, newStageDeploy())
+
+	p.sweep(context.Background())
+
+	got := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+
+	assert.Equal(t, []string{"main-1", "main-2", "main-6", "main-7"}, tagNames(got.Spec.Tags))
+	assert.ElementsMatch(t, []string{"main-3", "main-4", "main-5"}, got.Status.Retention.PrunedTags)
+	assert.Empty(t, reg.deleted, "images of the tags of other image streams must be kept")
+}
+
+func TestPruner_sweepDeployedWithoutCDStageDeploy(t *testing.T) {
+	t.Parallel()
+
+	keepLast := 2
+	stream := newStream("app-main", &codebaseApi.TagRetention{KeepLast: &keepLast, DeleteImages: true})
+	stream.Status.DeployedTags = []codebaseApi.DeployedTag{{Stage: "pipeline-prod", Tag: "main-2"}}
+
+	// The feature branch of the codebase recorded main-3 as deployed to another stage.
+	feature := newStream("app-feature", nil)
+	feature.Spec.Tags = nil
+	feature.Status.DeployedTags = []codebaseApi.DeployedTag{{Stage: "pipeline-qa", Tag: "main-3"}}
+
+	reg := &fakeRegistry{}
+
+	// The CDStageDeploys of the deployments have been deleted after they were processed.
+	p, k8sClient, _ := newPruner(t, reg, stream, feature)
+
+	p.sweep(context.Background())
+
+	got := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+
+	assert.Equal(t, []string{"main-2", "main-3", "main-6", "main-7"}, tagNames(got.Spec.Tags))
+	assert.ElementsMatch(t, []string{"main-1", "main-4", "main-5"}, got.Status.Retention.PrunedTags)
+	assert.Equal(t, []string{"sha256:one"}, reg.deleted, "images of deployed tags must be kept")
+}
+
+func TestPruner_pruneConcurrentUpdate(t *testing.T) {
+	t.Parallel()
+
+	keepLast := 2
+	stream := newStream("app-main", &codebaseApi.TagRetention{KeepLast: &keepLast})
+	patches := 0
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(newScheme(t)).
+		WithObjects(stream).
+		WithStatusSubresource(&codebaseApi.CodebaseImageStream{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(
+				ctx context.Context,
+				c client.WithWatch,
+				obj client.Object,
+				patch client.Patch,
+				opts ...client.PatchOption,
+			) error {
+				patches++
+
+				if patches == 1 {
+					// CI appends a tag while the policy is applied.
+					current := &codebaseApi.CodebaseImageStream{}
+					require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), current))
+
+					current.Spec.Tags = append(current.Spec.Tags,
+						codebaseApi.Tag{Name: "main-8", Created: "2026-10-08T10:00:00Z"})
+					require.NoError(t, c.Update(ctx, current))
+				}
+
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	p := NewPruner(k8sClient, testNamespace, time.Hour, nil, record.NewFakeRecorder(10))
+	p.now = func() time.Time { return time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC) }
+
+	got := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+	require.NoError(t, p.prune(context.Background(), got, imageUsage{}))
+
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+	assert.Equal(t, []string{"main-6", "main-7", "main-8"}, tagNames(got.Spec.Tags),
+		"the tag appended concurrently must be kept")
+}
+
+func TestPruner_sweepDryRun(t *testing.T) {
+	t.Parallel()
+
+	stream := newStream("app-main", &codebaseApi.TagRetention{
+		KeepNewerThan: &metav1.Duration{Duration: 14 * 24 * time.Hour},
+		DryRun:        true,
+		DeleteImages:  true,
+	})
+	reg := &fakeRegistry{}
+
+	p, k8sClient, recorder := newPruner(t, reg, stream, newStageDeploy())
+
+	p.sweep(context.Background())
+
+	got := &codebaseApi.CodebaseImageStream{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(stream), got))
+
+	assert.Len(t, got.Spec.Tags, 7)
+	assert.Empty(t, reg.deleted)
+	assert.Empty(t, recorder.Events)
+
+	// Tags created before 2026-10-05T08:00:00Z are too old, except the deployed main-1.
+	require.NotNil(t, got.Status.Retention)
+	assert.True(t, got.Status.Retention.DryRun)
+	assert.Equal(t, 3, got.Status.Retention.PrunedCount)
+	assert.ElementsMatch(t, []string{"main-2", "main-3", "main-4"}, got.Status.Retention.PrunedTags)
+	assert.Empty(t, got.Status.Retention.DeletedImages)
+}
+
+func TestPruner_partitionWithoutLimits(t *testing.T) {
+	t.Parallel()
+
+	p, _, _ := newPruner(t, &fakeRegistry{})
+	stream := newStream("app-main", &codebaseApi.TagRetention{DryRun: true})
+
+	kept, pruned, err := p.partition(context.Background(), &stream.Spec, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, kept, 7)
+	assert.Empty(t, pruned)
+}
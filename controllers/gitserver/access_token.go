@@ -0,0 +1,300 @@
+package gitserver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+const (
+	// gitHubAppTokenRefreshMargin is how long before expiry a GitHub App installation token,
+	// which lives for an hour, is replaced, so that a token read from the secret stays valid
+	// for the operation that uses it.
+	gitHubAppTokenRefreshMargin = 15 * time.Minute
+
+	// gitHubAppTokenUser is the user name git authenticates with over HTTPS with an
+	// installation token.
+	gitHubAppTokenUser = "x-access-token"
+
+	minGitLabTokenLifetime = 24 * time.Hour
+
+	// EventReasonAccessTokenLost is the reason of the event raised when a rotated GitLab
+	// access token, whose predecessor is revoked, could not be stored.
+	EventReasonAccessTokenLost = "AccessTokenLost"
+)
+
+type gitHubAppTokenIssuer interface {
+	CreateInstallationToken(
+		ctx context.Context,
+		githubURL,
+		appID,
+		installationID string,
+		privateKey []byte,
+	) (*gitprovider.AccessToken, error)
+}
+
+type gitLabTokenRotator interface {
+	RotateToken(ctx context.Context, gitlabURL, token string, expiresAt time.Time) (*gitprovider.AccessToken, error)
+}
+
+// gitHubAppTokenIssuerFactory creates the gitHubAppTokenIssuer of a GitServer.
+type gitHubAppTokenIssuerFactory func(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+) (gitHubAppTokenIssuer, error)
+
+// gitLabTokenRotatorFactory creates the gitLabTokenRotator of a GitServer.
+type gitLabTokenRotatorFactory func(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+) (gitLabTokenRotator, error)
+
+// newGitHubAppTokenIssuer creates a gitHubAppTokenIssuerFactory whose issuers send requests
+// with the shared HTTP client of the GitServer, so that they use its proxy and TLS settings
+// and count against its throttling.
+func newGitHubAppTokenIssuer(httpClients *gitprovider.HTTPClientPool) gitHubAppTokenIssuerFactory {
+	return func(ctx context.Context, gitServer *codebaseApi.GitServer) (gitHubAppTokenIssuer, error) {
+		restyClient, err := httpClients.RestyClient(ctx, gitServer)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // RestyClient errors are descriptive
+		}
+
+		return gitprovider.NewGitHubAppTokenIssuer(restyClient), nil
+	}
+}
+
+// newGitLabTokenRotator creates a gitLabTokenRotatorFactory whose rotators send requests with
+// the proxy and TLS settings of the GitServer. Rotation revokes the current token, so the
+// requests are not sent with the shared client, which retries throttled requests.
+func newGitLabTokenRotator(httpClients *gitprovider.HTTPClientPool) gitLabTokenRotatorFactory {
+	return func(ctx context.Context, gitServer *codebaseApi.GitServer) (gitLabTokenRotator, error) {
+		httpClient, err := httpClients.NonRetryingClient(ctx, gitServer)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // NonRetryingClient errors are descriptive
+		}
+
+		return gitprovider.NewGitLabTokenRotator(resty.NewWithClient(httpClient)), nil
+	}
+}
+
+// refreshAccessToken keeps the token field of the GitServer secret valid when the operator
+// owns the token: it mints GitHub App installation tokens and rotates GitLab access tokens.
+// Everything that talks to the git provider reads the token from the secret, so it does
+// not need to know how the token was issued. It returns the time the token must be
+// refreshed at, or zero if the token is static.
+func (r *ReconcileGitServer) refreshAccessToken(ctx context.Context, gitServer *codebaseApi.GitServer) (time.Time, error) {
+	secret := &coreV1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: gitServer.Namespace,
+		Name:      gitServer.Spec.NameSshKeySecret,
+	}, secret); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, fmt.Errorf("failed to get secret %s: %w", gitServer.Spec.NameSshKeySecret, err)
+	}
+
+	switch {
+	case len(secret.Data[util.GitServerSecretGitHubAppPrivateKeyField]) > 0:
+		return r.refreshGitHubAppToken(ctx, gitServer, secret)
+	case gitServer.Annotations[codebaseApi.GitLabTokenRotationAnnotation] != "":
+		return r.rotateGitLabToken(ctx, gitServer, secret)
+	default:
+		return time.Time{}, nil
+	}
+}
+
+func (r *ReconcileGitServer) refreshGitHubAppToken(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	secret *coreV1.Secret,
+) (time.Time, error) {
+	if gitServer.Spec.GitProvider != codebaseApi.GitProviderGithub {
+		return time.Time{}, fmt.Errorf("GitHub App credentials are supported only by the %s git provider",
+			codebaseApi.GitProviderGithub)
+	}
+
+	if refreshAt, ok := tokenRefreshTime(secret, gitHubAppTokenRefreshMargin); ok && time.Now().Before(refreshAt) {
+		return refreshAt, nil
+	}
+
+	appID := string(secret.Data[util.GitServerSecretGitHubAppIDField])
+	installationID := string(secret.Data[util.GitServerSecretGitHubAppInstallationIDField])
+
+	if appID == "" || installationID == "" {
+		return time.Time{}, fmt.Errorf("secret %s must contain %s and %s fields for GitHub App authentication",
+			secret.Name, util.GitServerSecretGitHubAppIDField, util.GitServerSecretGitHubAppInstallationIDField)
+	}
+
+	issuer, err := r.newGitHubAppTokenIssuer(ctx, gitServer)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	token, err := issuer.CreateInstallationToken(
+		ctx,
+		gitprovider.GetGitProviderAPIURL(gitServer),
+		appID,
+		installationID,
+		secret.Data[util.GitServerSecretGitHubAppPrivateKeyField],
+	)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to mint GitHub App installation token: %w", err)
+	}
+
+	if err = r.storeAccessToken(ctx, secret, token, gitHubAppTokenUser); err != nil {
+		return time.Time{}, err
+	}
+
+	ctrl.LoggerFrom(ctx).Info("GitHub App installation token has been refreshed", "expiresAt", token.ExpiresAt)
+
+	return token.ExpiresAt.Add(-gitHubAppTokenRefreshMargin), nil
+}
+
+func (r *ReconcileGitServer) rotateGitLabToken(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	secret *coreV1.Secret,
+) (time.Time, error) {
+	if gitServer.Spec.GitProvider != codebaseApi.GitProviderGitlab {
+		return time.Time{}, fmt.Errorf("%s annotation is supported only by the %s git provider",
+			codebaseApi.GitLabTokenRotationAnnotation, codebaseApi.GitProviderGitlab)
+	}
+
+	lifetime, err := parseGitLabTokenLifetime(gitServer)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if refreshAt, ok := tokenRefreshTime(secret, lifetime/3); ok && time.Now().Before(refreshAt) {
+		return refreshAt, nil
+	}
+
+	// Rotation revokes the current token. If another controller owns the secret it would
+	// restore the revoked token, and the GitServer would lose access to the git provider.
+	if owner := metaV1.GetControllerOf(secret); owner != nil && owner.UID != gitServer.UID {
+		return time.Time{}, fmt.Errorf("secret %s is managed by %s %s, rotate the token at its source instead",
+			secret.Name, owner.Kind, owner.Name)
+	}
+
+	days := int(math.Ceil(lifetime.Hours() / 24))
+
+	rotator, err := r.newGitLabTokenRotator(ctx, gitServer)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	token, err := rotator.RotateToken(
+		ctx,
+		gitprovider.GetGitProviderAPIURL(gitServer),
+		string(secret.Data[util.GitServerSecretTokenField]),
+		time.Now().AddDate(0, 0, days),
+	)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to rotate GitLab access token: %w", err)
+	}
+
+	if err = r.storeAccessToken(ctx, secret, token, ""); err != nil {
+		// The previous token is already revoked, the new one exists only in this error.
+		ctrl.LoggerFrom(ctx).Error(err, "Rotated GitLab access token is lost, replace the token in the secret manually",
+			"secret", secret.Name)
+		r.recorder.Eventf(gitServer, coreV1.EventTypeWarning, EventReasonAccessTokenLost,
+			"Rotated GitLab access token could not be stored in secret %s, replace the token in the secret manually: %v",
+			secret.Name, err)
+
+		return time.Time{}, err
+	}
+
+	ctrl.LoggerFrom(ctx).Info("GitLab access token has been rotated", "expiresAt", token.ExpiresAt)
+
+	return token.ExpiresAt.Add(-lifetime / 3), nil
+}
+
+// storeAccessToken writes the token and its expiry to the secret. The user is set only if
+// the secret has none. A rotated token exists nowhere else, so failed writes are retried
+// with the secret read afresh.
+func (r *ReconcileGitServer) storeAccessToken(
+	ctx context.Context,
+	secret *coreV1.Secret,
+	token *gitprovider.AccessToken,
+	user string,
+) error {
+	refetch := false
+
+	err := retry.OnError(retry.DefaultBackoff, func(err error) bool { return !k8sErrors.IsNotFound(err) }, func() error {
+		if refetch {
+			if err := r.client.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+				return err //nolint:wrapcheck // wrapped below
+			}
+		}
+
+		refetch = true
+
+		patch := client.MergeFromWithOptions(secret.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+
+		secret.Data[util.GitServerSecretTokenField] = []byte(token.Token)
+
+		if user != "" && len(secret.Data[util.GitServerSecretUserNameField]) == 0 {
+			secret.Data[util.GitServerSecretUserNameField] = []byte(user)
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+
+		secret.Annotations[codebaseApi.TokenExpiresAtAnnotation] = token.ExpiresAt.UTC().Format(time.RFC3339)
+
+		return r.client.Patch(ctx, secret, patch) //nolint:wrapcheck // wrapped below
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store access token in secret %s: %w", secret.Name, err)
+	}
+
+	return nil
+}
+
+// tokenRefreshTime returns the time the token in the secret must be replaced at, if the
+// operator issued it.
+func tokenRefreshTime(secret *coreV1.Secret, margin time.Duration) (time.Time, bool) {
+	if len(secret.Data[util.GitServerSecretTokenField]) == 0 {
+		return time.Time{}, false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[codebaseApi.TokenExpiresAtAnnotation])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return expiresAt.Add(-margin), true
+}
+
+func parseGitLabTokenLifetime(gitServer *codebaseApi.GitServer) (time.Duration, error) {
+	value := gitServer.Annotations[codebaseApi.GitLabTokenRotationAnnotation]
+
+	lifetime, err := time.ParseDuration(value)
+	if err != nil || lifetime < minGitLabTokenLifetime {
+		return 0, fmt.Errorf("invalid %s annotation %q: must be a duration of at least %s",
+			codebaseApi.GitLabTokenRotationAnnotation, value, minGitLabTokenLifetime)
+	}
+
+	return lifetime, nil
+}
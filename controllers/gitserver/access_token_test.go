@@ -0,0 +1,325 @@
+package gitserver
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+func newTokenTestReconciler(t *testing.T, objects ...client.Object) (*ReconcileGitServer, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	httpClients := gitprovider.NewHTTPClientPool(k8sClient,
+		gitprovider.WithTransport(httpmock.DefaultTransport), gitprovider.WithRetries(0, 0))
+	t.Cleanup(httpmock.Reset)
+
+	return &ReconcileGitServer{
+		client:                  k8sClient,
+		recorder:                record.NewFakeRecorder(10),
+		newGitHubAppTokenIssuer: newGitHubAppTokenIssuer(httpClients),
+		newGitLabTokenRotator:   newGitLabTokenRotator(httpClients),
+	}, k8sClient
+}
+
+func getSecret(t *testing.T, k8sClient client.Client) *corev1.Secret {
+	t.Helper()
+
+	secret := &corev1.Secret{}
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "git-credentials"}, secret))
+
+	return secret
+}
+
+func TestReconcileGitServer_refreshAccessToken_GitHubApp(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metaV1.ObjectMeta{Name: "github", Namespace: "default"},
+		Spec: codebaseApi.GitServerSpec{
+			GitHost:          "github.com",
+			GitProvider:      codebaseApi.GitProviderGithub,
+			NameSshKeySecret: "git-credentials",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "git-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			util.GitServerSecretGitHubAppIDField:             []byte("123"),
+			util.GitServerSecretGitHubAppInstallationIDField: []byte("42"),
+			util.GitServerSecretGitHubAppPrivateKeyField: pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(key),
+			}),
+		},
+	}
+
+	r, k8sClient := newTokenTestReconciler(t, gitServer, secret)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	responder, err := httpmock.NewJsonResponder(http.StatusCreated, map[string]string{
+		"token":      "ghs_token",
+		"expires_at": expiresAt.Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodPost, "https://api.github.com/app/installations/42/access_tokens", responder)
+
+	refreshAt, err := r.refreshAccessToken(context.Background(), gitServer)
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt.Add(-gitHubAppTokenRefreshMargin), refreshAt.UTC())
+
+	stored := getSecret(t, k8sClient)
+	assert.Equal(t, "ghs_token", string(stored.Data[util.GitServerSecretTokenField]))
+	assert.Equal(t, gitHubAppTokenUser, string(stored.Data[util.GitServerSecretUserNameField]))
+	assert.Equal(t, expiresAt.Format(time.RFC3339), stored.Annotations[codebaseApi.TokenExpiresAtAnnotation])
+
+	_, err = r.refreshAccessToken(context.Background(), gitServer)
+	require.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "a valid token must not be minted again")
+}
+
+func TestReconcileGitServer_refreshAccessToken_GitLabRotation(t *testing.T) {
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "gitlab",
+			Namespace:   "default",
+			UID:         "gitlab-uid",
+			Annotations: map[string]string{codebaseApi.GitLabTokenRotationAnnotation: "720h"},
+		},
+		Spec: codebaseApi.GitServerSpec{
+			GitHost:          "gitlab.example.com",
+			GitProvider:      codebaseApi.GitProviderGitlab,
+			NameSshKeySecret: "git-credentials",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "git-credentials", Namespace: "default"},
+		Data:       map[string][]byte{util.GitServerSecretTokenField: []byte("glpat-old")},
+	}
+
+	r, k8sClient := newTokenTestReconciler(t, gitServer, secret)
+
+	expiresAt := time.Now().AddDate(0, 0, 30).UTC().Truncate(24 * time.Hour)
+	responder, err := httpmock.NewJsonResponder(http.StatusOK, map[string]string{
+		"token":      "glpat-new",
+		"expires_at": expiresAt.Format("2006-01-02"),
+	})
+	require.NoError(t, err)
+	httpmock.RegisterResponder(http.MethodPost, "https://gitlab.example.com/api/v4/personal_access_tokens/self/rotate", responder)
+
+	refreshAt, err := r.refreshAccessToken(context.Background(), gitServer)
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt.Add(-240*time.Hour), refreshAt)
+
+	stored := getSecret(t, k8sClient)
+	assert.Equal(t, "glpat-new", string(stored.Data[util.GitServerSecretTokenField]))
+	assert.Empty(t, stored.Data[util.GitServerSecretUserNameField])
+}
+
+func TestReconcileGitServer_refreshAccessToken_GitLabRotationIsNotRetried(t *testing.T) {
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "gitlab",
+			Namespace:   "default",
+			Annotations: map[string]string{codebaseApi.GitLabTokenRotationAnnotation: "720h"},
+		},
+		Spec: codebaseApi.GitServerSpec{
+			GitHost:          "gitlab.example.com",
+			GitProvider:      codebaseApi.GitProviderGitlab,
+			NameSshKeySecret: "git-credentials",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "git-credentials", Namespace: "default"},
+		Data:       map[string][]byte{util.GitServerSecretTokenField: []byte("glpat-old")},
+	}
+
+	r, k8sClient := newTokenTestReconciler(t, gitServer, secret)
+
+	// The shared client retries throttled requests, the rotation must be sent once all the same.
+	r.newGitLabTokenRotator = newGitLabTokenRotator(gitprovider.NewHTTPClientPool(k8sClient,
+		gitprovider.WithTransport(httpmock.DefaultTransport), gitprovider.WithRetries(3, time.Millisecond)))
+
+	rotateURL := "https://gitlab.example.com/api/v4/personal_access_tokens/self/rotate"
+	httpmock.RegisterResponder(http.MethodPost, rotateURL, httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	_, err := r.refreshAccessToken(context.Background(), gitServer)
+	require.Error(t, err)
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()[http.MethodPost+" "+rotateURL])
+	assert.Equal(t, "glpat-old", string(getSecret(t, k8sClient).Data[util.GitServerSecretTokenField]))
+}
+
+func TestReconcileGitServer_refreshAccessToken_GitLabRotationStoreFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		failures    int
+		wantErr     require.ErrorAssertionFunc
+		wantToken   string
+		wantWarning bool
+	}{
+		{
+			name:      "should store the token after a conflict",
+			failures:  1,
+			wantErr:   require.NoError,
+			wantToken: "glpat-new",
+		},
+		{
+			name:        "should warn that the token is lost",
+			failures:    math.MaxInt,
+			wantErr:     require.Error,
+			wantToken:   "glpat-old",
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitServer := &codebaseApi.GitServer{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:        "gitlab",
+					Namespace:   "default",
+					Annotations: map[string]string{codebaseApi.GitLabTokenRotationAnnotation: "720h"},
+				},
+				Spec: codebaseApi.GitServerSpec{
+					GitHost:          "gitlab.example.com",
+					GitProvider:      codebaseApi.GitProviderGitlab,
+					NameSshKeySecret: "git-credentials",
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metaV1.ObjectMeta{Name: "git-credentials", Namespace: "default"},
+				Data:       map[string][]byte{util.GitServerSecretTokenField: []byte("glpat-old")},
+			}
+
+			r, k8sClient := newTokenTestReconciler(t, gitServer, secret)
+
+			failures := 0
+			r.client = interceptor.NewClient(k8sClient.(client.WithWatch), interceptor.Funcs{
+				Patch: func(
+					ctx context.Context,
+					c client.WithWatch,
+					obj client.Object,
+					patch client.Patch,
+					opts ...client.PatchOption,
+				) error {
+					if failures < tt.failures {
+						failures++
+
+						return k8sErrors.NewConflict(corev1.Resource("secrets"), obj.GetName(), errors.New("modified"))
+					}
+
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			})
+
+			responder, err := httpmock.NewJsonResponder(http.StatusOK, map[string]string{
+				"token":      "glpat-new",
+				"expires_at": time.Now().AddDate(0, 0, 30).Format("2006-01-02"),
+			})
+			require.NoError(t, err)
+			httpmock.RegisterResponder(http.MethodPost,
+				"https://gitlab.example.com/api/v4/personal_access_tokens/self/rotate", responder)
+
+			_, err = r.refreshAccessToken(context.Background(), gitServer)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantToken, string(getSecret(t, k8sClient).Data[util.GitServerSecretTokenField]))
+
+			recorder := r.recorder.(*record.FakeRecorder)
+			if tt.wantWarning {
+				require.Len(t, recorder.Events, 1)
+				assert.Contains(t, <-recorder.Events, "Warning "+EventReasonAccessTokenLost)
+			} else {
+				assert.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestReconcileGitServer_refreshAccessToken_GitLabRotationRejectsManagedSecret(t *testing.T) {
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "gitlab",
+			Namespace:   "default",
+			UID:         "gitlab-uid",
+			Annotations: map[string]string{codebaseApi.GitLabTokenRotationAnnotation: "720h"},
+		},
+		Spec: codebaseApi.GitServerSpec{
+			GitHost:          "gitlab.example.com",
+			GitProvider:      codebaseApi.GitProviderGitlab,
+			NameSshKeySecret: "git-credentials",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "git-credentials",
+			Namespace: "default",
+			OwnerReferences: []metaV1.OwnerReference{{
+				APIVersion: "external-secrets.io/v1",
+				Kind:       "ExternalSecret",
+				Name:       "git-credentials",
+				UID:        "external-secret-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Data: map[string][]byte{util.GitServerSecretTokenField: []byte("glpat-old")},
+	}
+
+	r, k8sClient := newTokenTestReconciler(t, gitServer, secret)
+
+	_, err := r.refreshAccessToken(context.Background(), gitServer)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is managed by ExternalSecret git-credentials")
+	assert.Zero(t, httpmock.GetTotalCallCount(), "the token must not be revoked")
+	assert.Equal(t, "glpat-old", string(getSecret(t, k8sClient).Data[util.GitServerSecretTokenField]))
+}
+
+func TestReconcileGitServer_refreshAccessToken_StaticToken(t *testing.T) {
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metaV1.ObjectMeta{Name: "github", Namespace: "default"},
+		Spec: codebaseApi.GitServerSpec{
+			GitProvider:      codebaseApi.GitProviderGithub,
+			NameSshKeySecret: "git-credentials",
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "git-credentials", Namespace: "default"},
+		Data:       map[string][]byte{util.GitServerSecretTokenField: []byte("ghp_token")},
+	}
+
+	r, _ := newTokenTestReconciler(t, gitServer, secret)
+
+	refreshAt, err := r.refreshAccessToken(context.Background(), gitServer)
+	require.NoError(t, err)
+	assert.True(t, refreshAt.IsZero())
+}
@@ -0,0 +1,104 @@
+package gitserver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+const (
+	webhookCheckTimeout = 10 * time.Second
+
+	// webhookRecheckTime is how soon an unreachable webhook endpoint is checked again,
+	// e.g. while DNS or the certificate of a new host is being provisioned.
+	webhookRecheckTime = 5 * time.Minute
+
+	// WebhookSelfTestHeader marks the self-test delivery. The delivery carries no git provider
+	// event header and payload, so it does not match the triggers of the EventListener.
+	WebhookSelfTestHeader = "X-Edp-Webhook-Self-Test"
+)
+
+type webhookChecker func(ctx context.Context, gitServer *codebaseApi.GitServer, url string) error
+
+// newWebhookChecker creates a webhookChecker that sends a self-test delivery to the public
+// URL of the EventListener, through the same DNS name and ingress controller the git provider uses.
+func newWebhookChecker() webhookChecker {
+	return func(ctx context.Context, gitServer *codebaseApi.GitServer, url string) error {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+
+		if gitServer.Spec.SkipWebhookSSLVerification {
+			// The git provider skips the verification as well.
+			transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // set by the user
+		}
+
+		httpClient := &http.Client{
+			Transport: transport,
+			Timeout:   webhookCheckTimeout,
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader("{}"))
+		if err != nil {
+			return fmt.Errorf("failed to create self-test request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(WebhookSelfTestHeader, "true")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to deliver self-test webhook: %w", err)
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return fmt.Errorf("webhook endpoint responded to self-test delivery with status %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+// checkWebhook sends a self-test delivery to the webhook endpoint of the EventListener and records
+// the result in the WebhookReachable condition. It returns false if the endpoint is unreachable.
+// Endpoints set in spec.webhookUrl are not managed by the operator and are not checked.
+func (r *ReconcileGitServer) checkWebhook(ctx context.Context, gitServer *codebaseApi.GitServer) bool {
+	webhook := gitServer.Status.Webhook
+
+	if webhook == nil || webhook.Exposure == codebaseApi.WebhookExposureExternal {
+		meta.RemoveStatusCondition(&gitServer.Status.Conditions, codebaseApi.GitServerConditionWebhookReachable)
+
+		return true
+	}
+
+	if err := r.webhookChecker(ctx, gitServer, webhook.URL); err != nil {
+		ctrl.LoggerFrom(ctx).Info("Webhook endpoint is unreachable", "url", webhook.URL, "reason", err.Error())
+
+		meta.SetStatusCondition(&gitServer.Status.Conditions, metaV1.Condition{
+			Type:    codebaseApi.GitServerConditionWebhookReachable,
+			Status:  metaV1.ConditionFalse,
+			Reason:  codebaseApi.ReasonWebhookUnreachable,
+			Message: err.Error(),
+		})
+
+		return false
+	}
+
+	meta.SetStatusCondition(&gitServer.Status.Conditions, metaV1.Condition{
+		Type:    codebaseApi.GitServerConditionWebhookReachable,
+		Status:  metaV1.ConditionTrue,
+		Reason:  codebaseApi.ReasonWebhookDelivered,
+		Message: fmt.Sprintf("The EventListener accepted a self-test delivery to %s", webhook.URL),
+	})
+
+	return true
+}
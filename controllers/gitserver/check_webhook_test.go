@@ -0,0 +1,139 @@
+package gitserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestNewWebhookChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "accepted",
+			status:  http.StatusAccepted,
+			wantErr: require.NoError,
+		},
+		{
+			name:   "no route to the EventListener",
+			status: http.StatusServiceUnavailable,
+			wantErr: func(t require.TestingT, err error, _ ...interface{}) {
+				require.ErrorContains(t, err, "status 503")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "true", r.Header.Get(WebhookSelfTestHeader))
+
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			gitServer := &codebaseApi.GitServer{
+				Spec: codebaseApi.GitServerSpec{SkipWebhookSSLVerification: true},
+			}
+
+			tt.wantErr(t, newWebhookChecker()(context.Background(), gitServer, server.URL))
+		})
+	}
+}
+
+func TestReconcileGitServer_checkWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		webhook       *codebaseApi.GitServerWebhookStatus
+		checkErr      error
+		wantReachable bool
+		wantCondition *metaV1.Condition
+	}{
+		{
+			name: "endpoint accepts self-test delivery",
+			webhook: &codebaseApi.GitServerWebhookStatus{
+				URL:      "https://el.example.com",
+				Exposure: codebaseApi.EventListenerExposureIngress,
+			},
+			wantReachable: true,
+			wantCondition: &metaV1.Condition{
+				Status: metaV1.ConditionTrue,
+				Reason: codebaseApi.ReasonWebhookDelivered,
+			},
+		},
+		{
+			name: "endpoint is unreachable",
+			webhook: &codebaseApi.GitServerWebhookStatus{
+				URL:      "https://el.example.com",
+				Exposure: codebaseApi.EventListenerExposureIngress,
+			},
+			checkErr:      errors.New("webhook endpoint responded to self-test delivery with status 404"),
+			wantReachable: false,
+			wantCondition: &metaV1.Condition{
+				Status: metaV1.ConditionFalse,
+				Reason: codebaseApi.ReasonWebhookUnreachable,
+			},
+		},
+		{
+			name: "external endpoint is not checked",
+			webhook: &codebaseApi.GitServerWebhookStatus{
+				URL:      "https://hooks.example.com",
+				Exposure: codebaseApi.WebhookExposureExternal,
+			},
+			checkErr:      errors.New("must not be called"),
+			wantReachable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitServer := &codebaseApi.GitServer{
+				Status: codebaseApi.GitServerStatus{
+					Webhook: tt.webhook,
+					Conditions: []metaV1.Condition{{
+						Type:   codebaseApi.GitServerConditionWebhookReachable,
+						Status: metaV1.ConditionUnknown,
+						Reason: "Stale",
+					}},
+				},
+			}
+
+			r := &ReconcileGitServer{
+				webhookChecker: func(_ context.Context, _ *codebaseApi.GitServer, url string) error {
+					assert.Equal(t, tt.webhook.URL, url)
+
+					return tt.checkErr
+				},
+			}
+
+			got := r.checkWebhook(ctrl.LoggerInto(context.Background(), logr.Discard()), gitServer)
+			assert.Equal(t, tt.wantReachable, got)
+
+			condition := meta.FindStatusCondition(gitServer.Status.Conditions, codebaseApi.GitServerConditionWebhookReachable)
+			if tt.wantCondition == nil {
+				assert.Nil(t, condition)
+
+				return
+			}
+
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.wantCondition.Status, condition.Status)
+			assert.Equal(t, tt.wantCondition.Reason, condition.Reason)
+		})
+	}
+}
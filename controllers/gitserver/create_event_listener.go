@@ -7,8 +7,12 @@ import (
 	routeApi "github.com/openshift/api/route/v1"
 	"github.com/tektoncd/triggers/pkg/reconciler/eventlistener"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -23,6 +27,10 @@ import (
 
 const errGetDNSWildcard = "failed to get dnsWildcard: %w"
 
+// This port is hardcoded in Tekton Triggers.
+// https://github.com/tektoncd/triggers/blob/v0.31.0/pkg/reconciler/eventlistener/resources/service.go#L37
+const elServicePort = 8080
+
 type CreateEventListener struct {
 	k8sClient client.Client
 }
@@ -40,11 +48,20 @@ func (h *CreateEventListener) ServeRequest(ctx context.Context, gitServer *codeb
 
 	if gitServer.Spec.TektonDisabled {
 		log.Info("Skip creating EventListener because Tekton is disabled")
+
+		gitServer.Status.Webhook = nil
+
 		return nil
 	}
 
 	if gitServer.Spec.WebhookUrl != "" {
 		log.Info("Skip creating EventListener because webhook URL is set")
+
+		gitServer.Status.Webhook = &codebaseApi.GitServerWebhookStatus{
+			URL:      gitServer.Spec.WebhookUrl,
+			Exposure: codebaseApi.WebhookExposureExternal,
+		}
+
 		return nil
 	}
 
@@ -52,15 +69,85 @@ func (h *CreateEventListener) ServeRequest(ctx context.Context, gitServer *codeb
 		return err
 	}
 
+	exposure := EventListenerExposure(gitServer)
+
+	var (
+		host string
+		err  error
+	)
+
+	switch exposure {
+	case codebaseApi.EventListenerExposureRoute:
+		host, err = h.createRoute(ctx, gitServer)
+	case codebaseApi.EventListenerExposureHTTPRoute:
+		host, err = h.createHTTPRoute(ctx, gitServer)
+	default:
+		host, err = h.createIngress(ctx, gitServer)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err = h.deleteStaleExposures(ctx, gitServer, exposure); err != nil {
+		return err
+	}
+
+	if host == "" {
+		log.Info("Skip reporting webhook URL because the EventListener exposure has no host", "kind", exposure)
+
+		gitServer.Status.Webhook = nil
+
+		return nil
+	}
+
+	gitServer.Status.Webhook = &codebaseApi.GitServerWebhookStatus{
+		URL:      eventListenerURL(gitServer, host),
+		Exposure: exposure,
+	}
+
+	return nil
+}
+
+// EventListenerExposure returns the kind of resource that exposes the EventListener of the GitServer:
+// the one set in spec.eventListener.exposure or, if it is not set, the one the platform provides.
+func EventListenerExposure(gitServer *codebaseApi.GitServer) string {
+	if gitServer.Spec.EventListener != nil && gitServer.Spec.EventListener.Exposure != "" {
+		return gitServer.Spec.EventListener.Exposure
+	}
+
 	if platform.IsOpenshift() {
-		return h.createRoute(ctx, gitServer)
+		return codebaseApi.EventListenerExposureRoute
 	}
 
 	if platform.IsEnvoy() {
-		return h.createHTTPRoute(ctx, gitServer)
+		return codebaseApi.EventListenerExposureHTTPRoute
+	}
+
+	return codebaseApi.EventListenerExposureIngress
+}
+
+func eventListenerURL(gitServer *codebaseApi.GitServer, host string) string {
+	if eventListenerTLSDisabled(gitServer) {
+		return "http://" + host
+	}
+
+	return "https://" + host
+}
+
+func eventListenerTLSDisabled(gitServer *codebaseApi.GitServer) bool {
+	return gitServer.Spec.EventListener != nil &&
+		gitServer.Spec.EventListener.TLS != nil &&
+		gitServer.Spec.EventListener.TLS.Disabled
+}
+
+func eventListenerTLSSecretName(gitServer *codebaseApi.GitServer) string {
+	if gitServer.Spec.EventListener == nil || gitServer.Spec.EventListener.TLS == nil ||
+		gitServer.Spec.EventListener.TLS.Disabled {
+		return ""
 	}
 
-	return h.createIngress(ctx, gitServer)
+	return gitServer.Spec.EventListener.TLS.SecretName
 }
 
 func (h *CreateEventListener) createEventListener(ctx context.Context, gitServer *codebaseApi.GitServer) error {
@@ -200,7 +287,7 @@ func desiredEventListenerLabelSelector(gitServer *codebaseApi.GitServer) map[str
 	}
 }
 
-func (h *CreateEventListener) createIngress(ctx context.Context, gitServer *codebaseApi.GitServer) error {
+func (h *CreateEventListener) createIngress(ctx context.Context, gitServer *codebaseApi.GitServer) (string, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	log.Info("Creating Ingress for EventListener")
@@ -208,28 +295,28 @@ func (h *CreateEventListener) createIngress(ctx context.Context, gitServer *code
 	pathType := networkingv1.PathTypePrefix
 	name := GenerateIngressName(gitServer.Name)
 
+	existing := &networkingv1.Ingress{}
+
 	err := h.k8sClient.Get(ctx, client.ObjectKey{
 		Namespace: gitServer.Namespace,
 		Name:      name,
-	}, &networkingv1.Ingress{})
+	}, existing)
 	if err == nil {
 		log.Info("Ingress already exists", "Ingress", name)
 
-		return nil
+		return h.reconcileIngress(ctx, gitServer, existing)
 	}
 
 	if client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to get Ingress: %w", err)
+		return "", fmt.Errorf("failed to get Ingress: %w", err)
 	}
 
 	config, err := platform.GetKrciConfig(ctx, h.k8sClient, gitServer.Namespace)
 	if err != nil {
-		return fmt.Errorf(errGetDNSWildcard, err)
+		return "", fmt.Errorf(errGetDNSWildcard, err)
 	}
 
-	// This port is hardcoded in Tekton Triggers.
-	// https://github.com/tektoncd/triggers/blob/v0.31.0/pkg/reconciler/eventlistener/resources/service.go#L37
-	const elServicePort = 8080
+	host := generateEventListenerHost(gitServer, config.DnsWildcard)
 
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -240,9 +327,11 @@ func (h *CreateEventListener) createIngress(ctx context.Context, gitServer *code
 			},
 		},
 		Spec: networkingv1.IngressSpec{
+			IngressClassName: desiredIngressClassName(gitServer),
+			TLS:              desiredIngressTLS(gitServer, host),
 			Rules: []networkingv1.IngressRule{
 				{
-					Host: generateEventListenerHost(gitServer, config.DnsWildcard),
+					Host: host,
 					IngressRuleValue: networkingv1.IngressRuleValue{
 						HTTP: &networkingv1.HTTPIngressRuleValue{
 							Paths: []networkingv1.HTTPIngressPath{
@@ -267,54 +356,108 @@ func (h *CreateEventListener) createIngress(ctx context.Context, gitServer *code
 	}
 
 	if err = controllerutil.SetControllerReference(gitServer, ingress, h.k8sClient.Scheme()); err != nil {
-		return fmt.Errorf("failed to set controller reference for Ingress: %w", err)
+		return "", fmt.Errorf("failed to set controller reference for Ingress: %w", err)
 	}
 
 	if err = h.k8sClient.Create(ctx, ingress); err != nil {
-		return fmt.Errorf("failed to create Ingress: %w", err)
+		return "", fmt.Errorf("failed to create Ingress: %w", err)
 	}
 
 	log.Info("Ingress has been created", "Ingress", ingress.Name)
 
-	return nil
+	return host, nil
+}
+
+// reconcileIngress enforces the ingress class and TLS of an existing Ingress
+// if they are set in spec.eventListener, and returns the host of the Ingress, if any.
+func (h *CreateEventListener) reconcileIngress(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	ingress *networkingv1.Ingress,
+) (string, error) {
+	host := ""
+	if len(ingress.Spec.Rules) > 0 {
+		host = ingress.Spec.Rules[0].Host
+	}
+
+	if gitServer.Spec.EventListener == nil {
+		return host, nil
+	}
+
+	original := ingress.DeepCopy()
+
+	ingress.Spec.IngressClassName = desiredIngressClassName(gitServer)
+	ingress.Spec.TLS = desiredIngressTLS(gitServer, host)
+
+	if equality.Semantic.DeepEqual(original.Spec, ingress.Spec) {
+		return host, nil
+	}
+
+	if err := h.k8sClient.Patch(ctx, ingress, client.MergeFrom(original)); err != nil {
+		return "", fmt.Errorf("failed to patch Ingress: %w", err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Ingress has been reconciled", "Ingress", ingress.Name)
+
+	return host, nil
 }
 
-func (h *CreateEventListener) createHTTPRoute(ctx context.Context, gitServer *codebaseApi.GitServer) error {
+func desiredIngressClassName(gitServer *codebaseApi.GitServer) *string {
+	if gitServer.Spec.EventListener == nil || gitServer.Spec.EventListener.IngressClassName == "" {
+		return nil
+	}
+
+	return ptr.To(gitServer.Spec.EventListener.IngressClassName)
+}
+
+func desiredIngressTLS(gitServer *codebaseApi.GitServer, host string) []networkingv1.IngressTLS {
+	secretName := eventListenerTLSSecretName(gitServer)
+	if secretName == "" {
+		return nil
+	}
+
+	return []networkingv1.IngressTLS{
+		{
+			Hosts:      []string{host},
+			SecretName: secretName,
+		},
+	}
+}
+
+func (h *CreateEventListener) createHTTPRoute(ctx context.Context, gitServer *codebaseApi.GitServer) (string, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	log.Info("Creating HTTPRoute for EventListener")
 
-	gatewayName := platform.GatewayName()
-	gatewayNamespace := platform.GatewayNamespace()
-
-	if gatewayName == "" || gatewayNamespace == "" {
-		return fmt.Errorf("GATEWAY_NAME/GATEWAY_NAMESPACE must be set when INGRESS_CONTROLLER_TYPE=envoy")
+	parentRef, err := desiredHTTPRouteParentRef(gitServer)
+	if err != nil {
+		return "", err
 	}
 
 	name := GenerateIngressName(gitServer.Name)
 
-	err := h.k8sClient.Get(ctx, client.ObjectKey{
+	existing := &gatewayv1.HTTPRoute{}
+
+	err = h.k8sClient.Get(ctx, client.ObjectKey{
 		Namespace: gitServer.Namespace,
 		Name:      name,
-	}, &gatewayv1.HTTPRoute{})
+	}, existing)
 	if err == nil {
 		log.Info("HTTPRoute already exists", "HTTPRoute", name)
 
-		return nil
+		return h.reconcileHTTPRoute(ctx, gitServer, existing, parentRef)
 	}
 
 	if client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to get HTTPRoute: %w", err)
+		return "", fmt.Errorf("failed to get HTTPRoute: %w", err)
 	}
 
 	config, err := platform.GetKrciConfig(ctx, h.k8sClient, gitServer.Namespace)
 	if err != nil {
-		return fmt.Errorf(errGetDNSWildcard, err)
+		return "", fmt.Errorf(errGetDNSWildcard, err)
 	}
 
-	// This port is hardcoded in Tekton Triggers.
-	// https://github.com/tektoncd/triggers/blob/v0.31.0/pkg/reconciler/eventlistener/resources/service.go#L37
-	const elServicePort = 8080
+	host := generateEventListenerHost(gitServer, config.DnsWildcard)
 
 	httpRoute := &gatewayv1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
@@ -326,15 +469,10 @@ func (h *CreateEventListener) createHTTPRoute(ctx context.Context, gitServer *co
 		},
 		Spec: gatewayv1.HTTPRouteSpec{
 			CommonRouteSpec: gatewayv1.CommonRouteSpec{
-				ParentRefs: []gatewayv1.ParentReference{
-					{
-						Name:      gatewayv1.ObjectName(gatewayName),
-						Namespace: ptr.To(gatewayv1.Namespace(gatewayNamespace)),
-					},
-				},
+				ParentRefs: []gatewayv1.ParentReference{parentRef},
 			},
 			Hostnames: []gatewayv1.Hostname{
-				gatewayv1.Hostname(generateEventListenerHost(gitServer, config.DnsWildcard)),
+				gatewayv1.Hostname(host),
 			},
 			Rules: []gatewayv1.HTTPRouteRule{
 				{
@@ -362,57 +500,138 @@ func (h *CreateEventListener) createHTTPRoute(ctx context.Context, gitServer *co
 	}
 
 	if err = controllerutil.SetControllerReference(gitServer, httpRoute, h.k8sClient.Scheme()); err != nil {
-		return fmt.Errorf("failed to set controller reference for HTTPRoute: %w", err)
+		return "", fmt.Errorf("failed to set controller reference for HTTPRoute: %w", err)
 	}
 
 	if err = h.k8sClient.Create(ctx, httpRoute); err != nil {
-		return fmt.Errorf("failed to create HTTPRoute: %w", err)
+		return "", fmt.Errorf("failed to create HTTPRoute: %w", err)
 	}
 
 	log.Info("HTTPRoute has been created", "HTTPRoute", httpRoute.Name)
 
-	return nil
+	return host, nil
+}
+
+// reconcileHTTPRoute enforces the parent Gateway of an existing HTTPRoute
+// if it is set in spec.eventListener, and returns the hostname of the HTTPRoute, if any.
+func (h *CreateEventListener) reconcileHTTPRoute(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	httpRoute *gatewayv1.HTTPRoute,
+	parentRef gatewayv1.ParentReference,
+) (string, error) {
+	host := ""
+	if len(httpRoute.Spec.Hostnames) > 0 {
+		host = string(httpRoute.Spec.Hostnames[0])
+	}
+
+	if gitServer.Spec.EventListener == nil {
+		return host, nil
+	}
+
+	if len(httpRoute.Spec.ParentRefs) == 1 && sameParentRef(httpRoute.Spec.ParentRefs[0], parentRef) {
+		return host, nil
+	}
+
+	original := httpRoute.DeepCopy()
+
+	httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{parentRef}
+
+	if err := h.k8sClient.Patch(ctx, httpRoute, client.MergeFrom(original)); err != nil {
+		return "", fmt.Errorf("failed to patch HTTPRoute: %w", err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("HTTPRoute has been reconciled", "HTTPRoute", httpRoute.Name)
+
+	return host, nil
+}
+
+// desiredHTTPRouteParentRef returns the Gateway from spec.eventListener.gateway
+// or, if it is not set, the one the operator is configured with.
+func desiredHTTPRouteParentRef(gitServer *codebaseApi.GitServer) (gatewayv1.ParentReference, error) {
+	if gitServer.Spec.EventListener != nil && gitServer.Spec.EventListener.Gateway != nil {
+		gateway := gitServer.Spec.EventListener.Gateway
+
+		parentRef := gatewayv1.ParentReference{
+			Name:      gatewayv1.ObjectName(gateway.Name),
+			Namespace: ptr.To(gatewayv1.Namespace(gitServer.Namespace)),
+		}
+
+		if gateway.Namespace != "" {
+			parentRef.Namespace = ptr.To(gatewayv1.Namespace(gateway.Namespace))
+		}
+
+		if gateway.SectionName != "" {
+			parentRef.SectionName = ptr.To(gatewayv1.SectionName(gateway.SectionName))
+		}
+
+		return parentRef, nil
+	}
+
+	gatewayName := platform.GatewayName()
+	gatewayNamespace := platform.GatewayNamespace()
+
+	if gatewayName == "" || gatewayNamespace == "" {
+		return gatewayv1.ParentReference{}, fmt.Errorf(
+			"GATEWAY_NAME/GATEWAY_NAMESPACE must be set when INGRESS_CONTROLLER_TYPE=envoy " +
+				"or spec.eventListener.gateway must be set",
+		)
+	}
+
+	return gatewayv1.ParentReference{
+		Name:      gatewayv1.ObjectName(gatewayName),
+		Namespace: ptr.To(gatewayv1.Namespace(gatewayNamespace)),
+	}, nil
 }
 
-func (h *CreateEventListener) createRoute(ctx context.Context, gitServer *codebaseApi.GitServer) error {
+// sameParentRef compares the fields the operator sets, ignoring the group and kind
+// the API server defaults.
+func sameParentRef(a, b gatewayv1.ParentReference) bool {
+	return a.Name == b.Name &&
+		ptr.Deref(a.Namespace, "") == ptr.Deref(b.Namespace, "") &&
+		ptr.Deref(a.SectionName, "") == ptr.Deref(b.SectionName, "")
+}
+
+func (h *CreateEventListener) createRoute(ctx context.Context, gitServer *codebaseApi.GitServer) (string, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	log.Info("Creating Route for EventListener")
 
 	name := GenerateIngressName(gitServer.Name)
 
+	existing := &routeApi.Route{}
+
 	err := h.k8sClient.Get(ctx, client.ObjectKey{
 		Namespace: gitServer.Namespace,
 		Name:      name,
-	}, &routeApi.Route{})
+	}, existing)
 	if err == nil {
 		log.Info("Route already exists", "Route", name)
 
-		return nil
+		return h.reconcileRoute(ctx, gitServer, existing)
 	}
 
 	if client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to get Route: %w", err)
+		return "", fmt.Errorf("failed to get Route: %w", err)
 	}
 
 	config, err := platform.GetKrciConfig(ctx, h.k8sClient, gitServer.Namespace)
 	if err != nil {
-		return fmt.Errorf(errGetDNSWildcard, err)
+		return "", fmt.Errorf(errGetDNSWildcard, err)
 	}
 
 	const routeWeight = int32(100)
 
+	host := generateEventListenerHost(gitServer, config.DnsWildcard)
+
 	route := &routeApi.Route{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: gitServer.Namespace,
 		},
 		Spec: routeApi.RouteSpec{
-			Host: generateEventListenerHost(gitServer, config.DnsWildcard),
-			TLS: &routeApi.TLSConfig{
-				InsecureEdgeTerminationPolicy: routeApi.InsecureEdgeTerminationPolicyRedirect,
-				Termination:                   routeApi.TLSTerminationEdge,
-			},
+			Host: host,
+			TLS:  desiredRouteTLS(gitServer),
 			To: routeApi.RouteTargetReference{
 				Kind:   "Service",
 				Name:   generateServiceName(gitServer.Name),
@@ -426,15 +645,110 @@ func (h *CreateEventListener) createRoute(ctx context.Context, gitServer *codeba
 	}
 
 	if err = controllerutil.SetControllerReference(gitServer, route, h.k8sClient.Scheme()); err != nil {
-		return fmt.Errorf("failed to set controller reference for Route: %w", err)
+		return "", fmt.Errorf("failed to set controller reference for Route: %w", err)
 	}
 
 	if err = h.k8sClient.Create(ctx, route); err != nil {
-		return fmt.Errorf("failed to create Route: %w", err)
+		return "", fmt.Errorf("failed to create Route: %w", err)
 	}
 
 	log.Info("Route has been created", "Route", route.Name)
 
+	return host, nil
+}
+
+// reconcileRoute enforces the TLS of an existing Route if it is set in spec.eventListener,
+// and returns the host of the Route, if any.
+func (h *CreateEventListener) reconcileRoute(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	route *routeApi.Route,
+) (string, error) {
+	host := route.Spec.Host
+	if host == "" && len(route.Status.Ingress) > 0 {
+		host = route.Status.Ingress[0].Host
+	}
+
+	if gitServer.Spec.EventListener == nil {
+		return host, nil
+	}
+
+	original := route.DeepCopy()
+
+	route.Spec.TLS = desiredRouteTLS(gitServer)
+
+	if equality.Semantic.DeepEqual(original.Spec, route.Spec) {
+		return host, nil
+	}
+
+	if err := h.k8sClient.Patch(ctx, route, client.MergeFrom(original)); err != nil {
+		return "", fmt.Errorf("failed to patch Route: %w", err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Route has been reconciled", "Route", route.Name)
+
+	return host, nil
+}
+
+func desiredRouteTLS(gitServer *codebaseApi.GitServer) *routeApi.TLSConfig {
+	if eventListenerTLSDisabled(gitServer) {
+		return nil
+	}
+
+	tlsConfig := &routeApi.TLSConfig{
+		InsecureEdgeTerminationPolicy: routeApi.InsecureEdgeTerminationPolicyRedirect,
+		Termination:                   routeApi.TLSTerminationEdge,
+	}
+
+	if secretName := eventListenerTLSSecretName(gitServer); secretName != "" {
+		tlsConfig.ExternalCertificate = &routeApi.LocalObjectReference{Name: secretName}
+	}
+
+	return tlsConfig
+}
+
+// deleteStaleExposures deletes the resources of the other exposure kinds after
+// spec.eventListener.exposure has been changed, so that they do not claim the same host.
+// Kinds that are not installed in the cluster are skipped.
+func (h *CreateEventListener) deleteStaleExposures(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	exposure string,
+) error {
+	if gitServer.Spec.EventListener == nil || gitServer.Spec.EventListener.Exposure == "" {
+		return nil
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      GenerateIngressName(gitServer.Name),
+		Namespace: gitServer.Namespace,
+	}
+
+	exposures := map[string]client.Object{
+		codebaseApi.EventListenerExposureIngress:   &networkingv1.Ingress{ObjectMeta: objectMeta},
+		codebaseApi.EventListenerExposureHTTPRoute: &gatewayv1.HTTPRoute{ObjectMeta: objectMeta},
+		codebaseApi.EventListenerExposureRoute:     &routeApi.Route{ObjectMeta: objectMeta},
+	}
+
+	for kind, obj := range exposures {
+		if kind == exposure {
+			continue
+		}
+
+		err := h.k8sClient.Delete(ctx, obj)
+		if err == nil {
+			ctrl.LoggerFrom(ctx).Info("Stale EventListener exposure has been deleted", "kind", kind, "name", obj.GetName())
+
+			continue
+		}
+
+		if k8sErrors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			continue
+		}
+
+		return fmt.Errorf("failed to delete stale %s: %w", kind, err)
+	}
+
 	return nil
 }
 
@@ -715,3 +715,214 @@ func TestCreateEventListener_ServeRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateEventListener_ServeRequest_Exposure(t *testing.T) {
+	scheme := runtime.NewScheme()
+
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+	require.NoError(t, routeApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	krciConfig := &corev1.ConfigMap{
+		ObjectMeta: controllerruntime.ObjectMeta{
+			Namespace: "default",
+			Name:      platform.KrciConfigMap,
+		},
+		Data: map[string]string{
+			"dns_wildcard": "example.com",
+		},
+	}
+
+	newGitServer := func(el *codebaseApi.GitServerEventListener) *codebaseApi.GitServer {
+		return &codebaseApi.GitServer{
+			ObjectMeta: controllerruntime.ObjectMeta{
+				Name:      "test-git-server",
+				Namespace: "default",
+			},
+			Spec: codebaseApi.GitServerSpec{
+				EventListener: el,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		gitServer *codebaseApi.GitServer
+		objects   []client.Object
+		prepare   func(t *testing.T)
+		want      func(t *testing.T, k8sClient client.Client, gitServer *codebaseApi.GitServer)
+	}{
+		{
+			name:      "webhook URL is set",
+			gitServer: &codebaseApi.GitServer{Spec: codebaseApi.GitServerSpec{WebhookUrl: "https://hooks.example.com"}},
+			want: func(t *testing.T, _ client.Client, gitServer *codebaseApi.GitServer) {
+				require.Equal(t, &codebaseApi.GitServerWebhookStatus{
+					URL:      "https://hooks.example.com",
+					Exposure: codebaseApi.WebhookExposureExternal,
+				}, gitServer.Status.Webhook)
+			},
+		},
+		{
+			name: "ingress with class and TLS secret",
+			gitServer: newGitServer(&codebaseApi.GitServerEventListener{
+				IngressClassName: "internal",
+				TLS:              &codebaseApi.GitServerEventListenerTLS{SecretName: "el-tls"},
+			}),
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.K8S)
+			},
+			want: func(t *testing.T, k8sClient client.Client, gitServer *codebaseApi.GitServer) {
+				i := &networkingv1.Ingress{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      GenerateIngressName("test-git-server"),
+				}, i))
+
+				require.Equal(t, "internal", *i.Spec.IngressClassName)
+				require.Equal(t, []networkingv1.IngressTLS{{
+					Hosts:      []string{"el-test-git-server-default.example.com"},
+					SecretName: "el-tls",
+				}}, i.Spec.TLS)
+
+				require.Equal(t, &codebaseApi.GitServerWebhookStatus{
+					URL:      "https://el-test-git-server-default.example.com",
+					Exposure: codebaseApi.EventListenerExposureIngress,
+				}, gitServer.Status.Webhook)
+			},
+		},
+		{
+			name: "existing ingress gets ingress class",
+			gitServer: newGitServer(&codebaseApi.GitServerEventListener{
+				IngressClassName: "internal",
+			}),
+			objects: []client.Object{
+				&networkingv1.Ingress{
+					ObjectMeta: controllerruntime.ObjectMeta{
+						Namespace: "default",
+						Name:      GenerateIngressName("test-git-server"),
+					},
+					Spec: networkingv1.IngressSpec{
+						Rules: []networkingv1.IngressRule{{Host: "custom.example.com"}},
+					},
+				},
+			},
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.K8S)
+			},
+			want: func(t *testing.T, k8sClient client.Client, gitServer *codebaseApi.GitServer) {
+				i := &networkingv1.Ingress{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      GenerateIngressName("test-git-server"),
+				}, i))
+
+				require.Equal(t, "internal", *i.Spec.IngressClassName)
+				require.Equal(t, "https://custom.example.com", gitServer.Status.Webhook.URL)
+			},
+		},
+		{
+			name: "http route with gateway from spec replaces stale ingress",
+			gitServer: newGitServer(&codebaseApi.GitServerEventListener{
+				Exposure: codebaseApi.EventListenerExposureHTTPRoute,
+				Gateway: &codebaseApi.GitServerGatewayRef{
+					Name:        "edge",
+					SectionName: "https",
+				},
+			}),
+			objects: []client.Object{
+				&networkingv1.Ingress{
+					ObjectMeta: controllerruntime.ObjectMeta{
+						Namespace: "default",
+						Name:      GenerateIngressName("test-git-server"),
+					},
+				},
+			},
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.K8S)
+			},
+			want: func(t *testing.T, k8sClient client.Client, gitServer *codebaseApi.GitServer) {
+				hr := &gatewayv1.HTTPRoute{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      GenerateIngressName("test-git-server"),
+				}, hr))
+
+				require.Len(t, hr.Spec.ParentRefs, 1)
+				require.Equal(t, gatewayv1.ObjectName("edge"), hr.Spec.ParentRefs[0].Name)
+				require.Equal(t, gatewayv1.Namespace("default"), *hr.Spec.ParentRefs[0].Namespace)
+				require.Equal(t, gatewayv1.SectionName("https"), *hr.Spec.ParentRefs[0].SectionName)
+
+				err := k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      GenerateIngressName("test-git-server"),
+				}, &networkingv1.Ingress{})
+				require.True(t, k8sErrors.IsNotFound(err))
+
+				require.Equal(t, codebaseApi.EventListenerExposureHTTPRoute, gitServer.Status.Webhook.Exposure)
+			},
+		},
+		{
+			name: "route without TLS",
+			gitServer: newGitServer(&codebaseApi.GitServerEventListener{
+				TLS: &codebaseApi.GitServerEventListenerTLS{Disabled: true},
+			}),
+			prepare: func(t *testing.T) {
+				t.Setenv(platform.TypeEnv, platform.Openshift)
+			},
+			want: func(t *testing.T, k8sClient client.Client, gitServer *codebaseApi.GitServer) {
+				r := &routeApi.Route{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      GenerateIngressName("test-git-server"),
+				}, r))
+
+				require.Nil(t, r.Spec.TLS)
+				require.Equal(t, &codebaseApi.GitServerWebhookStatus{
+					URL:      "http://el-test-git-server-default.example.com",
+					Exposure: codebaseApi.EventListenerExposureRoute,
+				}, gitServer.Status.Webhook)
+			},
+		},
+		{
+			name: "route with external certificate",
+			gitServer: newGitServer(&codebaseApi.GitServerEventListener{
+				Exposure: codebaseApi.EventListenerExposureRoute,
+				TLS:      &codebaseApi.GitServerEventListenerTLS{SecretName: "el-tls"},
+			}),
+			want: func(t *testing.T, k8sClient client.Client, gitServer *codebaseApi.GitServer) {
+				r := &routeApi.Route{}
+				require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: "default",
+					Name:      GenerateIngressName("test-git-server"),
+				}, r))
+
+				require.NotNil(t, r.Spec.TLS)
+				require.Equal(t, routeApi.TLSTerminationEdge, r.Spec.TLS.Termination)
+				require.Equal(t, &routeApi.LocalObjectReference{Name: "el-tls"}, r.Spec.TLS.ExternalCertificate)
+				require.Equal(t, "https://el-test-git-server-default.example.com", gitServer.Status.Webhook.URL)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.prepare != nil {
+				tt.prepare(t)
+			}
+
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append([]client.Object{krciConfig.DeepCopy()}, tt.objects...)...).
+				Build()
+
+			require.NoError(t, NewCreateEventListener(k8sClient).ServeRequest(
+				controllerruntime.LoggerInto(context.Background(), logr.Discard()),
+				tt.gitServer,
+			))
+
+			tt.want(t, k8sClient, tt.gitServer)
+		})
+	}
+}
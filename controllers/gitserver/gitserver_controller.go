@@ -3,12 +3,15 @@ package gitserver
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 	"github.com/epam/edp-codebase-operator/v2/pkg/model"
 	codebasepredicate "github.com/epam/edp-codebase-operator/v2/pkg/predicate"
 )
@@ -25,14 +29,34 @@ const (
 	successRequeueTime = time.Minute * 30
 )
 
-func NewReconcileGitServer(c client.Client) *ReconcileGitServer {
+func NewReconcileGitServer(
+	c client.Client,
+	httpClients *gitprovider.HTTPClientPool,
+	recorder record.EventRecorder,
+) *ReconcileGitServer {
 	return &ReconcileGitServer{
-		client: c,
+		client:                  c,
+		recorder:                recorder,
+		httpClients:             httpClients,
+		newGitHubAppTokenIssuer: newGitHubAppTokenIssuer(httpClients),
+		newGitLabTokenRotator:   newGitLabTokenRotator(httpClients),
+		apiProber:               newAPIProber(httpClients),
+		webhookChecker:          newWebhookChecker(),
+		knownHostsSecret:        os.Getenv(knownHostsSecretEnv),
 	}
 }
 
 type ReconcileGitServer struct {
-	client client.Client
+	client                  client.Client
+	recorder                record.EventRecorder
+	httpClients             *gitprovider.HTTPClientPool
+	newGitHubAppTokenIssuer gitHubAppTokenIssuerFactory
+	newGitLabTokenRotator   gitLabTokenRotatorFactory
+	apiProber               apiProber
+	webhookChecker          webhookChecker
+
+	// knownHostsSecret is the name of the Secret approved SSH host keys are stored in.
+	knownHostsSecret string
 }
 
 func (r *ReconcileGitServer) SetupWithManager(mgr ctrl.Manager) error {
@@ -51,10 +75,10 @@ func (r *ReconcileGitServer) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gitservers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gitservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=v2.edp.epam.com,namespace=placeholder,resources=gitservers/finalizers,verbs=update
-// +kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;update;patch
-// +kubebuilder:rbac:groups="networking.k8s.io",namespace=placeholder,resources=ingresses,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups="gateway.networking.k8s.io",namespace=placeholder,resources=httproutes,verbs=get;list;watch;create
-// +kubebuilder:rbac:groups="route.openshift.io",namespace=placeholder,resources=routes,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="networking.k8s.io",namespace=placeholder,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",namespace=placeholder,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="route.openshift.io",namespace=placeholder,resources=routes,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reads that state of the cluster for a GitServer object and makes changes based on the state.
 func (r *ReconcileGitServer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -64,13 +88,16 @@ func (r *ReconcileGitServer) Reconcile(ctx context.Context, request reconcile.Re
 	instance := &codebaseApi.GitServer{}
 	if err := r.client.Get(ctx, request.NamespacedName, instance); err != nil {
 		if k8sErrors.IsNotFound(err) {
+			// The GitServer is deleted, its HTTP clients are no longer needed.
+			r.httpClients.Evict(request.Namespace, request.Name)
+
 			return reconcile.Result{}, nil
 		}
 
 		return reconcile.Result{}, fmt.Errorf("failed to fetch resource %q: %w", request.NamespacedName, err)
 	}
 
-	oldStatus := instance.Status
+	oldStatus := *instance.Status.DeepCopy()
 	gitServer := model.ConvertToGitServer(instance)
 
 	if err := r.ensureSecretOwnership(ctx, instance); err != nil {
@@ -86,7 +113,36 @@ func (r *ReconcileGitServer) Reconcile(ctx context.Context, request reconcile.Re
 		return reconcile.Result{RequeueAfter: defaultRequeueTime}, nil
 	}
 
+	tokenRefreshAt, err := r.refreshAccessToken(ctx, instance)
+	if err != nil {
+		instance.Status.SetFailed(err.Error())
+		instance.Status.Connected = false
+
+		if statusErr := r.updateGitServerStatus(ctx, instance, oldStatus); statusErr != nil {
+			return reconcile.Result{}, statusErr
+		}
+
+		log.Error(err, "Failed to refresh Git Server access token")
+
+		return reconcile.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+
+	if err := r.approveHostKey(ctx, instance); err != nil {
+		instance.Status.SetFailed(err.Error())
+		instance.Status.Connected = false
+
+		if statusErr := r.updateGitServerStatus(ctx, instance, oldStatus); statusErr != nil {
+			return reconcile.Result{}, statusErr
+		}
+
+		log.Error(err, "Failed to approve SSH host key")
+
+		return reconcile.Result{RequeueAfter: defaultRequeueTime}, nil
+	}
+
 	if err := r.checkConnectionToGitServer(ctx, gitServer); err != nil {
+		err = r.handleUnknownHostKey(ctx, instance, err)
+
 		instance.Status.SetFailed(err.Error())
 		instance.Status.Connected = false
 
@@ -100,6 +156,11 @@ func (r *ReconcileGitServer) Reconcile(ctx context.Context, request reconcile.Re
 	}
 
 	instance.Status.Connected = true
+	instance.Status.PendingHostKey = nil
+
+	if r.apiProber != nil {
+		r.probeAPI(ctx, instance)
+	}
 
 	if err := NewCreateEventListener(r.client).ServeRequest(ctx, instance); err != nil {
 		log.Error(err, "Failed to create EventListener")
@@ -113,6 +174,11 @@ func (r *ReconcileGitServer) Reconcile(ctx context.Context, request reconcile.Re
 		return reconcile.Result{RequeueAfter: defaultRequeueTime}, nil
 	}
 
+	webhookReachable := true
+	if r.webhookChecker != nil {
+		webhookReachable = r.checkWebhook(ctx, instance)
+	}
+
 	instance.Status.SetSuccess()
 
 	if err := r.updateGitServerStatus(ctx, instance, oldStatus); err != nil {
@@ -121,8 +187,17 @@ func (r *ReconcileGitServer) Reconcile(ctx context.Context, request reconcile.Re
 
 	log.Info("Reconciling GitServer has been finished")
 
+	requeueAfter := successRequeueTime
+	if !webhookReachable {
+		requeueAfter = webhookRecheckTime
+	}
+
+	if !tokenRefreshAt.IsZero() {
+		requeueAfter = min(requeueAfter, max(time.Until(tokenRefreshAt), time.Second))
+	}
+
 	return reconcile.Result{
-		RequeueAfter: successRequeueTime,
+		RequeueAfter: requeueAfter,
 	}, nil
 }
 
@@ -213,7 +288,7 @@ func (r *ReconcileGitServer) updateGitServerStatus(
 	gitServer *codebaseApi.GitServer,
 	oldStatus codebaseApi.GitServerStatus,
 ) error {
-	if gitServer.Status == oldStatus {
+	if equality.Semantic.DeepEqual(gitServer.Status, oldStatus) {
 		return nil
 	}
 
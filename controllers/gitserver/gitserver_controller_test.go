@@ -14,6 +14,7 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -21,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
 	"github.com/epam/edp-codebase-operator/v2/pkg/platform"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
@@ -39,14 +41,25 @@ func TestReconcileGitServer_Reconcile_ShouldPassNotFound(t *testing.T) {
 		},
 	}
 
+	httpClients := gitprovider.NewHTTPClientPool(nil)
+	deleted := &codebaseApi.GitServer{ObjectMeta: metaV1.ObjectMeta{Name: "NewMockGitServer", Namespace: "namespace"}}
+
+	pooled, err := httpClients.Client(context.Background(), deleted)
+	require.NoError(t, err)
+
 	r := ReconcileGitServer{
-		client: fakeCl,
+		client:      fakeCl,
+		httpClients: httpClients,
 	}
 
 	res, err := r.Reconcile(ctrl.LoggerInto(context.Background(), logr.Discard()), req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, time.Duration(0), res.RequeueAfter)
+
+	got, err := httpClients.Client(context.Background(), deleted)
+	require.NoError(t, err)
+	assert.NotSame(t, pooled, got, "the HTTP client of a deleted GitServer must be evicted")
 }
 
 func TestReconcileGitServer_Reconcile_ShouldFailNotFound(t *testing.T) {
@@ -659,13 +672,13 @@ func TestNewReconcileGitServer(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			want := &ReconcileGitServer{
-				client: tt.args.c,
-			}
-
-			got := NewReconcileGitServer(tt.args.c)
+			got := NewReconcileGitServer(tt.args.c, gitprovider.NewHTTPClientPool(nil), record.NewFakeRecorder(1))
 
-			assert.Equal(t, want, got)
+			assert.Equal(t, tt.args.c, got.client)
+			assert.NotNil(t, got.newGitHubAppTokenIssuer)
+			assert.NotNil(t, got.newGitLabTokenRotator)
+			assert.NotNil(t, got.apiProber)
+			assert.NotNil(t, got.webhookChecker)
 		})
 	}
 }
@@ -0,0 +1,216 @@
+package gitserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/sshhostkey"
+)
+
+const (
+	// knownHostsSecretEnv is the name of the Secret the approved SSH host keys are stored in.
+	// The operator mounts it and lists its file in SSH_KNOWN_HOSTS. Host key approval is
+	// disabled when it is not set.
+	knownHostsSecretEnv = "SSH_KNOWN_HOSTS_SECRET"
+
+	// knownHostsSecretKey is the key of the known_hosts file in the approved host keys Secret.
+	knownHostsSecretKey = "known_hosts"
+
+	defaultSSHPort = 22
+)
+
+// approveHostKey adds the pending host key to the approved host keys Secret when the GitServer
+// carries the approval annotation with its fingerprint, and removes the annotation.
+func (r *ReconcileGitServer) approveHostKey(ctx context.Context, gitServer *codebaseApi.GitServer) error {
+	fingerprint := gitServer.Annotations[codebaseApi.ApproveHostKeyAnnotation]
+	if fingerprint == "" {
+		return nil
+	}
+
+	if r.knownHostsSecret == "" {
+		return fmt.Errorf("failed to approve host key: %s is not set", knownHostsSecretEnv)
+	}
+
+	pending := gitServer.Status.PendingHostKey
+	if pending == nil {
+		return fmt.Errorf("failed to approve host key %s: no host key is pending approval", fingerprint)
+	}
+
+	if pending.Fingerprint != fingerprint {
+		return fmt.Errorf("failed to approve host key %s: it does not match the pending host key %s of %s",
+			fingerprint, pending.Fingerprint, pending.Host)
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pending.PublicKey))
+	if err != nil {
+		return fmt.Errorf("failed to parse pending host key: %w", err)
+	}
+
+	if err = r.addApprovedHostKey(ctx, gitServer.Namespace, approvedHosts(gitServer, pending.Host), key); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(gitServer.DeepCopy())
+	delete(gitServer.Annotations, codebaseApi.ApproveHostKeyAnnotation)
+
+	if err = r.client.Patch(ctx, gitServer, patch); err != nil {
+		return fmt.Errorf("failed to remove %s annotation: %w", codebaseApi.ApproveHostKeyAnnotation, err)
+	}
+
+	gitServer.Status.PendingHostKey = nil
+
+	ctrl.LoggerFrom(ctx).Info("SSH host key has been approved", "host", pending.Host, "fingerprint", fingerprint)
+
+	return nil
+}
+
+// addApprovedHostKey appends the key to the approved host keys Secret, creating the Secret if needed.
+func (r *ReconcileGitServer) addApprovedHostKey(
+	ctx context.Context,
+	namespace string,
+	hostPorts []string,
+	key ssh.PublicKey,
+) error {
+	secret := &coreV1.Secret{}
+
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: r.knownHostsSecret}, secret)
+	if err != nil {
+		if !k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret %s: %w", r.knownHostsSecret, err)
+		}
+
+		secret = &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      r.knownHostsSecret,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				knownHostsSecretKey: []byte(sshhostkey.Line(hostPorts, key) + "\n"),
+			},
+		}
+
+		if err = r.client.Create(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create secret %s: %w", r.knownHostsSecret, err)
+		}
+
+		return nil
+	}
+
+	knownHosts := secret.Data[knownHostsSecretKey]
+	if sshhostkey.Contains(knownHosts, hostPorts[0], key) {
+		return nil
+	}
+
+	if len(knownHosts) > 0 && !strings.HasSuffix(string(knownHosts), "\n") {
+		knownHosts = append(knownHosts, '\n')
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	secret.Data[knownHostsSecretKey] = append(knownHosts, sshhostkey.Line(hostPorts, key)+"\n"...)
+
+	if err = r.client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to update secret %s: %w", r.knownHostsSecret, err)
+	}
+
+	return nil
+}
+
+// handleUnknownHostKey records the key offered by a host absent from known_hosts in
+// status.pendingHostKey and returns the error to report. A key that is already approved
+// is not offered again: it only waits for the kubelet to update the mounted Secret.
+func (r *ReconcileGitServer) handleUnknownHostKey(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	connErr error,
+) error {
+	var unknownErr *sshhostkey.UnknownHostKeyError
+	if !errors.As(connErr, &unknownErr) || r.knownHostsSecret == "" {
+		gitServer.Status.PendingHostKey = nil
+
+		return connErr
+	}
+
+	approved, err := r.isHostKeyApproved(ctx, gitServer.Namespace, unknownErr.HostPort, unknownErr.Key)
+	if err != nil {
+		return err
+	}
+
+	fingerprint := sshhostkey.Fingerprint(unknownErr.Key)
+
+	if approved {
+		gitServer.Status.PendingHostKey = nil
+
+		return fmt.Errorf("SSH host key %s of %s is approved and is being propagated to the operator",
+			fingerprint, unknownErr.HostPort)
+	}
+
+	pending := &codebaseApi.GitServerHostKey{
+		Host:        unknownErr.HostPort,
+		Type:        unknownErr.Key.Type(),
+		Fingerprint: fingerprint,
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(unknownErr.Key))),
+	}
+
+	if previous := gitServer.Status.PendingHostKey; previous != nil && previous.Fingerprint == fingerprint {
+		pending.FirstSeen = previous.FirstSeen
+	} else {
+		now := metaV1.Now()
+		pending.FirstSeen = &now
+	}
+
+	gitServer.Status.PendingHostKey = pending
+
+	return fmt.Errorf("%w. To trust the offered key, annotate the GitServer with %s=%s",
+		connErr, codebaseApi.ApproveHostKeyAnnotation, fingerprint)
+}
+
+func (r *ReconcileGitServer) isHostKeyApproved(
+	ctx context.Context,
+	namespace, hostPort string,
+	key ssh.PublicKey,
+) (bool, error) {
+	secret := &coreV1.Secret{}
+
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: r.knownHostsSecret}, secret)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to get secret %s: %w", r.knownHostsSecret, err)
+	}
+
+	return sshhostkey.Contains(secret.Data[knownHostsSecretKey], hostPort, key), nil
+}
+
+// approvedHosts returns the addresses to pin an approved key for. Repository operations on
+// GitHub, GitLab and Bitbucket always use port 22, while the connectivity check uses
+// spec.sshPort, so both addresses are pinned when they differ.
+func approvedHosts(gitServer *codebaseApi.GitServer, hostPort string) []string {
+	hosts := []string{hostPort}
+
+	if gitServer.Spec.GitProvider == codebaseApi.GitProviderGerrit {
+		return hosts
+	}
+
+	defaultHostPort := sshhostkey.HostPort(gitServer.Spec.GitHost, defaultSSHPort)
+	if defaultHostPort != hostPort {
+		hosts = append(hosts, defaultHostPort)
+	}
+
+	return hosts
+}
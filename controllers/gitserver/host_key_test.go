@@ -0,0 +1,301 @@
+package gitserver
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/sshhostkey"
+)
+
+const testKnownHostsSecret = "codebase-operator-ssh-known-hosts-approved"
+
+func newTestHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	return key
+}
+
+func pendingHostKey(hostPort string, key ssh.PublicKey) *codebaseApi.GitServerHostKey {
+	return &codebaseApi.GitServerHostKey{
+		Host:        hostPort,
+		Type:        key.Type(),
+		Fingerprint: sshhostkey.Fingerprint(key),
+		PublicKey:   string(ssh.MarshalAuthorizedKey(key)),
+	}
+}
+
+func TestReconcileGitServer_approveHostKey(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	key := newTestHostKey(t)
+	existingKey := newTestHostKey(t)
+	existingLine := sshhostkey.Line([]string{"other.example.com:22"}, existingKey)
+
+	tests := []struct {
+		name             string
+		provider         string
+		sshPort          int32
+		annotation       string
+		pending          bool
+		knownHostsSecret string
+		objects          []client.Object
+		wantErr          require.ErrorAssertionFunc
+		wantHosts        []string
+		wantExisting     bool
+	}{
+		{
+			name:             "creates the secret with the approved key",
+			provider:         codebaseApi.GitProviderGerrit,
+			sshPort:          29418,
+			annotation:       sshhostkey.Fingerprint(key),
+			pending:          true,
+			knownHostsSecret: testKnownHostsSecret,
+			wantErr:          require.NoError,
+			wantHosts:        []string{"git.example.com:29418"},
+		},
+		{
+			name:             "pins the default port for repository operations",
+			provider:         codebaseApi.GitProviderGitlab,
+			sshPort:          2222,
+			annotation:       sshhostkey.Fingerprint(key),
+			pending:          true,
+			knownHostsSecret: testKnownHostsSecret,
+			objects: []client.Object{&corev1.Secret{
+				ObjectMeta: metaV1.ObjectMeta{Name: testKnownHostsSecret, Namespace: "default"},
+				Data:       map[string][]byte{knownHostsSecretKey: []byte(existingLine)},
+			}},
+			wantErr:      require.NoError,
+			wantHosts:    []string{"git.example.com:2222", "git.example.com:22"},
+			wantExisting: true,
+		},
+		{
+			name:             "fingerprint does not match",
+			provider:         codebaseApi.GitProviderGitlab,
+			sshPort:          22,
+			annotation:       "SHA256:other",
+			pending:          true,
+			knownHostsSecret: testKnownHostsSecret,
+			wantErr: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorContains(t, err, "does not match the pending host key")
+			},
+		},
+		{
+			name:             "no pending host key",
+			provider:         codebaseApi.GitProviderGitlab,
+			sshPort:          22,
+			annotation:       sshhostkey.Fingerprint(key),
+			knownHostsSecret: testKnownHostsSecret,
+			wantErr: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorContains(t, err, "no host key is pending approval")
+			},
+		},
+		{
+			name:       "approval is not configured",
+			provider:   codebaseApi.GitProviderGitlab,
+			sshPort:    22,
+			annotation: sshhostkey.Fingerprint(key),
+			pending:    true,
+			wantErr: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorContains(t, err, knownHostsSecretEnv)
+			},
+		},
+		{
+			name:     "no annotation",
+			provider: codebaseApi.GitProviderGitlab,
+			sshPort:  22,
+			pending:  true,
+			wantErr:  require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gs := &codebaseApi.GitServer{
+				ObjectMeta: metaV1.ObjectMeta{Name: "git-server", Namespace: "default"},
+				Spec: codebaseApi.GitServerSpec{
+					GitHost:     "git.example.com",
+					GitProvider: tt.provider,
+					SshPort:     tt.sshPort,
+				},
+			}
+
+			if tt.annotation != "" {
+				gs.Annotations = map[string]string{codebaseApi.ApproveHostKeyAnnotation: tt.annotation}
+			}
+
+			if tt.pending {
+				gs.Status.PendingHostKey = pendingHostKey(sshhostkey.HostPort("git.example.com", int(tt.sshPort)), key)
+			}
+
+			fakeCl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(tt.objects, gs)...).
+				WithStatusSubresource(gs).
+				Build()
+
+			r := &ReconcileGitServer{client: fakeCl, knownHostsSecret: tt.knownHostsSecret}
+
+			err := r.approveHostKey(ctrl.LoggerInto(context.Background(), logr.Discard()), gs)
+			tt.wantErr(t, err)
+
+			if tt.wantHosts == nil {
+				if tt.annotation != "" {
+					assert.Contains(t, gs.Annotations, codebaseApi.ApproveHostKeyAnnotation)
+				}
+
+				assert.Equal(t, tt.pending, gs.Status.PendingHostKey != nil)
+
+				return
+			}
+
+			assert.NotContains(t, gs.Annotations, codebaseApi.ApproveHostKeyAnnotation)
+			assert.Nil(t, gs.Status.PendingHostKey)
+
+			secret := &corev1.Secret{}
+			require.NoError(t, fakeCl.Get(context.Background(), client.ObjectKey{
+				Namespace: "default",
+				Name:      testKnownHostsSecret,
+			}, secret))
+
+			knownHosts := secret.Data[knownHostsSecretKey]
+
+			for _, host := range tt.wantHosts {
+				assert.True(t, sshhostkey.Contains(knownHosts, host, key), "key must be pinned for %s", host)
+			}
+
+			assert.Equal(t, tt.wantExisting, sshhostkey.Contains(knownHosts, "other.example.com:22", existingKey))
+		})
+	}
+}
+
+func TestReconcileGitServer_handleUnknownHostKey(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	key := newTestHostKey(t)
+	hostPort := "git.example.com:22"
+	connErr := &sshhostkey.UnknownHostKeyError{HostPort: hostPort, Key: key, Err: errors.New("knownhosts: key is unknown")}
+	firstSeen := metaV1.NewTime(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+
+	previous := pendingHostKey(hostPort, key)
+	previous.FirstSeen = &firstSeen
+
+	approvedSecret := &corev1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: testKnownHostsSecret, Namespace: "default"},
+		Data:       map[string][]byte{knownHostsSecretKey: []byte(sshhostkey.Line([]string{hostPort}, key) + "\n")},
+	}
+
+	tests := []struct {
+		name             string
+		connErr          error
+		previous         *codebaseApi.GitServerHostKey
+		knownHostsSecret string
+		objects          []client.Object
+		wantErr          string
+		wantPending      bool
+		wantFirstSeen    *metaV1.Time
+	}{
+		{
+			name:             "records the offered key",
+			connErr:          connErr,
+			knownHostsSecret: testKnownHostsSecret,
+			wantErr:          codebaseApi.ApproveHostKeyAnnotation + "=" + sshhostkey.Fingerprint(key),
+			wantPending:      true,
+		},
+		{
+			name:             "keeps the time the key was first seen",
+			connErr:          connErr,
+			previous:         previous,
+			knownHostsSecret: testKnownHostsSecret,
+			wantErr:          "To trust the offered key",
+			wantPending:      true,
+			wantFirstSeen:    &firstSeen,
+		},
+		{
+			name:             "approved key is being propagated",
+			connErr:          connErr,
+			previous:         previous,
+			knownHostsSecret: testKnownHostsSecret,
+			objects:          []client.Object{approvedSecret},
+			wantErr:          "is approved and is being propagated",
+		},
+		{
+			name:             "other errors clear the pending key",
+			connErr:          errors.New("ssh: handshake failed: unable to authenticate"),
+			previous:         previous,
+			knownHostsSecret: testKnownHostsSecret,
+			wantErr:          "unable to authenticate",
+		},
+		{
+			name:     "approval is not configured",
+			connErr:  connErr,
+			previous: previous,
+			wantErr:  "key is unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gs := &codebaseApi.GitServer{
+				ObjectMeta: metaV1.ObjectMeta{Name: "git-server", Namespace: "default"},
+				Status:     codebaseApi.GitServerStatus{PendingHostKey: tt.previous.DeepCopy()},
+			}
+
+			r := &ReconcileGitServer{
+				client:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				knownHostsSecret: tt.knownHostsSecret,
+			}
+
+			err := r.handleUnknownHostKey(context.Background(), gs, tt.connErr)
+			require.ErrorContains(t, err, tt.wantErr)
+
+			if !tt.wantPending {
+				assert.Nil(t, gs.Status.PendingHostKey)
+
+				return
+			}
+
+			require.NotNil(t, gs.Status.PendingHostKey)
+			assert.Equal(t, hostPort, gs.Status.PendingHostKey.Host)
+			assert.Equal(t, sshhostkey.Fingerprint(key), gs.Status.PendingHostKey.Fingerprint)
+			assert.Equal(t, ssh.KeyAlgoED25519, gs.Status.PendingHostKey.Type)
+			require.NotNil(t, gs.Status.PendingHostKey.FirstSeen)
+
+			if tt.wantFirstSeen != nil {
+				assert.True(t, tt.wantFirstSeen.Equal(gs.Status.PendingHostKey.FirstSeen))
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package gitserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+// rateLimitLowDivisor sets the headroom below which the rate limit is reported as low:
+// less than a tenth of the requests left in the current window.
+const rateLimitLowDivisor = 10
+
+type apiProber func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (*gitprovider.APIProbe, error)
+
+// newAPIProber creates an apiProber that sends requests with the shared HTTP client of the
+// GitServer, so that probes count against the same throttling as the other API calls.
+func newAPIProber(httpClients *gitprovider.HTTPClientPool) apiProber {
+	return func(ctx context.Context, gitServer *codebaseApi.GitServer, token string) (*gitprovider.APIProbe, error) {
+		restyClient, err := httpClients.RestyClient(ctx, gitServer)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // RestyClient errors are descriptive
+		}
+
+		//nolint:wrapcheck // ProbeAPI errors are descriptive
+		return gitprovider.ProbeAPI(ctx, restyClient, gitServer, token)
+	}
+}
+
+// probeAPI checks the access token against the git provider API and records the result in
+// status.api and the status conditions. It does not fail the reconciliation: Codebase
+// reconcilers check the conditions before they use the API.
+func (r *ReconcileGitServer) probeAPI(ctx context.Context, gitServer *codebaseApi.GitServer) {
+	log := ctrl.LoggerFrom(ctx)
+
+	secret := &coreV1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: gitServer.Namespace,
+		Name:      gitServer.Spec.NameSshKeySecret,
+	}, secret); err != nil {
+		log.Error(err, "Failed to get secret to probe git provider API")
+
+		return
+	}
+
+	token := string(secret.Data[util.GitServerSecretTokenField])
+	if token == "" {
+		return
+	}
+
+	probe, err := r.apiProber(ctx, gitServer, token)
+	if errors.Is(err, gitprovider.ErrApiNotSupported) {
+		return
+	}
+
+	if err != nil {
+		log.Error(err, "Failed to probe git provider API")
+
+		setProbeFailedConditions(gitServer, err)
+
+		return
+	}
+
+	gitServer.Status.API = newAPIStatus(probe)
+
+	meta.SetStatusCondition(&gitServer.Status.Conditions, metaV1.Condition{
+		Type:    codebaseApi.GitServerConditionTokenValid,
+		Status:  metaV1.ConditionTrue,
+		Reason:  codebaseApi.ReasonTokenAccepted,
+		Message: "The git provider API accepts the access token",
+	})
+	meta.SetStatusCondition(&gitServer.Status.Conditions, permissionsCondition(probe))
+	meta.SetStatusCondition(&gitServer.Status.Conditions, rateLimitCondition(probe))
+}
+
+func setProbeFailedConditions(gitServer *codebaseApi.GitServer, err error) {
+	tokenValid := metaV1.Condition{
+		Type:    codebaseApi.GitServerConditionTokenValid,
+		Status:  metaV1.ConditionUnknown,
+		Reason:  codebaseApi.ReasonAPIProbeFailed,
+		Message: err.Error(),
+	}
+
+	if errors.Is(err, gitprovider.ErrTokenRejected) {
+		tokenValid.Status = metaV1.ConditionFalse
+		tokenValid.Reason = codebaseApi.ReasonTokenRejected
+		tokenValid.Message = "The git provider API rejects the access token, it may be revoked or expired"
+		gitServer.Status.API = nil
+	}
+
+	meta.SetStatusCondition(&gitServer.Status.Conditions, tokenValid)
+
+	for _, conditionType := range []string{
+		codebaseApi.GitServerConditionPermissionsSufficient,
+		codebaseApi.GitServerConditionRateLimitAvailable,
+	} {
+		meta.SetStatusCondition(&gitServer.Status.Conditions, metaV1.Condition{
+			Type:    conditionType,
+			Status:  metaV1.ConditionUnknown,
+			Reason:  codebaseApi.ReasonAPIProbeFailed,
+			Message: "The git provider API could not be probed",
+		})
+	}
+}
+
+func permissionsCondition(probe *gitprovider.APIProbe) metaV1.Condition {
+	switch {
+	case probe.Scopes == nil:
+		return metaV1.Condition{
+			Type:    codebaseApi.GitServerConditionPermissionsSufficient,
+			Status:  metaV1.ConditionUnknown,
+			Reason:  codebaseApi.ReasonScopesNotReported,
+			Message: "The git provider does not report the permissions of the access token",
+		}
+	case len(probe.MissingScopes) > 0:
+		return metaV1.Condition{
+			Type:    codebaseApi.GitServerConditionPermissionsSufficient,
+			Status:  metaV1.ConditionFalse,
+			Reason:  codebaseApi.ReasonScopesMissing,
+			Message: "The access token lacks scopes: " + strings.Join(probe.MissingScopes, ", "),
+		}
+	default:
+		return metaV1.Condition{
+			Type:    codebaseApi.GitServerConditionPermissionsSufficient,
+			Status:  metaV1.ConditionTrue,
+			Reason:  codebaseApi.ReasonScopesGranted,
+			Message: "The access token has the required scopes",
+		}
+	}
+}
+
+// rateLimitCondition keeps the message static while the limit is available, so that the
+// condition does not change on every probe.
+func rateLimitCondition(probe *gitprovider.APIProbe) metaV1.Condition {
+	rateLimit := probe.RateLimit
+
+	switch {
+	case rateLimit == nil:
+		return metaV1.Condition{
+			Type:    codebaseApi.GitServerConditionRateLimitAvailable,
+			Status:  metaV1.ConditionUnknown,
+			Reason:  codebaseApi.ReasonRateLimitNotReported,
+			Message: "The git provider does not report the rate limit",
+		}
+	case rateLimit.Remaining*rateLimitLowDivisor < rateLimit.Limit:
+		return metaV1.Condition{
+			Type:   codebaseApi.GitServerConditionRateLimitAvailable,
+			Status: metaV1.ConditionFalse,
+			Reason: codebaseApi.ReasonRateLimitLow,
+			Message: fmt.Sprintf("%d of %d API requests left until %s",
+				rateLimit.Remaining, rateLimit.Limit, rateLimit.ResetAt.Format(time.RFC3339)),
+		}
+	default:
+		return metaV1.Condition{
+			Type:    codebaseApi.GitServerConditionRateLimitAvailable,
+			Status:  metaV1.ConditionTrue,
+			Reason:  codebaseApi.ReasonRateLimitAvailable,
+			Message: "The access token has API requests left",
+		}
+	}
+}
+
+func newAPIStatus(probe *gitprovider.APIProbe) *codebaseApi.GitServerAPIStatus {
+	status := &codebaseApi.GitServerAPIStatus{
+		Version: probe.Version,
+		Scopes:  probe.Scopes,
+	}
+
+	if probe.RateLimit != nil {
+		status.RateLimit = &codebaseApi.GitServerRateLimit{
+			Limit:     probe.RateLimit.Limit,
+			Remaining: probe.RateLimit.Remaining,
+		}
+
+		if !probe.RateLimit.ResetAt.IsZero() {
+			status.RateLimit.ResetAt = &metaV1.Time{Time: probe.RateLimit.ResetAt}
+		}
+	}
+
+	if probe.TokenExpiresAt != nil {
+		status.TokenExpiresAt = &metaV1.Time{Time: *probe.TokenExpiresAt}
+	}
+
+	return status
+}
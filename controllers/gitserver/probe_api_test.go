@@ -0,0 +1,126 @@
+package gitserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+func TestReconcileGitServer_probeAPI(t *testing.T) {
+	resetAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		probe      *gitprovider.APIProbe
+		probeErr   error
+		wantStatus map[string]metaV1.ConditionStatus
+		wantReason string
+		wantAPI    bool
+	}{
+		{
+			name: "sufficient token",
+			probe: &gitprovider.APIProbe{
+				Version:   "17.2.1",
+				Scopes:    []string{"api"},
+				RateLimit: &gitprovider.RateLimit{Limit: 600, Remaining: 500, ResetAt: resetAt},
+			},
+			wantStatus: map[string]metaV1.ConditionStatus{
+				codebaseApi.GitServerConditionTokenValid:            metaV1.ConditionTrue,
+				codebaseApi.GitServerConditionPermissionsSufficient: metaV1.ConditionTrue,
+				codebaseApi.GitServerConditionRateLimitAvailable:    metaV1.ConditionTrue,
+			},
+			wantAPI: true,
+		},
+		{
+			name: "missing scopes and low rate limit",
+			probe: &gitprovider.APIProbe{
+				Scopes:        []string{"read_api"},
+				MissingScopes: []string{"api"},
+				RateLimit:     &gitprovider.RateLimit{Limit: 600, Remaining: 10, ResetAt: resetAt},
+			},
+			wantStatus: map[string]metaV1.ConditionStatus{
+				codebaseApi.GitServerConditionTokenValid:            metaV1.ConditionTrue,
+				codebaseApi.GitServerConditionPermissionsSufficient: metaV1.ConditionFalse,
+				codebaseApi.GitServerConditionRateLimitAvailable:    metaV1.ConditionFalse,
+			},
+			wantReason: "The access token lacks scopes: api",
+			wantAPI:    true,
+		},
+		{
+			name:  "scopes and rate limit not reported",
+			probe: &gitprovider.APIProbe{},
+			wantStatus: map[string]metaV1.ConditionStatus{
+				codebaseApi.GitServerConditionTokenValid:            metaV1.ConditionTrue,
+				codebaseApi.GitServerConditionPermissionsSufficient: metaV1.ConditionUnknown,
+				codebaseApi.GitServerConditionRateLimitAvailable:    metaV1.ConditionUnknown,
+			},
+			wantAPI: true,
+		},
+		{
+			name:     "rejected token",
+			probeErr: gitprovider.ErrTokenRejected,
+			wantStatus: map[string]metaV1.ConditionStatus{
+				codebaseApi.GitServerConditionTokenValid:            metaV1.ConditionFalse,
+				codebaseApi.GitServerConditionPermissionsSufficient: metaV1.ConditionUnknown,
+				codebaseApi.GitServerConditionRateLimitAvailable:    metaV1.ConditionUnknown,
+			},
+			wantReason: "The git provider API rejects the access token, it may be revoked or expired",
+		},
+		{
+			name:       "unsupported provider",
+			probeErr:   gitprovider.ErrApiNotSupported,
+			wantStatus: map[string]metaV1.ConditionStatus{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitServer := &codebaseApi.GitServer{
+				ObjectMeta: metaV1.ObjectMeta{Name: "gitlab", Namespace: "default"},
+				Spec: codebaseApi.GitServerSpec{
+					GitProvider:      codebaseApi.GitProviderGitlab,
+					NameSshKeySecret: "git-credentials",
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metaV1.ObjectMeta{Name: "git-credentials", Namespace: "default"},
+				Data:       map[string][]byte{util.GitServerSecretTokenField: []byte("token")},
+			}
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+
+			r := &ReconcileGitServer{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+				apiProber: func(context.Context, *codebaseApi.GitServer, string) (*gitprovider.APIProbe, error) {
+					return tt.probe, tt.probeErr
+				},
+			}
+
+			r.probeAPI(context.Background(), gitServer)
+
+			assert.Len(t, gitServer.Status.Conditions, len(tt.wantStatus))
+
+			for conditionType, status := range tt.wantStatus {
+				condition := meta.FindStatusCondition(gitServer.Status.Conditions, conditionType)
+				require.NotNil(t, condition, conditionType)
+				assert.Equal(t, status, condition.Status, conditionType)
+			}
+
+			assert.Equal(t, tt.wantReason, gitServer.Status.APIUnavailableReason())
+			assert.Equal(t, tt.wantAPI, gitServer.Status.API != nil)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkArtifactory checks that the credentials grant access to at least one repository.
+// The url key is the Artifactory base URL, e.g. https://example.jfrog.io/artifactory.
+// It authenticates with username and password, or with an access token in the token key.
+func checkArtifactory(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "url"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	resp, err := get(ctx, withAuth(newRequest(secretValue(secret, "url")), secret), "/api/repositories")
+	if err != nil {
+		return connectionInfo{}, err
+	}
+
+	var repositories []struct {
+		Key string `json:"key"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &repositories); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Artifactory repositories: %w", err)
+	}
+
+	// Artifactory lists only the repositories the user may read.
+	if len(repositories) == 0 {
+		return connectionInfo{}, errors.New("credentials grant access to no Artifactory repository")
+	}
+
+	return connectionInfo{}, nil
+}
@@ -0,0 +1,53 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckArtifactory(t *testing.T) {
+	t.Parallel()
+
+	withToken := func(routes map[string]http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			serveRoute(w, r, routes)
+		}
+	}
+
+	runCheckerTests(t, "artifactory", []checkerTestCase{
+		{
+			name: "token with readable repositories",
+			data: map[string][]byte{"token": []byte("token")},
+			handler: withToken(map[string]http.HandlerFunc{
+				"GET /api/repositories": respond(http.StatusOK, `[{"key":"libs-release","type":"LOCAL"}]`),
+			}),
+		},
+		{
+			name: "username and password",
+			data: map[string][]byte{"username": []byte("ci"), "password": []byte("secret")},
+			handler: basicAuthHandler("ci", "secret", map[string]http.HandlerFunc{
+				"GET /api/repositories": respond(http.StatusOK, `[{"key":"libs-release"}]`),
+			}),
+		},
+		{
+			name: "no readable repositories",
+			data: map[string][]byte{"token": []byte("token")},
+			handler: withToken(map[string]http.HandlerFunc{
+				"GET /api/repositories": respond(http.StatusOK, `[]`),
+			}),
+			wantErr: "no Artifactory repository",
+		},
+		{
+			name:    "invalid token",
+			data:    map[string][]byte{"token": []byte("wrong")},
+			handler: withToken(nil),
+			wantErr: "credentials are not accepted",
+		},
+	})
+}
@@ -0,0 +1,168 @@
+package integrationsecret
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// requestFunc creates a request to the tool at baseURL with the timeout and TLS settings
+// of the reconciler.
+type requestFunc func(baseURL string) *resty.Request
+
+// connectionChecker checks an integration secret against the tool it grants access to.
+// Checkers validate that the credentials are accepted and carry the permissions EDP needs,
+// not only that the tool is reachable.
+type connectionChecker interface {
+	check(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error)
+}
+
+// connectionInfo is what a check learned about the credentials besides that they work.
+type connectionInfo struct {
+	// ExpiresAt is the time the credentials expire at. Zero if they do not expire or the tool
+	// does not tell.
+	ExpiresAt time.Time
+}
+
+// checkerFunc adapts a function to connectionChecker.
+type checkerFunc func(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error)
+
+func (f checkerFunc) check(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	return f(ctx, newRequest, secret)
+}
+
+// connectionCheckers maps the app.edp.epam.com/secret-type label of an integration secret
+// to its checker. Secrets of other types are only checked for reachability.
+var connectionCheckers = map[string]connectionChecker{
+	"sonar":            checkerFunc(checkSonar),
+	"github":           checkerFunc(checkGitHub),
+	"nexus":            checkerFunc(checkNexus),
+	"dependency-track": checkerFunc(checkDependencyTrack),
+	"defectdojo":       checkerFunc(checkDefectDojo),
+	"registry":         checkerFunc(checkRegistry),
+	"argocd":           checkerFunc(checkArgoCD),
+	"artifactory":      checkerFunc(checkArtifactory),
+	"harbor":           checkerFunc(checkHarbor),
+	"keycloak":         checkerFunc(checkKeycloak),
+	"grafana":          checkerFunc(checkGrafana),
+	"vault":            checkerFunc(checkVault),
+	"jenkins":          checkerFunc(checkJenkins),
+	"opensearch":       checkerFunc(checkOpenSearch),
+}
+
+func checkerFor(secretType string) connectionChecker {
+	if checker, ok := connectionCheckers[secretType]; ok {
+		return checker
+	}
+
+	return checkerFunc(checkReachable)
+}
+
+func checkReachable(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	_, err := get(ctx, newRequest(secretValue(secret, "url")), "/")
+
+	return connectionInfo{}, err
+}
+
+func checkNexus(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	_, err := get(ctx, withAuth(newRequest(secretValue(secret, "url")), secret), "/service/rest/v1/status")
+
+	return connectionInfo{}, err
+}
+
+func checkDependencyTrack(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	_, err := get(ctx, newRequest(secretValue(secret, "url")).SetHeader("X-Api-Key", secretValue(secret, "token")),
+		"/api/v1/team/self")
+
+	return connectionInfo{}, err
+}
+
+func checkDefectDojo(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	_, err := get(ctx, newRequest(secretValue(secret, "url")).
+		SetHeader("Authorization", "Token "+secretValue(secret, "token")),
+		"/api/v2/user_profile")
+
+	return connectionInfo{}, err
+}
+
+func checkArgoCD(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	_, err := get(ctx, newRequest(secretValue(secret, "url")).
+		SetHeader("Authorization", "Bearer "+secretValue(secret, "token")),
+		"/api/v1/projects")
+
+	return connectionInfo{}, err
+}
+
+// withAuth authenticates the request with username and password if the secret has a username,
+// and with the token as a bearer token otherwise.
+func withAuth(req *resty.Request, secret *corev1.Secret) *resty.Request {
+	if _, ok := secret.Data["username"]; ok {
+		return req.SetBasicAuth(secretValue(secret, "username"), secretValue(secret, "password"))
+	}
+
+	return req.SetAuthToken(secretValue(secret, "token"))
+}
+
+// get sends a GET request and fails on a response other than 2xx.
+func get(ctx context.Context, req *resty.Request, path string) (*resty.Response, error) {
+	return send(ctx, req, http.MethodGet, path)
+}
+
+func send(ctx context.Context, req *resty.Request, method, path string) (*resty.Response, error) {
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Request has been made", logKeyUrl, resp.Request.URL, "status", resp.StatusCode())
+
+	if !resp.IsSuccess() {
+		return resp, statusError(resp)
+	}
+
+	return resp, nil
+}
+
+// statusError describes a failed response, naming the likely cause for authentication
+// and authorization failures.
+func statusError(resp *resty.Response) error {
+	switch resp.StatusCode() {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("credentials are not accepted: http status code %s", resp.Status())
+	case http.StatusForbidden:
+		return fmt.Errorf("credentials lack permissions: http status code %s", resp.Status())
+	default:
+		return fmt.Errorf("http status code %s", resp.Status())
+	}
+}
+
+// parseExpiry parses a token expiration time reported by a tool in one of the layouts.
+func parseExpiry(value string, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unknown expiration time format %q", value)
+}
+
+func secretValue(secret *corev1.Secret, key string) string {
+	return string(secret.Data[key])
+}
+
+// requireKeys fails if one of the keys is missing in the secret.
+func requireKeys(secret *corev1.Secret, keys ...string) error {
+	for _, key := range keys {
+		if len(secret.Data[key]) == 0 {
+			return fmt.Errorf("no %s key in secret %s", key, secret.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package integrationsecret
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// checkerTestCase runs a checker against an httptest stand-in of the tool.
+type checkerTestCase struct {
+	name          string
+	data          map[string][]byte
+	handler       http.HandlerFunc
+	wantErr       string
+	wantExpiresAt time.Time
+}
+
+func runCheckerTests(t *testing.T, secretType string, tests []checkerTestCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			data := map[string][]byte{"url": []byte(server.URL)}
+			for k, v := range tt.data {
+				data[k] = v
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretType, Namespace: "default"},
+				Data:       data,
+			}
+
+			newRequest := func(baseURL string) *resty.Request {
+				return resty.New().SetBaseURL(baseURL).SetDisableWarn(true).R()
+			}
+
+			info, err := checkerFor(secretType).check(
+				ctrl.LoggerInto(context.Background(), logr.Discard()), newRequest, secret)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.True(t, tt.wantExpiresAt.Equal(info.ExpiresAt),
+					"want expiry %s, got %s", tt.wantExpiresAt, info.ExpiresAt)
+
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// basicAuthHandler serves the routes to requests with the credentials and rejects others.
+func basicAuthHandler(username, password string, routes map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		serveRoute(w, r, routes)
+	}
+}
+
+func serveRoute(w http.ResponseWriter, r *http.Request, routes map[string]http.HandlerFunc) {
+	route, ok := routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	route(w, r)
+}
+
+func respond(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestCheckerFor(t *testing.T) {
+	t.Parallel()
+
+	runCheckerTests(t, "unknown", []checkerTestCase{
+		{
+			name:    "unknown types are checked for reachability",
+			handler: respond(http.StatusOK, ""),
+		},
+		{
+			name:    "unreachable",
+			handler: respond(http.StatusServiceUnavailable, ""),
+			wantErr: "http status code 503",
+		},
+	})
+}
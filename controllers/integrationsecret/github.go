@@ -0,0 +1,48 @@
+package integrationsecret
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	defaultGitHubAPIURL = "https://api.github.com"
+
+	// gitHubTokenExpirationHeader is set by GitHub on responses to requests authenticated with
+	// a personal access token that expires.
+	gitHubTokenExpirationHeader = "GitHub-Authentication-Token-Expiration"
+)
+
+// checkGitHub checks that GitHub accepts the personal access token in the token key.
+// The url key is the API URL, https://api.github.com by default; GitHub Enterprise Server
+// serves it at https://<host>/api/v3.
+func checkGitHub(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "token"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	apiURL := secretValue(secret, "url")
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+
+	resp, err := get(ctx, newRequest(apiURL).
+		SetAuthToken(secretValue(secret, "token")).
+		SetHeader("Accept", "application/vnd.github+json"), "/user")
+	if err != nil {
+		return connectionInfo{}, err
+	}
+
+	info := connectionInfo{}
+
+	if expiration := resp.Header().Get(gitHubTokenExpirationHeader); expiration != "" {
+		info.ExpiresAt, err = parseExpiry(expiration, "2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700")
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to parse GitHub token expiration")
+		}
+	}
+
+	return info, nil
+}
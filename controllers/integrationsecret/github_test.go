@@ -0,0 +1,57 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCheckGitHub(t *testing.T) {
+	t.Parallel()
+
+	github := func(expiration string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer ghp_token" {
+				respond(http.StatusUnauthorized, `{"message":"Bad credentials"}`)(w, r)
+
+				return
+			}
+
+			serveRoute(w, r, map[string]http.HandlerFunc{
+				"GET /user": func(w http.ResponseWriter, r *http.Request) {
+					if expiration != "" {
+						w.Header().Set(gitHubTokenExpirationHeader, expiration)
+					}
+
+					respond(http.StatusOK, `{"login":"edp-bot"}`)(w, r)
+				},
+			})
+		}
+	}
+
+	runCheckerTests(t, "github", []checkerTestCase{
+		{
+			name:    "token without expiration",
+			data:    map[string][]byte{"token": []byte("ghp_token")},
+			handler: github(""),
+		},
+		{
+			name:          "expiring token",
+			data:          map[string][]byte{"token": []byte("ghp_token")},
+			handler:       github("2026-10-25 12:00:00 UTC"),
+			wantExpiresAt: time.Date(2026, 10, 25, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "expiration with an offset",
+			data:          map[string][]byte{"token": []byte("ghp_token")},
+			handler:       github("2026-10-25 12:00:00 -0700"),
+			wantExpiresAt: time.Date(2026, 10, 25, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "bad credentials",
+			data:    map[string][]byte{"token": []byte("wrong")},
+			handler: github(""),
+			wantErr: "credentials are not accepted",
+		},
+	})
+}
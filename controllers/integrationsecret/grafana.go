@@ -0,0 +1,36 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const grafanaRequiredAction = "dashboards:create"
+
+// checkGrafana checks that the service account token in the token key, or username and
+// password, may create dashboards. It requires Grafana 9 or newer.
+func checkGrafana(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "url"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	resp, err := get(ctx, withAuth(newRequest(secretValue(secret, "url")), secret),
+		"/api/access-control/user/permissions")
+	if err != nil {
+		return connectionInfo{}, err
+	}
+
+	var permissions map[string][]string
+	if err = json.Unmarshal(resp.Body(), &permissions); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Grafana permissions: %w", err)
+	}
+
+	if _, ok := permissions[grafanaRequiredAction]; !ok {
+		return connectionInfo{}, fmt.Errorf("credentials lack the Grafana %s permission", grafanaRequiredAction)
+	}
+
+	return connectionInfo{}, nil
+}
@@ -0,0 +1,44 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckGrafana(t *testing.T) {
+	t.Parallel()
+
+	withToken := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer glsa_token" {
+				respond(http.StatusUnauthorized, `{"message":"invalid API key"}`)(w, r)
+
+				return
+			}
+
+			serveRoute(w, r, map[string]http.HandlerFunc{
+				"GET /api/access-control/user/permissions": respond(http.StatusOK, body),
+			})
+		}
+	}
+
+	runCheckerTests(t, "grafana", []checkerTestCase{
+		{
+			name:    "editor service account",
+			data:    map[string][]byte{"token": []byte("glsa_token")},
+			handler: withToken(`{"dashboards:create":[],"dashboards:read":["dashboards:*"]}`),
+		},
+		{
+			name:    "viewer service account",
+			data:    map[string][]byte{"token": []byte("glsa_token")},
+			handler: withToken(`{"dashboards:read":["dashboards:*"]}`),
+			wantErr: "lack the Grafana dashboards:create permission",
+		},
+		{
+			name:    "invalid token",
+			data:    map[string][]byte{"token": []byte("wrong")},
+			handler: withToken(`{}`),
+			wantErr: "credentials are not accepted",
+		},
+	})
+}
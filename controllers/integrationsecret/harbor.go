@@ -0,0 +1,65 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkHarbor checks that the credentials, usually of a robot account, may push images
+// to the Harbor project in the project key.
+func checkHarbor(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "url", "username", "password", "project"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	newHarborRequest := func() *resty.Request {
+		return newRequest(secretValue(secret, "url")).
+			SetBasicAuth(secretValue(secret, "username"), secretValue(secret, "password"))
+	}
+
+	project := secretValue(secret, "project")
+
+	resp, err := get(ctx, newHarborRequest().SetHeader("X-Is-Resource-Name", "true"),
+		"/api/v2.0/projects/"+url.PathEscape(project))
+	if err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to get Harbor project %s: %w", project, err)
+	}
+
+	var harborProject struct {
+		ProjectID int64 `json:"project_id"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &harborProject); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Harbor project: %w", err)
+	}
+
+	resp, err = get(ctx, newHarborRequest().SetQueryParams(map[string]string{
+		"scope":    fmt.Sprintf("/project/%d", harborProject.ProjectID),
+		"relative": "true",
+	}), "/api/v2.0/users/current/permissions")
+	if err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to get permissions in Harbor project %s: %w", project, err)
+	}
+
+	var permissions []struct {
+		Resource string `json:"resource"`
+		Action   string `json:"action"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &permissions); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Harbor permissions: %w", err)
+	}
+
+	for _, p := range permissions {
+		if p.Resource == "repository" && p.Action == "push" {
+			return connectionInfo{}, nil
+		}
+	}
+
+	return connectionInfo{}, fmt.Errorf("credentials may not push to Harbor project %s", project)
+}
@@ -0,0 +1,79 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckHarbor(t *testing.T) {
+	t.Parallel()
+
+	credentials := map[string][]byte{
+		"username": []byte("robot$edp+ci"),
+		"password": []byte("secret"),
+		"project":  []byte("edp"),
+	}
+
+	project := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Is-Resource-Name") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		respond(http.StatusOK, `{"project_id":7,"name":"edp"}`)(w, r)
+	}
+
+	permissions := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("scope") != "/project/7" || r.URL.Query().Get("relative") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			respond(http.StatusOK, body)(w, r)
+		}
+	}
+
+	runCheckerTests(t, "harbor", []checkerTestCase{
+		{
+			name: "may push",
+			data: credentials,
+			handler: basicAuthHandler("robot$edp+ci", "secret", map[string]http.HandlerFunc{
+				"GET /api/v2.0/projects/edp": project,
+				"GET /api/v2.0/users/current/permissions": permissions(
+					`[{"resource":"repository","action":"pull"},{"resource":"repository","action":"push"}]`),
+			}),
+		},
+		{
+			name: "may only pull",
+			data: credentials,
+			handler: basicAuthHandler("robot$edp+ci", "secret", map[string]http.HandlerFunc{
+				"GET /api/v2.0/projects/edp":              project,
+				"GET /api/v2.0/users/current/permissions": permissions(`[{"resource":"repository","action":"pull"}]`),
+			}),
+			wantErr: "may not push to Harbor project edp",
+		},
+		{
+			name: "project is not visible",
+			data: credentials,
+			handler: basicAuthHandler("robot$edp+ci", "secret", map[string]http.HandlerFunc{
+				"GET /api/v2.0/projects/edp": respond(http.StatusForbidden, `{"errors":[{"code":"FORBIDDEN"}]}`),
+			}),
+			wantErr: "failed to get Harbor project edp: credentials lack permissions",
+		},
+		{
+			name:    "invalid credentials",
+			data:    credentials,
+			handler: basicAuthHandler("robot$edp+ci", "other", nil),
+			wantErr: "credentials are not accepted",
+		},
+		{
+			name:    "no project",
+			data:    map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+			handler: respond(http.StatusOK, ""),
+			wantErr: "no project key",
+		},
+	})
+}
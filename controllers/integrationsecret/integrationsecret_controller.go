@@ -3,17 +3,15 @@ package integrationsecret
 import (
 	"context"
 	"crypto/tls"
-	"encoding/base64"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"strconv"
-	"strings"
+	"maps"
+	"reflect"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,14 +32,25 @@ const (
 )
 
 type ReconcileIntegrationSecret struct {
-	client client.Client
+	client   client.Client
+	recorder record.EventRecorder
+	// expiryWarning is how long before the credentials expire the TokenExpiring condition is set.
+	expiryWarning time.Duration
 	// Nil means verification against the system trust store, which includes
 	// CAs mounted through the chart's caCerts value. Only tests set it.
 	tlsConfig *tls.Config
 }
 
-func NewReconcileIntegrationSecret(k8sClient client.Client) *ReconcileIntegrationSecret {
-	return &ReconcileIntegrationSecret{client: k8sClient}
+func NewReconcileIntegrationSecret(
+	k8sClient client.Client,
+	recorder record.EventRecorder,
+	expiryWarning time.Duration,
+) *ReconcileIntegrationSecret {
+	return &ReconcileIntegrationSecret{
+		client:        k8sClient,
+		recorder:      recorder,
+		expiryWarning: expiryWarning,
+	}
 }
 
 func (r *ReconcileIntegrationSecret) SetupWithManager(mgr ctrl.Manager) error {
@@ -50,10 +59,11 @@ func (r *ReconcileIntegrationSecret) SetupWithManager(mgr ctrl.Manager) error {
 			return hasIntegrationSecretLabelLabel(event.Object)
 		},
 		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
-			return false
+			return hasIntegrationSecretLabelLabel(deleteEvent.Object)
 		},
 		UpdateFunc: func(updateEvent event.UpdateEvent) bool {
-			return hasIntegrationSecretLabelLabel(updateEvent.ObjectNew)
+			return hasIntegrationSecretLabelLabel(updateEvent.ObjectNew) &&
+				!onlyAnnotationsChanged(updateEvent.ObjectOld, updateEvent.ObjectNew)
 		},
 		GenericFunc: func(genericEvent event.GenericEvent) bool {
 			return hasIntegrationSecretLabelLabel(genericEvent.Object)
@@ -72,7 +82,7 @@ func (r *ReconcileIntegrationSecret) SetupWithManager(mgr ctrl.Manager) error {
 
 // +kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;update;patch
 
-// Reconcile reads secrets with integration-secret label and set connection status to the annotation.
+// Reconcile checks secrets with the integration-secret label and records the result in their annotations.
 func (r *ReconcileIntegrationSecret) Reconcile(
 	ctx context.Context,
 	request reconcile.Request,
@@ -80,6 +90,8 @@ func (r *ReconcileIntegrationSecret) Reconcile(
 	secret := &corev1.Secret{}
 	if err := r.client.Get(ctx, request.NamespacedName, secret); err != nil {
 		if k8sErrors.IsNotFound(err) {
+			deleteMetrics(request.Namespace, request.Name)
+
 			return reconcile.Result{}, nil
 		}
 
@@ -90,21 +102,17 @@ func (r *ReconcileIntegrationSecret) Reconcile(
 
 	log.Info("Start checking connection")
 
-	err := r.checkConnection(ctx, secret)
-	reachable := err == nil
-	errMess := ""
-
-	if err != nil {
-		log.Info("Connection failed", "error", err.Error())
-		errMess = fmt.Sprintf("connection failed: %s", err.Error())
+	info, checkErr := r.checkConnection(ctx, secret)
+	if checkErr != nil {
+		log.Info("Connection failed", "error", checkErr.Error())
 	}
 
-	if err = r.updateConnectionAnnotation(ctx, secret, reachable, errMess); err != nil {
+	if err := r.updateStatus(ctx, secret, info, checkErr, time.Now()); err != nil {
 		return reconcile.Result{}, err
 	}
 
 	requeue := successConnectionRequeueTime
-	if !reachable {
+	if checkErr != nil {
 		requeue = failConnectionRequeueTime
 	}
 
@@ -115,91 +123,16 @@ func (r *ReconcileIntegrationSecret) Reconcile(
 	}, nil
 }
 
-func (r *ReconcileIntegrationSecret) updateConnectionAnnotation(
+// checkConnection checks the secret with the checker registered for its type.
+func (r *ReconcileIntegrationSecret) checkConnection(
 	ctx context.Context,
 	secret *corev1.Secret,
-	reachable bool,
-	errMess string,
-) error {
-	log := ctrl.LoggerFrom(ctx)
-
-	if secret.GetAnnotations()[integrationSecretConnectionAnnotation] != strconv.FormatBool(reachable) ||
-		secret.GetAnnotations()[integrationSecretConnectionAnnotation] != errMess {
-		log.Info("Updating Secret connection status")
-
-		if secret.GetAnnotations() == nil {
-			secret.SetAnnotations(map[string]string{})
-		}
-
-		secret.GetAnnotations()[integrationSecretConnectionAnnotation] = strconv.FormatBool(reachable)
-		delete(secret.GetAnnotations(), integrationSecretErrorAnnotation)
-
-		if errMess != "" {
-			secret.GetAnnotations()[integrationSecretErrorAnnotation] = errMess
-		}
-
-		if err := r.client.Update(ctx, secret); err != nil {
-			return fmt.Errorf("failed to update Secret: %w", err)
-		}
+) (connectionInfo, error) {
+	newRequest := func(baseURL string) *resty.Request {
+		return r.newRequest(ctx, baseURL)
 	}
 
-	return nil
-}
-
-func (r *ReconcileIntegrationSecret) checkConnection(ctx context.Context, secret *corev1.Secret) error {
-	var (
-		path string
-		req  *resty.Request
-	)
-
-	switch secret.GetLabels()[integrationSecretTypeLabel] {
-	case "sonar":
-		path = "/api/system/ping"
-		req = r.newRequest(ctx, string(secret.Data["url"])).SetBasicAuth(string(secret.Data["token"]), "")
-	case "nexus":
-		path = "/service/rest/v1/status"
-		req = r.newRequestWithAuth(ctx, secret)
-	case "dependency-track":
-		path = "/api/v1/team/self"
-		req = r.newRequest(ctx, string(secret.Data["url"])).SetHeader("X-Api-Key", string(secret.Data["token"]))
-	case "defectdojo":
-		path = "/api/v2/user_profile"
-		req = r.newRequest(ctx, string(secret.Data["url"])).
-			SetHeader("Authorization", "Token "+string(secret.Data["token"]))
-	case "registry":
-		return r.checkRegistry(ctx, secret)
-	case "argocd":
-		path = "/api/v1/projects"
-		req = r.newRequest(ctx, string(secret.Data["url"])).
-			SetHeader("Authorization", "Bearer "+string(secret.Data["token"]))
-	default:
-		path = "/"
-		req = r.newRequest(ctx, string(secret.Data["url"]))
-	}
-
-	log := ctrl.LoggerFrom(ctx).WithValues(logKeyUrl, req.URL+path)
-	log.Info("Making request")
-
-	resp, err := req.Get(path)
-	if err != nil {
-		return fmt.Errorf("%w", err)
-	}
-
-	if !resp.IsSuccess() {
-		return fmt.Errorf("http status code %s", resp.Status())
-	}
-
-	return nil
-}
-
-func (r *ReconcileIntegrationSecret) newRequestWithAuth(ctx context.Context, secret *corev1.Secret) *resty.Request {
-	req := r.newRequest(ctx, string(secret.Data["url"]))
-
-	if _, ok := secret.Data["username"]; ok {
-		return req.SetBasicAuth(string(secret.Data["username"]), string(secret.Data["password"]))
-	}
-
-	return req.SetAuthToken(string(secret.Data["token"]))
+	return checkerFor(secret.GetLabels()[integrationSecretTypeLabel]).check(ctx, newRequest, secret)
 }
 
 func (r *ReconcileIntegrationSecret) newRequest(ctx context.Context, url string) *resty.Request {
@@ -214,105 +147,25 @@ func (r *ReconcileIntegrationSecret) newRequest(ctx context.Context, url string)
 	return c.R().SetContext(ctx)
 }
 
-type registryAuth struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-}
-
-type registryConfig struct {
-	Auths map[string]registryAuth `json:"auths"`
-}
-
-func (r *ReconcileIntegrationSecret) checkRegistry(ctx context.Context, secret *corev1.Secret) error {
-	rawConf := secret.Data[".dockerconfigjson"]
-
-	if len(rawConf) == 0 {
-		return fmt.Errorf("no .dockerconfigjson key in secret %s", secret.Name)
-	}
-
-	var conf registryConfig
-	if err := json.Unmarshal(rawConf, &conf); err != nil {
-		return fmt.Errorf("failed to unmarshal .dockerconfigjson: %w", err)
-	}
-
-	for url, auth := range conf.Auths {
-		// for docker hub we need to use custom endpoint
-		// see https://github.com/GoogleContainerTools/kaniko/blob/v1.19.0/README.md?plain=1#L540
-		if url == "https://index.docker.io/v1/" {
-			return r.checkDockerHub(ctx, auth.Username, auth.Password)
-		}
-
-		if !strings.HasPrefix(url, "https://") {
-			url = "https://" + url
-		}
-
-		if strings.HasPrefix(url, "https://ghcr.io") {
-			return r.checkGitHubRegistry(ctx, auth, url)
-		}
-
-		log := ctrl.LoggerFrom(ctx).WithValues(logKeyUrl, url+"/v2/")
-		log.Info("Making request")
-
-		// docker registry specification endpoint
-		// https://github.com/opencontainers/distribution-spec/blob/v1.0.1/spec.md#endpoints
-		resp, err := r.newRequest(ctx, url).SetBasicAuth(auth.Username, auth.Password).Get("/v2/")
-		if err != nil {
-			return fmt.Errorf("%w", err)
-		}
-
-		if !resp.IsSuccess() {
-			return fmt.Errorf("http status code %s", resp.Status())
-		}
-
-		return nil
-	}
-
-	return errors.New("no auths in .dockerconfigjson")
-}
-
-func (r *ReconcileIntegrationSecret) checkDockerHub(ctx context.Context, username, password string) error {
-	log := ctrl.LoggerFrom(ctx).WithValues(logKeyUrl, "https://hub.docker.com/v2")
-	log.Info("Making request")
-
-	resp, err := r.newRequest(ctx, "https://hub.docker.com").
-		SetHeader("Content-Type", "application/json").
-		SetBody(map[string]string{
-			"username": username,
-			"password": password,
-		}).
-		Post("/v2/users/login")
-	if err != nil {
-		return fmt.Errorf("%w", err)
-	}
-
-	if !resp.IsSuccess() {
-		return fmt.Errorf("http status code %s", resp.Status())
-	}
+func hasIntegrationSecretLabelLabel(object client.Object) bool {
+	label := object.GetLabels()[integrationSecretLabel]
 
-	return nil
+	return label == "true"
 }
 
-func (r *ReconcileIntegrationSecret) checkGitHubRegistry(ctx context.Context, auth registryAuth, url string) error {
-	log := ctrl.LoggerFrom(ctx).WithValues(logKeyUrl, url)
-	log.Info("Making request to GitHub registry")
-
-	resp, err := r.newRequest(ctx, url).
-		SetHeader("Content-Type", "application/json").
-		SetAuthToken(base64.StdEncoding.EncodeToString([]byte(auth.Password))).
-		Get("/v2/_catalog")
-	if err != nil {
-		return fmt.Errorf("failed to connect to GitHub registry %w", err)
+// onlyAnnotationsChanged reports whether an update changed nothing but the annotations,
+// e.g. the result of a check. Such updates must not trigger another check.
+func onlyAnnotationsChanged(oldObject, newObject client.Object) bool {
+	oldSecret, ok := oldObject.(*corev1.Secret)
+	if !ok {
+		return false
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("GitHub registry http status code %s", resp.Status())
+	newSecret, ok := newObject.(*corev1.Secret)
+	if !ok {
+		return false
 	}
 
-	return nil
-}
-
-func hasIntegrationSecretLabelLabel(object client.Object) bool {
-	label := object.GetLabels()[integrationSecretLabel]
-
-	return label == "true"
+	return reflect.DeepEqual(oldSecret.Data, newSecret.Data) &&
+		maps.Equal(oldSecret.Labels, newSecret.Labels)
 }
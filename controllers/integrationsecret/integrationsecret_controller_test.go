@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -28,6 +30,7 @@ func TestReconcileIntegrationSecret_Reconcile(t *testing.T) {
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.Contains(r.URL.String(), "success") {
 			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"valid":true}`))
 
 			return
 		}
@@ -442,7 +445,7 @@ func TestReconcileIntegrationSecret_Reconcile(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cl := tt.client(t)
-			r := NewReconcileIntegrationSecret(cl)
+			r := NewReconcileIntegrationSecret(cl, record.NewFakeRecorder(10), 7*24*time.Hour)
 
 			if !tt.systemTrust {
 				r.tlsConfig = serverTLSConfig
@@ -0,0 +1,47 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkJenkins checks that Jenkins authenticates the user with the API token in the token key
+// and grants the Overall/Read permission.
+func checkJenkins(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "url", "username", "token"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	newJenkinsRequest := func() *resty.Request {
+		return newRequest(secretValue(secret, "url")).
+			SetBasicAuth(secretValue(secret, "username"), secretValue(secret, "token"))
+	}
+
+	resp, err := get(ctx, newJenkinsRequest(), "/whoAmI/api/json")
+	if err != nil {
+		return connectionInfo{}, err
+	}
+
+	var whoAmI struct {
+		Anonymous bool `json:"anonymous"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &whoAmI); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Jenkins user: %w", err)
+	}
+
+	if whoAmI.Anonymous {
+		return connectionInfo{}, errors.New("Jenkins treats the credentials as anonymous")
+	}
+
+	if _, err = get(ctx, newJenkinsRequest().SetQueryParam("tree", "mode"), "/api/json"); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to check the Jenkins Overall/Read permission: %w", err)
+	}
+
+	return connectionInfo{}, nil
+}
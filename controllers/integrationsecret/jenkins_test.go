@@ -0,0 +1,46 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckJenkins(t *testing.T) {
+	t.Parallel()
+
+	credentials := map[string][]byte{"username": []byte("ci"), "token": []byte("api-token")}
+
+	runCheckerTests(t, "jenkins", []checkerTestCase{
+		{
+			name: "may read",
+			data: credentials,
+			handler: basicAuthHandler("ci", "api-token", map[string]http.HandlerFunc{
+				"GET /whoAmI/api/json": respond(http.StatusOK, `{"anonymous":false,"authenticated":true,"name":"ci"}`),
+				"GET /api/json":        respond(http.StatusOK, `{"mode":"NORMAL"}`),
+			}),
+		},
+		{
+			name: "lacks overall read",
+			data: credentials,
+			handler: basicAuthHandler("ci", "api-token", map[string]http.HandlerFunc{
+				"GET /whoAmI/api/json": respond(http.StatusOK, `{"anonymous":false,"authenticated":true,"name":"ci"}`),
+				"GET /api/json":        respond(http.StatusForbidden, ""),
+			}),
+			wantErr: "Overall/Read permission: credentials lack permissions",
+		},
+		{
+			name: "anonymous",
+			data: credentials,
+			handler: basicAuthHandler("ci", "api-token", map[string]http.HandlerFunc{
+				"GET /whoAmI/api/json": respond(http.StatusOK, `{"anonymous":true,"authenticated":true,"name":"anonymous"}`),
+			}),
+			wantErr: "as anonymous",
+		},
+		{
+			name:    "invalid token",
+			data:    credentials,
+			handler: basicAuthHandler("ci", "other", nil),
+			wantErr: "credentials are not accepted",
+		},
+	})
+}
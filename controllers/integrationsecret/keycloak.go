@@ -0,0 +1,79 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultKeycloakRealm    = "master"
+	defaultKeycloakClientID = "admin-cli"
+)
+
+// checkKeycloak checks that the credentials obtain a token in the realm and may view its
+// clients through the admin API. It logs in with username and password through clientId
+// (admin-cli by default), or with the client credentials grant if the secret has clientSecret.
+// The url key must include the /auth path on Keycloak versions that serve it.
+func checkKeycloak(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "url"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	realm := secretValue(secret, "realm")
+	if realm == "" {
+		realm = defaultKeycloakRealm
+	}
+
+	clientID := secretValue(secret, "clientId")
+	if clientID == "" {
+		clientID = defaultKeycloakClientID
+	}
+
+	form := map[string]string{"client_id": clientID}
+
+	if clientSecret := secretValue(secret, "clientSecret"); clientSecret != "" {
+		form["grant_type"] = "client_credentials"
+		form["client_secret"] = clientSecret
+	} else {
+		if err := requireKeys(secret, "username", "password"); err != nil {
+			return connectionInfo{}, err
+		}
+
+		form["grant_type"] = "password"
+		form["username"] = secretValue(secret, "username")
+		form["password"] = secretValue(secret, "password")
+	}
+
+	baseURL := secretValue(secret, "url")
+
+	resp, err := send(ctx, newRequest(baseURL).SetFormData(form), http.MethodPost,
+		"/realms/"+url.PathEscape(realm)+"/protocol/openid-connect/token")
+	if err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to log in to Keycloak realm %s: %w", realm, err)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &token); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Keycloak token: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return connectionInfo{}, errors.New("Keycloak returned no access token")
+	}
+
+	if _, err = get(ctx, newRequest(baseURL).SetAuthToken(token.AccessToken).SetQueryParam("max", "1"),
+		"/admin/realms/"+url.PathEscape(realm)+"/clients"); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to list clients of Keycloak realm %s: %w", realm, err)
+	}
+
+	return connectionInfo{}, nil
+}
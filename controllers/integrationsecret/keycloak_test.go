@@ -0,0 +1,81 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckKeycloak(t *testing.T) {
+	t.Parallel()
+
+	keycloak := func(clients http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			serveRoute(w, r, map[string]http.HandlerFunc{
+				"POST /realms/master/protocol/openid-connect/token": func(w http.ResponseWriter, r *http.Request) {
+					if err := r.ParseForm(); err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+
+						return
+					}
+
+					valid := r.PostForm.Get("grant_type") == "password" &&
+						r.PostForm.Get("client_id") == "admin-cli" &&
+						r.PostForm.Get("username") == "admin" && r.PostForm.Get("password") == "secret" ||
+						r.PostForm.Get("grant_type") == "client_credentials" &&
+							r.PostForm.Get("client_id") == "edp" && r.PostForm.Get("client_secret") == "secret"
+
+					if !valid {
+						respond(http.StatusUnauthorized, `{"error":"invalid_grant"}`)(w, r)
+
+						return
+					}
+
+					respond(http.StatusOK, `{"access_token":"access-token","token_type":"Bearer"}`)(w, r)
+				},
+				"GET /admin/realms/master/clients": func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("Authorization") != "Bearer access-token" {
+						w.WriteHeader(http.StatusUnauthorized)
+
+						return
+					}
+
+					clients(w, r)
+				},
+			})
+		}
+	}
+
+	runCheckerTests(t, "keycloak", []checkerTestCase{
+		{
+			name:    "admin user",
+			data:    map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+			handler: keycloak(respond(http.StatusOK, `[{"clientId":"account"}]`)),
+		},
+		{
+			name: "client credentials",
+			data: map[string][]byte{
+				"clientId":     []byte("edp"),
+				"clientSecret": []byte("secret"),
+				"realm":        []byte("master"),
+			},
+			handler: keycloak(respond(http.StatusOK, `[]`)),
+		},
+		{
+			name:    "invalid password",
+			data:    map[string][]byte{"username": []byte("admin"), "password": []byte("wrong")},
+			handler: keycloak(respond(http.StatusOK, `[]`)),
+			wantErr: "failed to log in to Keycloak realm master: credentials are not accepted",
+		},
+		{
+			name:    "may not view clients",
+			data:    map[string][]byte{"username": []byte("admin"), "password": []byte("secret")},
+			handler: keycloak(respond(http.StatusForbidden, `{"error":"unknown_error"}`)),
+			wantErr: "failed to list clients of Keycloak realm master: credentials lack permissions",
+		},
+		{
+			name:    "no credentials",
+			handler: keycloak(respond(http.StatusOK, `[]`)),
+			wantErr: "no username key",
+		},
+	})
+}
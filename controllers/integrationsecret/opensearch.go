@@ -0,0 +1,35 @@
+package integrationsecret
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkOpenSearch checks that the security plugin authenticates the user and that the user
+// may read the cluster health. Clusters without the security plugin are only checked for
+// the cluster health.
+func checkOpenSearch(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "url", "username", "password"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	newOpenSearchRequest := func() *resty.Request {
+		return newRequest(secretValue(secret, "url")).
+			SetBasicAuth(secretValue(secret, "username"), secretValue(secret, "password"))
+	}
+
+	resp, err := get(ctx, newOpenSearchRequest(), "/_plugins/_security/authinfo")
+	if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+		return connectionInfo{}, fmt.Errorf("failed to authenticate to OpenSearch: %w", err)
+	}
+
+	if _, err = get(ctx, newOpenSearchRequest(), "/_cluster/health"); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to read OpenSearch cluster health: %w", err)
+	}
+
+	return connectionInfo{}, nil
+}
@@ -0,0 +1,45 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckOpenSearch(t *testing.T) {
+	t.Parallel()
+
+	credentials := map[string][]byte{"username": []byte("edp"), "password": []byte("secret")}
+
+	runCheckerTests(t, "opensearch", []checkerTestCase{
+		{
+			name: "may read cluster health",
+			data: credentials,
+			handler: basicAuthHandler("edp", "secret", map[string]http.HandlerFunc{
+				"GET /_plugins/_security/authinfo": respond(http.StatusOK, `{"user_name":"edp","roles":["readall"]}`),
+				"GET /_cluster/health":             respond(http.StatusOK, `{"status":"green"}`),
+			}),
+		},
+		{
+			name: "without the security plugin",
+			data: credentials,
+			handler: basicAuthHandler("edp", "secret", map[string]http.HandlerFunc{
+				"GET /_cluster/health": respond(http.StatusOK, `{"status":"green"}`),
+			}),
+		},
+		{
+			name: "lacks cluster monitoring",
+			data: credentials,
+			handler: basicAuthHandler("edp", "secret", map[string]http.HandlerFunc{
+				"GET /_plugins/_security/authinfo": respond(http.StatusOK, `{"user_name":"edp"}`),
+				"GET /_cluster/health":             respond(http.StatusForbidden, `{"error":"no permissions"}`),
+			}),
+			wantErr: "failed to read OpenSearch cluster health: credentials lack permissions",
+		},
+		{
+			name:    "invalid password",
+			data:    credentials,
+			handler: basicAuthHandler("edp", "other", nil),
+			wantErr: "failed to authenticate to OpenSearch: credentials are not accepted",
+		},
+	})
+}
@@ -0,0 +1,117 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var ecrHostPattern = regexp.MustCompile(`^\d{12}\.dkr\.ecr\.[a-z0-9-]+\.amazonaws\.com(\.cn)?(/|$)`)
+
+type registryAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type registryConfig struct {
+	Auths map[string]registryAuth `json:"auths"`
+}
+
+func checkRegistry(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	rawConf := secret.Data[".dockerconfigjson"]
+
+	if len(rawConf) == 0 {
+		return connectionInfo{}, fmt.Errorf("no .dockerconfigjson key in secret %s", secret.Name)
+	}
+
+	var conf registryConfig
+	if err := json.Unmarshal(rawConf, &conf); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to unmarshal .dockerconfigjson: %w", err)
+	}
+
+	for url, auth := range conf.Auths {
+		// for docker hub we need to use custom endpoint
+		// see https://github.com/GoogleContainerTools/kaniko/blob/v1.19.0/README.md?plain=1#L540
+		if url == "https://index.docker.io/v1/" {
+			return checkDockerHub(ctx, newRequest, auth.Username, auth.Password)
+		}
+
+		if !strings.HasPrefix(url, "https://") {
+			url = "https://" + url
+		}
+
+		if strings.HasPrefix(url, "https://ghcr.io") {
+			return checkGitHubRegistry(ctx, newRequest, auth, url)
+		}
+
+		// docker registry specification endpoint
+		// https://github.com/opencontainers/distribution-spec/blob/v1.0.1/spec.md#endpoints
+		if _, err := get(ctx, newRequest(url).SetBasicAuth(auth.Username, auth.Password), "/v2/"); err != nil {
+			return connectionInfo{}, err
+		}
+
+		return connectionInfo{ExpiresAt: ecrTokenExpiry(url, auth.Password)}, nil
+	}
+
+	return connectionInfo{}, errors.New("no auths in .dockerconfigjson")
+}
+
+func checkDockerHub(ctx context.Context, newRequest requestFunc, username, password string) (connectionInfo, error) {
+	_, err := send(ctx, newRequest("https://hub.docker.com").
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]string{
+			"username": username,
+			"password": password,
+		}), http.MethodPost, "/v2/users/login")
+
+	return connectionInfo{}, err
+}
+
+func checkGitHubRegistry(
+	ctx context.Context,
+	newRequest requestFunc,
+	auth registryAuth,
+	url string,
+) (connectionInfo, error) {
+	_, err := get(ctx, newRequest(url).
+		SetHeader("Content-Type", "application/json").
+		SetAuthToken(base64.StdEncoding.EncodeToString([]byte(auth.Password))),
+		"/v2/_catalog")
+	if err != nil {
+		return connectionInfo{}, fmt.Errorf("GitHub registry: %w", err)
+	}
+
+	return connectionInfo{}, nil
+}
+
+// ecrTokenExpiry returns the expiration time of the password of an Amazon ECR registry.
+// It is a token valid for 12 hours, the base64 encoding of a JSON document that includes
+// the expiration time.
+func ecrTokenExpiry(url, password string) time.Time {
+	if !ecrHostPattern.MatchString(strings.TrimPrefix(url, "https://")) {
+		return time.Time{}
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(password)
+	if err != nil {
+		return time.Time{}
+	}
+
+	var token struct {
+		Expiration int64 `json:"expiration"`
+	}
+
+	if err = json.Unmarshal(raw, &token); err != nil || token.Expiration == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(token.Expiration, 0).UTC()
+}
@@ -0,0 +1,54 @@
+package integrationsecret
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ecrTokenExpiry(t *testing.T) {
+	t.Parallel()
+
+	ecrPassword := base64.StdEncoding.EncodeToString(
+		[]byte(`{"payload":"cGF5bG9hZA==","datakey":"ZGF0YWtleQ==","version":"2","type":"DATA_KEY","expiration":1792411200}`))
+
+	tests := []struct {
+		name     string
+		url      string
+		password string
+		want     time.Time
+	}{
+		{
+			name:     "ECR token",
+			url:      "https://123456789012.dkr.ecr.eu-central-1.amazonaws.com",
+			password: ecrPassword,
+			want:     time.Unix(1792411200, 0).UTC(),
+		},
+		{
+			name:     "ECR in China",
+			url:      "https://123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn",
+			password: ecrPassword,
+			want:     time.Unix(1792411200, 0).UTC(),
+		},
+		{
+			name:     "other registry",
+			url:      "https://registry.example.com",
+			password: ecrPassword,
+		},
+		{
+			name:     "not a token",
+			url:      "https://123456789012.dkr.ecr.eu-central-1.amazonaws.com",
+			password: "password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, tt.want.Equal(ecrTokenExpiry(tt.url, tt.password)))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sonarTokenExpirationHeader is set by SonarQube 9.6 and newer on responses to requests
+// authenticated with a token that expires.
+const sonarTokenExpirationHeader = "SonarQube-Authentication-Token-Expiration"
+
+// checkSonar checks that SonarQube is up and accepts the token.
+func checkSonar(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	newSonarRequest := func() *resty.Request {
+		return newRequest(secretValue(secret, "url")).SetBasicAuth(secretValue(secret, "token"), "")
+	}
+
+	if _, err := get(ctx, newSonarRequest(), "/api/system/ping"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	resp, err := get(ctx, newSonarRequest(), "/api/authentication/validate")
+	if err != nil {
+		return connectionInfo{}, err
+	}
+
+	var validation struct {
+		Valid bool `json:"valid"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &validation); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode SonarQube authentication: %w", err)
+	}
+
+	if !validation.Valid {
+		return connectionInfo{}, errors.New("SonarQube does not accept the token")
+	}
+
+	info := connectionInfo{}
+
+	if expiration := resp.Header().Get(sonarTokenExpirationHeader); expiration != "" {
+		info.ExpiresAt, err = parseExpiry(expiration, time.DateOnly, time.RFC3339, "2006-01-02T15:04:05-0700")
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to parse SonarQube token expiration")
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,44 @@
+package integrationsecret
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCheckSonar(t *testing.T) {
+	t.Parallel()
+
+	sonar := func(valid string, expiration string) http.HandlerFunc {
+		return basicAuthHandler("squ_token", "", map[string]http.HandlerFunc{
+			"GET /api/system/ping": respond(http.StatusOK, "pong"),
+			"GET /api/authentication/validate": func(w http.ResponseWriter, r *http.Request) {
+				if expiration != "" {
+					w.Header().Set(sonarTokenExpirationHeader, expiration)
+				}
+
+				respond(http.StatusOK, `{"valid":`+valid+`}`)(w, r)
+			},
+		})
+	}
+
+	runCheckerTests(t, "sonar", []checkerTestCase{
+		{
+			name:    "token without expiration",
+			data:    map[string][]byte{"token": []byte("squ_token")},
+			handler: sonar("true", ""),
+		},
+		{
+			name:          "expiring token",
+			data:          map[string][]byte{"token": []byte("squ_token")},
+			handler:       sonar("true", "2026-11-01"),
+			wantExpiresAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "token is not valid",
+			data:    map[string][]byte{"token": []byte("squ_token")},
+			handler: sonar("false", ""),
+			wantErr: "SonarQube does not accept the token",
+		},
+	})
+}
@@ -0,0 +1,207 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	integrationSecretLastCheckedAnnotation = "app.edp.epam.com/integration-secret-last-checked"
+	integrationSecretExpiresAtAnnotation   = "app.edp.epam.com/integration-secret-expires-at"
+	integrationSecretConditionsAnnotation  = "app.edp.epam.com/integration-secret-conditions"
+
+	// ConditionConnected reports whether the last check of the integration secret succeeded.
+	ConditionConnected = "Connected"
+	// ConditionTokenExpiring reports whether the credentials expire within the warning period.
+	// It is only set when the tool reports when the credentials expire.
+	ConditionTokenExpiring = "TokenExpiring"
+
+	reasonConnectionSucceeded = "ConnectionSucceeded"
+	reasonConnectionFailed    = "ConnectionFailed"
+	reasonTokenExpiresSoon    = "TokenExpiresSoon"
+	reasonTokenExpired        = "TokenExpired"
+	reasonTokenValid          = "TokenValid"
+
+	EventReasonConnectionFailed   = "IntegrationConnectionFailed"
+	EventReasonConnectionRestored = "IntegrationConnectionRestored"
+	EventReasonTokenExpiring      = "IntegrationTokenExpiring"
+)
+
+var (
+	integrationConnected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "codebase_operator_integration_secret_connected",
+		Help: "Whether the last check of an integration secret succeeded (1) or failed (0).",
+	}, []string{"namespace", "secret", "type"})
+
+	integrationTokenExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "codebase_operator_integration_secret_token_expiry_timestamp_seconds",
+		Help: "Time the credentials of an integration secret expire at, if the tool reports it.",
+	}, []string{"namespace", "secret", "type"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(integrationConnected, integrationTokenExpiry)
+}
+
+// updateStatus records the result of a check in the annotations of the secret, emits events
+// on transitions and exports the result as metrics.
+func (r *ReconcileIntegrationSecret) updateStatus(
+	ctx context.Context,
+	secret *corev1.Secret,
+	info connectionInfo,
+	checkErr error,
+	now time.Time,
+) error {
+	secretType := secret.GetLabels()[integrationSecretTypeLabel]
+	conditions := readConditions(secret)
+	previousConnected := metav1.ConditionUnknown
+
+	if c := meta.FindStatusCondition(conditions, ConditionConnected); c != nil {
+		previousConnected = c.Status
+	}
+
+	previousExpiring := meta.IsStatusConditionTrue(conditions, ConditionTokenExpiring)
+
+	connected := metav1.Condition{
+		Type:    ConditionConnected,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonConnectionSucceeded,
+		Message: "Connection succeeded",
+	}
+
+	if checkErr != nil {
+		connected.Status = metav1.ConditionFalse
+		connected.Reason = reasonConnectionFailed
+		connected.Message = checkErr.Error()
+	}
+
+	meta.SetStatusCondition(&conditions, connected)
+
+	switch {
+	case checkErr != nil && previousConnected != metav1.ConditionFalse:
+		r.recorder.Eventf(secret, corev1.EventTypeWarning, EventReasonConnectionFailed,
+			"Connection to %s failed: %s", secretType, checkErr.Error())
+	case checkErr == nil && previousConnected == metav1.ConditionFalse:
+		r.recorder.Eventf(secret, corev1.EventTypeNormal, EventReasonConnectionRestored,
+			"Connection to %s succeeded again", secretType)
+	}
+
+	patch := client.MergeFrom(secret.DeepCopy())
+
+	if secret.GetAnnotations() == nil {
+		secret.SetAnnotations(map[string]string{})
+	}
+
+	annotations := secret.GetAnnotations()
+	labels := prometheus.Labels{"namespace": secret.Namespace, "secret": secret.Name, "type": secretType}
+
+	if info.ExpiresAt.IsZero() {
+		meta.RemoveStatusCondition(&conditions, ConditionTokenExpiring)
+		delete(annotations, integrationSecretExpiresAtAnnotation)
+		integrationTokenExpiry.Delete(labels)
+	} else {
+		expiring := r.expiringCondition(info.ExpiresAt, now)
+		meta.SetStatusCondition(&conditions, expiring)
+		annotations[integrationSecretExpiresAtAnnotation] = info.ExpiresAt.UTC().Format(time.RFC3339)
+		integrationTokenExpiry.With(labels).Set(float64(info.ExpiresAt.Unix()))
+
+		if expiring.Status == metav1.ConditionTrue && !previousExpiring {
+			r.recorder.Event(secret, corev1.EventTypeWarning, EventReasonTokenExpiring, expiring.Message)
+		}
+	}
+
+	rawConditions, err := json.Marshal(conditions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal conditions: %w", err)
+	}
+
+	annotations[integrationSecretConnectionAnnotation] = strconv.FormatBool(checkErr == nil)
+	annotations[integrationSecretLastCheckedAnnotation] = now.UTC().Format(time.RFC3339)
+	annotations[integrationSecretConditionsAnnotation] = string(rawConditions)
+	delete(annotations, integrationSecretErrorAnnotation)
+
+	if checkErr != nil {
+		annotations[integrationSecretErrorAnnotation] = fmt.Sprintf("connection failed: %s", checkErr.Error())
+	}
+
+	integrationConnected.With(labels).Set(boolToFloat(checkErr == nil))
+
+	if err = r.client.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("failed to update Secret: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconcileIntegrationSecret) expiringCondition(expiresAt, now time.Time) metav1.Condition {
+	left := expiresAt.Sub(now)
+
+	switch {
+	case left <= 0:
+		return metav1.Condition{
+			Type:    ConditionTokenExpiring,
+			Status:  metav1.ConditionTrue,
+			Reason:  reasonTokenExpired,
+			Message: fmt.Sprintf("Token expired at %s", expiresAt.UTC().Format(time.RFC3339)),
+		}
+	case left <= r.expiryWarning:
+		return metav1.Condition{
+			Type:   ConditionTokenExpiring,
+			Status: metav1.ConditionTrue,
+			Reason: reasonTokenExpiresSoon,
+			Message: fmt.Sprintf("Token expires at %s, in %d days",
+				expiresAt.UTC().Format(time.RFC3339), int(math.Ceil(left.Hours()/24))),
+		}
+	default:
+		return metav1.Condition{
+			Type:    ConditionTokenExpiring,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonTokenValid,
+			Message: fmt.Sprintf("Token expires at %s", expiresAt.UTC().Format(time.RFC3339)),
+		}
+	}
+}
+
+// readConditions returns the conditions of the last check. Malformed annotations are
+// treated as no conditions, so that they are rewritten by the check.
+func readConditions(secret *corev1.Secret) []metav1.Condition {
+	var conditions []metav1.Condition
+
+	raw := secret.GetAnnotations()[integrationSecretConditionsAnnotation]
+	if raw == "" {
+		return conditions
+	}
+
+	if err := json.Unmarshal([]byte(raw), &conditions); err != nil {
+		return nil
+	}
+
+	return conditions
+}
+
+// deleteMetrics removes the metrics of a deleted secret.
+func deleteMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "secret": name}
+
+	integrationConnected.DeletePartialMatch(labels)
+	integrationTokenExpiry.DeletePartialMatch(labels)
+}
+
+func boolToFloat(v bool) float64 {
+	if v {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,207 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileIntegrationSecret_updateStatus(t *testing.T) {
+	t.Parallel()
+
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	conditionsAnnotation := func(conditions ...metav1.Condition) map[string]string {
+		raw, err := json.Marshal(conditions)
+		require.NoError(t, err)
+
+		return map[string]string{integrationSecretConditionsAnnotation: string(raw)}
+	}
+
+	failed := metav1.Condition{Type: ConditionConnected, Status: metav1.ConditionFalse, Reason: reasonConnectionFailed}
+	expiring := metav1.Condition{Type: ConditionTokenExpiring, Status: metav1.ConditionTrue, Reason: reasonTokenExpiresSoon}
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		info            connectionInfo
+		checkErr        error
+		wantConnected   metav1.ConditionStatus
+		wantExpiring    *metav1.Condition
+		wantExpiresAt   string
+		wantError       string
+		wantEventReason string
+	}{
+		{
+			name:            "first failure",
+			checkErr:        errors.New("credentials are not accepted: http status code 401 Unauthorized"),
+			wantConnected:   metav1.ConditionFalse,
+			wantError:       "connection failed: credentials are not accepted",
+			wantEventReason: EventReasonConnectionFailed,
+		},
+		{
+			name:          "failure persists",
+			annotations:   conditionsAnnotation(failed),
+			checkErr:      errors.New("http status code 503 Service Unavailable"),
+			wantConnected: metav1.ConditionFalse,
+			wantError:     "connection failed: http status code 503",
+		},
+		{
+			name:            "connection restored",
+			annotations:     conditionsAnnotation(failed),
+			wantConnected:   metav1.ConditionTrue,
+			wantEventReason: EventReasonConnectionRestored,
+		},
+		{
+			name:          "token expires soon",
+			info:          connectionInfo{ExpiresAt: now.Add(72 * time.Hour)},
+			wantConnected: metav1.ConditionTrue,
+			wantExpiring: &metav1.Condition{
+				Type:    ConditionTokenExpiring,
+				Status:  metav1.ConditionTrue,
+				Reason:  reasonTokenExpiresSoon,
+				Message: "Token expires at 2026-10-22T10:00:00Z, in 3 days",
+			},
+			wantExpiresAt:   "2026-10-22T10:00:00Z",
+			wantEventReason: EventReasonTokenExpiring,
+		},
+		{
+			name: "token expiry was already reported",
+			annotations: conditionsAnnotation(
+				metav1.Condition{Type: ConditionConnected, Status: metav1.ConditionTrue, Reason: reasonConnectionSucceeded},
+				expiring,
+			),
+			info:          connectionInfo{ExpiresAt: now.Add(48 * time.Hour)},
+			wantConnected: metav1.ConditionTrue,
+			wantExpiring: &metav1.Condition{
+				Type:    ConditionTokenExpiring,
+				Status:  metav1.ConditionTrue,
+				Reason:  reasonTokenExpiresSoon,
+				Message: "Token expires at 2026-10-21T10:00:00Z, in 2 days",
+			},
+			wantExpiresAt: "2026-10-21T10:00:00Z",
+		},
+		{
+			name:          "token expires later",
+			info:          connectionInfo{ExpiresAt: now.Add(30 * 24 * time.Hour)},
+			wantConnected: metav1.ConditionTrue,
+			wantExpiring: &metav1.Condition{
+				Type:    ConditionTokenExpiring,
+				Status:  metav1.ConditionFalse,
+				Reason:  reasonTokenValid,
+				Message: "Token expires at 2026-11-18T10:00:00Z",
+			},
+			wantExpiresAt: "2026-11-18T10:00:00Z",
+		},
+		{
+			name:          "expiry is no longer reported",
+			annotations:   conditionsAnnotation(expiring),
+			wantConnected: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			annotations := map[string]string{integrationSecretExpiresAtAnnotation: "2026-10-20T00:00:00Z"}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "sonar",
+					Namespace:   "default",
+					Labels:      map[string]string{integrationSecretTypeLabel: "sonar"},
+					Annotations: annotations,
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(secret).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewReconcileIntegrationSecret(cl, recorder, 7*24*time.Hour)
+
+			require.NoError(t, r.updateStatus(context.Background(), secret, tt.info, tt.checkErr, now))
+
+			got := &corev1.Secret{}
+			require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(secret), got))
+
+			assert.Equal(t, "2026-10-19T10:00:00Z", got.Annotations[integrationSecretLastCheckedAnnotation])
+			assert.Equal(t, tt.wantError, truncate(got.Annotations[integrationSecretErrorAnnotation], len(tt.wantError)))
+			assert.Equal(t, tt.wantExpiresAt, got.Annotations[integrationSecretExpiresAtAnnotation])
+			assert.Equal(t, string(tt.wantConnected) == "True",
+				got.Annotations[integrationSecretConnectionAnnotation] == "true")
+
+			conditions := readConditions(got)
+			assert.True(t, meta.IsStatusConditionPresentAndEqual(conditions, ConditionConnected, tt.wantConnected))
+
+			gotExpiring := meta.FindStatusCondition(conditions, ConditionTokenExpiring)
+			if tt.wantExpiring == nil {
+				assert.Nil(t, gotExpiring)
+			} else {
+				require.NotNil(t, gotExpiring)
+				assert.Equal(t, tt.wantExpiring.Status, gotExpiring.Status)
+				assert.Equal(t, tt.wantExpiring.Reason, gotExpiring.Reason)
+				assert.Equal(t, tt.wantExpiring.Message, gotExpiring.Message)
+			}
+
+			if tt.wantEventReason == "" {
+				assert.Empty(t, recorder.Events)
+
+				return
+			}
+
+			require.Len(t, recorder.Events, 1)
+			assert.Contains(t, <-recorder.Events, tt.wantEventReason)
+		})
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+
+	return s
+}
+
+func Test_onlyAnnotationsChanged(t *testing.T) {
+	t.Parallel()
+
+	base := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "sonar",
+			Labels: map[string]string{integrationSecretLabel: "true"},
+		},
+		Data: map[string][]byte{"token": []byte("token")},
+	}
+
+	annotated := base.DeepCopy()
+	annotated.Annotations = map[string]string{integrationSecretLastCheckedAnnotation: "2026-10-19T10:00:00Z"}
+
+	rotated := base.DeepCopy()
+	rotated.Data["token"] = []byte("new-token")
+
+	relabeled := base.DeepCopy()
+	relabeled.Labels[integrationSecretTypeLabel] = "sonar"
+
+	assert.True(t, onlyAnnotationsChanged(base, annotated))
+	assert.False(t, onlyAnnotationsChanged(base, rotated))
+	assert.False(t, onlyAnnotationsChanged(base, relabeled))
+}
@@ -0,0 +1,61 @@
+package integrationsecret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/go-resty/resty/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkVault checks that the token is valid and, if the secret has a path key, that it may
+// read that path. The namespace key selects a Vault Enterprise namespace.
+func checkVault(ctx context.Context, newRequest requestFunc, secret *corev1.Secret) (connectionInfo, error) {
+	if err := requireKeys(secret, "url", "token"); err != nil {
+		return connectionInfo{}, err
+	}
+
+	newVaultRequest := func() *resty.Request {
+		req := newRequest(secretValue(secret, "url")).SetHeader("X-Vault-Token", secretValue(secret, "token"))
+
+		if namespace := secretValue(secret, "namespace"); namespace != "" {
+			req.SetHeader("X-Vault-Namespace", namespace)
+		}
+
+		return req
+	}
+
+	if _, err := get(ctx, newVaultRequest(), "/v1/auth/token/lookup-self"); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to look up Vault token: %w", err)
+	}
+
+	path := secretValue(secret, "path")
+	if path == "" {
+		return connectionInfo{}, nil
+	}
+
+	resp, err := send(ctx, newVaultRequest().SetBody(map[string][]string{"paths": {path}}),
+		http.MethodPost, "/v1/sys/capabilities-self")
+	if err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to get Vault token capabilities: %w", err)
+	}
+
+	var capabilities map[string]json.RawMessage
+	if err = json.Unmarshal(resp.Body(), &capabilities); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Vault token capabilities: %w", err)
+	}
+
+	var pathCapabilities []string
+	if err = json.Unmarshal(capabilities[path], &pathCapabilities); err != nil {
+		return connectionInfo{}, fmt.Errorf("failed to decode Vault capabilities of %s: %w", path, err)
+	}
+
+	if !slices.Contains(pathCapabilities, "read") && !slices.Contains(pathCapabilities, "root") {
+		return connectionInfo{}, fmt.Errorf("token may not read Vault path %s", path)
+	}
+
+	return connectionInfo{}, nil
+}
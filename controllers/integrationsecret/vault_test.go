@@ -0,0 +1,68 @@
+package integrationsecret
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCheckVault(t *testing.T) {
+	t.Parallel()
+
+	vault := func(capabilities []string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "hvs.token" {
+				respond(http.StatusForbidden, `{"errors":["permission denied"]}`)(w, r)
+
+				return
+			}
+
+			serveRoute(w, r, map[string]http.HandlerFunc{
+				"GET /v1/auth/token/lookup-self": respond(http.StatusOK, `{"data":{"policies":["edp"],"ttl":3600}}`),
+				"POST /v1/sys/capabilities-self": func(w http.ResponseWriter, r *http.Request) {
+					var body struct {
+						Paths []string `json:"paths"`
+					}
+
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Paths) != 1 {
+						w.WriteHeader(http.StatusBadRequest)
+
+						return
+					}
+
+					resp, _ := json.Marshal(map[string][]string{
+						"capabilities": capabilities,
+						body.Paths[0]:  capabilities,
+					})
+
+					respond(http.StatusOK, string(resp))(w, r)
+				},
+			})
+		}
+	}
+
+	runCheckerTests(t, "vault", []checkerTestCase{
+		{
+			name:    "valid token",
+			data:    map[string][]byte{"token": []byte("hvs.token")},
+			handler: vault(nil),
+		},
+		{
+			name:    "may read path",
+			data:    map[string][]byte{"token": []byte("hvs.token"), "path": []byte("secret/data/edp")},
+			handler: vault([]string{"read", "list"}),
+		},
+		{
+			name:    "may not read path",
+			data:    map[string][]byte{"token": []byte("hvs.token"), "path": []byte("secret/data/edp")},
+			handler: vault([]string{"deny"}),
+			wantErr: "token may not read Vault path secret/data/edp",
+		},
+		{
+			name:    "invalid token",
+			data:    map[string][]byte{"token": []byte("wrong")},
+			handler: vault(nil),
+			wantErr: "failed to look up Vault token: credentials lack permissions",
+		},
+	})
+}
@@ -29,8 +29,7 @@ func (h ApplyTagsToIssues) ServeRequest(ctx context.Context, metadata *codebaseA
 	body := createRequestBody(requestPayload)
 	for _, ticket := range metadata.Spec.Tickets {
 		if err := h.client.ApplyTagsToIssue(ticket, body); err != nil {
-			metadata.Status.ErrorStrings = append(metadata.Status.ErrorStrings,
-				fmt.Sprintf("failed to apply tags to issue %s, err: %v", ticket, err))
+			addTicketError(metadata, ticket, fmt.Sprintf("failed to apply tags to issue %s, err: %v", ticket, err))
 		}
 	}
 
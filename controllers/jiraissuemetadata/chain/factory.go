@@ -10,6 +10,7 @@ import (
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/jiraissuemetadata/chain/handler"
 	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/issuetracker"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
 
@@ -33,26 +34,54 @@ func CreateChain(
 }
 
 func createDefChain(jiraClient jira.Client, c client.Client) handler.JiraIssueMetadataHandler {
-	return PutTagValue{
-		next: ApplyTagsToIssues{
+	return RecordTagTickets{
+		next: RecordReleaseChanges{
+			next: PutTagValue{
+				next: ApplyTagsToIssues{
+					next: PutIssueWebLink{
+						next: DeleteJiraIssueMetadataCr{
+							c: c,
+						},
+						client: jiraClient,
+					},
+					client: jiraClient,
+				},
+				client: jiraClient,
+			},
+			c: c,
+		},
+		c: c,
+	}
+}
+
+func createWithoutApplyingTagsChain(jiraClient jira.Client, c client.Client) handler.JiraIssueMetadataHandler {
+	return RecordTagTickets{
+		next: RecordReleaseChanges{
 			next: PutIssueWebLink{
 				next: DeleteJiraIssueMetadataCr{
 					c: c,
 				},
 				client: jiraClient,
 			},
-			client: jiraClient,
+			c: c,
 		},
-		client: jiraClient,
+		c: c,
 	}
 }
 
-func createWithoutApplyingTagsChain(jiraClient jira.Client, c client.Client) handler.JiraIssueMetadataHandler {
-	return PutIssueWebLink{
-		next: DeleteJiraIssueMetadataCr{
+// CreateTrackerChain creates the chain for codebases that use GitHub or GitLab issues.
+func CreateTrackerChain(tracker issuetracker.Tracker, c client.Client) handler.JiraIssueMetadataHandler {
+	return RecordTagTickets{
+		next: RecordReleaseChanges{
+			next: UpdateTrackerIssues{
+				next: DeleteJiraIssueMetadataCr{
+					c: c,
+				},
+				tracker: tracker,
+			},
 			c: c,
 		},
-		client: jiraClient,
+		c: c,
 	}
 }
 
@@ -0,0 +1,85 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+// addTicketError records the error of the ticket and keeps the ticket pending,
+// so that the next reconciliation updates only the tickets that failed.
+func addTicketError(metadata *codebaseApi.JiraIssueMetadata, ticket, msg string) {
+	metadata.Status.ErrorStrings = append(metadata.Status.ErrorStrings, msg)
+
+	if !slices.Contains(metadata.Status.PendingTickets, ticket) {
+		metadata.Status.PendingTickets = append(metadata.Status.PendingTickets, ticket)
+	}
+}
+
+// PendingWork returns a copy of the metadata to serve the chain with.
+// When tickets are pending after a partial failure, the copy has only these tickets and their issue links.
+// Pending tickets that are no longer in the spec are dropped.
+// The pending tickets of the copy are reset to collect the tickets that fail again.
+func PendingWork(metadata *codebaseApi.JiraIssueMetadata) (*codebaseApi.JiraIssueMetadata, error) {
+	work := metadata.DeepCopy()
+	work.Status.PendingTickets = nil
+	work.Status.ErrorStrings = nil
+
+	if len(metadata.Status.PendingTickets) == 0 {
+		return work, nil
+	}
+
+	payload := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(metadata.Spec.Payload), &payload); err != nil {
+		return nil, fmt.Errorf("invalid spec payload json: %w", err)
+	}
+
+	var links []link
+
+	if raw, ok := payload[issuesLinks]; ok {
+		if err := json.Unmarshal(raw, &links); err != nil {
+			return nil, fmt.Errorf("invalid issuesLinks in spec payload: %w", err)
+		}
+	}
+
+	pending := slices.DeleteFunc(slices.Clone(metadata.Status.PendingTickets), func(ticket string) bool {
+		return !slices.Contains(metadata.Spec.Tickets, ticket) &&
+			!slices.ContainsFunc(links, func(l link) bool { return l.Ticket == ticket })
+	})
+	if len(pending) == 0 {
+		return work, nil
+	}
+
+	work.Spec.Tickets = slices.DeleteFunc(slices.Clone(metadata.Spec.Tickets), func(ticket string) bool {
+		return !slices.Contains(pending, ticket)
+	})
+
+	// The tags are applied to all tickets when only links are pending, so only the links are left to create.
+	if len(work.Spec.Tickets) == 0 {
+		payload = map[string]json.RawMessage{issuesLinks: payload[issuesLinks]}
+	}
+
+	if _, ok := payload[issuesLinks]; ok {
+		pendingLinks := slices.DeleteFunc(links, func(l link) bool {
+			return !slices.Contains(pending, l.Ticket)
+		})
+
+		raw, err := json.Marshal(pendingLinks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal issuesLinks: %w", err)
+		}
+
+		payload[issuesLinks] = raw
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec payload: %w", err)
+	}
+
+	work.Spec.Payload = string(data)
+
+	return work, nil
+}
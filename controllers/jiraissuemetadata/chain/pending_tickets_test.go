@@ -0,0 +1,82 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestPendingWork(t *testing.T) {
+	t.Parallel()
+
+	const payload = `{"fixVersions":"1.0.0","issuesLinks":[{"ticket":"APP-1","title":"b","url":"u1"},` +
+		`{"ticket":"APP-2","title":"b","url":"u2"},{"ticket":"APP-3","title":"b","url":"u3"}]}`
+
+	tests := []struct {
+		name        string
+		pending     []string
+		wantTickets []string
+		wantPayload string
+	}{
+		{
+			name:        "all tickets without pending tickets",
+			wantTickets: []string{"APP-1", "APP-2"},
+			wantPayload: payload,
+		},
+		{
+			name:        "pending tickets only",
+			pending:     []string{"APP-2"},
+			wantTickets: []string{"APP-2"},
+			wantPayload: `{"fixVersions":"1.0.0","issuesLinks":[{"ticket":"APP-2","title":"b","url":"u2"}]}`,
+		},
+		{
+			name:        "links only when tags are applied to all tickets",
+			pending:     []string{"APP-3"},
+			wantTickets: []string{},
+			wantPayload: `{"issuesLinks":[{"ticket":"APP-3","title":"b","url":"u3"}]}`,
+		},
+		{
+			name:        "all tickets when pending tickets are no longer in the spec",
+			pending:     []string{"APP-9"},
+			wantTickets: []string{"APP-1", "APP-2"},
+			wantPayload: payload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			metadata := &codebaseApi.JiraIssueMetadata{
+				Spec: codebaseApi.JiraIssueMetadataSpec{
+					Tickets: []string{"APP-1", "APP-2"},
+					Payload: payload,
+				},
+				Status: codebaseApi.JiraIssueMetadataStatus{PendingTickets: tt.pending},
+			}
+
+			work, err := PendingWork(metadata)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantTickets, work.Spec.Tickets)
+			assert.JSONEq(t, tt.wantPayload, work.Spec.Payload)
+			assert.Empty(t, work.Status.PendingTickets)
+			assert.Equal(t, tt.pending, metadata.Status.PendingTickets, "the metadata must not change")
+		})
+	}
+}
+
+func TestAddTicketError(t *testing.T) {
+	t.Parallel()
+
+	metadata := &codebaseApi.JiraIssueMetadata{}
+
+	addTicketError(metadata, "APP-1", "failed to apply tags")
+	addTicketError(metadata, "APP-1", "failed to create link")
+
+	assert.Equal(t, []string{"APP-1"}, metadata.Status.PendingTickets)
+	assert.Len(t, metadata.Status.ErrorStrings, 2)
+}
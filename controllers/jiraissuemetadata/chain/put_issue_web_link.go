@@ -45,7 +45,7 @@ func (h PutIssueWebLink) ServeRequest(ctx context.Context, metadata *codebaseApi
 
 	for _, linkInfo := range payload.IssuesLinks {
 		if err = h.client.CreateIssueLink(linkInfo.Ticket, linkInfo.Title, linkInfo.Url); err != nil {
-			metadata.Status.ErrorStrings = append(metadata.Status.ErrorStrings,
+			addTicketError(metadata, linkInfo.Ticket,
 				fmt.Sprintf(
 					"failed to create remote link. ticket - %s, title - %s, url - %s, err: %v",
 					linkInfo.Ticket, linkInfo.Title, linkInfo.Url, err),
@@ -0,0 +1,63 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/controllers/jiraissuemetadata/chain/handler"
+	"github.com/epam/edp-codebase-operator/v2/pkg/releasenotes"
+)
+
+// RecordReleaseChanges collects the tickets and commits of the build per version of the CodebaseBranch,
+// so that the release notes of the version can be generated when it is released.
+type RecordReleaseChanges struct {
+	next handler.JiraIssueMetadataHandler
+	c    client.Client
+}
+
+func (h RecordReleaseChanges) ServeRequest(ctx context.Context, metadata *codebaseApi.JiraIssueMetadata) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if metadata.Spec.CodebaseBranch == "" {
+		return nextServeOrNil(ctx, h.next, metadata)
+	}
+
+	codebaseBranch := &codebaseApi.CodebaseBranch{}
+	if err := h.c.Get(ctx, types.NamespacedName{
+		Namespace: metadata.Namespace,
+		Name:      metadata.Spec.CodebaseBranch,
+	}, codebaseBranch); err != nil {
+		return fmt.Errorf("failed to get CodebaseBranch %s: %w", metadata.Spec.CodebaseBranch, err)
+	}
+
+	version := metadata.Spec.Version
+	if version == "" && codebaseBranch.Spec.Version != nil {
+		version = *codebaseBranch.Spec.Version
+	}
+
+	if version == "" {
+		log.Info("CodebaseBranch has no version. Skip recording release changes.")
+
+		return nextServeOrNil(ctx, h.next, metadata)
+	}
+
+	log.Info("Recording release changes", "codebasebranch", codebaseBranch.Name, "version", version)
+
+	if err := releasenotes.RecordChanges(
+		ctx,
+		h.c,
+		codebaseBranch,
+		version,
+		metadata.Spec.Tickets,
+		metadata.Spec.Commits,
+	); err != nil {
+		return fmt.Errorf("failed to record changes of version %s: %w", version, err)
+	}
+
+	return nextServeOrNil(ctx, h.next, metadata)
+}
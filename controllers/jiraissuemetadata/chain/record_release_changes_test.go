@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/releasenotes"
+)
+
+func TestRecordReleaseChanges_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		codebaseBranch string
+		version        string
+		wantVersion    string
+		wantErr        require.ErrorAssertionFunc
+	}{
+		{
+			name:           "changes are recorded for the version of the branch",
+			codebaseBranch: "app-main",
+			wantVersion:    "0.1.0-SNAPSHOT",
+			wantErr:        require.NoError,
+		},
+		{
+			name:           "changes are recorded for the version of the build",
+			codebaseBranch: "app-main",
+			version:        "0.0.9",
+			wantVersion:    "0.0.9",
+			wantErr:        require.NoError,
+		},
+		{
+			name:    "metadata without branch",
+			wantErr: require.NoError,
+		},
+		{
+			name:           "branch is missing",
+			codebaseBranch: "app-feature",
+			wantErr:        require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, codebaseApi.AddToScheme(scheme))
+			require.NoError(t, coreV1.AddToScheme(scheme))
+
+			codebaseBranch := &codebaseApi.CodebaseBranch{
+				ObjectMeta: metaV1.ObjectMeta{Name: "app-main", Namespace: "default"},
+				Spec:       codebaseApi.CodebaseBranchSpec{Version: ptr.To("0.1.0-SNAPSHOT")},
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(codebaseBranch).Build()
+
+			metadata := &codebaseApi.JiraIssueMetadata{
+				ObjectMeta: metaV1.ObjectMeta{Name: "app-build", Namespace: "default"},
+				Spec: codebaseApi.JiraIssueMetadataSpec{
+					CodebaseName:   "app",
+					CodebaseBranch: tt.codebaseBranch,
+					Version:        tt.version,
+					Tickets:        []string{"APP-1"},
+					Commits:        []string{"abc"},
+				},
+			}
+
+			err := RecordReleaseChanges{c: k8sClient}.
+				ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), metadata)
+			tt.wantErr(t, err)
+
+			cm, err := releasenotes.GetConfigMap(context.Background(), k8sClient, codebaseBranch)
+			require.NoError(t, err)
+
+			if tt.wantVersion == "" {
+				assert.Nil(t, cm)
+
+				return
+			}
+
+			changes, ok, err := releasenotes.GetChanges(cm, tt.wantVersion)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, &releasenotes.Changes{Tickets: []string{"APP-1"}, Commits: []string{"abc"}}, changes)
+		})
+	}
+}
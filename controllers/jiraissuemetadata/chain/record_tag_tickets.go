@@ -0,0 +1,44 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/controllers/jiraissuemetadata/chain/handler"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebaseimagestream"
+)
+
+// RecordTagTickets records the tickets on the CodebaseImageStream tag of the build,
+// so that the tickets can be transitioned when the tag is deployed.
+// It runs first, so that the Jira issues are not updated again while it waits for the tag to be pushed.
+type RecordTagTickets struct {
+	next handler.JiraIssueMetadataHandler
+	c    client.Client
+}
+
+func (h RecordTagTickets) ServeRequest(ctx context.Context, metadata *codebaseApi.JiraIssueMetadata) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if metadata.Spec.Tag == "" || len(metadata.Spec.Tickets) == 0 {
+		return nextServeOrNil(ctx, h.next, metadata)
+	}
+
+	log.Info("Recording tickets on the tag", "tag", metadata.Spec.Tag)
+
+	if err := codebaseimagestream.RecordTagTickets(
+		ctx,
+		h.c,
+		metadata.Spec.CodebaseName,
+		metadata.Namespace,
+		metadata.Spec.Tag,
+		metadata.Spec.Tickets,
+	); err != nil {
+		return fmt.Errorf("failed to record tickets on tag %s: %w", metadata.Spec.Tag, err)
+	}
+
+	return nextServeOrNil(ctx, h.next, metadata)
+}
@@ -0,0 +1,84 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebaseimagestream"
+)
+
+func TestRecordTagTickets_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		tag         string
+		wantTickets []string
+		wantErr     error
+	}{
+		{
+			name:        "tickets are recorded on the tag",
+			tag:         "0.1.0-SNAPSHOT.2",
+			wantTickets: []string{"APP-1", "APP-2"},
+		},
+		{
+			name:    "tag is not pushed yet",
+			tag:     "0.1.0-SNAPSHOT.3",
+			wantErr: codebaseimagestream.ErrTagNotFound,
+		},
+		{
+			name: "metadata without tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+			stream := &codebaseApi.CodebaseImageStream{
+				ObjectMeta: metaV1.ObjectMeta{Name: "app-main", Namespace: "default"},
+				Spec: codebaseApi.CodebaseImageStreamSpec{
+					Codebase: "app",
+					Tags:     []codebaseApi.Tag{{Name: "0.1.0-SNAPSHOT.2", Created: "2024-01-02T00:00:00Z"}},
+				},
+			}
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stream).Build()
+
+			metadata := &codebaseApi.JiraIssueMetadata{
+				ObjectMeta: metaV1.ObjectMeta{Name: "app-build", Namespace: "default"},
+				Spec: codebaseApi.JiraIssueMetadataSpec{
+					CodebaseName: "app",
+					Tickets:      []string{"APP-1", "APP-2"},
+					Tag:          tt.tag,
+				},
+			}
+
+			err := RecordTagTickets{c: k8sClient}.ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), metadata)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{
+				Namespace: "default",
+				Name:      "app-main",
+			}, stream))
+			assert.Equal(t, tt.wantTickets, stream.Spec.Tags[0].Tickets)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/controllers/jiraissuemetadata/chain/handler"
+	"github.com/epam/edp-codebase-operator/v2/pkg/issuetracker"
+)
+
+// trackerPayload are the fields of the payload that issue trackers other than Jira support.
+// fixVersions sets the milestone, components and labels are added as labels.
+type trackerPayload struct {
+	FixVersions string `json:"fixVersions,omitempty"`
+	Components  string `json:"components,omitempty"`
+	Labels      string `json:"labels,omitempty"`
+	IssuesLinks []link `json:"issuesLinks,omitempty"`
+}
+
+// UpdateTrackerIssues applies the payload to the issues of a GitHub or GitLab issue tracker.
+type UpdateTrackerIssues struct {
+	next    handler.JiraIssueMetadataHandler
+	tracker issuetracker.Tracker
+}
+
+func (h UpdateTrackerIssues) ServeRequest(ctx context.Context, metadata *codebaseApi.JiraIssueMetadata) error {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Start updating issues")
+
+	payload := trackerPayload{}
+	if err := json.Unmarshal([]byte(metadata.Spec.Payload), &payload); err != nil {
+		return fmt.Errorf("invalid spec payload json: %w", err)
+	}
+
+	var labels []string
+
+	for _, label := range []string{payload.Components, payload.Labels} {
+		if label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	for _, ticket := range metadata.Spec.Tickets {
+		if payload.FixVersions != "" {
+			if err := h.tracker.SetMilestone(ctx, ticket, payload.FixVersions); err != nil {
+				addTicketError(metadata, ticket,
+					fmt.Sprintf("failed to set milestone of issue %s, err: %v", ticket, err))
+			}
+		}
+
+		if len(labels) > 0 {
+			if err := h.tracker.AddLabels(ctx, ticket, labels); err != nil {
+				addTicketError(metadata, ticket,
+					fmt.Sprintf("failed to add labels to issue %s, err: %v", ticket, err))
+			}
+		}
+	}
+
+	for _, linkInfo := range payload.IssuesLinks {
+		if err := h.tracker.LinkBuild(ctx, linkInfo.Ticket, linkInfo.Title, linkInfo.Url); err != nil {
+			addTicketError(metadata, linkInfo.Ticket,
+				fmt.Sprintf(
+					"failed to link build to issue. ticket - %s, title - %s, url - %s, err: %v",
+					linkInfo.Ticket, linkInfo.Title, linkInfo.Url, err),
+			)
+		}
+	}
+
+	log.Info("End updating issues")
+
+	return nextServeOrNil(ctx, h.next, metadata)
+}
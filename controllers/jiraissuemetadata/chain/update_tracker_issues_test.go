@@ -0,0 +1,55 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	trackerMocks "github.com/epam/edp-codebase-operator/v2/pkg/issuetracker/mocks"
+)
+
+func TestUpdateTrackerIssues_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	tracker := trackerMocks.NewMockTracker(t)
+	tracker.On("SetMilestone", mock.Anything, "#1", "app-1.0.0").Return(nil)
+	tracker.On("SetMilestone", mock.Anything, "#2", "app-1.0.0").Return(errors.New("not found"))
+	tracker.On("AddLabels", mock.Anything, "#1", []string{"backend", "qa"}).Return(nil)
+	tracker.On("AddLabels", mock.Anything, "#2", []string{"backend", "qa"}).Return(nil)
+	tracker.On("LinkBuild", mock.Anything, "#1", "[#1] Build 1.0.0", "https://ci/1").Return(nil)
+
+	metadata := &codebaseApi.JiraIssueMetadata{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app-build", Namespace: "default"},
+		Spec: codebaseApi.JiraIssueMetadataSpec{
+			Tickets: []string{"#1", "#2"},
+			Payload: `{"fixVersions":"app-1.0.0","components":"backend","labels":"qa",` +
+				`"issuesLinks":[{"ticket":"#1","title":"[#1] Build 1.0.0","url":"https://ci/1"}]}`,
+		},
+	}
+
+	err := UpdateTrackerIssues{tracker: tracker}.
+		ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), metadata)
+	require.NoError(t, err)
+	require.Len(t, metadata.Status.ErrorStrings, 1)
+	assert.Contains(t, metadata.Status.ErrorStrings[0], "failed to set milestone of issue #2")
+}
+
+func TestUpdateTrackerIssues_ServeRequest_InvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	metadata := &codebaseApi.JiraIssueMetadata{
+		Spec: codebaseApi.JiraIssueMetadataSpec{Tickets: []string{"#1"}, Payload: `{"fixVersions":1}`},
+	}
+
+	err := UpdateTrackerIssues{tracker: trackerMocks.NewMockTracker(t)}.
+		ServeRequest(ctrl.LoggerInto(context.Background(), logr.Discard()), metadata)
+	require.Error(t, err)
+}
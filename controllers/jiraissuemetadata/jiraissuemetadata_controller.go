@@ -22,8 +22,10 @@ import (
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/jiraissuemetadata/chain"
+	"github.com/epam/edp-codebase-operator/v2/controllers/jiraissuemetadata/chain/handler"
 	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
-	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/issuetracker"
 	codebasepredicate "github.com/epam/edp-codebase-operator/v2/pkg/predicate"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
@@ -39,18 +41,24 @@ func NewReconcileJiraIssueMetadata(
 	c client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	httpClients *gitprovider.HTTPClientPool,
+	jiraClients *jira.ClientPool,
 ) *ReconcileJiraIssueMetadata {
 	return &ReconcileJiraIssueMetadata{
-		client: c,
-		scheme: scheme,
-		log:    log.WithName("jira-issue-metadata"),
+		client:      c,
+		scheme:      scheme,
+		log:         log.WithName("jira-issue-metadata"),
+		newTracker:  issuetracker.NewFactory(c, httpClients, jiraClients).New,
+		jiraClients: jiraClients,
 	}
 }
 
 type ReconcileJiraIssueMetadata struct {
-	client client.Client
-	scheme *runtime.Scheme
-	log    logr.Logger
+	client      client.Client
+	scheme      *runtime.Scheme
+	log         logr.Logger
+	newTracker  func(ctx context.Context, codebase *codebaseApi.Codebase) (issuetracker.Tracker, error)
+	jiraClients *jira.ClientPool
 }
 
 func (r *ReconcileJiraIssueMetadata) SetupWithManager(mgr ctrl.Manager) error {
@@ -113,11 +121,23 @@ func (r *ReconcileJiraIssueMetadata) Reconcile(
 
 	defer r.updateStatus(ctx, i)
 
-	if err := r.setOwnerRef(ctx, i); err != nil {
+	codebase, err := r.setOwnerRef(ctx, i)
+	if err != nil {
+		setErrorStatus(i, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	work, err := chain.PendingWork(i)
+	if err != nil {
 		setErrorStatus(i, err.Error())
 		return reconcile.Result{}, err
 	}
 
+	if tracker := codebase.Spec.GetIssueTracker(); tracker == codebaseApi.IssueTrackerGitHub ||
+		tracker == codebaseApi.IssueTrackerGitLab {
+		return r.reconcileGitTracker(ctx, i, work, codebase)
+	}
+
 	js, err := r.getJiraServer(ctx, i)
 	if err != nil {
 		setErrorStatus(i, err.Error())
@@ -129,23 +149,53 @@ func (r *ReconcileJiraIssueMetadata) Reconcile(
 		return reconcile.Result{RequeueAfter: r.setFailureCount(i)}, nil
 	}
 
-	jc, err := r.initJiraClient(js)
+	jc, err := r.initJiraClient(ctx, js)
 	if err != nil {
 		setErrorStatus(i, err.Error())
 		return reconcile.Result{}, err
 	}
 
-	ch, err := chain.CreateChain(i.Spec.Payload, jc, r.client)
+	ch, err := chain.CreateChain(work.Spec.Payload, jc, r.client)
 	if err != nil {
 		setErrorStatus(i, err.Error())
 		return reconcile.Result{}, fmt.Errorf("failed to configure `CreateChain`: %w", err)
 	}
 
-	err = ch.ServeRequest(ctx, i)
+	return r.serve(ctx, ch, i, work)
+}
+
+// reconcileGitTracker applies the metadata to the GitHub or GitLab issues of the codebase.
+func (r *ReconcileJiraIssueMetadata) reconcileGitTracker(
+	ctx context.Context,
+	metadata, work *codebaseApi.JiraIssueMetadata,
+	codebase *codebaseApi.Codebase,
+) (reconcile.Result, error) {
+	tracker, err := r.newTracker(ctx, codebase)
 	if err != nil {
-		setErrorStatus(i, err.Error())
-		timeout := r.setFailureCount(i)
-		log.Error(err, "failed to set jira issue metadata", "name", i.Name)
+		setErrorStatus(metadata, err.Error())
+		return reconcile.Result{}, fmt.Errorf("failed to create issue tracker: %w", err)
+	}
+
+	return r.serve(ctx, chain.CreateTrackerChain(tracker, r.client), metadata, work)
+}
+
+// serve serves the chain with the work of the metadata, see chain.PendingWork.
+// The tickets that fail are kept pending in the metadata status to be retried alone.
+func (r *ReconcileJiraIssueMetadata) serve(
+	ctx context.Context,
+	ch handler.JiraIssueMetadataHandler,
+	metadata, work *codebaseApi.JiraIssueMetadata,
+) (reconcile.Result, error) {
+	err := ch.ServeRequest(ctx, work)
+
+	if len(work.Status.PendingTickets) > 0 {
+		metadata.Status.PendingTickets = work.Status.PendingTickets
+	}
+
+	if err != nil {
+		setErrorStatus(metadata, err.Error())
+		timeout := r.setFailureCount(metadata)
+		ctrl.LoggerFrom(ctx).Error(err, "failed to set jira issue metadata", "name", metadata.Name)
 
 		return reconcile.Result{RequeueAfter: timeout}, nil
 	}
@@ -179,7 +229,10 @@ func (r *ReconcileJiraIssueMetadata) setFailureCount(metadata *codebaseApi.JiraI
 	return timeout
 }
 
-func (r *ReconcileJiraIssueMetadata) setOwnerRef(ctx context.Context, metadata *codebaseApi.JiraIssueMetadata) error {
+func (r *ReconcileJiraIssueMetadata) setOwnerRef(
+	ctx context.Context,
+	metadata *codebaseApi.JiraIssueMetadata,
+) (*codebaseApi.Codebase, error) {
 	c := &codebaseApi.Codebase{}
 
 	err := r.client.Get(ctx, types.NamespacedName{
@@ -187,14 +240,14 @@ func (r *ReconcileJiraIssueMetadata) setOwnerRef(ctx context.Context, metadata *
 		Name:      metadata.Spec.CodebaseName,
 	}, c)
 	if err != nil {
-		return fmt.Errorf("failed to fetch Codebase resource %q: %w", metadata.Spec.CodebaseName, err)
+		return nil, fmt.Errorf("failed to fetch Codebase resource %q: %w", metadata.Spec.CodebaseName, err)
 	}
 
 	if err := controllerutil.SetControllerReference(c, metadata, r.scheme); err != nil {
-		return fmt.Errorf("failed to set owner ref for JiraIssueMetadata CR: %w", err)
+		return nil, fmt.Errorf("failed to set owner ref for JiraIssueMetadata CR: %w", err)
 	}
 
-	return nil
+	return c, nil
 }
 
 func setErrorStatus(metadata *codebaseApi.JiraIssueMetadata, msg string) {
@@ -211,16 +264,16 @@ func (r *ReconcileJiraIssueMetadata) updateStatus(ctx context.Context, instance
 	}
 }
 
-func (r *ReconcileJiraIssueMetadata) initJiraClient(js *codebaseApi.JiraServer) (jira.Client, error) {
-	s, err := util.GetSecret(r.client, js.Spec.CredentialName, js.Namespace)
+func (r *ReconcileJiraIssueMetadata) initJiraClient(
+	ctx context.Context,
+	js *codebaseApi.JiraServer,
+) (jira.Client, error) {
+	server, err := jira.ServerFromSecret(ctx, r.client, js)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get secret %v: %w", js.Spec.CredentialName, err)
+		return nil, fmt.Errorf("failed to get Jira credentials: %w", err)
 	}
 
-	user := string(s.Data["username"])
-	pwd := string(s.Data["password"])
-
-	c, err := new(jira.GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(js.Spec.ApiUrl, user, pwd))
+	c, err := r.jiraClients.Client(js, server)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Jira client: %w", err)
 	}
@@ -2,6 +2,7 @@ package jiraissuemetadata
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -10,14 +11,20 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/issuetracker"
+	trackerMocks "github.com/epam/edp-codebase-operator/v2/pkg/issuetracker/mocks"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
 
@@ -599,3 +606,125 @@ func TestReconcileJiraIssueMetadata_Reconcile_ShouldPass(t *testing.T) {
 
 	assert.Equal(t, rec.RequeueAfter, duration)
 }
+
+func TestReconcileJiraIssueMetadata_Reconcile_ShouldUpdateGitHubIssues(t *testing.T) {
+	ist := &codebaseApi.JiraIssueMetadata{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "JIM",
+			Namespace: "namespace",
+		},
+		Spec: codebaseApi.JiraIssueMetadataSpec{
+			CodebaseName: "codebase",
+			Tickets:      []string{"#12"},
+			Payload:      `{"fixVersions":"codebase-1.0.0"}`,
+		},
+	}
+
+	c := &codebaseApi.Codebase{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "codebase",
+			Namespace: "namespace",
+		},
+		Spec: codebaseApi.CodebaseSpec{
+			IssueTracker: codebaseApi.IssueTrackerGitHub,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	fakeCl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ist, c).Build()
+
+	tracker := trackerMocks.NewMockTracker(t)
+	tracker.On("SetMilestone", mock.Anything, "#12", "codebase-1.0.0").Return(nil)
+
+	r := ReconcileJiraIssueMetadata{
+		client: fakeCl,
+		scheme: scheme,
+		log:    logr.Discard(),
+		newTracker: func(_ context.Context, codebase *codebaseApi.Codebase) (issuetracker.Tracker, error) {
+			assert.Equal(t, "codebase", codebase.Name)
+
+			return tracker, nil
+		},
+	}
+
+	_, err := r.Reconcile(ctrl.LoggerInto(context.Background(), logr.Discard()), reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "JIM",
+			Namespace: "namespace",
+		},
+	})
+	require.NoError(t, err)
+
+	err = fakeCl.Get(context.Background(), types.NamespacedName{Name: "JIM", Namespace: "namespace"}, ist)
+	assert.True(t, k8sErrors.IsNotFound(err), "JiraIssueMetadata should be deleted")
+}
+
+func TestReconcileJiraIssueMetadata_Reconcile_ShouldRetryPendingTickets(t *testing.T) {
+	ist := &codebaseApi.JiraIssueMetadata{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "JIM",
+			Namespace: "namespace",
+		},
+		Spec: codebaseApi.JiraIssueMetadataSpec{
+			CodebaseName: "codebase",
+			Tickets:      []string{"#1", "#2"},
+			Payload:      `{"fixVersions":"codebase-1.0.0"}`,
+		},
+	}
+
+	c := &codebaseApi.Codebase{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "codebase",
+			Namespace: "namespace",
+		},
+		Spec: codebaseApi.CodebaseSpec{
+			IssueTracker: codebaseApi.IssueTrackerGitHub,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	fakeCl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(ist, c).
+		WithStatusSubresource(ist).
+		Build()
+
+	tracker := trackerMocks.NewMockTracker(t)
+	tracker.On("SetMilestone", mock.Anything, "#1", "codebase-1.0.0").Return(nil).Once()
+	tracker.On("SetMilestone", mock.Anything, "#2", "codebase-1.0.0").Return(errors.New("rate limited")).Once()
+	tracker.On("SetMilestone", mock.Anything, "#2", "codebase-1.0.0").Return(nil).Once()
+
+	r := ReconcileJiraIssueMetadata{
+		client: fakeCl,
+		scheme: scheme,
+		log:    logr.Discard(),
+		newTracker: func(context.Context, *codebaseApi.Codebase) (issuetracker.Tracker, error) {
+			return tracker, nil
+		},
+	}
+
+	ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "JIM",
+			Namespace: "namespace",
+		},
+	}
+
+	_, err := r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeCl.Get(ctx, req.NamespacedName, ist))
+	assert.Equal(t, []string{"#2"}, ist.Status.PendingTickets)
+	assert.Equal(t, errorStatus, ist.Status.Status)
+
+	_, err = r.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	err = fakeCl.Get(ctx, req.NamespacedName, ist)
+	assert.True(t, k8sErrors.IsNotFound(err), "JiraIssueMetadata should be deleted")
+}
@@ -1,7 +1,10 @@
 package chain
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/jiraserver/chain/handler"
@@ -17,7 +20,11 @@ func (h CheckConnection) ServeRequest(js *codebaseApi.JiraServer) error {
 	rl := log.WithValues("jira server name", js.Name)
 	rl.V(2).Info("start checking connection...")
 
-	connected, err := h.checkConnection()
+	if err := validateAuthType(js); err != nil {
+		return err
+	}
+
+	connected, err := h.checkConnection(authTypeOrDefault(js.Spec.AuthType))
 	if err != nil {
 		return err
 	}
@@ -29,13 +36,54 @@ func (h CheckConnection) ServeRequest(js *codebaseApi.JiraServer) error {
 	return nextServeOrNil(h.next, js)
 }
 
-func (h CheckConnection) checkConnection() (bool, error) {
+func (h CheckConnection) checkConnection(authType string) (bool, error) {
 	connected, err := h.client.Connected()
 	if err != nil {
-		return false, fmt.Errorf("failed to connect to Jira server: %w", err)
+		return false, fmt.Errorf("failed to connect to Jira server with %s auth: %w", authType, err)
 	}
 
 	log.Info("connection to Jira server", "established", connected)
 
 	return connected, nil
 }
+
+// oauthAPIURLPrefix is the prefix of the API URL of Jira Cloud sites for OAuth 2.0 (3LO) apps,
+// followed by the cloud ID of the site.
+const oauthAPIURLPrefix = "https://api.atlassian.com/ex/jira/"
+
+// validateAuthType checks that the auth type can be used with the Jira API URL.
+// OAuth 2.0 access tokens are accepted only by the Atlassian API gateway, and Jira Cloud has no personal access tokens.
+func validateAuthType(js *codebaseApi.JiraServer) error {
+	switch authTypeOrDefault(js.Spec.AuthType) {
+	case codebaseApi.JiraAuthTypeOAuth2:
+		if !strings.HasPrefix(js.Spec.ApiUrl, oauthAPIURLPrefix) {
+			return fmt.Errorf("oauth2 auth requires an API URL of the form %s<cloud id>", oauthAPIURLPrefix)
+		}
+	case codebaseApi.JiraAuthTypePAT:
+		if isJiraCloud(js.Spec.ApiUrl) {
+			return errors.New("pat auth is not supported by Jira Cloud, use cloudToken or oauth2")
+		}
+	case codebaseApi.JiraAuthTypeBasic, codebaseApi.JiraAuthTypeCloudToken:
+	default:
+		return fmt.Errorf("unsupported Jira auth type %q", js.Spec.AuthType)
+	}
+
+	return nil
+}
+
+func authTypeOrDefault(authType string) string {
+	if authType == "" {
+		return codebaseApi.JiraAuthTypeBasic
+	}
+
+	return authType
+}
+
+func isJiraCloud(apiURL string) bool {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return false
+	}
+
+	return strings.HasSuffix(u.Hostname(), ".atlassian.net") || u.Hostname() == "api.atlassian.com"
+}
@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/mocks"
+)
+
+func TestCheckConnection_ServeRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		authType      string
+		apiURL        string
+		connected     bool
+		connectErr    error
+		wantErr       string
+		wantAvailable bool
+	}{
+		{
+			name:          "basic auth",
+			apiURL:        "https://jira.example.com",
+			connected:     true,
+			wantAvailable: true,
+		},
+		{
+			name:          "pat on Data Center",
+			authType:      codebaseApi.JiraAuthTypePAT,
+			apiURL:        "https://jira.example.com",
+			connected:     true,
+			wantAvailable: true,
+		},
+		{
+			name:     "pat on Jira Cloud",
+			authType: codebaseApi.JiraAuthTypePAT,
+			apiURL:   "https://example.atlassian.net",
+			wantErr:  "pat auth is not supported by Jira Cloud",
+		},
+		{
+			name:          "cloud API token",
+			authType:      codebaseApi.JiraAuthTypeCloudToken,
+			apiURL:        "https://example.atlassian.net",
+			connected:     true,
+			wantAvailable: true,
+		},
+		{
+			name:          "oauth2 through the API gateway",
+			authType:      codebaseApi.JiraAuthTypeOAuth2,
+			apiURL:        "https://api.atlassian.com/ex/jira/11223344-a1b2-3b33-c444-def123456789",
+			connected:     true,
+			wantAvailable: true,
+		},
+		{
+			name:     "oauth2 with site URL",
+			authType: codebaseApi.JiraAuthTypeOAuth2,
+			apiURL:   "https://example.atlassian.net",
+			wantErr:  "oauth2 auth requires an API URL of the form https://api.atlassian.com/ex/jira/<cloud id>",
+		},
+		{
+			name:       "credentials rejected",
+			authType:   codebaseApi.JiraAuthTypeCloudToken,
+			apiURL:     "https://example.atlassian.net",
+			connectErr: errors.New("401 Unauthorized"),
+			wantErr:    "failed to connect to Jira server with cloudToken auth: 401 Unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			jc := mocks.NewMockClient(t)
+			if tt.wantErr == "" || tt.connectErr != nil {
+				jc.On("Connected").Return(tt.connected, tt.connectErr)
+			}
+
+			js := &codebaseApi.JiraServer{
+				Spec: codebaseApi.JiraServerSpec{ApiUrl: tt.apiURL, AuthType: tt.authType},
+			}
+
+			err := CheckConnection{client: jc}.ServeRequest(js)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAvailable, js.Status.Available)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package jiraserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/issuetracker"
+)
+
+const (
+	EventReasonConnectionFailed   = "JiraConnectionFailed"
+	EventReasonConnectionRestored = "JiraConnectionRestored"
+	EventReasonPermissionsMissing = "JiraPermissionsMissing"
+	EventReasonPermissionsGranted = "JiraPermissionsGranted"
+
+	permissionBrowseProjects    = "BROWSE_PROJECTS"
+	permissionEditIssues        = "EDIT_ISSUES"
+	permissionLinkIssues        = "LINK_ISSUES"
+	permissionAdministerProject = "ADMINISTER_PROJECTS"
+	permissionTransitionIssues  = "TRANSITION_ISSUES"
+)
+
+// errNotConnected is the connection error when Jira answers, but does not accept the credentials.
+var errNotConnected = errors.New("jira did not accept the credentials")
+
+var (
+	jiraServerConnected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "codebase_operator_jira_server_connected",
+		Help: "Whether the last connection to a JiraServer succeeded (1) or failed (0).",
+	}, []string{"namespace", "jiraserver"})
+
+	jiraProjectPermission = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "codebase_operator_jira_project_permission",
+		Help: "Whether the account of a JiraServer has (1) or lacks (0) a permission the operator needs in a project.",
+	}, []string{"namespace", "jiraserver", "project", "permission"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(jiraServerConnected, jiraProjectPermission)
+}
+
+// setConnectedCondition records whether the operator connects to Jira, emitting events on transitions.
+func (r *ReconcileJiraServer) setConnectedCondition(js *codebaseApi.JiraServer, connErr error) {
+	previous := metaV1.ConditionUnknown
+	if c := meta.FindStatusCondition(js.Status.Conditions, codebaseApi.JiraServerConditionConnected); c != nil {
+		previous = c.Status
+	}
+
+	condition := metaV1.Condition{
+		Type:    codebaseApi.JiraServerConditionConnected,
+		Status:  metaV1.ConditionTrue,
+		Reason:  codebaseApi.ReasonConnectionSucceeded,
+		Message: "Connection succeeded",
+	}
+
+	if connErr != nil {
+		condition.Status = metaV1.ConditionFalse
+		condition.Reason = codebaseApi.ReasonConnectionFailed
+		condition.Message = connErr.Error()
+	}
+
+	meta.SetStatusCondition(&js.Status.Conditions, condition)
+
+	switch {
+	case connErr != nil && previous != metaV1.ConditionFalse:
+		r.recorder.Eventf(js, corev1.EventTypeWarning, EventReasonConnectionFailed,
+			"Connection to Jira failed: %s", connErr.Error())
+	case connErr == nil && previous == metaV1.ConditionFalse:
+		r.recorder.Event(js, corev1.EventTypeNormal, EventReasonConnectionRestored, "Connection to Jira succeeded again")
+	}
+
+	jiraServerConnected.With(prometheus.Labels{"namespace": js.Namespace, "jiraserver": js.Name}).
+		Set(boolToFloat(connErr == nil))
+}
+
+// checkHealth records the version of Jira and the permissions the account lacks in the projects
+// of the codebases that use the JiraServer. It does not fail the reconciliation: a failed check
+// leaves the permissions unknown.
+func (r *ReconcileJiraServer) checkHealth(ctx context.Context, js *codebaseApi.JiraServer, jc jira.Client) {
+	log := ctrl.LoggerFrom(ctx)
+
+	info, err := jc.GetServerInfo(ctx)
+	if err != nil {
+		log.Error(err, "Failed to get Jira server info")
+	} else {
+		js.Status.Version = info.Version
+		js.Status.DeploymentType = info.DeploymentType
+	}
+
+	projects, err := r.projectPermissions(ctx, js)
+	if err != nil {
+		log.Error(err, "Failed to get Jira projects of codebases")
+		r.setPermissionsUnknown(js, err.Error())
+
+		return
+	}
+
+	if len(projects) == 0 {
+		js.Status.Projects = nil
+		r.setPermissionsUnknown(js, "No codebase with a ticket name pattern of a Jira project uses the JiraServer")
+
+		return
+	}
+
+	statuses := make([]codebaseApi.JiraProjectStatus, 0, len(projects))
+	labels := prometheus.Labels{"namespace": js.Namespace, "jiraserver": js.Name}
+
+	jiraProjectPermission.DeletePartialMatch(labels)
+
+	for _, key := range slices.Sorted(maps.Keys(projects)) {
+		status := codebaseApi.JiraProjectStatus{Key: key}
+
+		granted, err := jc.GetMyPermissions(ctx, key, projects[key])
+
+		switch {
+		case errors.Is(err, jira.ErrNotFound):
+			// A missing project is a finding of its own, the other projects are still checked.
+			status.NotFound = true
+		case err != nil:
+			log.Error(err, "Failed to get Jira project permissions", "project", key)
+			r.setPermissionsUnknown(js, fmt.Sprintf("failed to get permissions in project %s: %s", key, err))
+
+			return
+		}
+
+		for _, permission := range projects[key] {
+			jiraProjectPermission.With(prometheus.Labels{
+				"namespace":  js.Namespace,
+				"jiraserver": js.Name,
+				"project":    key,
+				"permission": permission,
+			}).Set(boolToFloat(granted[permission]))
+
+			if !granted[permission] && !status.NotFound {
+				status.MissingPermissions = append(status.MissingPermissions, permission)
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	js.Status.Projects = statuses
+
+	r.setPermissionsCondition(js)
+}
+
+// projectPermissions returns the Jira projects of the codebases that use the JiraServer,
+// with the permissions the operator needs in each of them.
+func (r *ReconcileJiraServer) projectPermissions(
+	ctx context.Context,
+	js *codebaseApi.JiraServer,
+) (map[string][]string, error) {
+	codebases := &codebaseApi.CodebaseList{}
+	if err := r.client.List(ctx, codebases, client.InNamespace(js.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list codebases: %w", err)
+	}
+
+	projects := make(map[string][]string)
+
+	for i := range codebases.Items {
+		codebase := &codebases.Items[i]
+
+		if codebase.Spec.JiraServer == nil || *codebase.Spec.JiraServer != js.Name {
+			continue
+		}
+
+		keys, err := issuetracker.JiraProjectKeys(issuetracker.TicketNamePattern(codebase))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Jira projects of codebase %s: %w", codebase.Name, err)
+		}
+
+		required := []string{
+			permissionBrowseProjects,
+			permissionEditIssues,
+			permissionLinkIssues,
+			permissionAdministerProject,
+		}
+		if len(js.Spec.Transitions) > 0 || len(codebase.Spec.JiraTransitions) > 0 {
+			required = append(required, permissionTransitionIssues)
+		}
+
+		for _, key := range keys {
+			for _, permission := range required {
+				if !slices.Contains(projects[key], permission) {
+					projects[key] = append(projects[key], permission)
+				}
+			}
+		}
+	}
+
+	return projects, nil
+}
+
+func (r *ReconcileJiraServer) setPermissionsCondition(js *codebaseApi.JiraServer) {
+	var missing []string
+
+	for _, project := range js.Status.Projects {
+		if project.NotFound {
+			missing = append(missing, project.Key+": project not found")
+		}
+
+		if len(project.MissingPermissions) > 0 {
+			missing = append(missing, fmt.Sprintf("%s: %s", project.Key, strings.Join(project.MissingPermissions, ", ")))
+		}
+	}
+
+	// The condition is copied, SetStatusCondition updates it in place.
+	var previous metaV1.Condition
+	c := meta.FindStatusCondition(js.Status.Conditions, codebaseApi.JiraServerConditionPermissionsSufficient)
+	if c != nil {
+		previous = *c
+	}
+
+	if len(missing) > 0 {
+		message := "Missing permissions in Jira projects " + strings.Join(missing, "; ")
+
+		meta.SetStatusCondition(&js.Status.Conditions, metaV1.Condition{
+			Type:    codebaseApi.JiraServerConditionPermissionsSufficient,
+			Status:  metaV1.ConditionFalse,
+			Reason:  codebaseApi.ReasonPermissionsMissing,
+			Message: message,
+		})
+
+		if previous.Status != metaV1.ConditionFalse || previous.Message != message {
+			r.recorder.Event(js, corev1.EventTypeWarning, EventReasonPermissionsMissing, message)
+		}
+
+		return
+	}
+
+	meta.SetStatusCondition(&js.Status.Conditions, metaV1.Condition{
+		Type:    codebaseApi.JiraServerConditionPermissionsSufficient,
+		Status:  metaV1.ConditionTrue,
+		Reason:  codebaseApi.ReasonPermissionsGranted,
+		Message: "The account has the required permissions in all Jira projects",
+	})
+
+	if previous.Status == metaV1.ConditionFalse {
+		r.recorder.Event(js, corev1.EventTypeNormal, EventReasonPermissionsGranted,
+			"The account has the required permissions in all Jira projects")
+	}
+}
+
+func (*ReconcileJiraServer) setPermissionsUnknown(js *codebaseApi.JiraServer, message string) {
+	meta.SetStatusCondition(&js.Status.Conditions, metaV1.Condition{
+		Type:    codebaseApi.JiraServerConditionPermissionsSufficient,
+		Status:  metaV1.ConditionUnknown,
+		Reason:  codebaseApi.ReasonPermissionsNotKnown,
+		Message: message,
+	})
+}
+
+// deleteMetrics removes the metrics of a deleted JiraServer.
+func deleteMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "jiraserver": name}
+
+	jiraServerConnected.DeletePartialMatch(labels)
+	jiraProjectPermission.DeletePartialMatch(labels)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,210 @@
+package jiraserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	jiraMocks "github.com/epam/edp-codebase-operator/v2/pkg/client/jira/mocks"
+)
+
+func newTestCodebase(name, jiraServer, pattern string) *codebaseApi.Codebase {
+	return &codebaseApi.Codebase{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: codebaseApi.CodebaseSpec{
+			JiraServer:        ptr.To(jiraServer),
+			TicketNamePattern: ptr.To(pattern),
+		},
+	}
+}
+
+func TestReconcileJiraServer_checkHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		transitions     []codebaseApi.JiraTransition
+		codebases       []*codebaseApi.Codebase
+		jiraClient      func(t *testing.T) jira.Client
+		wantProjects    []codebaseApi.JiraProjectStatus
+		wantPermissions metaV1.ConditionStatus
+		wantEvent       string
+	}{
+		{
+			name: "missing permissions",
+			codebases: []*codebaseApi.Codebase{
+				newTestCodebase("app", "jira", `APP-\d+`),
+				newTestCodebase("web", "jira", `(WEB|APP)-\d+`),
+				newTestCodebase("other", "other-jira", `OTHER-\d+`),
+			},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("GetServerInfo", mock.Anything).
+					Return(&jira.ServerInfo{Version: "1001.0.0", DeploymentType: "Cloud"}, nil)
+				m.On("GetMyPermissions", mock.Anything, "APP", mock.Anything).
+					Return(map[string]bool{"BROWSE_PROJECTS": true, "EDIT_ISSUES": true}, nil)
+				m.On("GetMyPermissions", mock.Anything, "WEB", mock.Anything).
+					Return(map[string]bool{
+						"BROWSE_PROJECTS":     true,
+						"EDIT_ISSUES":         true,
+						"LINK_ISSUES":         true,
+						"ADMINISTER_PROJECTS": true,
+					}, nil)
+
+				return m
+			},
+			wantProjects: []codebaseApi.JiraProjectStatus{
+				{Key: "APP", MissingPermissions: []string{"LINK_ISSUES", "ADMINISTER_PROJECTS"}},
+				{Key: "WEB"},
+			},
+			wantPermissions: metaV1.ConditionFalse,
+			wantEvent:       "APP: LINK_ISSUES, ADMINISTER_PROJECTS",
+		},
+		{
+			name: "project not found",
+			codebases: []*codebaseApi.Codebase{
+				newTestCodebase("app", "jira", `APP-\d+`),
+				newTestCodebase("web", "jira", `WEB-\d+`),
+			},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("GetServerInfo", mock.Anything).Return(&jira.ServerInfo{Version: "9.12.2"}, nil)
+				m.On("GetMyPermissions", mock.Anything, "APP", mock.Anything).
+					Return(nil, fmt.Errorf("jira project APP: %w", jira.ErrNotFound))
+				m.On("GetMyPermissions", mock.Anything, "WEB", mock.Anything).
+					Return(map[string]bool{
+						"BROWSE_PROJECTS":     true,
+						"EDIT_ISSUES":         true,
+						"LINK_ISSUES":         true,
+						"ADMINISTER_PROJECTS": true,
+					}, nil)
+
+				return m
+			},
+			wantProjects: []codebaseApi.JiraProjectStatus{
+				{Key: "APP", NotFound: true},
+				{Key: "WEB"},
+			},
+			wantPermissions: metaV1.ConditionFalse,
+			wantEvent:       "APP: project not found",
+		},
+		{
+			name:        "transition permission with transitions",
+			transitions: []codebaseApi.JiraTransition{{Transition: "Deployed"}},
+			codebases:   []*codebaseApi.Codebase{newTestCodebase("app", "jira", `APP-\d+`)},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("GetServerInfo", mock.Anything).Return(&jira.ServerInfo{Version: "9.12.2"}, nil)
+				m.On("GetMyPermissions", mock.Anything, "APP", []string{
+					"BROWSE_PROJECTS", "EDIT_ISSUES", "LINK_ISSUES", "ADMINISTER_PROJECTS", "TRANSITION_ISSUES",
+				}).Return(map[string]bool{
+					"BROWSE_PROJECTS":     true,
+					"EDIT_ISSUES":         true,
+					"LINK_ISSUES":         true,
+					"ADMINISTER_PROJECTS": true,
+					"TRANSITION_ISSUES":   true,
+				}, nil)
+
+				return m
+			},
+			wantProjects:    []codebaseApi.JiraProjectStatus{{Key: "APP"}},
+			wantPermissions: metaV1.ConditionTrue,
+		},
+		{
+			name:      "no projects in default ticket name pattern",
+			codebases: []*codebaseApi.Codebase{newTestCodebase("app", "jira", "")},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("GetServerInfo", mock.Anything).Return(&jira.ServerInfo{Version: "9.12.2"}, nil)
+
+				return m
+			},
+			wantPermissions: metaV1.ConditionUnknown,
+		},
+		{
+			name:      "failed to get permissions",
+			codebases: []*codebaseApi.Codebase{newTestCodebase("app", "jira", `APP-\d+`)},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("GetServerInfo", mock.Anything).Return(nil, errors.New("server error"))
+				m.On("GetMyPermissions", mock.Anything, "APP", mock.Anything).
+					Return(nil, errors.New("service unavailable"))
+
+				return m
+			},
+			wantPermissions: metaV1.ConditionUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, codebase := range tt.codebases {
+				builder.WithObjects(codebase)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			r := NewReconcileJiraServer(builder.Build(), scheme, logr.Discard(), recorder, jira.NewClientPool())
+			js := &codebaseApi.JiraServer{
+				ObjectMeta: metaV1.ObjectMeta{Name: "jira", Namespace: "default"},
+				Spec:       codebaseApi.JiraServerSpec{Transitions: tt.transitions},
+			}
+
+			r.checkHealth(context.Background(), js, tt.jiraClient(t))
+
+			assert.Equal(t, tt.wantProjects, js.Status.Projects)
+
+			condition := meta.FindStatusCondition(js.Status.Conditions, codebaseApi.JiraServerConditionPermissionsSufficient)
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.wantPermissions, condition.Status)
+
+			if tt.wantEvent != "" {
+				require.Len(t, recorder.Events, 1)
+				assert.Contains(t, <-recorder.Events, tt.wantEvent)
+			} else {
+				assert.Empty(t, recorder.Events)
+			}
+		})
+	}
+}
+
+func TestReconcileJiraServer_setConnectedCondition(t *testing.T) {
+	t.Parallel()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &ReconcileJiraServer{recorder: recorder}
+	js := &codebaseApi.JiraServer{ObjectMeta: metaV1.ObjectMeta{Name: "jira", Namespace: "default"}}
+
+	r.setConnectedCondition(js, nil)
+	assert.Empty(t, recorder.Events, "a first successful connection must not emit an event")
+
+	r.setConnectedCondition(js, errors.New("unauthorized"))
+	r.setConnectedCondition(js, errors.New("unauthorized"))
+	require.Len(t, recorder.Events, 1, "a failure must emit one event until the connection is restored")
+	assert.Contains(t, <-recorder.Events, EventReasonConnectionFailed)
+	assert.True(t, meta.IsStatusConditionFalse(js.Status.Conditions, codebaseApi.JiraServerConditionConnected))
+
+	r.setConnectedCondition(js, nil)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, EventReasonConnectionRestored)
+	assert.True(t, meta.IsStatusConditionTrue(js.Status.Conditions, codebaseApi.JiraServerConditionConnected))
+}
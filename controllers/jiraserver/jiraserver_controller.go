@@ -3,11 +3,14 @@ package jiraserver
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,28 +21,39 @@ import (
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/controllers/jiraserver/chain"
 	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
-	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
 	codebasepredicate "github.com/epam/edp-codebase-operator/v2/pkg/predicate"
-	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
 
 const (
 	statusError    = "error"
 	statusFinished = "finished"
+
+	// healthCheckInterval is how often the connection and the permissions of the account are checked again.
+	healthCheckInterval = 30 * time.Minute
 )
 
-func NewReconcileJiraServer(c client.Client, scheme *runtime.Scheme, log logr.Logger) *ReconcileJiraServer {
+func NewReconcileJiraServer(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	recorder record.EventRecorder,
+	jiraClients *jira.ClientPool,
+) *ReconcileJiraServer {
 	return &ReconcileJiraServer{
-		client: c,
-		scheme: scheme,
-		log:    log.WithName("jira-server"),
+		client:      c,
+		scheme:      scheme,
+		log:         log.WithName("jira-server"),
+		recorder:    recorder,
+		jiraClients: jiraClients,
 	}
 }
 
 type ReconcileJiraServer struct {
-	client client.Client
-	scheme *runtime.Scheme
-	log    logr.Logger
+	client      client.Client
+	scheme      *runtime.Scheme
+	log         logr.Logger
+	recorder    record.EventRecorder
+	jiraClients *jira.ClientPool
 }
 
 func (r *ReconcileJiraServer) SetupWithManager(mgr ctrl.Manager) error {
@@ -59,7 +73,7 @@ func (r *ReconcileJiraServer) SetupWithManager(mgr ctrl.Manager) error {
 				return true
 			}
 
-			return oldObject.Status == newObject.Status
+			return equality.Semantic.DeepEqual(oldObject.Status, newObject.Status)
 		},
 	}
 
@@ -78,6 +92,7 @@ func (r *ReconcileJiraServer) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups=v2.edp.epam.com,resources=jiraservers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=v2.edp.epam.com,resources=jiraservers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=v2.edp.epam.com,resources=jiraservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=secrets,verbs=get;list;watch;update;patch
 
 // Reconcile reads that state of the cluster for a JiraServer object and makes changes based on the state.
 func (r *ReconcileJiraServer) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -87,6 +102,8 @@ func (r *ReconcileJiraServer) Reconcile(ctx context.Context, request reconcile.R
 	i := &codebaseApi.JiraServer{}
 	if err := r.client.Get(ctx, request.NamespacedName, i); err != nil {
 		if k8sErrors.IsNotFound(err) {
+			deleteMetrics(request.Namespace, request.Name)
+
 			return reconcile.Result{}, nil
 		}
 
@@ -95,9 +112,13 @@ func (r *ReconcileJiraServer) Reconcile(ctx context.Context, request reconcile.R
 
 	defer r.updateStatus(ctx, i)
 
-	c, err := r.initJiraClient(i)
+	c, err := r.initJiraClient(ctx, i)
 	if err != nil {
 		i.Status.Available = false
+		i.Status.Status = statusError
+		i.Status.DetailedMessage = err.Error()
+		r.setConnectedCondition(i, err)
+
 		return reconcile.Result{}, err
 	}
 
@@ -105,6 +126,7 @@ func (r *ReconcileJiraServer) Reconcile(ctx context.Context, request reconcile.R
 	if err := jiraHandler.ServeRequest(i); err != nil {
 		i.Status.Status = statusError
 		i.Status.DetailedMessage = err.Error()
+		r.setConnectedCondition(i, err)
 
 		return reconcile.Result{}, fmt.Errorf("failed serving default chain: %w", err)
 	}
@@ -112,9 +134,18 @@ func (r *ReconcileJiraServer) Reconcile(ctx context.Context, request reconcile.R
 	i.Status.Status = statusFinished
 	i.Status.DetailedMessage = ""
 
+	if !i.Status.Available {
+		r.setConnectedCondition(i, errNotConnected)
+
+		return reconcile.Result{RequeueAfter: healthCheckInterval}, nil
+	}
+
+	r.setConnectedCondition(i, nil)
+	r.checkHealth(ctrl.LoggerInto(ctx, log), i, c)
+
 	log.Info("Reconciling JiraServer has been finished")
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: healthCheckInterval}, nil
 }
 
 func (r *ReconcileJiraServer) updateStatus(ctx context.Context, instance *codebaseApi.JiraServer) {
@@ -126,16 +157,13 @@ func (r *ReconcileJiraServer) updateStatus(ctx context.Context, instance *codeba
 	}
 }
 
-func (r *ReconcileJiraServer) initJiraClient(js *codebaseApi.JiraServer) (jira.Client, error) {
-	s, err := util.GetSecret(r.client, js.Spec.CredentialName, js.Namespace)
+func (r *ReconcileJiraServer) initJiraClient(ctx context.Context, js *codebaseApi.JiraServer) (jira.Client, error) {
+	server, err := jira.ServerFromSecret(ctx, r.client, js)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get secret %v: %w", js.Spec.CredentialName, err)
+		return nil, fmt.Errorf("failed to get Jira credentials: %w", err)
 	}
 
-	user := string(s.Data["username"])
-	pwd := string(s.Data["password"])
-
-	c, err := new(jira.GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(js.Spec.ApiUrl, user, pwd))
+	c, err := r.jiraClients.Client(js, server)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Jira client: %w", err)
 	}
@@ -12,9 +12,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -37,9 +40,10 @@ func TestReconcileJiraServer_Reconcile_ShouldPassNotFound(t *testing.T) {
 	}
 
 	r := ReconcileJiraServer{
-		client: fakeCl,
-		log:    logr.Discard(),
-		scheme: scheme,
+		client:   fakeCl,
+		log:      logr.Discard(),
+		scheme:   scheme,
+		recorder: record.NewFakeRecorder(10),
 	}
 
 	res, err := r.Reconcile(context.TODO(), req)
@@ -61,9 +65,10 @@ func TestReconcileJiraServer_Reconcile_ShouldFailNotFound(t *testing.T) {
 	}
 
 	r := ReconcileJiraServer{
-		client: fakeCl,
-		log:    logr.Discard(),
-		scheme: scheme,
+		client:   fakeCl,
+		log:      logr.Discard(),
+		scheme:   scheme,
+		recorder: record.NewFakeRecorder(10),
 	}
 
 	res, err := r.Reconcile(context.TODO(), req)
@@ -101,9 +106,10 @@ func TestReconcileJiraServer_Reconcile_ShouldFailInitJiraClientWithSecretNotFoun
 	}
 
 	r := ReconcileJiraServer{
-		client: fakeCl,
-		log:    logr.Discard(),
-		scheme: scheme,
+		client:   fakeCl,
+		log:      logr.Discard(),
+		scheme:   scheme,
+		recorder: record.NewFakeRecorder(10),
 	}
 
 	res, err := r.Reconcile(context.TODO(), req)
@@ -152,9 +158,10 @@ func TestReconcileJiraServer_Reconcile_ShouldFailToCreateNewJiraClient(t *testin
 	}
 
 	r := ReconcileJiraServer{
-		client: fakeCl,
-		log:    logr.Discard(),
-		scheme: scheme,
+		client:   fakeCl,
+		log:      logr.Discard(),
+		scheme:   scheme,
+		recorder: record.NewFakeRecorder(10),
 	}
 
 	res, err := r.Reconcile(context.TODO(), req)
@@ -188,12 +195,22 @@ func TestReconcileJiraServer_Reconcile_ShouldPass(t *testing.T) {
 			"password": []byte("pass"),
 		},
 	}
+	codebase := &codebaseApi.Codebase{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      "app",
+			Namespace: "namespace",
+		},
+		Spec: codebaseApi.CodebaseSpec{
+			JiraServer:        ptr.To("NewJira"),
+			TicketNamePattern: ptr.To(`APP-\d+`),
+		},
+	}
 
 	scheme := runtime.NewScheme()
 	require.NoError(t, codebaseApi.AddToScheme(scheme))
 	require.NoError(t, coreV1.AddToScheme(scheme))
 
-	fakeCl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(j, s).Build()
+	fakeCl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(j, s, codebase).Build()
 
 	httpmock.Reset()
 	httpmock.Activate()
@@ -204,6 +221,12 @@ func TestReconcileJiraServer_Reconcile_ShouldPass(t *testing.T) {
 
 	httpmock.RegisterResponder("GET", "/j-api/rest/api/2/myself",
 		httpmock.NewJsonResponderOrPanic(200, &ju))
+	httpmock.RegisterResponder("GET", "/j-api/rest/api/2/serverInfo",
+		httpmock.NewStringResponder(200, `{"version":"9.12.2","deploymentType":"Server"}`))
+	httpmock.RegisterResponder("GET", "/j-api/rest/api/2/mypermissions",
+		httpmock.NewStringResponder(200, `{"permissions":{"BROWSE_PROJECTS":{"havePermission":true},`+
+			`"EDIT_ISSUES":{"havePermission":true},"LINK_ISSUES":{"havePermission":true},`+
+			`"ADMINISTER_PROJECTS":{"havePermission":true}}}`))
 
 	// request
 	req := reconcile.Request{
@@ -214,15 +237,16 @@ func TestReconcileJiraServer_Reconcile_ShouldPass(t *testing.T) {
 	}
 
 	r := ReconcileJiraServer{
-		client: fakeCl,
-		log:    logr.Discard(),
-		scheme: scheme,
+		client:   fakeCl,
+		log:      logr.Discard(),
+		scheme:   scheme,
+		recorder: record.NewFakeRecorder(10),
 	}
 
 	res, err := r.Reconcile(context.TODO(), req)
 
 	require.NoError(t, err)
-	require.Equal(t, time.Duration(0), res.RequeueAfter)
+	require.Equal(t, healthCheckInterval, res.RequeueAfter)
 
 	jiraServer := &codebaseApi.JiraServer{}
 	err = fakeCl.Get(context.Background(), types.NamespacedName{
@@ -232,4 +256,11 @@ func TestReconcileJiraServer_Reconcile_ShouldPass(t *testing.T) {
 
 	require.NoError(t, err)
 	require.Equal(t, statusFinished, jiraServer.Status.Status)
+	assert.Equal(t, "9.12.2", jiraServer.Status.Version)
+	assert.Equal(t, []codebaseApi.JiraProjectStatus{{Key: "APP"}}, jiraServer.Status.Projects)
+	assert.True(t, meta.IsStatusConditionTrue(jiraServer.Status.Conditions, codebaseApi.JiraServerConditionConnected))
+	assert.True(t, meta.IsStatusConditionTrue(
+		jiraServer.Status.Conditions,
+		codebaseApi.JiraServerConditionPermissionsSufficient,
+	))
 }
@@ -0,0 +1,329 @@
+package releasenotes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	gojira "github.com/andygrunwald/go-jira"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/releasenotes"
+)
+
+const jiraServerUnavailableRequeueTime = time.Minute
+
+// ReconcileReleaseNotes generates the release notes of the released versions of CodebaseBranches.
+// A version is released when the branch moves on to the next version, or on demand with the release-notes annotation.
+type ReconcileReleaseNotes struct {
+	client        client.Client
+	newJiraClient func(ctx context.Context, js *codebaseApi.JiraServer) (jira.Client, error)
+}
+
+func NewReconcileReleaseNotes(k8sClient client.Client, jiraClients *jira.ClientPool) *ReconcileReleaseNotes {
+	return &ReconcileReleaseNotes{
+		client: k8sClient,
+		newJiraClient: func(ctx context.Context, js *codebaseApi.JiraServer) (jira.Client, error) {
+			server, err := jira.ServerFromSecret(ctx, k8sClient, js)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Jira credentials: %w", err)
+			}
+
+			jc, err := jiraClients.Client(js, server)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create Jira client: %w", err)
+			}
+
+			return jc, nil
+		},
+	}
+}
+
+func (r *ReconcileReleaseNotes) SetupWithManager(mgr ctrl.Manager) error {
+	p := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObject, ok := e.ObjectOld.(*codebaseApi.CodebaseBranch)
+			if !ok {
+				return false
+			}
+
+			newObject, ok := e.ObjectNew.(*codebaseApi.CodebaseBranch)
+			if !ok {
+				return false
+			}
+
+			return !slices.Equal(oldObject.Status.VersionHistory, newObject.Status.VersionHistory) ||
+				newObject.GetAnnotations()[codebaseApi.ReleaseNotesAnnotation] != ""
+		},
+		DeleteFunc: func(event.DeleteEvent) bool {
+			return false
+		},
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("release-notes").
+		For(&codebaseApi.CodebaseBranch{}, builder.WithPredicates(p)).
+		Complete(r)
+	if err != nil {
+		return fmt.Errorf("failed to build ReleaseNotes controller: %w", err)
+	}
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups="",namespace=placeholder,resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// Reconcile generates the release notes of the versions of the CodebaseBranch that have been released
+// and stores them in the release notes ConfigMap of the branch.
+func (r *ReconcileReleaseNotes) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	codebaseBranch := &codebaseApi.CodebaseBranch{}
+	if err := r.client.Get(ctx, request.NamespacedName, codebaseBranch); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get CodebaseBranch: %w", err)
+	}
+
+	cm, err := releasenotes.GetConfigMap(ctx, r.client, codebaseBranch)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	requested := codebaseBranch.GetAnnotations()[codebaseApi.ReleaseNotesAnnotation]
+
+	if err = trimVersions(ctx, r.client, codebaseBranch, cm, requested); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	versions := releasedVersions(codebaseBranch, cm)
+	if requested != "" && !slices.Contains(versions, requested) {
+		versions = append(versions, requested)
+	}
+
+	if len(versions) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	js, err := r.getJiraServer(ctx, codebaseBranch)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	var jc jira.Client
+
+	if js != nil {
+		if !js.Status.Available {
+			log.Info("JiraServer is not available. Waiting for it to generate release notes.", "jiraserver", js.Name)
+
+			return reconcile.Result{RequeueAfter: jiraServerUnavailableRequeueTime}, nil
+		}
+
+		if jc, err = r.newJiraClient(ctx, js); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	for _, version := range versions {
+		if err = r.generate(ctx, codebaseBranch, cm, version, js, jc); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to generate release notes of version %s: %w", version, err)
+		}
+
+		log.Info("Release notes have been generated", "version", version)
+	}
+
+	if requested != "" {
+		patch := client.MergeFrom(codebaseBranch.DeepCopy())
+		delete(codebaseBranch.Annotations, codebaseApi.ReleaseNotesAnnotation)
+
+		if err = r.client.Patch(ctx, codebaseBranch, patch); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to remove release notes annotation: %w", err)
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// trimVersions removes the changes and release notes of the versions that dropped out of
+// status.versionHistory, which the version history retention of the codebase limits, from
+// the ConfigMap. Branches without a version history are left as they are.
+func trimVersions(
+	ctx context.Context,
+	c client.Client,
+	codebaseBranch *codebaseApi.CodebaseBranch,
+	cm *corev1.ConfigMap,
+	requested string,
+) error {
+	if cm == nil || len(codebaseBranch.Status.VersionHistory) == 0 {
+		return nil
+	}
+
+	keep := slices.Clone(codebaseBranch.Status.VersionHistory)
+
+	if codebaseBranch.Spec.Version != nil {
+		keep = append(keep, *codebaseBranch.Spec.Version)
+	}
+
+	if requested != "" {
+		keep = append(keep, requested)
+	}
+
+	//nolint:wrapcheck // TrimVersions errors are descriptive
+	return releasenotes.TrimVersions(ctx, c, codebaseBranch, keep)
+}
+
+// releasedVersions returns the previous versions of the branch that have changes but no release notes yet.
+func releasedVersions(codebaseBranch *codebaseApi.CodebaseBranch, cm *corev1.ConfigMap) []string {
+	history := codebaseBranch.Status.VersionHistory
+	if len(history) < 2 {
+		return nil
+	}
+
+	var versions []string
+
+	for _, version := range history[:len(history)-1] {
+		if _, ok, _ := releasenotes.GetChanges(cm, version); ok && !releasenotes.HasNotes(cm, version) {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions
+}
+
+// getJiraServer returns the JiraServer of the codebase or nil if the codebase does not use Jira.
+func (r *ReconcileReleaseNotes) getJiraServer(
+	ctx context.Context,
+	codebaseBranch *codebaseApi.CodebaseBranch,
+) (*codebaseApi.JiraServer, error) {
+	codebase := &codebaseApi.Codebase{}
+	if err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: codebaseBranch.Namespace,
+		Name:      codebaseBranch.Spec.CodebaseName,
+	}, codebase); err != nil {
+		return nil, fmt.Errorf("failed to get Codebase: %w", err)
+	}
+
+	if codebase.Spec.JiraServer == nil || *codebase.Spec.JiraServer == "" {
+		return nil, nil
+	}
+
+	js := &codebaseApi.JiraServer{}
+	if err := r.client.Get(ctx, types.NamespacedName{
+		Namespace: codebaseBranch.Namespace,
+		Name:      *codebase.Spec.JiraServer,
+	}, js); err != nil {
+		return nil, fmt.Errorf("failed to get JiraServer: %w", err)
+	}
+
+	return js, nil
+}
+
+func (r *ReconcileReleaseNotes) generate(
+	ctx context.Context,
+	codebaseBranch *codebaseApi.CodebaseBranch,
+	cm *corev1.ConfigMap,
+	version string,
+	js *codebaseApi.JiraServer,
+	jc jira.Client,
+) error {
+	changes, _, err := releasenotes.GetChanges(cm, version)
+	if err != nil {
+		return err
+	}
+
+	notes := &releasenotes.Notes{
+		Codebase:   codebaseBranch.Spec.CodebaseName,
+		Branch:     codebaseBranch.Spec.BranchName,
+		Version:    version,
+		ReleasedAt: time.Now(),
+		Commits:    changes.Commits,
+	}
+
+	var projects []string
+
+	for _, ticket := range changes.Tickets {
+		if jc == nil {
+			notes.AddIssue(releasenotes.OtherIssueType, releasenotes.Issue{Key: ticket})
+			continue
+		}
+
+		issue, err := jc.GetIssue(ctx, ticket)
+		if errors.Is(err, jira.ErrNotFound) {
+			ctrl.LoggerFrom(ctx).Info("Jira issue is not found. Add it without details.", "issue", ticket)
+
+			notes.AddIssue(releasenotes.OtherIssueType, releasenotes.Issue{Key: ticket})
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		notes.AddIssue(issueType(issue), releasenotes.Issue{
+			Key:     ticket,
+			Summary: issueSummary(issue),
+			Status:  issueStatus(issue),
+			URL:     fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(js.Spec.RootUrl, "/"), ticket),
+		})
+
+		if issue.Fields != nil && issue.Fields.Project.Key != "" && !slices.Contains(projects, issue.Fields.Project.Key) {
+			projects = append(projects, issue.Fields.Project.Key)
+		}
+	}
+
+	if err = releasenotes.StoreNotes(ctx, r.client, codebaseBranch, notes); err != nil {
+		return err
+	}
+
+	if js == nil || !js.Spec.ReleaseFixVersions {
+		return nil
+	}
+
+	for _, project := range projects {
+		if err = jc.ReleaseFixVersion(ctx, project, version); err != nil {
+			return fmt.Errorf("failed to release fix version in project %s: %w", project, err)
+		}
+	}
+
+	return nil
+}
+
+func issueType(issue *gojira.Issue) string {
+	if issue.Fields == nil {
+		return releasenotes.OtherIssueType
+	}
+
+	return issue.Fields.Type.Name
+}
+
+func issueSummary(issue *gojira.Issue) string {
+	if issue.Fields == nil {
+		return ""
+	}
+
+	return issue.Fields.Summary
+}
+
+func issueStatus(issue *gojira.Issue) string {
+	if issue.Fields == nil || issue.Fields.Status == nil {
+		return ""
+	}
+
+	return issue.Fields.Status.Name
+}
@@ -0,0 +1,223 @@
+package releasenotes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gojira "github.com/andygrunwald/go-jira"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	jiraMocks "github.com/epam/edp-codebase-operator/v2/pkg/client/jira/mocks"
+	"github.com/epam/edp-codebase-operator/v2/pkg/releasenotes"
+)
+
+func TestReconcileReleaseNotes_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	jiraServerName := "jira"
+
+	codebase := func(jiraServer *string) *codebaseApi.Codebase {
+		return &codebaseApi.Codebase{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       codebaseApi.CodebaseSpec{JiraServer: jiraServer},
+		}
+	}
+
+	jiraServer := func(available, releaseFixVersions bool) *codebaseApi.JiraServer {
+		return &codebaseApi.JiraServer{
+			ObjectMeta: metav1.ObjectMeta{Name: jiraServerName, Namespace: "default"},
+			Spec: codebaseApi.JiraServerSpec{
+				RootUrl:            "https://jira.example.com/",
+				ReleaseFixVersions: releaseFixVersions,
+			},
+			Status: codebaseApi.JiraServerStatus{Available: available},
+		}
+	}
+
+	codebaseBranch := func(annotation string, history ...string) *codebaseApi.CodebaseBranch {
+		cb := &codebaseApi.CodebaseBranch{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-main", Namespace: "default", UID: "uid"},
+			Spec:       codebaseApi.CodebaseBranchSpec{CodebaseName: "app", BranchName: "main"},
+			Status:     codebaseApi.CodebaseBranchStatus{VersionHistory: history},
+		}
+
+		if annotation != "" {
+			cb.Annotations = map[string]string{codebaseApi.ReleaseNotesAnnotation: annotation}
+		}
+
+		return cb
+	}
+
+	issue := func(project, issueType, summary string) *gojira.Issue {
+		return &gojira.Issue{
+			Fields: &gojira.IssueFields{
+				Project: gojira.Project{Key: project},
+				Type:    gojira.IssueType{Name: issueType},
+				Summary: summary,
+				Status:  &gojira.Status{Name: "Done"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		codebaseBranch *codebaseApi.CodebaseBranch
+		objects        []client.Object
+		jiraClient     func(t *testing.T) jira.Client
+		want           reconcile.Result
+		wantErr        require.ErrorAssertionFunc
+		wantNotes      map[string][]string
+		wantNoNotes    []string
+		wantTrimmed    []string
+	}{
+		{
+			name:           "should generate notes of the previous version",
+			codebaseBranch: codebaseBranch("", "0.1.0", "0.2.0"),
+			objects:        []client.Object{codebase(&jiraServerName), jiraServer(true, true)},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("GetIssue", mock.Anything, "APP-1").Return(issue("APP", "Bug", "Fix crash"), nil)
+				m.On("GetIssue", mock.Anything, "APP-2").Return(nil, jira.ErrNotFound)
+				m.On("ReleaseFixVersion", mock.Anything, "APP", "0.1.0").Return(nil)
+
+				return m
+			},
+			wantErr: require.NoError,
+			wantNotes: map[string][]string{
+				"0.1.0": {"## Bug", "- [APP-1](https://jira.example.com/browse/APP-1) Fix crash", "## Other", "- APP-2"},
+			},
+			wantNoNotes: []string{"0.2.0"},
+		},
+		{
+			name:           "should generate notes of the requested version without Jira",
+			codebaseBranch: codebaseBranch("0.2.0", "0.1.0", "0.2.0"),
+			objects:        []client.Object{codebase(nil)},
+			wantErr:        require.NoError,
+			wantNotes: map[string][]string{
+				"0.1.0": {"## Other", "- APP-1"},
+				"0.2.0": {"## Other", "- APP-3"},
+			},
+		},
+		{
+			name:           "should wait for an unavailable JiraServer",
+			codebaseBranch: codebaseBranch("", "0.1.0", "0.2.0"),
+			objects:        []client.Object{codebase(&jiraServerName), jiraServer(false, false)},
+			want:           reconcile.Result{RequeueAfter: jiraServerUnavailableRequeueTime},
+			wantErr:        require.NoError,
+			wantNoNotes:    []string{"0.1.0"},
+		},
+		{
+			name:           "should fail on Jira errors",
+			codebaseBranch: codebaseBranch("", "0.1.0", "0.2.0"),
+			objects:        []client.Object{codebase(&jiraServerName), jiraServer(true, false)},
+			jiraClient: func(t *testing.T) jira.Client {
+				m := jiraMocks.NewMockClient(t)
+				m.On("GetIssue", mock.Anything, "APP-1").Return(nil, errors.New("connection refused"))
+
+				return m
+			},
+			wantErr:     require.Error,
+			wantNoNotes: []string{"0.1.0"},
+		},
+		{
+			name:           "should trim versions that dropped out of the version history",
+			codebaseBranch: codebaseBranch("", "0.2.0", "0.3.0"),
+			objects:        []client.Object{codebase(nil)},
+			wantErr:        require.NoError,
+			wantNotes: map[string][]string{
+				"0.2.0": {"## Other", "- APP-3"},
+			},
+			wantTrimmed: []string{"0.1.0"},
+		},
+		{
+			name:           "should skip the current version",
+			codebaseBranch: codebaseBranch("", "0.2.0"),
+			objects:        []client.Object{codebase(&jiraServerName)},
+			wantErr:        require.NoError,
+			wantNoNotes:    []string{"0.2.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(tt.objects, tt.codebaseBranch)...).
+				Build()
+			ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+
+			require.NoError(t, releasenotes.RecordChanges(ctx, k8sClient, tt.codebaseBranch, "0.1.0",
+				[]string{"APP-1", "APP-2"}, []string{"abc"}))
+			require.NoError(t, releasenotes.RecordChanges(ctx, k8sClient, tt.codebaseBranch, "0.2.0",
+				[]string{"APP-3"}, nil))
+
+			r := &ReconcileReleaseNotes{
+				client: k8sClient,
+				newJiraClient: func(context.Context, *codebaseApi.JiraServer) (jira.Client, error) {
+					if tt.jiraClient == nil {
+						t.Fatal("Jira client should not be created")
+					}
+
+					return tt.jiraClient(t), nil
+				},
+			}
+
+			got, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "app-main",
+			}})
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+
+			cm, err := releasenotes.GetConfigMap(ctx, k8sClient, tt.codebaseBranch)
+			require.NoError(t, err)
+
+			for version, lines := range tt.wantNotes {
+				require.True(t, releasenotes.HasNotes(cm, version), version)
+
+				for _, line := range lines {
+					assert.Contains(t, cm.Data[releasenotes.MarkdownKey(version)], line)
+				}
+
+				assert.Contains(t, cm.Data[releasenotes.JSONKey(version)], `"version": "`+version+`"`)
+			}
+
+			for _, version := range tt.wantNoNotes {
+				assert.False(t, releasenotes.HasNotes(cm, version), version)
+			}
+
+			for _, version := range tt.wantTrimmed {
+				_, ok, err := releasenotes.GetChanges(cm, version)
+				require.NoError(t, err)
+				assert.False(t, ok, version)
+			}
+
+			cb := &codebaseApi.CodebaseBranch{}
+			require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "app-main"}, cb))
+
+			if err == nil {
+				assert.NotContains(t, cb.Annotations, codebaseApi.ReleaseNotesAnnotation)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// Package httpretry implements the transport the clients of rate-limited APIs, such as the
+// git providers and Jira, send requests with.
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned when the server asks to wait longer than a request can wait.
+// Options.ErrRateLimited replaces it with an error of the API.
+var ErrRateLimited = errors.New("API rate limit is exceeded")
+
+// Options configure a Transport.
+type Options struct {
+	// Limit and Burst configure the token bucket requests are throttled with.
+	Limit rate.Limit
+	Burst int
+
+	// MaxRetries is how many times a throttled or failed request is retried. RetryBaseDelay is
+	// the first backoff delay, which doubles with every retry unless the server asks for another.
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+
+	// MaxWait is the longest a request waits for the server. When the server asks to wait
+	// longer, the request fails with ErrRateLimited at once, so that reconcilers requeue
+	// instead of blocking their workers.
+	MaxWait time.Duration
+
+	// ErrRateLimited is returned, wrapped, instead of ErrRateLimited.
+	ErrRateLimited error
+
+	// IsThrottled reports whether the server did not process the request because of its rate
+	// limit, in addition to 429 Too Many Requests and 503 Service Unavailable.
+	IsThrottled func(resp *http.Response) bool
+
+	// RetryAfter returns how long the server asks to wait, in addition to the Retry-After header.
+	RetryAfter func(resp *http.Response) (time.Duration, bool)
+
+	// OnResponse is called with the response, or the error, of every request sent.
+	OnResponse func(req *http.Request, resp *http.Response, err error, took time.Duration)
+
+	// OnRetry is called with the response of every request that is retried.
+	OnRetry func(req *http.Request, resp *http.Response)
+}
+
+// Transport throttles requests with a token bucket, holds them back while the server asks to
+// wait and retries throttled and failed requests.
+type Transport struct {
+	opts    Options
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	base        http.RoundTripper
+	pausedUntil time.Time
+}
+
+// New creates a Transport that sends requests with the base transport.
+func New(base http.RoundTripper, opts Options) *Transport {
+	if opts.ErrRateLimited == nil {
+		opts.ErrRateLimited = ErrRateLimited
+	}
+
+	return &Transport{
+		opts:    opts,
+		limiter: rate.NewLimiter(opts.Limit, opts.Burst),
+		base:    base,
+	}
+}
+
+// SetBase replaces the transport underneath, keeping the throttling state. The idle
+// connections of the replaced transport were made with the previous settings, so they are
+// closed unless the transport is shared.
+func (t *Transport) SetBase(base, shared http.RoundTripper) {
+	t.mu.Lock()
+	replaced := t.base
+	t.base = base
+	t.mu.Unlock()
+
+	closeIdleConnections(replaced, shared)
+}
+
+// CloseIdleConnections closes the idle connections of the transport underneath unless it is shared.
+func (t *Transport) CloseIdleConnections(shared http.RoundTripper) {
+	t.mu.Lock()
+	base := t.base
+	t.mu.Unlock()
+
+	closeIdleConnections(base, shared)
+}
+
+// PauseUntil holds back all requests until the time, e.g. when the server reports that its
+// rate limit is exhausted until then.
+func (t *Transport) PauseUntil(until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if until.After(t.pausedUntil) {
+		t.pausedUntil = until
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		// Requests without a body, such as GET, have no GetBody and are sent again as is.
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		t.mu.Lock()
+		base := t.base
+		t.mu.Unlock()
+
+		start := time.Now()
+
+		resp, err := base.RoundTrip(req)
+
+		if t.opts.OnResponse != nil {
+			t.opts.OnResponse(req, resp, err, time.Since(start))
+		}
+
+		if err != nil {
+			return nil, err //nolint:wrapcheck // RoundTrip errors are returned as is
+		}
+
+		delay, ok := t.retryDelay(req, resp, attempt)
+		if !ok {
+			return resp, nil
+		}
+
+		if t.opts.OnRetry != nil {
+			t.opts.OnRetry(req, resp)
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if err = sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait blocks until the request may be sent.
+func (t *Transport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	pausedUntil := t.pausedUntil
+	t.mu.Unlock()
+
+	pause := time.Until(pausedUntil)
+	if pause > t.opts.MaxWait {
+		return fmt.Errorf("%w until %s", t.opts.ErrRateLimited, pausedUntil.Format(time.RFC3339))
+	}
+
+	if err := sleep(ctx, pause); err != nil {
+		return err
+	}
+
+	if err := t.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("failed to wait for request rate limiter: %w", err)
+	}
+
+	return nil
+}
+
+// retryDelay reports whether the request should be retried and after which delay. Throttled
+// requests were not processed and are retried whatever the method; other server errors are
+// retried for idempotent methods only. When the server asks a throttled request to wait, all
+// requests wait.
+func (t *Transport) retryDelay(req *http.Request, resp *http.Response, attempt int) (time.Duration, bool) {
+	throttled := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusServiceUnavailable ||
+		(t.opts.IsThrottled != nil && t.opts.IsThrottled(resp))
+
+	retryAfter, hasRetryAfter := ParseRetryAfter(resp.Header.Get("Retry-After"))
+	if !hasRetryAfter && t.opts.RetryAfter != nil {
+		retryAfter, hasRetryAfter = t.opts.RetryAfter(resp)
+	}
+
+	if throttled && hasRetryAfter {
+		t.PauseUntil(time.Now().Add(retryAfter))
+	}
+
+	if attempt >= t.opts.MaxRetries || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return 0, false
+	}
+
+	serverError := resp.StatusCode == http.StatusInternalServerError ||
+		resp.StatusCode == http.StatusBadGateway ||
+		resp.StatusCode == http.StatusGatewayTimeout
+
+	if !throttled && !(serverError && isIdempotent(req.Method)) {
+		return 0, false
+	}
+
+	delay := t.opts.RetryBaseDelay * time.Duration(math.Pow(2, float64(attempt)))
+	if hasRetryAfter {
+		delay = retryAfter
+	}
+
+	if delay > t.opts.MaxWait {
+		return 0, false
+	}
+
+	return max(delay, 0), true
+}
+
+// ParseRetryAfter parses the Retry-After header, which holds either seconds or an HTTP date.
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func closeIdleConnections(transport, shared http.RoundTripper) {
+	if transport == shared {
+		return
+	}
+
+	if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck // context errors are returned as is
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,194 @@
+package httpretry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func newTestTransport(base http.RoundTripper, opts Options) *Transport {
+	opts.Limit = rate.Inf
+	opts.MaxRetries = 2
+	opts.RetryBaseDelay = time.Millisecond
+	opts.MaxWait = time.Minute
+
+	return New(base, opts)
+}
+
+func TestTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		method       string
+		statusCodes  []int
+		wantCode     int
+		wantRequests int32
+	}{
+		{
+			name:         "should retry throttled requests",
+			method:       http.MethodPost,
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusOK},
+			wantCode:     http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "should retry server errors of idempotent requests",
+			method:       http.MethodGet,
+			statusCodes:  []int{http.StatusBadGateway, http.StatusOK},
+			wantCode:     http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "should not retry server errors of non-idempotent requests",
+			method:       http.MethodPost,
+			statusCodes:  []int{http.StatusBadGateway, http.StatusOK},
+			wantCode:     http.StatusBadGateway,
+			wantRequests: 1,
+		},
+		{
+			name:         "should give up after the last retry",
+			method:       http.MethodGet,
+			statusCodes:  []int{http.StatusServiceUnavailable},
+			wantCode:     http.StatusServiceUnavailable,
+			wantRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				i := int(requests.Add(1)) - 1
+				w.WriteHeader(tt.statusCodes[min(i, len(tt.statusCodes)-1)])
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, server.URL, strings.NewReader("{}"))
+			require.NoError(t, err)
+
+			resp, err := newTestTransport(http.DefaultTransport, Options{}).RoundTrip(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.wantCode, resp.StatusCode)
+			assert.Equal(t, tt.wantRequests, requests.Load())
+		})
+	}
+}
+
+func TestTransport_RoundTrip_Hooks(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var responses, retries int
+
+	transport := newTestTransport(http.DefaultTransport, Options{
+		IsThrottled: func(resp *http.Response) bool {
+			return resp.StatusCode == http.StatusForbidden
+		},
+		RetryAfter: func(*http.Response) (time.Duration, bool) {
+			return time.Millisecond, true
+		},
+		OnResponse: func(*http.Request, *http.Response, error, time.Duration) {
+			responses++
+		},
+		OnRetry: func(*http.Request, *http.Response) {
+			retries++
+		},
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, responses)
+	assert.Equal(t, 1, retries)
+}
+
+func TestTransport_PauseUntil(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newTestTransport(http.DefaultTransport, Options{})
+	transport.PauseUntil(time.Now().Add(time.Hour))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req) //nolint:bodyclose // the request must fail
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.Zero(t, requests.Load(), "requests must be held back while paused")
+}
+
+type idleConnectionsCloser struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (c *idleConnectionsCloser) CloseIdleConnections() {
+	c.closed = true
+}
+
+func TestTransport_SetBase(t *testing.T) {
+	t.Parallel()
+
+	shared := &idleConnectionsCloser{}
+	previous := &idleConnectionsCloser{}
+	transport := New(previous, Options{})
+
+	transport.SetBase(shared, shared)
+	assert.True(t, previous.closed, "the connections of the replaced transport must be closed")
+
+	transport.SetBase(&idleConnectionsCloser{}, shared)
+	assert.False(t, shared.closed, "the connections of the shared transport must be kept")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	got, ok := ParseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, got)
+
+	got, ok = ParseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, time.Hour.Seconds(), got.Seconds(), 5)
+
+	_, ok = ParseRetryAfter("soon")
+	assert.False(t, ok)
+}
@@ -3,6 +3,7 @@ package autodeploy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -142,11 +143,15 @@ func (*StrategyManager) getLatestTag(
 	ctx context.Context,
 	imageStream *codebaseApi.CodebaseImageStream,
 ) (string, codebaseApi.Tag, error) {
-	t, err := codebaseimagestream.GetLastTag(imageStream.Spec.Tags, ctrl.LoggerFrom(ctx))
-	if err != nil {
+	t, err := codebaseimagestream.GetLatestTag(imageStream.Spec.Tags, imageStream.Spec.LatestTag, ctrl.LoggerFrom(ctx))
+	if errors.Is(err, codebaseimagestream.ErrLatestTagNotFound) {
 		return "", codebaseApi.Tag{}, ErrLasTagNotFound
 	}
 
+	if err != nil {
+		return "", codebaseApi.Tag{}, fmt.Errorf("failed to get latest tag of %s: %w", imageStream.Name, err)
+	}
+
 	return imageStream.Spec.Codebase, t, nil
 }
 
@@ -116,6 +116,54 @@ func TestStrategyManager_GetAppPayloadForAllLatestStrategy(t *testing.T) {
 			want:    `{"app1":{"imageTag":"1.3"},"app2":{"imageTag":"1.0"}}`,
 			wantErr: require.NoError,
 		},
+		{
+			name: "get payload with latest tag selection",
+			pipeline: &pipelineAPi.CDPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pipeline",
+					Namespace: "default",
+				},
+				Spec: pipelineAPi.CDPipelineSpec{
+					InputDockerStreams: []string{"app1-main"},
+				},
+			},
+			k8sClient: func(t *testing.T) client.Client {
+				return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&codebaseApi.CodebaseImageStream{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "app1-main",
+							Namespace: "default",
+							Labels: map[string]string{
+								codebaseApi.CodebaseBranchLabel: "app1-main",
+							},
+						},
+						Spec: codebaseApi.CodebaseImageStreamSpec{
+							Codebase: "app1",
+							LatestTag: &codebaseApi.LatestTagSelection{
+								Strategy: codebaseApi.LatestTagStrategySemver,
+								Pattern:  `^\d+\.\d+\.\d+$`,
+							},
+							Tags: []codebaseApi.Tag{
+								{
+									Name:    "1.10.0",
+									Created: time.Now().Format(time.RFC3339),
+								},
+								{
+									Name:    "1.9.0",
+									Created: time.Now().Add(time.Hour).Format(time.RFC3339),
+								},
+								{
+									Name:    "1.11.0-SNAPSHOT.1",
+									Created: time.Now().Add(time.Hour * 2).Format(time.RFC3339),
+								},
+							},
+						},
+					},
+				).Build()
+			},
+			want:    `{"app1":{"imageTag":"1.10.0"}}`,
+			wantErr: require.NoError,
+		},
 		{
 			name: "latest tag not found",
 			pipeline: &pipelineAPi.CDPipeline{
@@ -0,0 +1,187 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+)
+
+// cachingClient is a Client that caches project and issue type metadata and skips issue updates
+// that were already applied, so that JiraIssueMetadata of the same tickets do not repeat requests.
+// Creating a field value drops the cached issue type metadata of the project.
+type cachingClient struct {
+	Client
+
+	projects      *ttlCache[*jira.Project]
+	issueTypeMeta *ttlCache[map[string]IssueTypeMeta]
+	applied       *ttlCache[struct{}]
+}
+
+func newCachingClient(c Client, ttl time.Duration) *cachingClient {
+	return &cachingClient{
+		Client:        c,
+		projects:      newTTLCache[*jira.Project](ttl),
+		issueTypeMeta: newTTLCache[map[string]IssueTypeMeta](ttl),
+		applied:       newTTLCache[struct{}](ttl),
+	}
+}
+
+func (c *cachingClient) GetProjectInfo(issueId string) (*jira.Project, error) {
+	if project, ok := c.projects.get(issueId); ok {
+		return project, nil
+	}
+
+	project, err := c.Client.GetProjectInfo(issueId)
+	if err != nil {
+		return nil, err
+	}
+
+	c.projects.set(issueId, project)
+
+	return project, nil
+}
+
+func (c *cachingClient) GetIssueTypeMeta(
+	ctx context.Context,
+	projectID, issueTypeID string,
+) (map[string]IssueTypeMeta, error) {
+	key := projectID + "/" + issueTypeID
+
+	if meta, ok := c.issueTypeMeta.get(key); ok {
+		return meta, nil
+	}
+
+	meta, err := c.Client.GetIssueTypeMeta(ctx, projectID, issueTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.issueTypeMeta.set(key, meta)
+
+	return meta, nil
+}
+
+func (c *cachingClient) CreateFixVersionValue(ctx context.Context, projectId int, versionName string) error {
+	defer c.dropIssueTypeMeta(projectId)
+
+	return c.Client.CreateFixVersionValue(ctx, projectId, versionName)
+}
+
+func (c *cachingClient) CreateComponentValue(ctx context.Context, projectId int, componentName string) error {
+	defer c.dropIssueTypeMeta(projectId)
+
+	return c.Client.CreateComponentValue(ctx, projectId, componentName)
+}
+
+func (c *cachingClient) ApplyTagsToIssue(issue string, tags map[string]interface{}) error {
+	body, err := json.Marshal(tags)
+	if err != nil {
+		return c.Client.ApplyTagsToIssue(issue, tags)
+	}
+
+	key := "tags/" + issue + "/" + string(body)
+
+	if _, ok := c.applied.get(key); ok {
+		return nil
+	}
+
+	if err = c.Client.ApplyTagsToIssue(issue, tags); err != nil {
+		return err
+	}
+
+	c.applied.set(key, struct{}{})
+
+	return nil
+}
+
+func (c *cachingClient) CreateIssueLink(issueId, title, url string) error {
+	key := "link/" + issueId + "/" + title + "/" + url
+
+	if _, ok := c.applied.get(key); ok {
+		return nil
+	}
+
+	if err := c.Client.CreateIssueLink(issueId, title, url); err != nil {
+		return err
+	}
+
+	c.applied.set(key, struct{}{})
+
+	return nil
+}
+
+func (c *cachingClient) dropIssueTypeMeta(projectId int) {
+	prefix := strconv.Itoa(projectId) + "/"
+
+	c.issueTypeMeta.deleteFunc(func(key string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+type ttlCache[T any] struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	items map[string]ttlCacheItem[T]
+	now   func() time.Time
+}
+
+type ttlCacheItem[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func newTTLCache[T any](ttl time.Duration) *ttlCache[T] {
+	return &ttlCache[T]{
+		ttl:   ttl,
+		items: make(map[string]ttlCacheItem[T]),
+		now:   time.Now,
+	}
+}
+
+func (c *ttlCache[T]) get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok || !c.now().Before(item.expires) {
+		delete(c.items, key)
+
+		var zero T
+
+		return zero, false
+	}
+
+	return item.value, true
+}
+
+func (c *ttlCache[T]) set(key string, value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	// Expired items are dropped on writes, so that the cache does not grow with tickets seen once.
+	for k, item := range c.items {
+		if !now.Before(item.expires) {
+			delete(c.items, k)
+		}
+	}
+
+	c.items[key] = ttlCacheItem[T]{value: value, expires: now.Add(c.ttl)}
+}
+
+func (c *ttlCache[T]) deleteFunc(del func(key string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.items {
+		if del(k) {
+			delete(c.items, k)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingClient counts the calls of the methods that the cachingClient caches.
+type countingClient struct {
+	Client
+
+	calls      map[string]int
+	applyError error
+}
+
+func (c *countingClient) GetProjectInfo(string) (*jira.Project, error) {
+	c.calls["GetProjectInfo"]++
+
+	return &jira.Project{ID: "10"}, nil
+}
+
+func (c *countingClient) GetIssueTypeMeta(context.Context, string, string) (map[string]IssueTypeMeta, error) {
+	c.calls["GetIssueTypeMeta"]++
+
+	return map[string]IssueTypeMeta{"fixVersions": {FieldID: "fixVersions"}}, nil
+}
+
+func (c *countingClient) CreateFixVersionValue(context.Context, int, string) error {
+	c.calls["CreateFixVersionValue"]++
+
+	return nil
+}
+
+func (c *countingClient) ApplyTagsToIssue(string, map[string]interface{}) error {
+	c.calls["ApplyTagsToIssue"]++
+
+	return c.applyError
+}
+
+func (c *countingClient) CreateIssueLink(string, string, string) error {
+	c.calls["CreateIssueLink"]++
+
+	return nil
+}
+
+func TestCachingClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	wrapped := &countingClient{calls: map[string]int{}}
+	c := newCachingClient(wrapped, time.Minute)
+
+	for range 2 {
+		_, err := c.GetProjectInfo("APP-1")
+		require.NoError(t, err)
+
+		_, err = c.GetIssueTypeMeta(ctx, "10", "1")
+		require.NoError(t, err)
+
+		require.NoError(t, c.ApplyTagsToIssue("APP-1", map[string]interface{}{"update": "1.0.0"}))
+		require.NoError(t, c.CreateIssueLink("APP-1", "build", "https://ci/1"))
+	}
+
+	assert.Equal(t, 1, wrapped.calls["GetProjectInfo"])
+	assert.Equal(t, 1, wrapped.calls["GetIssueTypeMeta"])
+	assert.Equal(t, 1, wrapped.calls["ApplyTagsToIssue"], "the same update must be applied once")
+	assert.Equal(t, 1, wrapped.calls["CreateIssueLink"], "the same link must be created once")
+
+	require.NoError(t, c.ApplyTagsToIssue("APP-1", map[string]interface{}{"update": "1.0.1"}))
+	assert.Equal(t, 2, wrapped.calls["ApplyTagsToIssue"], "another update must be applied")
+
+	require.NoError(t, c.CreateFixVersionValue(ctx, 10, "1.0.1"))
+
+	_, err := c.GetIssueTypeMeta(ctx, "10", "1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, wrapped.calls["GetIssueTypeMeta"], "a new field value must drop the project metadata")
+}
+
+func TestCachingClient_FailedUpdatesAreRetried(t *testing.T) {
+	t.Parallel()
+
+	wrapped := &countingClient{calls: map[string]int{}, applyError: errors.New("rate limited")}
+	c := newCachingClient(wrapped, time.Minute)
+
+	require.Error(t, c.ApplyTagsToIssue("APP-1", map[string]interface{}{"update": "1.0.0"}))
+	require.Error(t, c.ApplyTagsToIssue("APP-1", map[string]interface{}{"update": "1.0.0"}))
+	assert.Equal(t, 2, wrapped.calls["ApplyTagsToIssue"])
+}
+
+func TestTTLCache_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cache := newTTLCache[string](time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.set("key", "value")
+
+	got, ok := cache.get("key")
+	require.True(t, ok)
+	assert.Equal(t, "value", got)
+
+	now = now.Add(time.Minute)
+
+	_, ok = cache.get("key")
+	assert.False(t, ok)
+}
@@ -0,0 +1,158 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+// Keys of the JiraServer credential secret.
+const (
+	SecretKeyUsername       = "username"
+	SecretKeyPassword       = "password"
+	SecretKeyToken          = "token"
+	SecretKeyClientID       = "clientId"
+	SecretKeyClientSecret   = "clientSecret"
+	SecretKeyRefreshToken   = "refreshToken"
+	SecretKeyAccessToken    = "accessToken"
+	SecretKeyAccessTokenExp = "accessTokenExpiresAt"
+)
+
+// accessTokenRefreshMargin is how long before expiry an OAuth 2.0 access token is refreshed.
+const accessTokenRefreshMargin = time.Minute
+
+// oauthTokenURL is a variable so that tests can point it to a fake token endpoint.
+var oauthTokenURL = AtlassianTokenURL
+
+// ServerFromSecret returns the connection settings of the JiraServer with the credentials from its secret.
+// For the oauth2 auth type, the access token is refreshed when it is missing or about to expire,
+// and the new access token and the rotated refresh token are stored back into the secret.
+func ServerFromSecret(ctx context.Context, c client.Client, js *codebaseApi.JiraServer) (dto.JiraServer, error) {
+	s, err := util.GetSecret(c, js.Spec.CredentialName, js.Namespace)
+	if err != nil {
+		return dto.JiraServer{}, fmt.Errorf("failed to get secret %v: %w", js.Spec.CredentialName, err)
+	}
+
+	server := dto.JiraServer{
+		ApiUrl:   js.Spec.ApiUrl,
+		AuthType: js.Spec.AuthType,
+	}
+
+	switch js.Spec.AuthType {
+	case codebaseApi.JiraAuthTypePAT:
+		if err = requireKeys(s, SecretKeyToken); err != nil {
+			return dto.JiraServer{}, err
+		}
+
+		server.Token = string(s.Data[SecretKeyToken])
+	case codebaseApi.JiraAuthTypeCloudToken:
+		if err = requireKeys(s, SecretKeyUsername, SecretKeyToken); err != nil {
+			return dto.JiraServer{}, err
+		}
+
+		server.User = string(s.Data[SecretKeyUsername])
+		server.Token = string(s.Data[SecretKeyToken])
+	case codebaseApi.JiraAuthTypeOAuth2:
+		if err = requireKeys(s, SecretKeyClientID, SecretKeyClientSecret, SecretKeyRefreshToken); err != nil {
+			return dto.JiraServer{}, err
+		}
+
+		if server.Token, err = accessToken(ctx, c, s); err != nil {
+			return dto.JiraServer{}, err
+		}
+	case "", codebaseApi.JiraAuthTypeBasic:
+		server.User = string(s.Data[SecretKeyUsername])
+		server.Pwd = string(s.Data[SecretKeyPassword])
+	default:
+		return dto.JiraServer{}, fmt.Errorf("unsupported Jira auth type %q", js.Spec.AuthType)
+	}
+
+	return server, nil
+}
+
+func requireKeys(s *coreV1.Secret, keys ...string) error {
+	for _, key := range keys {
+		if len(s.Data[key]) == 0 {
+			return fmt.Errorf("no %s key in secret %s", key, s.Name)
+		}
+	}
+
+	return nil
+}
+
+// accessToken returns the cached OAuth 2.0 access token of the secret if it is still valid,
+// otherwise it refreshes the token and stores it in the secret.
+// If another reconciliation has rotated the refresh token in the meantime, the secret keeps its tokens.
+func accessToken(ctx context.Context, c client.Client, s *coreV1.Secret) (string, error) {
+	if token, ok := validAccessToken(s); ok {
+		return token, nil
+	}
+
+	usedRefreshToken := string(s.Data[SecretKeyRefreshToken])
+
+	token, err := RefreshOAuthToken(
+		ctx,
+		oauthTokenURL,
+		string(s.Data[SecretKeyClientID]),
+		string(s.Data[SecretKeyClientSecret]),
+		usedRefreshToken,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := token.Expiry(time.Now())
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &coreV1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, latest); err != nil {
+			return err
+		}
+
+		if string(latest.Data[SecretKeyRefreshToken]) != usedRefreshToken {
+			// Rotated by another reconciliation, keep its tokens.
+			return nil
+		}
+
+		if latest.Data == nil {
+			latest.Data = map[string][]byte{}
+		}
+
+		latest.Data[SecretKeyAccessToken] = []byte(token.AccessToken)
+		latest.Data[SecretKeyAccessTokenExp] = []byte(expiresAt.UTC().Format(time.RFC3339))
+
+		if token.RefreshToken != "" {
+			latest.Data[SecretKeyRefreshToken] = []byte(token.RefreshToken)
+		}
+
+		return c.Update(ctx, latest)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store rotated OAuth tokens in secret %s: %w", s.Name, err)
+	}
+
+	return token.AccessToken, nil
+}
+
+func validAccessToken(s *coreV1.Secret) (string, bool) {
+	token := string(s.Data[SecretKeyAccessToken])
+	if token == "" {
+		return "", false
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, string(s.Data[SecretKeyAccessTokenExp]))
+	if err != nil {
+		return "", false
+	}
+
+	return token, time.Until(expiresAt) > accessTokenRefreshMargin
+}
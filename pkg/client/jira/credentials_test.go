@@ -0,0 +1,223 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
+)
+
+func jiraServer(authType string) *codebaseApi.JiraServer {
+	return &codebaseApi.JiraServer{
+		ObjectMeta: metaV1.ObjectMeta{Name: "jira", Namespace: "default"},
+		Spec: codebaseApi.JiraServerSpec{
+			ApiUrl:         "https://jira.example.com",
+			CredentialName: "jira-secret",
+			AuthType:       authType,
+		},
+	}
+}
+
+func jiraSecret(data map[string]string) *coreV1.Secret {
+	s := &coreV1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "jira-secret", Namespace: "default"},
+		Data:       map[string][]byte{},
+	}
+
+	for k, v := range data {
+		s.Data[k] = []byte(v)
+	}
+
+	return s
+}
+
+func TestServerFromSecret(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		authType string
+		data     map[string]string
+		want     dto.JiraServer
+		wantErr  string
+	}{
+		{
+			name: "default basic auth",
+			data: map[string]string{"username": "user", "password": "pass"},
+			want: dto.JiraServer{ApiUrl: "https://jira.example.com", User: "user", Pwd: "pass"},
+		},
+		{
+			name:     "personal access token",
+			authType: codebaseApi.JiraAuthTypePAT,
+			data:     map[string]string{"token": "pat"},
+			want: dto.JiraServer{
+				ApiUrl:   "https://jira.example.com",
+				AuthType: codebaseApi.JiraAuthTypePAT,
+				Token:    "pat",
+			},
+		},
+		{
+			name:     "personal access token without token key",
+			authType: codebaseApi.JiraAuthTypePAT,
+			data:     map[string]string{"username": "user", "password": "pass"},
+			wantErr:  "no token key in secret jira-secret",
+		},
+		{
+			name:     "cloud API token",
+			authType: codebaseApi.JiraAuthTypeCloudToken,
+			data:     map[string]string{"username": "user@example.com", "token": "api-token"},
+			want: dto.JiraServer{
+				ApiUrl:   "https://jira.example.com",
+				AuthType: codebaseApi.JiraAuthTypeCloudToken,
+				User:     "user@example.com",
+				Token:    "api-token",
+			},
+		},
+		{
+			name:     "cloud API token without username",
+			authType: codebaseApi.JiraAuthTypeCloudToken,
+			data:     map[string]string{"token": "api-token"},
+			wantErr:  "no username key in secret jira-secret",
+		},
+		{
+			name:     "oauth2 with valid access token",
+			authType: codebaseApi.JiraAuthTypeOAuth2,
+			data: map[string]string{
+				"clientId":             "id",
+				"clientSecret":         "secret",
+				"refreshToken":         "refresh",
+				"accessToken":          "access",
+				"accessTokenExpiresAt": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			},
+			want: dto.JiraServer{
+				ApiUrl:   "https://jira.example.com",
+				AuthType: codebaseApi.JiraAuthTypeOAuth2,
+				Token:    "access",
+			},
+		},
+		{
+			name:     "oauth2 without refresh token",
+			authType: codebaseApi.JiraAuthTypeOAuth2,
+			data:     map[string]string{"clientId": "id", "clientSecret": "secret"},
+			wantErr:  "no refreshToken key in secret jira-secret",
+		},
+		{
+			name:     "unsupported auth type",
+			authType: "kerberos",
+			data:     map[string]string{},
+			wantErr:  `unsupported Jira auth type "kerberos"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sClient := fake.NewClientBuilder().WithObjects(jiraSecret(tt.data)).Build()
+
+			got, err := ServerFromSecret(context.Background(), k8sClient, jiraServer(tt.authType))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServerFromSecret_RefreshesOAuthToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if req["grant_type"] != "refresh_token" || req["refresh_token"] != "old-refresh" ||
+			req["client_id"] != "id" || req["client_secret"] != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"access_token":"new-access","refresh_token":"new-refresh","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	oauthTokenURL = server.URL
+
+	defer func() {
+		oauthTokenURL = AtlassianTokenURL
+	}()
+
+	k8sClient := fake.NewClientBuilder().WithObjects(jiraSecret(map[string]string{
+		"clientId":             "id",
+		"clientSecret":         "secret",
+		"refreshToken":         "old-refresh",
+		"accessToken":          "old-access",
+		"accessTokenExpiresAt": time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339),
+	})).Build()
+
+	got, err := ServerFromSecret(context.Background(), k8sClient, jiraServer(codebaseApi.JiraAuthTypeOAuth2))
+	require.NoError(t, err)
+	assert.Equal(t, "new-access", got.Token)
+
+	s := &coreV1.Secret{}
+	require.NoError(t, k8sClient.Get(
+		context.Background(),
+		types.NamespacedName{Namespace: "default", Name: "jira-secret"},
+		s,
+	))
+	assert.Equal(t, "new-refresh", string(s.Data["refreshToken"]))
+	assert.Equal(t, "new-access", string(s.Data["accessToken"]))
+
+	expiresAt, err := time.Parse(time.RFC3339, string(s.Data["accessTokenExpiresAt"]))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+	s.Data["refreshToken"] = []byte("revoked")
+	s.Data["accessToken"] = nil
+	require.NoError(t, k8sClient.Update(context.Background(), s))
+
+	_, err = ServerFromSecret(context.Background(), k8sClient, jiraServer(codebaseApi.JiraAuthTypeOAuth2))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to refresh OAuth token")
+}
+
+func TestRefreshOAuthToken_Timeout(t *testing.T) {
+	unblock := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	previous := oauthHTTPClient
+	oauthHTTPClient = &http.Client{Timeout: 10 * time.Millisecond}
+
+	defer func() {
+		oauthHTTPClient = previous
+	}()
+
+	_, err := RefreshOAuthToken(context.Background(), server.URL, "id", "secret", "refresh")
+	require.ErrorContains(t, err, "failed to request OAuth token")
+}
@@ -4,6 +4,11 @@ type JiraServer struct {
 	ApiUrl string
 	User   string
 	Pwd    string `json:"-"`
+
+	// AuthType is one of the JiraServer spec auth types. Basic auth is used when empty.
+	AuthType string
+	// Token is the bearer token for the pat and oauth2 auth types.
+	Token string `json:"-"`
 }
 
 func ConvertSpecToJiraServer(url, user, password string) JiraServer {
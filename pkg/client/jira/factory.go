@@ -2,9 +2,12 @@ package jira
 
 import (
 	"fmt"
+	"net/http"
+	"sync/atomic"
 
 	goJira "github.com/andygrunwald/go-jira"
 
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
 )
 
@@ -14,28 +17,72 @@ func (GoJiraAdapterFactory) New(js dto.JiraServer) (Client, error) {
 	rl := log.WithValues("jira dto", js)
 	rl.V(2).Info("start new Jira client creation")
 
-	client, err := initClient(js)
+	adapter, err := newAdapter(js, newAuthTransport(js, nil))
 	if err != nil {
 		return nil, err
 	}
 
 	rl.Info("Jira client has been created")
 
+	return adapter, nil
+}
+
+// newAdapter creates a GoJiraAdapter that sends requests with the transport.
+func newAdapter(js dto.JiraServer, transport http.RoundTripper) (*GoJiraAdapter, error) {
+	client, err := goJira.NewClient(&http.Client{Transport: transport}, js.ApiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new jira client: %w", err)
+	}
+
 	return &GoJiraAdapter{
 		client: *client,
 	}, nil
 }
 
-func initClient(js dto.JiraServer) (*goJira.Client, error) {
-	tp := goJira.BasicAuthTransport{
-		Username: js.User,
-		Password: js.Pwd,
+// httpClient returns an HTTP client that authenticates requests with the auth type of the server.
+func httpClient(js dto.JiraServer, base http.RoundTripper) *http.Client {
+	return &http.Client{Transport: newAuthTransport(js, base)}
+}
+
+// authTransport authenticates requests with the auth type of the server. Jira Cloud API tokens
+// are sent with basic auth in place of the password. The credentials can be replaced while the
+// transport is in use, so that a refreshed OAuth2 access token keeps the pooled client.
+type authTransport struct {
+	base   http.RoundTripper
+	server atomic.Pointer[dto.JiraServer]
+}
+
+// newAuthTransport creates an authTransport that sends requests with the base transport,
+// or with http.DefaultTransport when it is nil.
+func newAuthTransport(js dto.JiraServer, base http.RoundTripper) *authTransport {
+	if base == nil {
+		base = http.DefaultTransport
 	}
 
-	client, err := goJira.NewClient(tp.Client(), js.ApiUrl)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create new jira client: %w", err)
+	t := &authTransport{base: base}
+	t.setServer(js)
+
+	return t
+}
+
+func (t *authTransport) setServer(js dto.JiraServer) {
+	t.server.Store(&js)
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	js := t.server.Load()
+
+	// A RoundTripper must not modify the request.
+	req = req.Clone(req.Context())
+
+	switch js.AuthType {
+	case codebaseApi.JiraAuthTypePAT, codebaseApi.JiraAuthTypeOAuth2:
+		req.Header.Set("Authorization", "Bearer "+js.Token)
+	case codebaseApi.JiraAuthTypeCloudToken:
+		req.SetBasicAuth(js.User, js.Token)
+	default:
+		req.SetBasicAuth(js.User, js.Pwd)
 	}
 
-	return client, nil
+	return t.base.RoundTrip(req) //nolint:wrapcheck // RoundTrip errors are returned as is
 }
@@ -0,0 +1,63 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
+)
+
+func TestHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		js   dto.JiraServer
+		want string
+	}{
+		{
+			name: "basic",
+			js:   dto.JiraServer{User: "user", Pwd: "pass"},
+			want: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name: "pat",
+			js:   dto.JiraServer{AuthType: codebaseApi.JiraAuthTypePAT, Token: "pat"},
+			want: "Bearer pat",
+		},
+		{
+			name: "cloud API token",
+			js:   dto.JiraServer{AuthType: codebaseApi.JiraAuthTypeCloudToken, User: "user", Token: "pass"},
+			want: "Basic dXNlcjpwYXNz",
+		},
+		{
+			name: "oauth2",
+			js:   dto.JiraServer{AuthType: codebaseApi.JiraAuthTypeOAuth2, Token: "access"},
+			want: "Bearer access",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+
+			server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+			}))
+			defer server.Close()
+
+			resp, err := httpClient(tt.js, nil).Get(server.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	url "net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/andygrunwald/go-jira"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -17,6 +18,10 @@ var log = ctrl.Log.WithName("gojira_adapter")
 
 var ErrNotFound = errors.New("404")
 
+// ErrTransitionNotAvailable is returned when the workflow of an issue has no transition with the requested name
+// from its current status.
+var ErrTransitionNotAvailable = errors.New("transition is not available")
+
 // IssueTypeMeta represents issue metadata response from Jira.
 // It is not full representation of response, only fields that are used in codebase-operator.
 // See https://docs.atlassian.com/software/jira/docs/api/REST/9.4.5/#api/2/issue-getCreateIssueMetaFields.
@@ -57,7 +62,11 @@ func (a *GoJiraAdapter) Connected() (bool, error) {
 }
 
 func (a *GoJiraAdapter) GetIssue(ctx context.Context, issueId string) (*jira.Issue, error) {
-	issue, _, err := a.client.Issue.GetWithContext(ctx, issueId, nil)
+	issue, httpResp, err := a.client.Issue.GetWithContext(ctx, issueId, nil)
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("jira issue %s: %w", issueId, ErrNotFound)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch jira issue: %w", err)
 	}
@@ -219,3 +228,172 @@ func (a *GoJiraAdapter) GetIssueTypeMeta(
 
 	return issueTypeMetaMap, nil
 }
+
+// TransitionIssue moves the issue through the workflow transition with the given name.
+// The name of the target status of a transition is accepted as well.
+// Nothing is done if the issue is already in the target status.
+func (a *GoJiraAdapter) TransitionIssue(ctx context.Context, issueId, transition string) error {
+	transitions, _, err := a.client.Issue.GetTransitionsWithContext(ctx, issueId)
+	if err != nil {
+		return fmt.Errorf("failed to get transitions of jira issue %s: %w", issueId, err)
+	}
+
+	for i := range transitions {
+		t := &transitions[i]
+
+		if !strings.EqualFold(t.Name, transition) && !strings.EqualFold(t.To.Name, transition) {
+			continue
+		}
+
+		if _, err = a.client.Issue.DoTransitionWithContext(ctx, issueId, t.ID); err != nil {
+			return fmt.Errorf("failed to transition jira issue %s with %q: %w", issueId, t.Name, err)
+		}
+
+		log.Info("jira issue has been transitioned", "issue", issueId, "transition", t.Name, "status", t.To.Name)
+
+		return nil
+	}
+
+	issue, err := a.GetIssue(ctx, issueId)
+	if err != nil {
+		return err
+	}
+
+	if issue.Fields != nil && issue.Fields.Status != nil && strings.EqualFold(issue.Fields.Status.Name, transition) {
+		log.Info("jira issue is already in the target status", "issue", issueId, "status", issue.Fields.Status.Name)
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q for jira issue %s", ErrTransitionNotAvailable, transition, issueId)
+}
+
+// ReleaseFixVersion marks the fix version of the project as released.
+// The version is created as released if the project does not have it.
+func (a *GoJiraAdapter) ReleaseFixVersion(ctx context.Context, projectKey, versionName string) error {
+	logv := ctrl.LoggerFrom(ctx).WithValues("project", projectKey, "version name", versionName)
+
+	project, _, err := a.client.Project.GetWithContext(ctx, projectKey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jira project %s: %w", projectKey, err)
+	}
+
+	released := true
+	releaseDate := time.Now().Format(time.DateOnly)
+
+	for i := range project.Versions {
+		v := &project.Versions[i]
+
+		if v.Name != versionName {
+			continue
+		}
+
+		if v.Released != nil && *v.Released {
+			logv.Info("Fix version is already released")
+
+			return nil
+		}
+
+		if _, _, err = a.client.Version.UpdateWithContext(ctx, &jira.Version{
+			ID:          v.ID,
+			Released:    &released,
+			ReleaseDate: releaseDate,
+		}); err != nil {
+			return fmt.Errorf("failed to release jira version %s: %w", versionName, err)
+		}
+
+		logv.Info("Fix version has been released")
+
+		return nil
+	}
+
+	projectID, err := strconv.Atoi(project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to parse jira project id %s: %w", project.ID, err)
+	}
+
+	if _, _, err = a.client.Version.CreateWithContext(ctx, &jira.Version{
+		Name:        versionName,
+		ProjectID:   projectID,
+		Released:    &released,
+		ReleaseDate: releaseDate,
+	}); err != nil {
+		return fmt.Errorf("failed to create released jira version %s: %w", versionName, err)
+	}
+
+	logv.Info("Fix version has been created as released")
+
+	return nil
+}
+
+func (a *GoJiraAdapter) AddComment(ctx context.Context, issueId, body string) error {
+	if _, _, err := a.client.Issue.AddCommentWithContext(ctx, issueId, &jira.Comment{Body: body}); err != nil {
+		return fmt.Errorf("failed to add comment to jira issue %s: %w", issueId, err)
+	}
+
+	return nil
+}
+
+// ServerInfo represents the server info response from Jira.
+// It is not full representation of response, only fields that are used in codebase-operator.
+// See https://docs.atlassian.com/software/jira/docs/api/REST/9.4.5/#api/2/serverInfo.
+type ServerInfo struct {
+	Version string `json:"version,omitempty"`
+	// DeploymentType is Cloud for Jira Cloud and Server for Jira Server and Data Center.
+	DeploymentType string `json:"deploymentType,omitempty"`
+}
+
+// GetServerInfo returns the version and deployment type of the Jira server.
+func (a *GoJiraAdapter) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	req, err := a.client.NewRequestWithContext(ctx, http.MethodGet, "rest/api/2/serverInfo", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetServerInfo HTTP request to jira: %w", err)
+	}
+
+	info := &ServerInfo{}
+	if _, err = a.client.Do(req, info); err != nil {
+		return nil, fmt.Errorf("failed to perform GetServerInfo HTTP request to jira: %w", err)
+	}
+
+	return info, nil
+}
+
+// GetMyPermissions returns whether the user has each of the permissions in the project.
+// Permission keys are e.g. EDIT_ISSUES,
+// see https://developer.atlassian.com/cloud/jira/platform/rest/v2/api-group-permissions.
+func (a *GoJiraAdapter) GetMyPermissions(
+	ctx context.Context,
+	projectKey string,
+	permissions []string,
+) (map[string]bool, error) {
+	uv := url.Values{}
+	uv.Add("projectKey", projectKey)
+	uv.Add("permissions", strings.Join(permissions, ","))
+
+	req, err := a.client.NewRequestWithContext(ctx, http.MethodGet, "rest/api/2/mypermissions?"+uv.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GetMyPermissions HTTP request to jira: %w", err)
+	}
+
+	resp := &struct {
+		Permissions map[string]struct {
+			HavePermission bool `json:"havePermission"`
+		} `json:"permissions"`
+	}{}
+
+	httpResp, err := a.client.Do(req, resp)
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("jira project %s: %w", projectKey, ErrNotFound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform GetMyPermissions HTTP request to jira: %w", err)
+	}
+
+	granted := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		granted[permission] = resp.Permissions[permission].HavePermission
+	}
+
+	return granted, nil
+}
@@ -416,3 +416,220 @@ func TestGoJiraAdapter_GetIssueTypeMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestGoJiraAdapter_TransitionIssue(t *testing.T) {
+	transitions := `{"transitions":[{"id":"31","name":"Deploy to QA","to":{"name":"Deployed to QA"}}]}`
+
+	tests := []struct {
+		name        string
+		transition  string
+		issueStatus string
+		wantErr     error
+	}{
+		{
+			name:       "by transition name",
+			transition: "deploy to qa",
+		},
+		{
+			name:       "by target status",
+			transition: "Deployed to QA",
+		},
+		{
+			name:        "issue is already in the target status",
+			transition:  "Done",
+			issueStatus: "Done",
+		},
+		{
+			name:        "transition is not available",
+			transition:  "Done",
+			issueStatus: "In Progress",
+			wantErr:     ErrTransitionNotAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transitioned := false
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/T1/transitions":
+					_, _ = w.Write([]byte(transitions))
+				case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/T1/transitions":
+					transitioned = true
+
+					w.WriteHeader(http.StatusNoContent)
+				case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/T1":
+					_, _ = w.Write([]byte(`{"key":"T1","fields":{"status":{"name":"` + tt.issueStatus + `"}}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			jc, err := new(GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(server.URL, "user", "pwd"))
+			require.NoError(t, err)
+
+			err = jc.TransitionIssue(context.Background(), "T1", tt.transition)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.issueStatus == "", transitioned)
+		})
+	}
+}
+
+func TestGoJiraAdapter_GetIssue_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	jc, err := new(GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(server.URL, "user", "pwd"))
+	require.NoError(t, err)
+
+	_, err = jc.GetIssue(context.Background(), "T1")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGoJiraAdapter_ReleaseFixVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		versions    string
+		wantRequest string
+	}{
+		{
+			name:        "release existing version",
+			versions:    `[{"id":"10","name":"1.0.0","released":false}]`,
+			wantRequest: "PUT /rest/api/2/version/10",
+		},
+		{
+			name:     "version is already released",
+			versions: `[{"id":"10","name":"1.0.0","released":true}]`,
+		},
+		{
+			name:        "create released version",
+			versions:    `[{"id":"10","name":"0.9.0","released":true}]`,
+			wantRequest: "POST /rest/api/2/version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotRequest string
+				gotVersion jira.Version
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/project/APP" {
+					_, _ = w.Write([]byte(`{"id":"100","key":"APP","versions":` + tt.versions + `}`))
+					return
+				}
+
+				gotRequest = r.Method + " " + r.URL.Path
+
+				if err := json.NewDecoder(r.Body).Decode(&gotVersion); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"id":"10","name":"1.0.0","released":true}`))
+			}))
+			defer server.Close()
+
+			jc, err := new(GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(server.URL, "user", "pwd"))
+			require.NoError(t, err)
+
+			require.NoError(t, jc.ReleaseFixVersion(context.Background(), "APP", "1.0.0"))
+			assert.Equal(t, tt.wantRequest, gotRequest)
+
+			if tt.wantRequest != "" {
+				require.NotNil(t, gotVersion.Released)
+				assert.True(t, *gotVersion.Released)
+				assert.NotEmpty(t, gotVersion.ReleaseDate)
+			}
+		})
+	}
+}
+
+func TestGoJiraAdapter_AddComment(t *testing.T) {
+	var gotComment jira.Comment
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue/T1/comment" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&gotComment); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	jc, err := new(GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(server.URL, "user", "pwd"))
+	require.NoError(t, err)
+
+	require.NoError(t, jc.AddComment(context.Background(), "T1", "Deployed"))
+	assert.Equal(t, "Deployed", gotComment.Body)
+
+	require.Error(t, jc.AddComment(context.Background(), "T2", "Deployed"))
+}
+
+func TestGoJiraAdapter_GetServerInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/serverInfo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"version":"1001.0.0-SNAPSHOT","deploymentType":"Cloud","buildNumber":100}`))
+	}))
+	defer server.Close()
+
+	jc, err := new(GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(server.URL, "user", "pwd"))
+	require.NoError(t, err)
+
+	info, err := jc.GetServerInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ServerInfo{Version: "1001.0.0-SNAPSHOT", DeploymentType: "Cloud"}, info)
+}
+
+func TestGoJiraAdapter_GetMyPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/mypermissions" || r.URL.Query().Get("projectKey") != "APP" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("permissions") != "EDIT_ISSUES,LINK_ISSUES" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"permissions":{"EDIT_ISSUES":{"key":"EDIT_ISSUES","havePermission":true},` +
+			`"LINK_ISSUES":{"key":"LINK_ISSUES","havePermission":false}}}`))
+	}))
+	defer server.Close()
+
+	jc, err := new(GoJiraAdapterFactory).New(dto.ConvertSpecToJiraServer(server.URL, "user", "pwd"))
+	require.NoError(t, err)
+
+	permissions := []string{"EDIT_ISSUES", "LINK_ISSUES"}
+
+	granted, err := jc.GetMyPermissions(context.Background(), "APP", permissions)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"EDIT_ISSUES": true, "LINK_ISSUES": false}, granted)
+
+	_, err = jc.GetMyPermissions(context.Background(), "WEB", permissions)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -25,6 +25,16 @@ type Client interface {
 	CreateIssueLink(issueId, title, url string) error
 
 	GetIssueTypeMeta(ctx context.Context, projectID, issueTypeID string) (map[string]IssueTypeMeta, error)
+
+	TransitionIssue(ctx context.Context, issueId, transition string) error
+
+	ReleaseFixVersion(ctx context.Context, projectKey, versionName string) error
+
+	AddComment(ctx context.Context, issueId, body string) error
+
+	GetServerInfo(ctx context.Context) (*ServerInfo, error)
+
+	GetMyPermissions(ctx context.Context, projectKey string, permissions []string) (map[string]bool, error)
 }
 
 type ClientFactory interface {
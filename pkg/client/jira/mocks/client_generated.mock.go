@@ -541,3 +541,328 @@ func (_c *MockClient_GetProjectInfo_Call) RunAndReturn(run func(issueId string)
 	_c.Call.Return(run)
 	return _c
 }
+
+// TransitionIssue provides a mock function for the type MockClient
+func (_mock *MockClient) TransitionIssue(ctx context.Context, issueId string, transition string) error {
+	ret := _mock.Called(ctx, issueId, transition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionIssue")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, issueId, transition)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_TransitionIssue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransitionIssue'
+type MockClient_TransitionIssue_Call struct {
+	*mock.Call
+}
+
+// TransitionIssue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issueId string
+//   - transition string
+func (_e *MockClient_Expecter) TransitionIssue(ctx interface{}, issueId interface{}, transition interface{}) *MockClient_TransitionIssue_Call {
+	return &MockClient_TransitionIssue_Call{Call: _e.mock.On("TransitionIssue", ctx, issueId, transition)}
+}
+
+func (_c *MockClient_TransitionIssue_Call) Run(run func(ctx context.Context, issueId string, transition string)) *MockClient_TransitionIssue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_TransitionIssue_Call) Return(err error) *MockClient_TransitionIssue_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_TransitionIssue_Call) RunAndReturn(run func(ctx context.Context, issueId string, transition string) error) *MockClient_TransitionIssue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseFixVersion provides a mock function for the type MockClient
+func (_mock *MockClient) ReleaseFixVersion(ctx context.Context, projectKey string, versionName string) error {
+	ret := _mock.Called(ctx, projectKey, versionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseFixVersion")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, projectKey, versionName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_ReleaseFixVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseFixVersion'
+type MockClient_ReleaseFixVersion_Call struct {
+	*mock.Call
+}
+
+// ReleaseFixVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectKey string
+//   - versionName string
+func (_e *MockClient_Expecter) ReleaseFixVersion(ctx interface{}, projectKey interface{}, versionName interface{}) *MockClient_ReleaseFixVersion_Call {
+	return &MockClient_ReleaseFixVersion_Call{Call: _e.mock.On("ReleaseFixVersion", ctx, projectKey, versionName)}
+}
+
+func (_c *MockClient_ReleaseFixVersion_Call) Run(run func(ctx context.Context, projectKey string, versionName string)) *MockClient_ReleaseFixVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_ReleaseFixVersion_Call) Return(err error) *MockClient_ReleaseFixVersion_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_ReleaseFixVersion_Call) RunAndReturn(run func(ctx context.Context, projectKey string, versionName string) error) *MockClient_ReleaseFixVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddComment provides a mock function for the type MockClient
+func (_mock *MockClient) AddComment(ctx context.Context, issueId string, body string) error {
+	ret := _mock.Called(ctx, issueId, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, issueId, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockClient_AddComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddComment'
+type MockClient_AddComment_Call struct {
+	*mock.Call
+}
+
+// AddComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - issueId string
+//   - body string
+func (_e *MockClient_Expecter) AddComment(ctx interface{}, issueId interface{}, body interface{}) *MockClient_AddComment_Call {
+	return &MockClient_AddComment_Call{Call: _e.mock.On("AddComment", ctx, issueId, body)}
+}
+
+func (_c *MockClient_AddComment_Call) Run(run func(ctx context.Context, issueId string, body string)) *MockClient_AddComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_AddComment_Call) Return(err error) *MockClient_AddComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockClient_AddComment_Call) RunAndReturn(run func(ctx context.Context, issueId string, body string) error) *MockClient_AddComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServerInfo provides a mock function for the type MockClient
+func (_mock *MockClient) GetServerInfo(ctx context.Context) (*jira0.ServerInfo, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServerInfo")
+	}
+
+	var r0 *jira0.ServerInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*jira0.ServerInfo, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *jira0.ServerInfo); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jira0.ServerInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetServerInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerInfo'
+type MockClient_GetServerInfo_Call struct {
+	*mock.Call
+}
+
+// GetServerInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetServerInfo(ctx interface{}) *MockClient_GetServerInfo_Call {
+	return &MockClient_GetServerInfo_Call{Call: _e.mock.On("GetServerInfo", ctx)}
+}
+
+func (_c *MockClient_GetServerInfo_Call) Run(run func(ctx context.Context)) *MockClient_GetServerInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetServerInfo_Call) Return(serverInfo *jira0.ServerInfo, err error) *MockClient_GetServerInfo_Call {
+	_c.Call.Return(serverInfo, err)
+	return _c
+}
+
+func (_c *MockClient_GetServerInfo_Call) RunAndReturn(run func(ctx context.Context) (*jira0.ServerInfo, error)) *MockClient_GetServerInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMyPermissions provides a mock function for the type MockClient
+func (_mock *MockClient) GetMyPermissions(ctx context.Context, projectKey string, permissions []string) (map[string]bool, error) {
+	ret := _mock.Called(ctx, projectKey, permissions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMyPermissions")
+	}
+
+	var r0 map[string]bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (map[string]bool, error)); ok {
+		return returnFunc(ctx, projectKey, permissions)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) map[string]bool); ok {
+		r0 = returnFunc(ctx, projectKey, permissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, projectKey, permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockClient_GetMyPermissions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMyPermissions'
+type MockClient_GetMyPermissions_Call struct {
+	*mock.Call
+}
+
+// GetMyPermissions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectKey string
+//   - permissions []string
+func (_e *MockClient_Expecter) GetMyPermissions(ctx interface{}, projectKey interface{}, permissions interface{}) *MockClient_GetMyPermissions_Call {
+	return &MockClient_GetMyPermissions_Call{Call: _e.mock.On("GetMyPermissions", ctx, projectKey, permissions)}
+}
+
+func (_c *MockClient_GetMyPermissions_Call) Run(run func(ctx context.Context, projectKey string, permissions []string)) *MockClient_GetMyPermissions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockClient_GetMyPermissions_Call) Return(stringToBool map[string]bool, err error) *MockClient_GetMyPermissions_Call {
+	_c.Call.Return(stringToBool, err)
+	return _c
+}
+
+func (_c *MockClient_GetMyPermissions_Call) RunAndReturn(run func(ctx context.Context, projectKey string, permissions []string) (map[string]bool, error)) *MockClient_GetMyPermissions_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,86 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// AtlassianTokenURL is the token endpoint of Atlassian OAuth 2.0 (3LO) apps.
+const AtlassianTokenURL = "https://auth.atlassian.com/oauth/token"
+
+// oauthRequestTimeout bounds a token request, so that an unresponsive token endpoint does
+// not block the reconciliation that needs the token.
+const oauthRequestTimeout = 30 * time.Second
+
+var oauthHTTPClient = &http.Client{Timeout: oauthRequestTimeout}
+
+// OAuthToken is the response of the token endpoint to a refresh token grant.
+type OAuthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// RefreshOAuthToken exchanges the refresh token for a new access token.
+// Atlassian rotates refresh tokens, so the returned RefreshToken replaces the one used.
+func RefreshOAuthToken(
+	ctx context.Context,
+	tokenURL, clientID, clientSecret, refreshToken string,
+) (*OAuthToken, error) {
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     clientID,
+		"client_secret": clientSecret,
+		"refresh_token": refreshToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := oauthHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request OAuth token: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to refresh OAuth token: status %s: %s", resp.Status, respBody)
+	}
+
+	token := &OAuthToken{}
+	if err = json.Unmarshal(respBody, token); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+
+	return token, nil
+}
+
+// Expiry returns when the access token expires if it was issued at issuedAt.
+func (t *OAuthToken) Expiry(issuedAt time.Time) time.Time {
+	return issuedAt.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
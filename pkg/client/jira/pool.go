@@ -0,0 +1,174 @@
+package jira
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/internal/httpretry"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
+)
+
+const (
+	// DefaultRequestsPerSecond and DefaultRequestBurst throttle the API requests made for a
+	// JiraServer, below the rate limits of Jira Cloud and the default limits of Jira Data Center.
+	DefaultRequestsPerSecond = 5
+	DefaultRequestBurst      = 10
+
+	// DefaultCacheTTL is how long project and issue type metadata, and the updates already
+	// applied to issues, are remembered.
+	DefaultCacheTTL = 10 * time.Minute
+
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = time.Second
+
+	// maxRetryAfter is the longest a request waits before it is retried. When Jira asks to wait
+	// longer the request fails with ErrRateLimited, so that reconcilers requeue instead of
+	// blocking their workers.
+	maxRetryAfter = time.Minute
+)
+
+// ErrRateLimited is returned when Jira asks to wait longer than a request can wait.
+var ErrRateLimited = errors.New("jira API rate limit is exceeded")
+
+// ClientPool hands out one Client per JiraServer, shared by every controller that calls it,
+// so that throttling, the Retry-After Jira sends and cached metadata apply to all of them
+// together. A nil pool hands out unshared clients without throttling and caching.
+type ClientPool struct {
+	mu             sync.Mutex
+	clients        map[string]*pooledClient
+	limit          rate.Limit
+	burst          int
+	maxRetries     int
+	retryBaseDelay time.Duration
+	cacheTTL       time.Duration
+	transport      http.RoundTripper
+}
+
+type pooledClient struct {
+	client      Client
+	auth        *authTransport
+	fingerprint string
+}
+
+// ClientPoolOption configures a ClientPool.
+type ClientPoolOption func(*ClientPool)
+
+// WithRequestRate sets the number of requests per second, and the burst, allowed for a JiraServer.
+func WithRequestRate(requestsPerSecond float64, burst int) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.limit = rate.Limit(requestsPerSecond)
+		p.burst = burst
+	}
+}
+
+// WithRetries sets how many times a throttled or failed request is retried, and the first
+// backoff delay, which doubles with every retry unless Jira sends Retry-After.
+func WithRetries(maxRetries int, baseDelay time.Duration) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.maxRetries = maxRetries
+		p.retryBaseDelay = baseDelay
+	}
+}
+
+// WithCacheTTL sets how long cached metadata and applied issue updates are remembered.
+func WithCacheTTL(ttl time.Duration) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.cacheTTL = ttl
+	}
+}
+
+// WithTransport sets the transport the clients send requests with.
+func WithTransport(transport http.RoundTripper) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.transport = transport
+	}
+}
+
+// NewClientPool creates a new ClientPool.
+func NewClientPool(opts ...ClientPoolOption) *ClientPool {
+	p := &ClientPool{
+		clients:        make(map[string]*pooledClient),
+		limit:          DefaultRequestsPerSecond,
+		burst:          DefaultRequestBurst,
+		maxRetries:     defaultMaxRetries,
+		retryBaseDelay: defaultRetryBaseDelay,
+		cacheTTL:       DefaultCacheTTL,
+		transport:      http.DefaultTransport,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Client returns the Client of the JiraServer that connects with the server credentials.
+// A changed URL, user or password replaces the client, dropping its cache. A changed token,
+// such as a refreshed OAuth2 access token, is used by the same client.
+func (p *ClientPool) Client(jiraServer *codebaseApi.JiraServer, server dto.JiraServer) (Client, error) {
+	if p == nil {
+		return new(GoJiraAdapterFactory).New(server)
+	}
+
+	key := jiraServer.Namespace + "/" + jiraServer.Name
+	fingerprint := serverFingerprint(server)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pooled, ok := p.clients[key]; ok && pooled.fingerprint == fingerprint {
+		pooled.auth.setServer(server)
+
+		return pooled.client, nil
+	}
+
+	auth := newAuthTransport(server, p.newTransport())
+
+	adapter, err := newAdapter(server, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	p.clients[key] = &pooledClient{
+		client:      newCachingClient(adapter, p.cacheTTL),
+		auth:        auth,
+		fingerprint: fingerprint,
+	}
+
+	return p.clients[key].client, nil
+}
+
+// serverFingerprint identifies the server and the account the client connects as. The token
+// is left out: OAuth2 access tokens are refreshed every hour, which must keep the throttling
+// state and the cache of the client.
+func serverFingerprint(server dto.JiraServer) string {
+	h := sha256.New()
+
+	for _, v := range []string{server.ApiUrl, server.AuthType, server.User, server.Pwd} {
+		_, _ = h.Write([]byte(v))
+		_, _ = h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newTransport creates the transport of a JiraServer, which throttles requests, holds them
+// back for as long as Jira asks with Retry-After and retries throttled and failed requests.
+func (p *ClientPool) newTransport() *httpretry.Transport {
+	return httpretry.New(p.transport, httpretry.Options{
+		Limit:          p.limit,
+		Burst:          p.burst,
+		MaxRetries:     p.maxRetries,
+		RetryBaseDelay: p.retryBaseDelay,
+		MaxWait:        maxRetryAfter,
+		ErrRateLimited: ErrRateLimited,
+	})
+}
@@ -0,0 +1,143 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira/dto"
+)
+
+func newTestJiraServer(name string) *codebaseApi.JiraServer {
+	return &codebaseApi.JiraServer{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+}
+
+func TestClientPool_Client(t *testing.T) {
+	t.Parallel()
+
+	pool := NewClientPool()
+	server := dto.JiraServer{ApiUrl: "https://jira.example.com", User: "user", Pwd: "pwd"}
+
+	first, err := pool.Client(newTestJiraServer("jira"), server)
+	require.NoError(t, err)
+
+	second, err := pool.Client(newTestJiraServer("jira"), server)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "a JiraServer must share its client")
+
+	other, err := pool.Client(newTestJiraServer("jira-cloud"), server)
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+
+	server.Pwd = "rotated"
+
+	rotated, err := pool.Client(newTestJiraServer("jira"), server)
+	require.NoError(t, err)
+	assert.NotSame(t, first, rotated, "changed credentials must replace the client")
+
+	var nilPool *ClientPool
+
+	unshared, err := nilPool.Client(newTestJiraServer("jira"), server)
+	require.NoError(t, err)
+	assert.IsType(t, &GoJiraAdapter{}, unshared)
+}
+
+func TestClientPool_Client_RefreshedToken(t *testing.T) {
+	t.Parallel()
+
+	var got atomic.Value
+
+	jiraAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Store(r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"name":"user"}`))
+	}))
+	defer jiraAPI.Close()
+
+	pool := NewClientPool(WithRequestRate(float64(rate.Inf), 0))
+	server := dto.JiraServer{ApiUrl: jiraAPI.URL, AuthType: codebaseApi.JiraAuthTypeOAuth2, Token: "access"}
+
+	first, err := pool.Client(newTestJiraServer("jira"), server)
+	require.NoError(t, err)
+
+	server.Token = "refreshed"
+
+	refreshed, err := pool.Client(newTestJiraServer("jira"), server)
+	require.NoError(t, err)
+	assert.Same(t, first, refreshed, "a refreshed token must keep the client")
+
+	_, err = first.Connected()
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer refreshed", got.Load())
+}
+
+func TestRateLimitTransport_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := NewClientPool(WithRequestRate(float64(rate.Inf), 0), WithRetries(3, time.Millisecond)).newTransport()
+
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "the request must wait for Retry-After")
+}
+
+func TestRateLimitTransport_RetryAfterTooLong(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport := NewClientPool(WithRequestRate(float64(rate.Inf), 0), WithRetries(3, time.Millisecond)).newTransport()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "a long Retry-After must not block the request")
+
+	_, err = transport.RoundTrip(req)
+	require.ErrorIs(t, err, ErrRateLimited, "further requests must be held back")
+	assert.Equal(t, int32(1), requests.Load())
+}
@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	integrationSecretLabel     = "app.edp.epam.com/integration-secret"
+	integrationSecretTypeLabel = "app.edp.epam.com/secret-type"
+	registrySecretType         = "registry"
+	dockerConfigKey            = ".dockerconfigjson"
+
+	requestTimeout = 30 * time.Second
+)
+
+// NewHostClient creates a client of the registry at host over HTTPS.
+func NewHostClient(host string, auth Auth) *Client {
+	return NewClient("https://"+host, auth, resty.New().SetTimeout(requestTimeout))
+}
+
+// CredentialsFor returns the credentials of the registry host from the first registry
+// integration secret of the namespace that has them, or no credentials when none has,
+// so that the registry is read anonymously.
+func CredentialsFor(ctx context.Context, reader client.Reader, namespace, host string) (Auth, error) {
+	secrets := &corev1.SecretList{}
+	if err := reader.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabels{
+		integrationSecretLabel:     "true",
+		integrationSecretTypeLabel: registrySecretType,
+	}); err != nil {
+		return Auth{}, fmt.Errorf("failed to list registry integration secrets: %w", err)
+	}
+
+	for i := range secrets.Items {
+		dockerConfig := secrets.Items[i].Data[dockerConfigKey]
+		if len(dockerConfig) == 0 {
+			continue
+		}
+
+		auth, found, err := AuthFromDockerConfig(dockerConfig, host)
+		if err != nil {
+			return Auth{}, fmt.Errorf("invalid registry integration secret %s: %w", secrets.Items[i].Name, err)
+		}
+
+		if found {
+			return auth, nil
+		}
+	}
+
+	return Auth{}, nil
+}
@@ -0,0 +1,20 @@
+package registry
+
+import "context"
+
+// Registry is the part of Client the CodebaseImageStream sweeps use: registry tag discovery
+// reads the tags and their images, tag retention deletes images.
+type Registry interface {
+	Tags(ctx context.Context, repository string) ([]string, error)
+	Image(ctx context.Context, repository, tag string) (Image, error)
+	DeleteImage(ctx context.Context, repository, digest string) error
+}
+
+// Factory creates a client of the registry at host. It is the injection seam for tests;
+// production wiring uses NewRegistry.
+type Factory func(host string, auth Auth) Registry
+
+// NewRegistry creates a client of the registry at host over HTTPS.
+func NewRegistry(host string, auth Auth) Registry {
+	return NewHostClient(host, auth)
+}
@@ -0,0 +1,397 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// DockerHubHost is the registry of images without a registry host in their name.
+	DockerHubHost = "registry-1.docker.io"
+
+	tagsPageSize = 1000
+
+	// maxTagPages bounds the tags listed for a repository to 50000.
+	maxTagPages = 50
+
+	mediaTypeOCIIndex          = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest       = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerManifest    = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestSet = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+var (
+	ErrNotFound = errors.New("not found in registry")
+
+	linkNextPattern   = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+	challengeParamsRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// Auth is the credentials of a registry, as in the auths of a .dockerconfigjson.
+type Auth struct {
+	Username string
+	Password string
+}
+
+// Image is an image a tag points to.
+type Image struct {
+	// Digest is the digest of the manifest, or of the index for multi-platform images.
+	Digest string
+
+	// Created is the creation time in the image configuration. It is zero when the
+	// configuration has none.
+	Created time.Time
+}
+
+// Client reads repositories of a registry through the OCI distribution API, which Harbor,
+// Amazon ECR, GHCR and Docker Hub implement. It authenticates with the credentials of the
+// registry, exchanging them for a bearer token when the registry asks for one.
+// A Client is not safe for concurrent use.
+type Client struct {
+	http  *resty.Client
+	auth  Auth
+	token map[string]string
+}
+
+// NewClient creates a client of the registry at baseURL, e.g. "https://harbor.example.com".
+func NewClient(baseURL string, auth Auth, restyClient *resty.Client) *Client {
+	return &Client{
+		http:  restyClient.SetBaseURL(strings.TrimSuffix(baseURL, "/")),
+		auth:  auth,
+		token: make(map[string]string),
+	}
+}
+
+// ParseImageName splits an image name without a tag into the registry host and the
+// repository, the way docker does: the first path component is the host when it contains
+// a dot or a port, or is localhost. Other names are Docker Hub repositories.
+func ParseImageName(imageName string) (host, repository string) {
+	first, rest, found := strings.Cut(imageName, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		host, repository = first, rest
+	} else {
+		host, repository = DockerHubHost, imageName
+	}
+
+	if host == "docker.io" || host == "index.docker.io" {
+		host = DockerHubHost
+	}
+
+	if host == DockerHubHost && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	return host, repository
+}
+
+// AuthFromDockerConfig returns the credentials of the registry host in a .dockerconfigjson.
+// Entries may be hosts or URLs; https://index.docker.io/v1/ is the entry of Docker Hub.
+func AuthFromDockerConfig(dockerConfig []byte, host string) (Auth, bool, error) {
+	var conf struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+
+	if err := json.Unmarshal(dockerConfig, &conf); err != nil {
+		return Auth{}, false, fmt.Errorf("failed to unmarshal .dockerconfigjson: %w", err)
+	}
+
+	for entry, auth := range conf.Auths {
+		entryHost := strings.TrimPrefix(strings.TrimPrefix(entry, "https://"), "http://")
+		entryHost, _, _ = strings.Cut(entryHost, "/")
+
+		if entryHost == "index.docker.io" || entryHost == "docker.io" {
+			entryHost = DockerHubHost
+		}
+
+		if !strings.EqualFold(entryHost, host) {
+			continue
+		}
+
+		if auth.Username == "" && auth.Auth != "" {
+			username, password, err := decodeBasicAuth(auth.Auth)
+			if err != nil {
+				return Auth{}, false, fmt.Errorf("invalid auth of %s in .dockerconfigjson: %w", entry, err)
+			}
+
+			return Auth{Username: username, Password: password}, true, nil
+		}
+
+		return Auth{Username: auth.Username, Password: auth.Password}, true, nil
+	}
+
+	return Auth{}, false, nil
+}
+
+// Tags returns the tags of the repository.
+func (c *Client) Tags(ctx context.Context, repository string) ([]string, error) {
+	var tags []string
+
+	path := fmt.Sprintf("/v2/%s/tags/list?n=%d", repository, tagsPageSize)
+
+	for page := 0; path != "" && page < maxTagPages; page++ {
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+
+		resp, err := c.get(ctx, repository, path, "application/json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", repository, err)
+		}
+
+		if err = json.Unmarshal(resp.Body(), &list); err != nil {
+			return nil, fmt.Errorf("failed to decode tags of %s: %w", repository, err)
+		}
+
+		tags = append(tags, list.Tags...)
+		path = nextPage(resp.Header().Get("Link"))
+	}
+
+	return tags, nil
+}
+
+// Image returns the digest and the creation time of the image the tag points to. The creation
+// time of a multi-platform image is the one of its first platform image.
+func (c *Client) Image(ctx context.Context, repository, tag string) (Image, error) {
+	accept := strings.Join([]string{
+		mediaTypeOCIIndex, mediaTypeDockerManifestSet, mediaTypeOCIManifest, mediaTypeDockerManifest,
+	}, ", ")
+
+	resp, err := c.get(ctx, repository, fmt.Sprintf("/v2/%s/manifests/%s", repository, tag), accept)
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to get manifest of %s:%s: %w", repository, tag, err)
+	}
+
+	image := Image{Digest: resp.Header().Get("Docker-Content-Digest")}
+	if image.Digest == "" {
+		sum := sha256.Sum256(resp.Body())
+		image.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	var manifest struct {
+		Config struct {
+			Digest string `json:"digest"`
+		} `json:"config"`
+		Manifests []struct {
+			Digest   string `json:"digest"`
+			Platform struct {
+				OS string `json:"os"`
+			} `json:"platform"`
+		} `json:"manifests"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &manifest); err != nil {
+		return Image{}, fmt.Errorf("failed to decode manifest of %s:%s: %w", repository, tag, err)
+	}
+
+	if len(manifest.Manifests) > 0 {
+		// Attestation manifests of BuildKit have the "unknown" platform.
+		platformDigest := manifest.Manifests[0].Digest
+
+		for _, m := range manifest.Manifests {
+			if m.Platform.OS != "unknown" {
+				platformDigest = m.Digest
+				break
+			}
+		}
+
+		resp, err = c.get(ctx, repository, fmt.Sprintf("/v2/%s/manifests/%s", repository, platformDigest),
+			strings.Join([]string{mediaTypeOCIManifest, mediaTypeDockerManifest}, ", "))
+		if err != nil {
+			return Image{}, fmt.Errorf("failed to get platform manifest of %s:%s: %w", repository, tag, err)
+		}
+
+		if err = json.Unmarshal(resp.Body(), &manifest); err != nil {
+			return Image{}, fmt.Errorf("failed to decode platform manifest of %s:%s: %w", repository, tag, err)
+		}
+	}
+
+	if manifest.Config.Digest == "" {
+		return image, nil
+	}
+
+	resp, err = c.get(ctx, repository, fmt.Sprintf("/v2/%s/blobs/%s", repository, manifest.Config.Digest), "")
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to get configuration of %s:%s: %w", repository, tag, err)
+	}
+
+	var config struct {
+		Created time.Time `json:"created"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &config); err != nil {
+		return Image{}, fmt.Errorf("failed to decode configuration of %s:%s: %w", repository, tag, err)
+	}
+
+	image.Created = config.Created
+
+	return image, nil
+}
+
+// DeleteImage deletes the manifest with the digest from the repository, and with it all tags
+// that point to it. Registries that do not allow deleting manifests, such as Amazon ECR, fail.
+func (c *Client) DeleteImage(ctx context.Context, repository, digest string) error {
+	_, err := c.send(ctx, http.MethodDelete, fmt.Sprintf("repository:%s:pull,push,delete", repository),
+		fmt.Sprintf("/v2/%s/manifests/%s", repository, digest), "")
+	if err != nil {
+		return fmt.Errorf("failed to delete image %s@%s: %w", repository, digest, err)
+	}
+
+	return nil
+}
+
+func (c *Client) get(ctx context.Context, repository, path, accept string) (*resty.Response, error) {
+	return c.send(ctx, http.MethodGet, fmt.Sprintf("repository:%s:pull", repository), path, accept)
+}
+
+// send sends a request that needs the access of the token scope, e.g. "repository:app:pull".
+// When the registry answers 401, it gets a token for the scope as the challenge in
+// WWW-Authenticate asks and sends the request again.
+func (c *Client) send(ctx context.Context, method, scope, path, accept string) (*resty.Response, error) {
+	resp, err := c.request(ctx, scope, accept).Execute(method, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusUnauthorized {
+		if err = c.authenticate(ctx, scope, resp.Header().Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+
+		if resp, err = c.request(ctx, scope, accept).Execute(method, path); err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+	}
+
+	switch {
+	case resp.StatusCode() == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.IsError():
+		return nil, fmt.Errorf("registry returned %s: %s", resp.Status(), strings.TrimSpace(resp.String()))
+	}
+
+	return resp, nil
+}
+
+func (c *Client) request(ctx context.Context, scope, accept string) *resty.Request {
+	req := c.http.R().SetContext(ctx)
+
+	if accept != "" {
+		req.SetHeader("Accept", accept)
+	}
+
+	if token := c.token[scope]; token != "" {
+		req.SetAuthToken(token)
+	} else if c.auth != (Auth{}) {
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	}
+
+	return req
+}
+
+// authenticate answers the challenge of the registry with a token for the scope, which it
+// gets from the realm of the challenge. Registries that ask for Basic authentication get
+// the credentials with every request already, so they rejected them.
+func (c *Client) authenticate(ctx context.Context, scope, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+
+	if strings.EqualFold(scheme, "Basic") || c.token[scope] != "" {
+		return errors.New("registry rejected the credentials")
+	}
+
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("unsupported registry authentication %q", challenge)
+	}
+
+	values := make(map[string]string)
+	for _, m := range challengeParamsRe.FindAllStringSubmatch(params, -1) {
+		values[strings.ToLower(m[1])] = m[2]
+	}
+
+	if values["realm"] == "" {
+		return fmt.Errorf("registry authentication has no realm: %q", challenge)
+	}
+
+	query := url.Values{"scope": {scope}}
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+
+	req := resty.NewWithClient(c.http.GetClient()).R().SetContext(ctx).SetQueryParamsFromValues(query)
+	if c.auth != (Auth{}) {
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	}
+
+	resp, err := req.Get(values["realm"])
+	if err != nil {
+		return fmt.Errorf("failed to get registry token: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to get registry token: %s", resp.Status())
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+
+	if err = json.Unmarshal(resp.Body(), &token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %w", err)
+	}
+
+	c.token[scope] = token.Token
+	if c.token[scope] == "" {
+		c.token[scope] = token.AccessToken
+	}
+
+	if c.token[scope] == "" {
+		return errors.New("registry returned an empty token")
+	}
+
+	return nil
+}
+
+func decodeBasicAuth(auth string) (username, password string, err error) {
+	raw, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode auth: %w", err)
+	}
+
+	username, password, found := strings.Cut(string(raw), ":")
+	if !found {
+		return "", "", errors.New("auth is not username:password")
+	}
+
+	return username, password, nil
+}
+
+// nextPage returns the path of the next page in a Link header, or "" on the last page.
+func nextPage(link string) string {
+	m := linkNextPattern.FindStringSubmatch(link)
+	if m == nil {
+		return ""
+	}
+
+	next, err := url.Parse(m[1])
+	if err != nil {
+		return ""
+	}
+
+	return next.RequestURI()
+}
@@ -0,0 +1,213 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		imageName      string
+		wantHost       string
+		wantRepository string
+	}{
+		{imageName: "harbor.example.com/project/app", wantHost: "harbor.example.com", wantRepository: "project/app"},
+		{imageName: "localhost:5000/app", wantHost: "localhost:5000", wantRepository: "app"},
+		{imageName: "org/app", wantHost: DockerHubHost, wantRepository: "org/app"},
+		{imageName: "nginx", wantHost: DockerHubHost, wantRepository: "library/nginx"},
+		{imageName: "docker.io/nginx", wantHost: DockerHubHost, wantRepository: "library/nginx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.imageName, func(t *testing.T) {
+			t.Parallel()
+
+			host, repository := ParseImageName(tt.imageName)
+
+			assert.Equal(t, tt.wantHost, host)
+			assert.Equal(t, tt.wantRepository, repository)
+		})
+	}
+}
+
+func TestAuthFromDockerConfig(t *testing.T) {
+	t.Parallel()
+
+	config := []byte(`{"auths":{` +
+		`"https://index.docker.io/v1/":{"username":"hub","password":"hub-pass"},` +
+		`"harbor.example.com":{"auth":"` + base64.StdEncoding.EncodeToString([]byte("robot$app:secret")) + `"}}}`)
+
+	auth, found, err := AuthFromDockerConfig(config, DockerHubHost)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, Auth{Username: "hub", Password: "hub-pass"}, auth)
+
+	auth, found, err = AuthFromDockerConfig(config, "harbor.example.com")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, Auth{Username: "robot$app", Password: "secret"}, auth)
+
+	_, found, err = AuthFromDockerConfig(config, "ghcr.io")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, _, err = AuthFromDockerConfig([]byte(`{`), "ghcr.io")
+	require.Error(t, err)
+}
+
+// newTestRegistry serves the repository "team/app" behind bearer token authentication:
+// the tag "1.0" is a multi-platform image, "2.0" a single-platform one.
+func newTestRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	var server *httptest.Server
+
+	authorized := func(w http.ResponseWriter, r *http.Request) bool {
+		if r.Header.Get("Authorization") == "Bearer registry-token" {
+			return true
+		}
+
+		w.Header().Set("WWW-Authenticate",
+			`Bearer realm="`+server.URL+`/token",service="registry",scope="repository:team/app:pull"`)
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return false
+	}
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "pass" ||
+			r.URL.Query().Get("scope") != "repository:team/app:pull" || r.URL.Query().Get("service") != "registry" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"access_token":"registry-token"}`))
+	})
+	mux.HandleFunc("/v2/team/app/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/team/app/tags/list?n=1000&last=1.0>; rel="next"`)
+			_, _ = w.Write([]byte(`{"name":"team/app","tags":["1.0"]}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"name":"team/app","tags":["2.0"]}`))
+	})
+	mux.HandleFunc("/v2/team/app/manifests/", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/team/app/manifests/1.0":
+			w.Header().Set("Docker-Content-Digest", "sha256:index")
+			_, _ = w.Write([]byte(`{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[` +
+				`{"digest":"sha256:attestation","platform":{"os":"unknown"}},` +
+				`{"digest":"sha256:amd64","platform":{"os":"linux"}}]}`))
+		case "/v2/team/app/manifests/sha256:amd64", "/v2/team/app/manifests/2.0":
+			_, _ = w.Write([]byte(`{"config":{"digest":"sha256:config"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/v2/team/app/blobs/sha256:config", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(w, r) {
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"created":"2026-10-01T12:00:00.123Z","architecture":"amd64"}`))
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_Tags(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRegistry(t)
+	c := NewClient(server.URL, Auth{Username: "user", Password: "pass"}, resty.New())
+
+	tags, err := c.Tags(context.Background(), "team/app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.0", "2.0"}, tags)
+
+	_, err = NewClient(server.URL, Auth{Username: "user", Password: "wrong"}, resty.New()).
+		Tags(context.Background(), "team/app")
+	require.ErrorContains(t, err, "failed to get registry token")
+}
+
+func TestClient_Image(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRegistry(t)
+	c := NewClient(server.URL, Auth{Username: "user", Password: "pass"}, resty.New())
+	created := time.Date(2026, 10, 1, 12, 0, 0, 123000000, time.UTC)
+
+	image, err := c.Image(context.Background(), "team/app", "1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:index", image.Digest)
+	assert.True(t, created.Equal(image.Created))
+
+	// Without Docker-Content-Digest the digest is computed from the manifest.
+	image, err = c.Image(context.Background(), "team/app", "2.0")
+	require.NoError(t, err)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, image.Digest)
+	assert.True(t, created.Equal(image.Created))
+
+	_, err = c.Image(context.Background(), "team/app", "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClient_DeleteImage(t *testing.T) {
+	t.Parallel()
+
+	var deleted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch {
+		case r.Method != http.MethodDelete:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/v2/team/app/manifests/sha256:one":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(server.URL, Auth{Username: "user", Password: "pass"}, resty.New())
+
+	require.NoError(t, c.DeleteImage(context.Background(), "team/app", "sha256:one"))
+	assert.Equal(t, []string{"/v2/team/app/manifests/sha256:one"}, deleted)
+
+	require.ErrorIs(t, c.DeleteImage(context.Background(), "team/app", "sha256:missing"), ErrNotFound)
+}
@@ -0,0 +1,72 @@
+package codebasebranch
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+// DiscoveryPatterns selects the remote branches that branch discovery adopts as
+// CodebaseBranches for a Codebase.
+type DiscoveryPatterns struct {
+	include []string
+	exclude []string
+}
+
+// NewDiscoveryPatterns reads the branch discovery annotations of the codebase. It returns
+// nil when the codebase has not opted in, and an error when a pattern is malformed.
+func NewDiscoveryPatterns(codebase *codebaseApi.Codebase) (*DiscoveryPatterns, error) {
+	include, err := parseBranchPatterns(codebase.Annotations[codebaseApi.BranchDiscoveryIncludeAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", codebaseApi.BranchDiscoveryIncludeAnnotation, err)
+	}
+
+	exclude, err := parseBranchPatterns(codebase.Annotations[codebaseApi.BranchDiscoveryExcludeAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", codebaseApi.BranchDiscoveryExcludeAnnotation, err)
+	}
+
+	if len(include) == 0 {
+		return nil, nil
+	}
+
+	return &DiscoveryPatterns{include: include, exclude: exclude}, nil
+}
+
+// Match reports whether the git branch matches an include pattern and no exclude pattern.
+func (p *DiscoveryPatterns) Match(branchName string) bool {
+	return matchAny(p.include, branchName) && !matchAny(p.exclude, branchName)
+}
+
+func parseBranchPatterns(value string) ([]string, error) {
+	var patterns []string
+
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		// path.Match validates the whole pattern whatever name it is given.
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+func matchAny(patterns []string, branchName string) bool {
+	for _, pattern := range patterns {
+		// Patterns are validated on parsing, so matching cannot fail here.
+		if ok, _ := path.Match(pattern, branchName); ok {
+			return true
+		}
+	}
+
+	return false
+}
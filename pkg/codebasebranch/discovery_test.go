@@ -0,0 +1,83 @@
+package codebasebranch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestNewDiscoveryPatterns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     require.ErrorAssertionFunc
+		wantNil     bool
+		match       []string
+		noMatch     []string
+	}{
+		{
+			name:    "discovery is disabled without include patterns",
+			wantErr: require.NoError,
+			wantNil: true,
+		},
+		{
+			name: "exclude patterns alone do not enable discovery",
+			annotations: map[string]string{
+				codebaseApi.BranchDiscoveryExcludeAnnotation: "feature/*",
+			},
+			wantErr: require.NoError,
+			wantNil: true,
+		},
+		{
+			name: "include and exclude patterns",
+			annotations: map[string]string{
+				codebaseApi.BranchDiscoveryIncludeAnnotation: "feature/*, release/*",
+				codebaseApi.BranchDiscoveryExcludeAnnotation: "feature/wip-*",
+			},
+			wantErr: require.NoError,
+			match:   []string{"feature/login", "release/1.0"},
+			noMatch: []string{"feature/wip-login", "feature/a/b", "main", "hotfix/1"},
+		},
+		{
+			name: "malformed pattern",
+			annotations: map[string]string{
+				codebaseApi.BranchDiscoveryIncludeAnnotation: "feature/[",
+			},
+			wantErr: require.Error,
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			patterns, err := NewDiscoveryPatterns(&codebaseApi.Codebase{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+			})
+
+			tt.wantErr(t, err)
+
+			if tt.wantNil {
+				assert.Nil(t, patterns)
+				return
+			}
+
+			require.NotNil(t, patterns)
+
+			for _, name := range tt.match {
+				assert.True(t, patterns.Match(name), name)
+			}
+
+			for _, name := range tt.noMatch {
+				assert.False(t, patterns.Match(name), name)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package codebasebranch
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+const (
+	// DefaultVersionHistoryLimit is the number of versions a CodebaseBranch keeps when the
+	// codebase sets no limit.
+	DefaultVersionHistoryLimit = 100
+
+	// DefaultBuildHistoryLimit is the number of builds a CodebaseBranch keeps when the
+	// codebase sets no limit.
+	DefaultBuildHistoryLimit = 20
+)
+
+// HistoryRetention limits the version and build history of the CodebaseBranches of a
+// Codebase. A zero limit keeps the whole history.
+type HistoryRetention struct {
+	VersionHistoryLimit int
+	BuildHistoryLimit   int
+}
+
+// NewHistoryRetention reads the history limit annotations of the codebase.
+func NewHistoryRetention(codebase *codebaseApi.Codebase) (HistoryRetention, error) {
+	versions, err := parseHistoryLimit(codebase, codebaseApi.VersionHistoryLimitAnnotation, DefaultVersionHistoryLimit)
+	if err != nil {
+		return HistoryRetention{}, err
+	}
+
+	builds, err := parseHistoryLimit(codebase, codebaseApi.BuildHistoryLimitAnnotation, DefaultBuildHistoryLimit)
+	if err != nil {
+		return HistoryRetention{}, err
+	}
+
+	return HistoryRetention{VersionHistoryLimit: versions, BuildHistoryLimit: builds}, nil
+}
+
+// TrimVersionHistory drops the oldest versions above the limit.
+func (r HistoryRetention) TrimVersionHistory(status *codebaseApi.CodebaseBranchStatus) {
+	status.VersionHistory = keepLast(status.VersionHistory, r.VersionHistoryLimit)
+}
+
+// AddBuild records a build, replacing an earlier record of the same build, so that a build
+// can report it is running and later report its result. A build is identified by its version
+// and number, since build numbers restart with every version. Builds are kept grouped by
+// version, in the order the versions were first reported, and in number order within a
+// version. It advances the build counters the CI pipelines read, which count the builds of
+// the current version only, and drops the oldest builds above the limit.
+func (r HistoryRetention) AddBuild(
+	status *codebaseApi.CodebaseBranchStatus,
+	currentVersion string,
+	build codebaseApi.BuildRecord,
+) {
+	i := slices.IndexFunc(status.Builds, func(b codebaseApi.BuildRecord) bool {
+		return b.Version == build.Version && b.Number == build.Number
+	})
+
+	if i >= 0 {
+		status.Builds[i] = build
+	} else {
+		status.Builds = append(status.Builds, build)
+	}
+
+	versionOrder := make(map[string]int)
+
+	for j, b := range status.Builds {
+		if _, ok := versionOrder[b.Version]; !ok {
+			versionOrder[b.Version] = j
+		}
+	}
+
+	slices.SortStableFunc(status.Builds, func(a, b codebaseApi.BuildRecord) int {
+		return cmp.Or(
+			cmp.Compare(versionOrder[a.Version], versionOrder[b.Version]),
+			cmp.Compare(a.Number, b.Number),
+		)
+	})
+
+	status.Builds = keepLast(status.Builds, r.BuildHistoryLimit)
+
+	// A late report of a build of an earlier version must not advance the counters, which
+	// were reset for the current version.
+	if build.Version != currentVersion {
+		return
+	}
+
+	number := strconv.FormatInt(build.Number, 10)
+
+	if current, err := strconv.ParseInt(ptr.Deref(status.Build, ""), 10, 64); err != nil || build.Number > current {
+		status.Build = &number
+	}
+
+	if build.Result != codebaseApi.BuildResultSucceeded {
+		return
+	}
+
+	if last, err := strconv.ParseInt(ptr.Deref(status.LastSuccessfulBuild, ""), 10, 64); err != nil || build.Number > last {
+		status.LastSuccessfulBuild = &number
+	}
+}
+
+// BuildReport is a build CI reported through a build report annotation.
+type BuildReport struct {
+	// Annotation is the key of the annotation the build was reported with.
+	Annotation string
+
+	Build codebaseApi.BuildRecord
+}
+
+// GetBuildReports returns the builds reported through the annotations of the branch, in
+// build number order, and the decoding errors of malformed reports by annotation key, so
+// that the caller can drop them.
+func GetBuildReports(branch *codebaseApi.CodebaseBranch) (reports []BuildReport, invalid map[string]error) {
+	for key, value := range branch.Annotations {
+		suffix, ok := strings.CutPrefix(key, codebaseApi.BuildReportAnnotationPrefix)
+		if !ok {
+			continue
+		}
+
+		build, err := decodeBuildReport(suffix, value)
+		if err != nil {
+			if invalid == nil {
+				invalid = make(map[string]error)
+			}
+
+			invalid[key] = err
+
+			continue
+		}
+
+		reports = append(reports, BuildReport{Annotation: key, Build: build})
+	}
+
+	slices.SortFunc(reports, func(a, b BuildReport) int {
+		return cmp.Compare(a.Build.Number, b.Build.Number)
+	})
+
+	return reports, invalid
+}
+
+// HasBuildReports reports whether the branch has build report annotations.
+func HasBuildReports(branch *codebaseApi.CodebaseBranch) bool {
+	for key := range branch.Annotations {
+		if strings.HasPrefix(key, codebaseApi.BuildReportAnnotationPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeBuildReport(number, value string) (codebaseApi.BuildRecord, error) {
+	build := codebaseApi.BuildRecord{}
+
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n < 0 {
+		return build, fmt.Errorf("annotation name must end with the build number, got %q", number)
+	}
+
+	if err = json.Unmarshal([]byte(value), &build); err != nil {
+		return build, fmt.Errorf("failed to decode build report: %w", err)
+	}
+
+	// The annotation name identifies the build, so that a report cannot claim another number.
+	build.Number = n
+
+	switch build.Result {
+	case codebaseApi.BuildResultRunning, codebaseApi.BuildResultSucceeded, codebaseApi.BuildResultFailed:
+	default:
+		return build, fmt.Errorf("unsupported build result %q", build.Result)
+	}
+
+	return build, nil
+}
+
+func parseHistoryLimit(codebase *codebaseApi.Codebase, annotation string, defaultLimit int) (int, error) {
+	value, ok := codebase.Annotations[annotation]
+	if !ok {
+		return defaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid %s annotation: must be a non-negative integer", annotation)
+	}
+
+	return limit, nil
+}
+
+func keepLast[T any](items []T, limit int) []T {
+	if limit == 0 || len(items) <= limit {
+		return items
+	}
+
+	return slices.Clone(items[len(items)-limit:])
+}
@@ -0,0 +1,164 @@
+package codebasebranch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestNewHistoryRetention(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     require.ErrorAssertionFunc
+		want        HistoryRetention
+	}{
+		{
+			name:    "defaults",
+			wantErr: require.NoError,
+			want:    HistoryRetention{VersionHistoryLimit: DefaultVersionHistoryLimit, BuildHistoryLimit: DefaultBuildHistoryLimit},
+		},
+		{
+			name: "custom limits",
+			annotations: map[string]string{
+				codebaseApi.VersionHistoryLimitAnnotation: "0",
+				codebaseApi.BuildHistoryLimitAnnotation:   "5",
+			},
+			wantErr: require.NoError,
+			want:    HistoryRetention{VersionHistoryLimit: 0, BuildHistoryLimit: 5},
+		},
+		{
+			name: "negative limit",
+			annotations: map[string]string{
+				codebaseApi.BuildHistoryLimitAnnotation: "-1",
+			},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewHistoryRetention(&codebaseApi.Codebase{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+			})
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHistoryRetention_TrimVersionHistory(t *testing.T) {
+	t.Parallel()
+
+	status := &codebaseApi.CodebaseBranchStatus{VersionHistory: []string{"0.1.0", "0.2.0", "0.3.0"}}
+
+	HistoryRetention{VersionHistoryLimit: 0}.TrimVersionHistory(status)
+	assert.Equal(t, []string{"0.1.0", "0.2.0", "0.3.0"}, status.VersionHistory)
+
+	HistoryRetention{VersionHistoryLimit: 2}.TrimVersionHistory(status)
+	assert.Equal(t, []string{"0.2.0", "0.3.0"}, status.VersionHistory)
+}
+
+func TestHistoryRetention_AddBuild(t *testing.T) {
+	t.Parallel()
+
+	retention := HistoryRetention{BuildHistoryLimit: 2}
+	status := &codebaseApi.CodebaseBranchStatus{Build: ptr.To("3"), LastSuccessfulBuild: ptr.To("2")}
+
+	retention.AddBuild(status, "", codebaseApi.BuildRecord{Number: 4, Result: codebaseApi.BuildResultRunning})
+	assert.Equal(t, "4", *status.Build)
+	assert.Equal(t, "2", *status.LastSuccessfulBuild)
+
+	retention.AddBuild(status, "", codebaseApi.BuildRecord{
+		Number: 4, Commit: "abc", Result: codebaseApi.BuildResultSucceeded,
+	})
+	require.Len(t, status.Builds, 1, "a repeated report must replace the build")
+	assert.Equal(t, "abc", status.Builds[0].Commit)
+	assert.Equal(t, "4", *status.LastSuccessfulBuild)
+
+	retention.AddBuild(status, "", codebaseApi.BuildRecord{Number: 6, Result: codebaseApi.BuildResultFailed})
+	retention.AddBuild(status, "", codebaseApi.BuildRecord{Number: 5, Result: codebaseApi.BuildResultSucceeded})
+
+	require.Len(t, status.Builds, 2)
+	assert.Equal(t, int64(5), status.Builds[0].Number, "builds must be kept in number order, oldest dropped")
+	assert.Equal(t, int64(6), status.Builds[1].Number)
+	assert.Equal(t, "6", *status.Build)
+	assert.Equal(t, "5", *status.LastSuccessfulBuild)
+}
+
+func TestHistoryRetention_AddBuildVersionChange(t *testing.T) {
+	t.Parallel()
+
+	retention := HistoryRetention{BuildHistoryLimit: 10}
+	status := &codebaseApi.CodebaseBranchStatus{}
+
+	retention.AddBuild(status, "1.0.0", codebaseApi.BuildRecord{
+		Version: "1.0.0", Number: 1, Commit: "old", Result: codebaseApi.BuildResultSucceeded,
+	})
+	retention.AddBuild(status, "1.0.0", codebaseApi.BuildRecord{
+		Version: "1.0.0", Number: 2, Result: codebaseApi.BuildResultRunning,
+	})
+
+	// The new version resets the counters.
+	status.Build = ptr.To("0")
+	status.LastSuccessfulBuild = nil
+
+	retention.AddBuild(status, "1.1.0", codebaseApi.BuildRecord{
+		Version: "1.1.0", Number: 1, Commit: "new", Result: codebaseApi.BuildResultSucceeded,
+	})
+
+	require.Len(t, status.Builds, 3, "build 1 of a new version must not replace build 1 of the previous one")
+	assert.Equal(t, "old", status.Builds[0].Commit)
+	assert.Equal(t, "new", status.Builds[2].Commit)
+
+	// A late result of a build of the previous version replaces its record only.
+	retention.AddBuild(status, "1.1.0", codebaseApi.BuildRecord{
+		Version: "1.0.0", Number: 2, Result: codebaseApi.BuildResultSucceeded,
+	})
+
+	require.Len(t, status.Builds, 3)
+	assert.Equal(t, codebaseApi.BuildRecord{
+		Version: "1.0.0", Number: 2, Result: codebaseApi.BuildResultSucceeded,
+	}, status.Builds[1])
+	assert.Equal(t, "1", *status.Build, "a build of the previous version must not advance the counters")
+	assert.Equal(t, "1", *status.LastSuccessfulBuild)
+}
+
+func TestGetBuildReports(t *testing.T) {
+	t.Parallel()
+
+	branch := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				codebaseApi.BuildReportAnnotationPrefix + "8": `{"number":1,"commit":"abc","pipelineRun":"build-8","result":"Succeeded"}`,
+				codebaseApi.BuildReportAnnotationPrefix + "7": `{"result":"Failed"}`,
+				codebaseApi.BuildReportAnnotationPrefix + "x": `{"result":"Failed"}`,
+				codebaseApi.BuildReportAnnotationPrefix + "9": `{"result":"Done"}`,
+				"unrelated": "value",
+			},
+		},
+	}
+
+	assert.True(t, HasBuildReports(branch))
+
+	reports, invalid := GetBuildReports(branch)
+
+	require.Len(t, reports, 2)
+	assert.Equal(t, int64(7), reports[0].Build.Number)
+	assert.Equal(t, int64(8), reports[1].Build.Number, "the annotation name must decide the build number")
+	assert.Equal(t, "build-8", reports[1].Build.PipelineRun)
+
+	assert.Len(t, invalid, 2)
+	assert.Contains(t, invalid, codebaseApi.BuildReportAnnotationPrefix+"x")
+	assert.Contains(t, invalid, codebaseApi.BuildReportAnnotationPrefix+"9")
+}
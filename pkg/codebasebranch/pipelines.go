@@ -0,0 +1,69 @@
+package codebasebranch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	tektonpipelineApi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/deploymentusage"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+// ErrPipelineAPIUnavailable is returned when the Tekton Pipeline API is not installed
+// in the cluster, so pipeline bindings cannot be checked.
+var ErrPipelineAPIUnavailable = errors.New("tekton Pipeline API is not available")
+
+// UsesTektonPipelines reports whether the branch pipelines of the codebase are Tekton
+// Pipelines. GitLab CI codebases keep their pipelines in .gitlab-ci.yml instead.
+func UsesTektonPipelines(codebase *codebaseApi.Codebase) bool {
+	return codebase.Spec.CiTool != util.CIGitLab
+}
+
+// FindMissingPipelines returns the "<binding>: <pipeline>" pairs of the branch pipeline
+// bindings whose Tekton Pipeline does not exist in the namespace, in binding order.
+func FindMissingPipelines(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	pipelines map[string]string,
+) ([]string, error) {
+	bindings := make([]string, 0, len(pipelines))
+	for binding := range pipelines {
+		bindings = append(bindings, binding)
+	}
+
+	slices.Sort(bindings)
+
+	var missing []string
+
+	for _, binding := range bindings {
+		name := pipelines[binding]
+		if name == "" {
+			missing = append(missing, binding+": <empty>")
+			continue
+		}
+
+		err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &tektonpipelineApi.Pipeline{})
+		if err == nil {
+			continue
+		}
+
+		if deploymentusage.IsKindUnavailable(err) {
+			return nil, ErrPipelineAPIUnavailable
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get pipeline %s: %w", name, err)
+		}
+
+		missing = append(missing, fmt.Sprintf("%s: %s", binding, name))
+	}
+
+	return missing, nil
+}
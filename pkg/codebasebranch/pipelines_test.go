@@ -0,0 +1,56 @@
+package codebasebranch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tektonpipelineApi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+func TestFindMissingPipelines(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, tektonpipelineApi.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&tektonpipelineApi.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "github-go-gin-app-build-semver"},
+		}).
+		Build()
+
+	missing, err := FindMissingPipelines(context.Background(), k8sClient, "default", map[string]string{
+		"review": "github-go-gin-app-review",
+		"build":  "github-go-gin-app-build-semver",
+		"deploy": "",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy: <empty>", "review: github-go-gin-app-review"}, missing)
+}
+
+func TestFindMissingPipelines_APIUnavailable(t *testing.T) {
+	t.Parallel()
+
+	k8sClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	_, err := FindMissingPipelines(context.Background(), k8sClient, "default", map[string]string{"build": "build"})
+
+	require.ErrorIs(t, err, ErrPipelineAPIUnavailable)
+}
+
+func TestUsesTektonPipelines(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, UsesTektonPipelines(&codebaseApi.Codebase{}))
+	assert.False(t, UsesTektonPipelines(&codebaseApi.Codebase{Spec: codebaseApi.CodebaseSpec{CiTool: util.CIGitLab}}))
+}
@@ -0,0 +1,56 @@
+package codebasebranch
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+// DefaultPreviewTTL is the lifetime of a preview CodebaseBranch when the codebase sets none.
+const DefaultPreviewTTL = 72 * time.Hour
+
+// previewStageRegexp matches the "<cdpipeline>/<stage>" env label format PutCDStageDeploy expects.
+// Both parts become part of DNS label names, e.g. of the CDStageDeploy and the stage namespace.
+var previewStageRegexp = regexp.MustCompile("^[a-z0-9-]+/[a-z0-9-]+$")
+
+// PreviewSettings are the preview environment settings of a Codebase.
+type PreviewSettings struct {
+	// TTL is how long a preview CodebaseBranch lives at most.
+	TTL time.Duration
+
+	// Stage is the "<cdpipeline>/<stage>" preview images are deployed to, empty when
+	// previews are only built.
+	Stage string
+}
+
+// NewPreviewSettings reads the preview environment annotations of the codebase. It returns
+// nil when the codebase has not opted in, and an error when a setting is malformed.
+func NewPreviewSettings(codebase *codebaseApi.Codebase) (*PreviewSettings, error) {
+	settings := &PreviewSettings{
+		TTL:   DefaultPreviewTTL,
+		Stage: codebase.Annotations[codebaseApi.PreviewStageAnnotation],
+	}
+
+	if ttl, ok := codebase.Annotations[codebaseApi.PreviewTTLAnnotation]; ok {
+		d, err := time.ParseDuration(ttl)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid %s annotation: must be a positive duration, e.g. 72h",
+				codebaseApi.PreviewTTLAnnotation)
+		}
+
+		settings.TTL = d
+	}
+
+	if settings.Stage != "" && !previewStageRegexp.MatchString(settings.Stage) {
+		return nil, fmt.Errorf("invalid %s annotation: must be in the <cdpipeline>/<stage> format",
+			codebaseApi.PreviewStageAnnotation)
+	}
+
+	if codebase.Annotations[codebaseApi.PreviewEnvironmentsAnnotation] != "true" {
+		return nil, nil
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,90 @@
+package codebasebranch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestNewPreviewSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     require.ErrorAssertionFunc
+		want        *PreviewSettings
+	}{
+		{
+			name:    "previews are disabled by default",
+			wantErr: require.NoError,
+		},
+		{
+			name: "defaults",
+			annotations: map[string]string{
+				codebaseApi.PreviewEnvironmentsAnnotation: "true",
+			},
+			wantErr: require.NoError,
+			want:    &PreviewSettings{TTL: DefaultPreviewTTL},
+		},
+		{
+			name: "ttl and stage",
+			annotations: map[string]string{
+				codebaseApi.PreviewEnvironmentsAnnotation: "true",
+				codebaseApi.PreviewTTLAnnotation:          "24h",
+				codebaseApi.PreviewStageAnnotation:        "app-pipeline/preview",
+			},
+			wantErr: require.NoError,
+			want:    &PreviewSettings{TTL: 24 * time.Hour, Stage: "app-pipeline/preview"},
+		},
+		{
+			name: "malformed settings are reported even when previews are disabled",
+			annotations: map[string]string{
+				codebaseApi.PreviewTTLAnnotation: "3 days",
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "non-positive ttl",
+			annotations: map[string]string{
+				codebaseApi.PreviewEnvironmentsAnnotation: "true",
+				codebaseApi.PreviewTTLAnnotation:          "0s",
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "stage without pipeline",
+			annotations: map[string]string{
+				codebaseApi.PreviewEnvironmentsAnnotation: "true",
+				codebaseApi.PreviewStageAnnotation:        "preview",
+			},
+			wantErr: require.Error,
+		},
+		{
+			name: "stage not a DNS label",
+			annotations: map[string]string{
+				codebaseApi.PreviewEnvironmentsAnnotation: "true",
+				codebaseApi.PreviewStageAnnotation:        "App_Pipeline/preview",
+			},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			settings, err := NewPreviewSettings(&codebaseApi.Codebase{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+			})
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, settings)
+		})
+	}
+}
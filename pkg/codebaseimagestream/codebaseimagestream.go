@@ -4,37 +4,115 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 )
 
-func GetLastTag(tags []codebaseApi.Tag, log logr.Logger) (codebaseApi.Tag, error) {
+var ErrLatestTagNotFound = errors.New("latest tag is not found")
+
+// GetLatestTag returns the latest of the tags as the selection of the CodebaseImageStream
+// orders them. Without a selection, it is the tag with the latest creation time.
+// Tags that do not match the pattern of the selection, tags that are not semantic versions
+// with the semver strategy, and tags with a creation time that cannot be parsed with the
+// created strategy are not selected. It returns ErrLatestTagNotFound if no tag is selected.
+func GetLatestTag(
+	tags []codebaseApi.Tag,
+	selection *codebaseApi.LatestTagSelection,
+	log logr.Logger,
+) (codebaseApi.Tag, error) {
+	strategy := codebaseApi.LatestTagStrategyCreated
+
+	var pattern *regexp.Regexp
+
+	if selection != nil {
+		if selection.Strategy != "" {
+			strategy = selection.Strategy
+		}
+
+		if selection.Pattern != "" {
+			var err error
+
+			if pattern, err = regexp.Compile(selection.Pattern); err != nil {
+				return codebaseApi.Tag{}, fmt.Errorf("invalid latest tag pattern %q: %w", selection.Pattern, err)
+			}
+		}
+	}
+
+	if strategy != codebaseApi.LatestTagStrategyCreated && strategy != codebaseApi.LatestTagStrategySemver {
+		return codebaseApi.Tag{}, fmt.Errorf("unsupported latest tag strategy %q", strategy)
+	}
+
 	var (
-		latestTag     codebaseApi.Tag
-		latestTagTime = time.Time{}
+		latest        codebaseApi.Tag
+		latestCreated time.Time
+		latestVersion semver.Version
+		found         bool
+		notSemver     int
 	)
 
-	for i, s := range tags {
-		current, err := time.Parse(time.RFC3339, tags[i].Created)
+	for _, tag := range tags {
+		version := tag.Name
+
+		if pattern != nil {
+			match := pattern.FindStringSubmatch(tag.Name)
+			if match == nil {
+				continue
+			}
+
+			if len(match) > 1 {
+				version = match[1]
+			}
+		}
+
+		created, err := time.Parse(time.RFC3339, tag.Created)
+
+		if strategy == codebaseApi.LatestTagStrategySemver {
+			v, verr := semver.ParseTolerant(version)
+			if verr != nil {
+				notSemver++
+				continue
+			}
+
+			// Tags with the same version, e.g. rebuilds, are ordered by their creation time.
+			if found {
+				if c := v.Compare(latestVersion); c < 0 || (c == 0 && !created.After(latestCreated)) {
+					continue
+				}
+			}
+
+			latest, latestCreated, latestVersion, found = tag, created, v, true
+
+			continue
+		}
+
 		if err != nil {
-			log.Error(err, "Failed to parse tag created time. Skip tag.", "tag", s.Name)
+			log.Error(err, "Failed to parse tag created time. Skip tag.", "tag", tag.Name)
+			continue
 		}
 
-		if current.After(latestTagTime) {
-			latestTagTime = current
-			latestTag = s
+		if !found || created.After(latestCreated) {
+			latest, latestCreated, found = tag, created, true
 		}
 	}
 
-	if latestTag.Name == "" {
-		return latestTag, errors.New("latest tag is not found")
+	// Streams with the semver strategy may hold many other tags, e.g. snapshots, so they are
+	// reported once per call rather than per tag.
+	if notSemver > 0 {
+		log.V(2).Info("Skipped tags that are not semantic versions", "count", notSemver)
 	}
 
-	return latestTag, nil
+	if !found {
+		return codebaseApi.Tag{}, ErrLatestTagNotFound
+	}
+
+	return latest, nil
 }
 
 var ErrCodebaseImageStreamNotFound = errors.New("CodebaseImageStream not found")
@@ -72,3 +150,108 @@ func GetCodebaseImageStreamByCodebaseBaseBranchName(
 
 	return &codebaseImageStreamList.Items[0], nil
 }
+
+var ErrTagNotFound = errors.New("tag not found")
+
+// RecordTagTickets adds the tickets to the tag in the CodebaseImageStreams of the codebase.
+// It returns ErrTagNotFound if no CodebaseImageStream of the codebase has the tag yet.
+func RecordTagTickets(
+	ctx context.Context,
+	k8sCl client.Client,
+	codebaseName, namespace, tag string,
+	tickets []string,
+) error {
+	streams, err := listByCodebase(ctx, k8sCl, codebaseName, namespace)
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	for i := range streams {
+		stream := &streams[i]
+		patch := client.MergeFrom(stream.DeepCopy())
+		changed := false
+
+		for j := range stream.Spec.Tags {
+			if stream.Spec.Tags[j].Name != tag {
+				continue
+			}
+
+			found = true
+
+			for _, ticket := range tickets {
+				if !slices.Contains(stream.Spec.Tags[j].Tickets, ticket) {
+					stream.Spec.Tags[j].Tickets = append(stream.Spec.Tags[j].Tickets, ticket)
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err = k8sCl.Patch(ctx, stream, patch); err != nil {
+			return fmt.Errorf("failed to record tickets in CodebaseImageStream %s: %w", stream.Name, err)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s in CodebaseImageStreams of codebase %s", ErrTagNotFound, tag, codebaseName)
+	}
+
+	return nil
+}
+
+// GetTagTickets returns the tickets recorded on the tag in the CodebaseImageStreams of the codebase.
+func GetTagTickets(
+	ctx context.Context,
+	k8sCl client.Client,
+	codebaseName, namespace, tag string,
+) ([]string, error) {
+	streams, err := listByCodebase(ctx, k8sCl, codebaseName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets []string
+
+	for i := range streams {
+		for _, t := range streams[i].Spec.Tags {
+			if t.Name != tag {
+				continue
+			}
+
+			for _, ticket := range t.Tickets {
+				if !slices.Contains(tickets, ticket) {
+					tickets = append(tickets, ticket)
+				}
+			}
+		}
+	}
+
+	return tickets, nil
+}
+
+func listByCodebase(
+	ctx context.Context,
+	k8sCl client.Client,
+	codebaseName, namespace string,
+) ([]codebaseApi.CodebaseImageStream, error) {
+	var list codebaseApi.CodebaseImageStreamList
+
+	if err := k8sCl.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list CodebaseImageStreams: %w", err)
+	}
+
+	streams := make([]codebaseApi.CodebaseImageStream, 0, len(list.Items))
+
+	for i := range list.Items {
+		if list.Items[i].Spec.Codebase == codebaseName {
+			streams = append(streams, list.Items[i])
+		}
+	}
+
+	return streams, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,14 +16,23 @@ import (
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 )
 
-func TestGetLastTag(t *testing.T) {
+func TestGetLatestTag(t *testing.T) {
 	t.Parallel()
 
+	releases := []codebaseApi.Tag{
+		{Name: "1.10.0", Created: "2026-10-01T12:00:00Z"},
+		// Built on a runner with a clock ahead.
+		{Name: "1.9.0", Created: "2026-10-03T12:00:00Z"},
+		{Name: "1.11.0-SNAPSHOT.1", Created: "2026-10-02T12:00:00Z"},
+		{Name: "latest", Created: "2026-10-02T13:00:00Z"},
+	}
+
 	tests := []struct {
-		name    string
-		tags    []codebaseApi.Tag
-		want    codebaseApi.Tag
-		wantErr assert.ErrorAssertionFunc
+		name      string
+		tags      []codebaseApi.Tag
+		selection *codebaseApi.LatestTagSelection
+		want      codebaseApi.Tag
+		wantErr   assert.ErrorAssertionFunc
 	}{
 		{
 			name: "should return latest tag",
@@ -64,19 +74,98 @@ func TestGetLastTag(t *testing.T) {
 			name:    "should return error if latest tag is not found",
 			wantErr: assert.Error,
 		},
+		{
+			name:      "should return tag with latest created time matching pattern",
+			tags:      releases,
+			selection: &codebaseApi.LatestTagSelection{Pattern: `^\d+\.\d+\.\d+$`},
+			want:      releases[1],
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "should return tag with highest version",
+			tags:      releases,
+			selection: &codebaseApi.LatestTagSelection{Strategy: codebaseApi.LatestTagStrategySemver},
+			want:      releases[2],
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "should return tag with highest version matching pattern",
+			tags: releases,
+			selection: &codebaseApi.LatestTagSelection{
+				Strategy: codebaseApi.LatestTagStrategySemver,
+				Pattern:  `^\d+\.\d+\.\d+$`,
+			},
+			want:    releases[0],
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should return latest build of highest version captured by pattern",
+			tags: []codebaseApi.Tag{
+				{Name: "main-0.10.0-1", Created: "2026-10-02T12:00:00Z"},
+				{Name: "main-0.10.0-2", Created: "2026-10-03T12:00:00Z"},
+				{Name: "main-0.9.0-7", Created: "2026-10-04T12:00:00Z"},
+				{Name: "feature-1.0.0-1", Created: "2026-10-05T12:00:00Z"},
+			},
+			selection: &codebaseApi.LatestTagSelection{
+				Strategy: codebaseApi.LatestTagStrategySemver,
+				Pattern:  `^main-(\d+\.\d+\.\d+)-\d+$`,
+			},
+			want:    codebaseApi.Tag{Name: "main-0.10.0-2", Created: "2026-10-03T12:00:00Z"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "should return error if no tag matches pattern",
+			tags:      releases,
+			selection: &codebaseApi.LatestTagSelection{Pattern: `^v\d+$`},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrLatestTagNotFound)
+			},
+		},
+		{
+			name:      "should return error if pattern is invalid",
+			tags:      releases,
+			selection: &codebaseApi.LatestTagSelection{Pattern: `^(\d+$`},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorContains(t, err, "invalid latest tag pattern")
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := GetLastTag(tt.tags, logr.Discard())
+			got, err := GetLatestTag(tt.tags, tt.selection, logr.Discard())
 			tt.wantErr(t, err)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func TestGetLatestTag_logsSkippedTagsOnce(t *testing.T) {
+	t.Parallel()
+
+	var lines []string
+
+	log := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 2})
+
+	tags := []codebaseApi.Tag{
+		{Name: "1.0.0", Created: "2026-10-01T10:00:00Z"},
+		{Name: "main-1", Created: "2026-10-02T10:00:00Z"},
+		{Name: "main-2", Created: "2026-10-03T10:00:00Z"},
+		{Name: "main-3", Created: "2026-10-04T10:00:00Z"},
+	}
+
+	got, err := GetLatestTag(tags, &codebaseApi.LatestTagSelection{Strategy: codebaseApi.LatestTagStrategySemver}, log)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", got.Name)
+
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"count"=3`)
+}
+
 func TestGetCodebaseImageStreamByCodebaseBaseBranchName(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, codebaseApi.AddToScheme(scheme))
@@ -174,3 +263,47 @@ func TestGetCodebaseImageStreamByCodebaseBaseBranchName(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordTagTickets(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+
+	streams := []client.Object{
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-main", Namespace: "default"},
+			Spec: codebaseApi.CodebaseImageStreamSpec{
+				Codebase: "app",
+				Tags: []codebaseApi.Tag{
+					{Name: "0.1.0-SNAPSHOT.1", Created: "2024-01-01T00:00:00Z"},
+					{Name: "0.1.0-SNAPSHOT.2", Created: "2024-01-02T00:00:00Z", Tickets: []string{"APP-1"}},
+				},
+			},
+		},
+		&codebaseApi.CodebaseImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-main", Namespace: "default"},
+			Spec: codebaseApi.CodebaseImageStreamSpec{
+				Codebase: "other",
+				Tags:     []codebaseApi.Tag{{Name: "0.1.0-SNAPSHOT.2", Created: "2024-01-02T00:00:00Z"}},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(streams...).Build()
+	ctx := context.Background()
+
+	err := RecordTagTickets(ctx, k8sClient, "app", "default", "0.1.0-SNAPSHOT.2", []string{"APP-1", "APP-2"})
+	require.NoError(t, err)
+
+	tickets, err := GetTagTickets(ctx, k8sClient, "app", "default", "0.1.0-SNAPSHOT.2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"APP-1", "APP-2"}, tickets)
+
+	tickets, err = GetTagTickets(ctx, k8sClient, "other", "default", "0.1.0-SNAPSHOT.2")
+	require.NoError(t, err)
+	assert.Empty(t, tickets)
+
+	err = RecordTagTickets(ctx, k8sClient, "app", "default", "0.1.0-SNAPSHOT.3", []string{"APP-3"})
+	require.ErrorIs(t, err, ErrTagNotFound)
+}
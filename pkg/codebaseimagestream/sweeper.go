@@ -0,0 +1,55 @@
+package codebaseimagestream
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Sweeper is a manager Runnable (leader-only) that sweeps the CodebaseImageStreams once on
+// startup and then on every tick of the interval. It is used rather than a watch-driven
+// controller for work that depends on time or on external state, not on a change of the
+// CodebaseImageStreams.
+type Sweeper struct {
+	name     string
+	interval time.Duration
+	sweep    func(ctx context.Context)
+}
+
+// NewSweeper creates a Sweeper that calls sweep with a logger of the name.
+func NewSweeper(name string, interval time.Duration, sweep func(ctx context.Context)) *Sweeper {
+	return &Sweeper{
+		name:     name,
+		interval: interval,
+		sweep:    sweep,
+	}
+}
+
+// Start implements manager.Runnable.
+func (s *Sweeper) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName(s.name)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	log.Info("Starting sweeps", "interval", s.interval)
+
+	s.sweep(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Stopping sweeps")
+			return nil
+		case <-ticker.C:
+			s.sweep(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection ensures only the elected leader updates the CodebaseImageStreams.
+func (s *Sweeper) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,39 @@
+package codebaseimagestream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweeper_Start(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sweeps := make(chan struct{}, 1)
+
+	s := NewSweeper("test-sweeper", time.Millisecond, func(context.Context) {
+		select {
+		case sweeps <- struct{}{}:
+		default:
+		}
+	})
+
+	done := make(chan error)
+
+	go func() {
+		done <- s.Start(ctx)
+	}()
+
+	// The first sweep runs on startup, the next one on a tick.
+	<-sweeps
+	<-sweeps
+
+	cancel()
+
+	require.NoError(t, <-done)
+	assert.True(t, s.NeedLeaderElection())
+}
@@ -1,9 +1,14 @@
 package v2
 
 import (
+	"context"
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitconnection"
 	"github.com/epam/edp-codebase-operator/v2/pkg/util"
 )
 
@@ -11,15 +16,32 @@ import (
 // This is a factory pattern to enable dependency injection and mocking in tests.
 type GitProviderFactory func(cfg Config) Git
 
-// DefaultGitProviderFactory is the default factory implementation for creating GitProvider instances.
-func DefaultGitProviderFactory(gitServer *codebaseApi.GitServer, secret *corev1.Secret) Git {
-	return NewGitProvider(NewConfigFromGitServerAndSecret(gitServer, secret))
-}
-
 func NewGitProviderFactory(cfg Config) Git {
 	return NewGitProvider(cfg)
 }
 
+// NewConfig creates the Config of the GitServer, with the TLS settings read from the Secrets
+// the GitServer refers to.
+func NewConfig(
+	ctx context.Context,
+	c client.Reader,
+	gitServer *codebaseApi.GitServer,
+	secret *corev1.Secret,
+) (Config, error) {
+	cfg := NewConfigFromGitServerAndSecret(gitServer, secret)
+
+	conn, err := gitconnection.Load(ctx, c, gitServer)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to load GitServer connection settings: %w", err)
+	}
+
+	cfg.Connection = conn
+
+	return cfg, nil
+}
+
+// NewConfigFromGitServerAndSecret creates the Config of the GitServer. It has the proxy
+// settings of the GitServer but not its TLS settings, use NewConfig to read them.
 func NewConfigFromGitServerAndSecret(gitServer *codebaseApi.GitServer, secret *corev1.Secret) Config {
 	return Config{
 		SSHKey:      string(secret.Data[util.PrivateSShKeyName]),
@@ -28,5 +50,6 @@ func NewConfigFromGitServerAndSecret(gitServer *codebaseApi.GitServer, secret *c
 		GitProvider: gitServer.Spec.GitProvider,
 		Token:       string(secret.Data[util.GitServerSecretTokenField]),
 		Username:    string(secret.Data[util.GitServerSecretUserNameField]),
+		Connection:  gitconnection.FromGitServer(gitServer),
 	}
 }
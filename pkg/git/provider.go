@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitconnection"
 	"github.com/epam/edp-codebase-operator/v2/pkg/sshhostkey"
 )
 
@@ -67,6 +69,9 @@ type Config struct {
 	// Token authentication fields (optional)
 	Token    string // Access token for HTTP authentication
 	Username string // Username for token auth (required for Bitbucket)
+
+	// Connection holds the proxy and TLS settings of HTTPS remotes (optional)
+	Connection gitconnection.Settings
 }
 
 // GitProvider provides git operations using go-git library exclusively.
@@ -122,6 +127,57 @@ func (p *GitProvider) getAuth() (transport.AuthMethod, error) {
 	return nil, nil
 }
 
+// remoteOptions are the proxy and TLS options of a remote operation. They apply to HTTPS
+// remotes only; SSH remotes ignore them.
+type remoteOptions struct {
+	proxy      transport.ProxyOptions
+	caBundle   []byte
+	clientCert []byte
+	clientKey  []byte
+}
+
+func (p *GitProvider) getRemoteOptions(repoURL string) (remoteOptions, error) {
+	conn := p.config.Connection
+
+	opts := remoteOptions{
+		caBundle:   conn.CABundle,
+		clientCert: conn.ClientCert,
+		clientKey:  conn.ClientKey,
+	}
+
+	if conn.ProxyURL == "" || repoURL == "" {
+		return opts, nil
+	}
+
+	ep, err := transport.NewEndpoint(repoURL)
+	if err != nil {
+		return remoteOptions{}, fmt.Errorf("failed to parse repository URL %q: %w", repoURL, err)
+	}
+
+	if ep.Protocol != "http" && ep.Protocol != "https" {
+		return opts, nil
+	}
+
+	proxyURL, err := conn.ConfiguredProxy(&url.URL{Scheme: ep.Protocol, Host: ep.Host})
+	if err != nil {
+		return remoteOptions{}, fmt.Errorf("failed to get proxy: %w", err)
+	}
+
+	opts.proxy = transport.ProxyOptions{URL: proxyURL}
+
+	return opts, nil
+}
+
+func listOptions(auth transport.AuthMethod, remoteOpts remoteOptions) *git.ListOptions {
+	return &git.ListOptions{
+		Auth:         auth,
+		CABundle:     remoteOpts.caBundle,
+		ClientCert:   remoteOpts.clientCert,
+		ClientKey:    remoteOpts.clientKey,
+		ProxyOptions: remoteOpts.proxy,
+	}
+}
+
 // remoteErr passes non-host-key errors through untouched, so it is safe to wrap
 // every error returned by a remote operation.
 func remoteErr(err error, repoURL string) error {
@@ -187,11 +243,20 @@ func (p *GitProvider) Clone(ctx context.Context, repoURL, destination string) er
 		return fmt.Errorf("failed to get authentication: %w", err)
 	}
 
+	remoteOpts, err := p.getRemoteOptions(repoURL)
+	if err != nil {
+		return err
+	}
+
 	// Clone the repository (gets default branch)
 	cloneOptions := &git.CloneOptions{
-		URL:      repoURL,
-		Progress: os.Stdout,
-		Auth:     auth,
+		URL:          repoURL,
+		Progress:     os.Stdout,
+		Auth:         auth,
+		CABundle:     remoteOpts.caBundle,
+		ClientCert:   remoteOpts.clientCert,
+		ClientKey:    remoteOpts.clientKey,
+		ProxyOptions: remoteOpts.proxy,
 	}
 
 	repo, err := git.PlainCloneContext(ctx, destination, false, cloneOptions)
@@ -210,6 +275,10 @@ func (p *GitProvider) Clone(ctx context.Context, repoURL, destination string) er
 			config.RefSpec("+refs/heads/*:refs/heads/*"),
 			config.RefSpec("+refs/tags/*:refs/tags/*"),
 		},
+		CABundle:     remoteOpts.caBundle,
+		ClientCert:   remoteOpts.clientCert,
+		ClientKey:    remoteOpts.clientKey,
+		ProxyOptions: remoteOpts.proxy,
 	}
 
 	err = repo.FetchContext(ctx, fetchOptions)
@@ -299,10 +368,19 @@ func (p *GitProvider) Push(ctx context.Context, directory string, refspecs ...st
 		return fmt.Errorf("failed to get authentication: %w", err)
 	}
 
+	remoteOpts, err := p.getRemoteOptions(originURL(repo))
+	if err != nil {
+		return err
+	}
+
 	pushOptions := &git.PushOptions{
-		RemoteName: "origin",
-		Auth:       auth,
-		Progress:   os.Stdout,
+		RemoteName:   "origin",
+		Auth:         auth,
+		Progress:     os.Stdout,
+		CABundle:     remoteOpts.caBundle,
+		ClientCert:   remoteOpts.clientCert,
+		ClientKey:    remoteOpts.clientKey,
+		ProxyOptions: remoteOpts.proxy,
 	}
 
 	// Convert refspecs if provided
@@ -348,6 +426,11 @@ func (p *GitProvider) Checkout(ctx context.Context, directory, branchName string
 			return fmt.Errorf("failed to get authentication: %w", err)
 		}
 
+		remoteOpts, err := p.getRemoteOptions(originURL(repo))
+		if err != nil {
+			return err
+		}
+
 		fetchOptions := &git.FetchOptions{
 			RefSpecs: []config.RefSpec{"refs/*:refs/*"},
 			Auth:     auth,
@@ -355,7 +438,11 @@ func (p *GitProvider) Checkout(ctx context.Context, directory, branchName string
 			// The refspec has no force prefix, so without this a rebased or
 			// force-pushed upstream branch fails the fetch with ErrForceNeeded
 			// against a cached workdir.
-			Force: true,
+			Force:        true,
+			CABundle:     remoteOpts.caBundle,
+			ClientCert:   remoteOpts.clientCert,
+			ClientKey:    remoteOpts.clientKey,
+			ProxyOptions: remoteOpts.proxy,
 		}
 
 		err = repo.FetchContext(ctx, fetchOptions)
@@ -421,6 +508,11 @@ func (p *GitProvider) ListRemoteBranches(ctx context.Context, repoURL string) ([
 		return nil, fmt.Errorf("failed to get authentication: %w", err)
 	}
 
+	remoteOpts, err := p.getRemoteOptions(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
 	repo, _ := git.Init(memory.NewStorage(), nil)
 
 	remote, err := repo.CreateRemote(&config.RemoteConfig{
@@ -431,9 +523,7 @@ func (p *GitProvider) ListRemoteBranches(ctx context.Context, repoURL string) ([
 		return nil, fmt.Errorf("failed to create remote: %w", err)
 	}
 
-	refs, err := remote.ListContext(ctx, &git.ListOptions{
-		Auth: auth,
-	})
+	refs, err := remote.ListContext(ctx, listOptions(auth, remoteOpts))
 	if err != nil {
 		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
 			log.Info("Repository is empty, no branches found")
@@ -472,6 +562,11 @@ func (p *GitProvider) CheckPermissions(ctx context.Context, repoURL string) erro
 		return fmt.Errorf("failed to get authentication: %w", err)
 	}
 
+	remoteOpts, err := p.getRemoteOptions(repoURL)
+	if err != nil {
+		return err
+	}
+
 	// Create a temporary in-memory remote to test access
 	repo, _ := git.Init(memory.NewStorage(), nil)
 
@@ -484,9 +579,7 @@ func (p *GitProvider) CheckPermissions(ctx context.Context, repoURL string) erro
 	}
 
 	// Try to list references
-	_, err = remote.ListContext(ctx, &git.ListOptions{
-		Auth: auth,
-	})
+	_, err = remote.ListContext(ctx, listOptions(auth, remoteOpts))
 	if err != nil {
 		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
 			log.Info("Repository is empty but accessible")
@@ -627,12 +720,21 @@ func (p *GitProvider) CheckoutRemoteBranch(ctx context.Context, directory, branc
 		return fmt.Errorf("failed to get authentication: %w", err)
 	}
 
+	remoteOpts, err := p.getRemoteOptions(originURL(repo))
+	if err != nil {
+		return err
+	}
+
 	fetchOptions := &git.FetchOptions{
-		RemoteName: "origin",
-		RefSpecs:   []config.RefSpec{"refs/*:refs/*"},
-		Auth:       auth,
-		Progress:   os.Stdout,
-		Force:      true, // Equivalent to --update-head-ok
+		RemoteName:   "origin",
+		RefSpecs:     []config.RefSpec{"refs/*:refs/*"},
+		Auth:         auth,
+		Progress:     os.Stdout,
+		Force:        true, // Equivalent to --update-head-ok
+		CABundle:     remoteOpts.caBundle,
+		ClientCert:   remoteOpts.clientCert,
+		ClientKey:    remoteOpts.clientKey,
+		ProxyOptions: remoteOpts.proxy,
 	}
 
 	err = repo.FetchContext(ctx, fetchOptions)
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitconnection"
 	"github.com/epam/edp-codebase-operator/v2/pkg/platform"
 )
 
@@ -1204,6 +1205,50 @@ func TestGitProvider_CheckoutRemoteBranch_NoRemote(t *testing.T) {
 	}
 }
 
+func TestGitProvider_getRemoteOptions(t *testing.T) {
+	gp := NewGitProvider(Config{
+		Connection: gitconnection.Settings{
+			ProxyURL:   "http://proxy.example.com:3128",
+			NoProxy:    ".internal.example.com",
+			CABundle:   []byte("ca"),
+			ClientCert: []byte("cert"),
+			ClientKey:  []byte("key"),
+		},
+	})
+
+	tests := []struct {
+		name      string
+		repoURL   string
+		wantProxy string
+	}{
+		{
+			name:      "https remote goes through the proxy",
+			repoURL:   "https://gitlab.example.com/owner/repo.git",
+			wantProxy: "http://proxy.example.com:3128",
+		},
+		{
+			name:    "remote listed in no-proxy is reached directly",
+			repoURL: "https://git.internal.example.com/owner/repo.git",
+		},
+		{
+			name:    "ssh remote ignores the proxy",
+			repoURL: "ssh://git@gitlab.example.com:22/owner/repo.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gp.getRemoteOptions(tt.repoURL)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantProxy, got.proxy.URL)
+			assert.Equal(t, []byte("ca"), got.caBundle)
+			assert.Equal(t, []byte("cert"), got.clientCert)
+			assert.Equal(t, []byte("key"), got.clientKey)
+		})
+	}
+}
+
 // Host key lookups are keyed on the port actually dialled, which the repository
 // URL determines: ssh:// carries a port, the scp-style form cannot.
 func TestSshTarget(t *testing.T) {
@@ -143,6 +143,16 @@ func (p *GitProvider) newTransportClient(
 		return nil, nil, nil, fmt.Errorf("failed to parse repository URL %q: %w", repoURL, err)
 	}
 
+	remoteOpts, err := p.getRemoteOptions(repoURL)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ep.CaBundle = remoteOpts.caBundle
+	ep.ClientCert = remoteOpts.clientCert
+	ep.ClientKey = remoteOpts.clientKey
+	ep.Proxy = remoteOpts.proxy
+
 	c, err := client.NewClient(ep)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create transport client: %w", err)
@@ -0,0 +1,222 @@
+// Package gitconnection provides the proxy and TLS settings used to connect to a GitServer
+// over HTTP(S), shared by the go-git transports and the git provider REST clients.
+package gitconnection
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+const (
+	// CABundleKey is the key of the CA bundle Secret that holds the PEM CA certificates.
+	CABundleKey = "ca.crt"
+)
+
+// Settings are the proxy and TLS settings of a GitServer. The zero value connects with
+// the system defaults.
+type Settings struct {
+	// ProxyURL is the URL of the HTTP(S) proxy. Empty means the proxy environment variables apply.
+	ProxyURL string
+	// NoProxy lists the hosts reached directly, in the NO_PROXY format.
+	NoProxy string
+
+	// CABundle holds PEM CA certificates trusted in addition to the system ones.
+	CABundle []byte
+
+	// ClientCert and ClientKey are the PEM client certificate and key for mutual TLS.
+	ClientCert []byte
+	ClientKey  []byte
+}
+
+// Load reads the proxy settings of the GitServer and the Secrets its TLS settings refer to.
+// The Secrets must be in the GitServer namespace.
+func Load(ctx context.Context, c client.Reader, gitServer *codebaseApi.GitServer) (Settings, error) {
+	settings := FromGitServer(gitServer)
+
+	tlsSpec := gitServer.Spec.TLS
+	if tlsSpec == nil {
+		return settings, nil
+	}
+
+	if tlsSpec.CABundleSecretRef != nil {
+		secret, err := getSecret(ctx, c, gitServer.Namespace, tlsSpec.CABundleSecretRef.Name)
+		if err != nil {
+			return Settings{}, err
+		}
+
+		settings.CABundle = secret.Data[CABundleKey]
+		if len(settings.CABundle) == 0 {
+			return Settings{}, fmt.Errorf("CA bundle secret %s has no %s key", secret.Name, CABundleKey)
+		}
+	}
+
+	if tlsSpec.ClientCertSecretRef != nil {
+		secret, err := getSecret(ctx, c, gitServer.Namespace, tlsSpec.ClientCertSecretRef.Name)
+		if err != nil {
+			return Settings{}, err
+		}
+
+		settings.ClientCert = secret.Data[corev1.TLSCertKey]
+		settings.ClientKey = secret.Data[corev1.TLSPrivateKeyKey]
+
+		if len(settings.ClientCert) == 0 || len(settings.ClientKey) == 0 {
+			return Settings{}, fmt.Errorf(
+				"client certificate secret %s must have %s and %s keys",
+				secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey,
+			)
+		}
+	}
+
+	return settings, nil
+}
+
+// FromGitServer returns the proxy settings of the GitServer, without the TLS settings,
+// which need their Secrets to be read with Load.
+func FromGitServer(gitServer *codebaseApi.GitServer) Settings {
+	if gitServer.Spec.Proxy == nil {
+		return Settings{}
+	}
+
+	return Settings{
+		ProxyURL: gitServer.Spec.Proxy.URL,
+		NoProxy:  gitServer.Spec.Proxy.NoProxy,
+	}
+}
+
+// IsZero reports whether the settings are all defaults.
+func (s Settings) IsZero() bool {
+	return s.ProxyURL == "" && len(s.CABundle) == 0 && len(s.ClientCert) == 0
+}
+
+// Proxy returns the proxy URL for the target, or nil when it is reached directly. Targets
+// without a configured proxy, either because there is none or because NoProxy lists them,
+// follow the proxy environment variables, as go-git transports do.
+func (s Settings) Proxy(target *url.URL) (*url.URL, error) {
+	proxyURL, err := s.configuredProxy(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL != nil {
+		return proxyURL, nil
+	}
+
+	return http.ProxyFromEnvironment(&http.Request{URL: target}) //nolint:wrapcheck // the error is descriptive
+}
+
+// ConfiguredProxy returns the configured proxy URL for the target, or an empty string when
+// there is none for it.
+func (s Settings) ConfiguredProxy(target *url.URL) (string, error) {
+	proxyURL, err := s.configuredProxy(target)
+	if err != nil || proxyURL == nil {
+		return "", err
+	}
+
+	return proxyURL.String(), nil
+}
+
+func (s Settings) configuredProxy(target *url.URL) (*url.URL, error) {
+	if s.ProxyURL == "" {
+		return nil, nil
+	}
+
+	cfg := httpproxy.Config{
+		HTTPProxy:  s.ProxyURL,
+		HTTPSProxy: s.ProxyURL,
+		NoProxy:    s.NoProxy,
+	}
+
+	proxyURL, err := cfg.ProxyFunc()(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proxy for %s: %w", target.Host, err)
+	}
+
+	return proxyURL, nil
+}
+
+// TLSConfig returns the TLS configuration with the CA bundle and client certificate, or nil
+// when both are unset.
+func (s Settings) TLSConfig() (*tls.Config, error) {
+	if len(s.CABundle) == 0 && len(s.ClientCert) == 0 {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(s.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(s.CABundle) {
+			return nil, errors.New("CA bundle has no valid PEM certificates")
+		}
+
+		cfg.RootCAs = pool
+	}
+
+	if len(s.ClientCert) > 0 {
+		cert, err := tls.X509KeyPair(s.ClientCert, s.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// Transport returns a copy of the base transport that connects with the settings.
+func (s Settings) Transport(base *http.Transport) (*http.Transport, error) {
+	tlsConfig, err := s.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := base.Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return s.Proxy(req.URL)
+	}
+
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// Fingerprint identifies the settings, so that clients built from them can be reused until they change.
+func (s Settings) Fingerprint() string {
+	h := sha256.New()
+
+	for _, part := range [][]byte{[]byte(s.ProxyURL), []byte(s.NoProxy), s.CABundle, s.ClientCert, s.ClientKey} {
+		_, _ = fmt.Fprintf(h, "%d:", len(part))
+		_, _ = h.Write(part)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func getSecret(ctx context.Context, c client.Reader, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,151 @@
+package gitconnection
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "git-ca"},
+			Data:       map[string][]byte{CABundleKey: []byte("ca")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "git-client-cert"},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("cert"), corev1.TLSPrivateKeyKey: []byte("key")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "empty"},
+		},
+	).Build()
+
+	tests := []struct {
+		name    string
+		spec    codebaseApi.GitServerSpec
+		want    Settings
+		wantErr string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "proxy and TLS",
+			spec: codebaseApi.GitServerSpec{
+				Proxy: &codebaseApi.GitServerProxy{URL: "http://proxy:3128", NoProxy: ".internal"},
+				TLS: &codebaseApi.GitServerTLS{
+					CABundleSecretRef:   &corev1.LocalObjectReference{Name: "git-ca"},
+					ClientCertSecretRef: &corev1.LocalObjectReference{Name: "git-client-cert"},
+				},
+			},
+			want: Settings{
+				ProxyURL:   "http://proxy:3128",
+				NoProxy:    ".internal",
+				CABundle:   []byte("ca"),
+				ClientCert: []byte("cert"),
+				ClientKey:  []byte("key"),
+			},
+		},
+		{
+			name: "CA bundle without key",
+			spec: codebaseApi.GitServerSpec{
+				TLS: &codebaseApi.GitServerTLS{CABundleSecretRef: &corev1.LocalObjectReference{Name: "empty"}},
+			},
+			wantErr: "has no ca.crt key",
+		},
+		{
+			name: "client certificate without keys",
+			spec: codebaseApi.GitServerSpec{
+				TLS: &codebaseApi.GitServerTLS{ClientCertSecretRef: &corev1.LocalObjectReference{Name: "empty"}},
+			},
+			wantErr: "must have tls.crt and tls.key keys",
+		},
+		{
+			name: "missing secret",
+			spec: codebaseApi.GitServerSpec{
+				TLS: &codebaseApi.GitServerTLS{CABundleSecretRef: &corev1.LocalObjectReference{Name: "missing"}},
+			},
+			wantErr: "failed to get secret missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Load(context.Background(), k8sClient, &codebaseApi.GitServer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "git"},
+				Spec:       tt.spec,
+			})
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSettings_ConfiguredProxy(t *testing.T) {
+	t.Parallel()
+
+	settings := Settings{ProxyURL: "http://proxy:3128", NoProxy: ".internal.example.com,10.0.0.0/8"}
+
+	got, err := settings.ConfiguredProxy(&url.URL{Scheme: "https", Host: "gitlab.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy:3128", got)
+
+	got, err = settings.ConfiguredProxy(&url.URL{Scheme: "https", Host: "git.internal.example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, got, "hosts listed in NoProxy must be reached directly")
+
+	got, err = settings.ConfiguredProxy(&url.URL{Scheme: "https", Host: "10.1.2.3"})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = Settings{}.ConfiguredProxy(&url.URL{Scheme: "https", Host: "gitlab.example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestSettings_TLSConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := Settings{}.TLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	_, err = Settings{CABundle: []byte("not a certificate")}.TLSConfig()
+	require.ErrorContains(t, err, "no valid PEM certificates")
+
+	_, err = Settings{ClientCert: []byte("cert"), ClientKey: []byte("key")}.TLSConfig()
+	require.ErrorContains(t, err, "failed to parse client certificate")
+}
+
+func TestSettings_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	settings := Settings{ProxyURL: "http://proxy:3128", CABundle: []byte("ca")}
+
+	assert.Equal(t, settings.Fingerprint(), Settings{ProxyURL: "http://proxy:3128", CABundle: []byte("ca")}.Fingerprint())
+	assert.NotEqual(t, settings.Fingerprint(), Settings{ProxyURL: "http://proxy:3128", CABundle: []byte("ca2")}.Fingerprint())
+	assert.NotEqual(t, Settings{ProxyURL: "ab"}.Fingerprint(), Settings{NoProxy: "ab"}.Fingerprint())
+}
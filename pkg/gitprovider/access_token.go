@@ -0,0 +1,152 @@
+package gitprovider
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// gitHubAppJWTLifetime is the lifetime of the JWT a GitHub App authenticates with;
+	// GitHub rejects JWTs that live longer than 10 minutes.
+	gitHubAppJWTLifetime = 9 * time.Minute
+
+	// gitHubAppJWTClockSkew backdates the JWT to allow for clock drift.
+	gitHubAppJWTClockSkew = time.Minute
+
+	gitLabTokenDateLayout = "2006-01-02"
+)
+
+// AccessToken is a short-lived git provider access token.
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// GitHubAppTokenIssuer mints GitHub App installation access tokens.
+type GitHubAppTokenIssuer struct {
+	restyClient *resty.Client
+	now         func() time.Time
+}
+
+// NewGitHubAppTokenIssuer creates a new GitHub App token issuer.
+func NewGitHubAppTokenIssuer(restyClient *resty.Client) *GitHubAppTokenIssuer {
+	return &GitHubAppTokenIssuer{restyClient: restyClient, now: time.Now}
+}
+
+// CreateInstallationToken mints an installation access token of the GitHub App with the
+// given id and PEM-encoded private key. GitHub installation tokens expire after an hour.
+func (c *GitHubAppTokenIssuer) CreateInstallationToken(
+	ctx context.Context,
+	githubURL,
+	appID,
+	installationID string,
+	privateKey []byte,
+) (*AccessToken, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+
+	appJWT, err := c.appJWT(appID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}{}
+
+	c.restyClient.HostURL = githubURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(appJWT).
+		SetHeader("Accept", "application/vnd.github+json").
+		SetPathParams(map[string]string{
+			"installation-id": installationID,
+		}).
+		SetResult(token).
+		Post("/app/installations/{installation-id}/access_tokens")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub App installation token: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to create GitHub App installation token: %s", resp.String())
+	}
+
+	return &AccessToken{Token: token.Token, ExpiresAt: token.ExpiresAt}, nil
+}
+
+func (c *GitHubAppTokenIssuer) appJWT(appID string, key *rsa.PrivateKey) (string, error) {
+	now := c.now()
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    appID,
+		IssuedAt:  jwt.NewNumericDate(now.Add(-gitHubAppJWTClockSkew)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(gitHubAppJWTLifetime)),
+	}).SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	return signed, nil
+}
+
+// GitLabTokenRotator rotates GitLab personal, group and project access tokens.
+// Rotation revokes the current token, so the client must not retry requests.
+type GitLabTokenRotator struct {
+	restyClient *resty.Client
+}
+
+// NewGitLabTokenRotator creates a new GitLab token rotator.
+func NewGitLabTokenRotator(restyClient *resty.Client) *GitLabTokenRotator {
+	return &GitLabTokenRotator{restyClient: restyClient}
+}
+
+// RotateToken replaces the access token with a new one that expires at the given date.
+// The given token is revoked once the request succeeds.
+func (c *GitLabTokenRotator) RotateToken(
+	ctx context.Context,
+	gitlabURL,
+	token string,
+	expiresAt time.Time,
+) (*AccessToken, error) {
+	rotated := &struct {
+		Token     string `json:"token"`
+		ExpiresAt string `json:"expires_at"`
+	}{}
+
+	c.restyClient.HostURL = gitlabURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetBody(map[string]string{
+			"expires_at": expiresAt.UTC().Format(gitLabTokenDateLayout),
+		}).
+		SetResult(rotated).
+		Post("/api/v4/personal_access_tokens/self/rotate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate GitLab access token: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to rotate GitLab access token: %s", resp.String())
+	}
+
+	expires, err := time.Parse(gitLabTokenDateLayout, rotated.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitLab access token expiration date: %w", err)
+	}
+
+	return &AccessToken{Token: rotated.Token, ExpiresAt: expires}, nil
+}
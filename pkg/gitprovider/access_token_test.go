@@ -0,0 +1,106 @@
+package gitprovider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAppTokenIssuer_CreateInstallationToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	defer httpmock.DeactivateAndReset()
+
+	expiresAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://api.github.com/app/installations/42/access_tokens",
+		func(req *http.Request) (*http.Response, error) {
+			appJWT := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+			claims := &jwt.RegisteredClaims{}
+			if _, err := jwt.ParseWithClaims(appJWT, claims, func(*jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			}); err != nil || claims.Issuer != "123" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, `{"message":"bad credentials"}`), nil
+			}
+
+			return httpmock.NewJsonResponse(http.StatusCreated, map[string]string{
+				"token":      "ghs_token",
+				"expires_at": expiresAt.Format(time.RFC3339),
+			})
+		},
+	)
+
+	got, err := NewGitHubAppTokenIssuer(restyClient).
+		CreateInstallationToken(context.Background(), "https://api.github.com", "123", "42", privateKey)
+
+	require.NoError(t, err)
+	assert.Equal(t, &AccessToken{Token: "ghs_token", ExpiresAt: expiresAt}, got)
+
+	_, err = NewGitHubAppTokenIssuer(restyClient).
+		CreateInstallationToken(context.Background(), "https://api.github.com", "123", "42", []byte("not a key"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse GitHub App private key")
+}
+
+func TestGitLabTokenRotator_RotateToken(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://gitlab.example.com/api/v4/personal_access_tokens/self/rotate",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(gitLabTokenHeaderName) != "old-token" {
+				return httpmock.NewStringResponse(http.StatusUnauthorized, `{"message":"401 Unauthorized"}`), nil
+			}
+
+			body := map[string]string{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, map[string]string{
+				"token":      "new-token",
+				"expires_at": body["expires_at"],
+			})
+		},
+	)
+
+	rotator := NewGitLabTokenRotator(restyClient)
+
+	got, err := rotator.RotateToken(context.Background(), "https://gitlab.example.com", "old-token",
+		time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, &AccessToken{Token: "new-token", ExpiresAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}, got)
+
+	_, err = rotator.RotateToken(context.Background(), "https://gitlab.example.com", "revoked", time.Now())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to rotate GitLab access token")
+}
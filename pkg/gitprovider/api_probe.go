@@ -0,0 +1,267 @@
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+// ErrTokenRejected is returned when the git provider API rejects the access token.
+var ErrTokenRejected = errors.New("access token is rejected by the git provider API")
+
+// gitHubTokenExpirationLayout is the format of the GitHub token expiration header.
+const gitHubTokenExpirationLayout = "2006-01-02 15:04:05 MST"
+
+// requiredScopes are the token scopes the operator needs to create repositories, manage
+// webhooks and set default branches. Each entry lists the scopes that satisfy it, the first
+// one is reported as missing. On GitHub, the repo scope grants access to webhooks too.
+var requiredScopes = map[string][][]string{
+	codebaseApi.GitProviderGithub: {{"repo"}, {"admin:repo_hook", "write:repo_hook", "repo"}},
+	codebaseApi.GitProviderGitlab: {{"api"}},
+}
+
+// APIProbe is what the git provider API reports about an access token.
+type APIProbe struct {
+	// Version is the version of the git provider or its API.
+	Version string
+
+	// Scopes are the scopes of the token, nil if the provider does not report them.
+	Scopes []string
+
+	// MissingScopes are the required scopes the token lacks. Only set when Scopes are known.
+	MissingScopes []string
+
+	// RateLimit is nil if the provider does not report rate limits.
+	RateLimit *RateLimit
+
+	// TokenExpiresAt is nil if the token does not expire or the provider does not report it.
+	TokenExpiresAt *time.Time
+}
+
+// RateLimit is the API rate limit of an access token.
+type RateLimit struct {
+	Limit     int64
+	Remaining int64
+	ResetAt   time.Time
+}
+
+// ProbeAPI checks the access token against the git provider API. It returns ErrTokenRejected
+// if the API does not accept the token and ErrApiNotSupported for providers it cannot probe.
+func ProbeAPI(
+	ctx context.Context,
+	restyClient *resty.Client,
+	gitServer *codebaseApi.GitServer,
+	token string,
+) (*APIProbe, error) {
+	var (
+		probe *APIProbe
+		err   error
+	)
+
+	apiURL := GetGitProviderAPIURL(gitServer)
+
+	switch gitServer.Spec.GitProvider {
+	case codebaseApi.GitProviderGithub:
+		probe, err = probeGitHub(ctx, restyClient, apiURL, token)
+	case codebaseApi.GitProviderGitlab:
+		probe, err = probeGitLab(ctx, restyClient, apiURL, token)
+	default:
+		return nil, ErrApiNotSupported
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if probe.Scopes != nil {
+		probe.MissingScopes = missingScopes(requiredScopes[gitServer.Spec.GitProvider], probe.Scopes)
+	}
+
+	return probe, nil
+}
+
+func probeGitHub(ctx context.Context, restyClient *resty.Client, apiURL, token string) (*APIProbe, error) {
+	rateLimit := &struct {
+		Resources struct {
+			Core struct {
+				Limit     int64 `json:"limit"`
+				Remaining int64 `json:"remaining"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}{}
+
+	// The rate limit endpoint does not count against the rate limit and accepts every kind
+	// of token, including GitHub App installation tokens that cannot read /user.
+	resp, err := restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetResult(rateLimit).
+		Get(apiURL + "/rate_limit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe GitHub API: %w", err)
+	}
+
+	if err = checkProbeResponse(resp); err != nil {
+		return nil, err
+	}
+
+	probe := &APIProbe{
+		Version: resp.Header().Get("X-GitHub-Enterprise-Version"),
+		RateLimit: &RateLimit{
+			Limit:     rateLimit.Resources.Core.Limit,
+			Remaining: rateLimit.Resources.Core.Remaining,
+			ResetAt:   time.Unix(rateLimit.Resources.Core.Reset, 0).UTC(),
+		},
+	}
+
+	if probe.Version == "" {
+		probe.Version = resp.Header().Get("X-GitHub-Api-Version-Selected")
+	}
+
+	// Only classic tokens report scopes; fine-grained and installation tokens have permissions
+	// that the API does not expose.
+	if _, ok := resp.Header()[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok {
+		probe.Scopes = splitScopes(resp.Header().Get("X-OAuth-Scopes"))
+	}
+
+	if expiration := resp.Header().Get("GitHub-Authentication-Token-Expiration"); expiration != "" {
+		if expiresAt, parseErr := time.Parse(gitHubTokenExpirationLayout, expiration); parseErr == nil {
+			probe.TokenExpiresAt = &expiresAt
+		}
+	}
+
+	return probe, nil
+}
+
+func probeGitLab(ctx context.Context, restyClient *resty.Client, apiURL, token string) (*APIProbe, error) {
+	version := &struct {
+		Version string `json:"version"`
+	}{}
+
+	resp, err := restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetResult(version).
+		Get(apiURL + "/api/v4/version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe GitLab API: %w", err)
+	}
+
+	if err = checkProbeResponse(resp); err != nil {
+		return nil, err
+	}
+
+	probe := &APIProbe{
+		Version:   version.Version,
+		RateLimit: gitLabRateLimit(resp.Header()),
+	}
+
+	self := &struct {
+		Scopes    []string `json:"scopes"`
+		ExpiresAt string   `json:"expires_at"`
+	}{}
+
+	resp, err = restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetResult(self).
+		Get(apiURL + "/api/v4/personal_access_tokens/self")
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe GitLab access token: %w", err)
+	}
+
+	// GitLab versions before 15.5 do not have the endpoint, and OAuth tokens are not access
+	// tokens: their scopes stay unknown.
+	if resp.StatusCode() == http.StatusNotFound {
+		return probe, nil
+	}
+
+	if err = checkProbeResponse(resp); err != nil {
+		return nil, err
+	}
+
+	probe.Scopes = self.Scopes
+	if probe.Scopes == nil {
+		probe.Scopes = []string{}
+	}
+
+	if self.ExpiresAt != "" {
+		if expiresAt, parseErr := time.Parse(gitLabTokenDateLayout, self.ExpiresAt); parseErr == nil {
+			probe.TokenExpiresAt = &expiresAt
+		}
+	}
+
+	return probe, nil
+}
+
+func checkProbeResponse(resp *resty.Response) error {
+	if resp.StatusCode() == http.StatusUnauthorized {
+		return ErrTokenRejected
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("git provider API responded with %s: %s", resp.Status(), resp.String())
+	}
+
+	return nil
+}
+
+// gitLabRateLimit reads the rate limit headers GitLab sends when rate limiting is enabled.
+func gitLabRateLimit(header http.Header) *RateLimit {
+	limit, err := strconv.ParseInt(header.Get("RateLimit-Limit"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	remaining, err := strconv.ParseInt(header.Get("RateLimit-Remaining"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	rateLimit := &RateLimit{Limit: limit, Remaining: remaining}
+
+	if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.ResetAt = time.Unix(reset, 0).UTC()
+	}
+
+	return rateLimit
+}
+
+func splitScopes(header string) []string {
+	scopes := []string{}
+
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func missingScopes(required [][]string, granted []string) []string {
+	var missing []string
+
+	for _, alternatives := range required {
+		if !slices.ContainsFunc(alternatives, func(scope string) bool {
+			return slices.Contains(granted, scope)
+		}) {
+			missing = append(missing, alternatives[0])
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,178 @@
+package gitprovider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestProbeAPI_GitHub(t *testing.T) {
+	gitServer := &codebaseApi.GitServer{
+		Spec: codebaseApi.GitServerSpec{GitProvider: codebaseApi.GitProviderGithub, GitHost: "github.com"},
+	}
+	rateLimit := map[string]any{
+		"resources": map[string]any{
+			"core": map[string]any{"limit": 5000, "remaining": 4990, "reset": 1767268800},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		status  int
+		wantErr require.ErrorAssertionFunc
+		check   func(t *testing.T, probe *APIProbe)
+	}{
+		{
+			name: "classic token with repo scope",
+			header: http.Header{
+				"X-Oauth-Scopes":                         []string{"repo, read:org"},
+				"X-Github-Api-Version-Selected":          []string{"2022-11-28"},
+				"Github-Authentication-Token-Expiration": []string{"2026-03-31 21:15:51 UTC"},
+			},
+			status:  http.StatusOK,
+			wantErr: require.NoError,
+			check: func(t *testing.T, probe *APIProbe) {
+				assert.Equal(t, "2022-11-28", probe.Version)
+				assert.Equal(t, []string{"repo", "read:org"}, probe.Scopes)
+				assert.Empty(t, probe.MissingScopes, "the repo scope grants access to webhooks")
+				assert.Equal(t, &RateLimit{Limit: 5000, Remaining: 4990, ResetAt: time.Unix(1767268800, 0).UTC()}, probe.RateLimit)
+				require.NotNil(t, probe.TokenExpiresAt)
+				assert.Equal(t, time.Date(2026, 3, 31, 21, 15, 51, 0, time.UTC), probe.TokenExpiresAt.UTC())
+			},
+		},
+		{
+			name:    "classic token with webhook scope only",
+			header:  http.Header{"X-Oauth-Scopes": []string{"write:repo_hook"}},
+			status:  http.StatusOK,
+			wantErr: require.NoError,
+			check: func(t *testing.T, probe *APIProbe) {
+				assert.Equal(t, []string{"repo"}, probe.MissingScopes)
+			},
+		},
+		{
+			name:    "classic token without required scopes",
+			header:  http.Header{"X-Oauth-Scopes": []string{"read:org"}},
+			status:  http.StatusOK,
+			wantErr: require.NoError,
+			check: func(t *testing.T, probe *APIProbe) {
+				assert.Equal(t, []string{"repo", "admin:repo_hook"}, probe.MissingScopes)
+			},
+		},
+		{
+			name:    "installation token does not report scopes",
+			header:  http.Header{"X-Github-Enterprise-Version": []string{"3.14.0"}},
+			status:  http.StatusOK,
+			wantErr: require.NoError,
+			check: func(t *testing.T, probe *APIProbe) {
+				assert.Equal(t, "3.14.0", probe.Version)
+				assert.Nil(t, probe.Scopes)
+				assert.Empty(t, probe.MissingScopes)
+			},
+		},
+		{
+			name:   "rejected token",
+			status: http.StatusUnauthorized,
+			wantErr: func(t require.TestingT, err error, _ ...any) {
+				require.ErrorIs(t, err, ErrTokenRejected)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restyClient := resty.New()
+			httpmock.ActivateNonDefault(restyClient.GetClient())
+
+			defer httpmock.DeactivateAndReset()
+
+			httpmock.RegisterResponder(http.MethodGet, "https://api.github.com/rate_limit",
+				func(*http.Request) (*http.Response, error) {
+					resp, err := httpmock.NewJsonResponse(tt.status, rateLimit)
+					if err != nil {
+						return nil, err
+					}
+
+					for key, values := range tt.header {
+						resp.Header[key] = values
+					}
+
+					return resp, nil
+				},
+			)
+
+			probe, err := ProbeAPI(context.Background(), restyClient, gitServer, "token")
+
+			tt.wantErr(t, err)
+
+			if tt.check != nil {
+				tt.check(t, probe)
+			}
+		})
+	}
+}
+
+func TestProbeAPI_GitLab(t *testing.T) {
+	gitServer := &codebaseApi.GitServer{
+		Spec: codebaseApi.GitServerSpec{GitProvider: codebaseApi.GitProviderGitlab, GitHost: "gitlab.example.com"},
+	}
+
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodGet, "https://gitlab.example.com/api/v4/version",
+		func(*http.Request) (*http.Response, error) {
+			resp, err := httpmock.NewJsonResponse(http.StatusOK, map[string]string{"version": "17.2.1"})
+			if err != nil {
+				return nil, err
+			}
+
+			resp.Header.Set("RateLimit-Limit", "600")
+			resp.Header.Set("RateLimit-Remaining", "30")
+
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder(http.MethodGet, "https://gitlab.example.com/api/v4/personal_access_tokens/self",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{
+			"scopes":     []string{"read_api", "write_repository"},
+			"expires_at": "2026-06-01",
+		}),
+	)
+
+	probe, err := ProbeAPI(context.Background(), restyClient, gitServer, "token")
+
+	require.NoError(t, err)
+	assert.Equal(t, "17.2.1", probe.Version)
+	assert.Equal(t, []string{"api"}, probe.MissingScopes)
+	assert.Equal(t, &RateLimit{Limit: 600, Remaining: 30}, probe.RateLimit)
+	require.NotNil(t, probe.TokenExpiresAt)
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), *probe.TokenExpiresAt)
+
+	httpmock.RegisterResponder(http.MethodGet, "https://gitlab.example.com/api/v4/personal_access_tokens/self",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"message":"404 Not Found"}`),
+	)
+
+	probe, err = ProbeAPI(context.Background(), restyClient, gitServer, "token")
+
+	require.NoError(t, err)
+	assert.Nil(t, probe.Scopes, "scopes must stay unknown on GitLab versions without the endpoint")
+}
+
+func TestProbeAPI_UnsupportedProvider(t *testing.T) {
+	_, err := ProbeAPI(context.Background(), resty.New(), &codebaseApi.GitServer{
+		Spec: codebaseApi.GitServerSpec{GitProvider: codebaseApi.GitProviderBitbucket},
+	}, "token")
+
+	require.ErrorIs(t, err, ErrApiNotSupported)
+}
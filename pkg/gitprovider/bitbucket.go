@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/utils/ptr"
 
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider/bitbucket/generated"
 )
 
@@ -19,7 +22,8 @@ type BitbucketClient struct {
 }
 
 type BitbucketClientOpts struct {
-	Url string
+	Url        string
+	HTTPClient generated.HttpRequestDoer
 }
 
 type BitbucketClientOptsSetter func(*BitbucketClientOpts)
@@ -30,6 +34,13 @@ func WithBitbucketClientUrl(url string) func(*BitbucketClientOpts) {
 	}
 }
 
+// WithBitbucketHTTPClient sets the HTTP client the Bitbucket client sends requests with.
+func WithBitbucketHTTPClient(httpClient generated.HttpRequestDoer) func(*BitbucketClientOpts) {
+	return func(opts *BitbucketClientOpts) {
+		opts.HTTPClient = httpClient
+	}
+}
+
 func NewBitbucketClient(token string, opts ...BitbucketClientOptsSetter) (*BitbucketClient, error) {
 	defaults := &BitbucketClientOpts{
 		Url: "https://api.bitbucket.org/2.0",
@@ -41,10 +52,12 @@ func NewBitbucketClient(token string, opts ...BitbucketClientOptsSetter) (*Bitbu
 
 	tokenProvider := NewBasicTokenAuthProvider(token)
 
-	c, err := generated.NewClientWithResponses(
-		defaults.Url,
-		generated.WithRequestEditorFn(tokenProvider.Intercept),
-	)
+	clientOpts := []generated.ClientOption{generated.WithRequestEditorFn(tokenProvider.Intercept)}
+	if defaults.HTTPClient != nil {
+		clientOpts = append(clientOpts, generated.WithHTTPClient(defaults.HTTPClient))
+	}
+
+	c, err := generated.NewClientWithResponses(defaults.Url, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Bitbucket client: %w", err)
 	}
@@ -71,21 +84,10 @@ func (b *BitbucketClient) CreateWebHook(
 		func(ctx context.Context, req *http.Request) error {
 			var body []byte
 
-			body, err = json.Marshal(map[string]interface{}{
-				"description":            fmt.Sprintf("Automatically created %s", uuid.NewUUID()),
-				"url":                    webHookURL,
-				"active":                 true,
-				"secret":                 webHookSecret,
-				"skip_cert_verification": skipTLS,
-				"history_enabled":        true,
-				"events": []string{
-					"pullrequest:created",
-					"pullrequest:updated",
-					"pullrequest:fulfilled",
-					"pullrequest:comment_created",
-					"pullrequest:comment_updated",
-				},
-			})
+			reqBody := bitbucketWebHookBody(webHookSecret, webHookURL, skipTLS)
+			reqBody["description"] = fmt.Sprintf("Automatically created %s", uuid.NewUUID())
+
+			body, err = json.Marshal(reqBody)
 			if err != nil {
 				return fmt.Errorf("failed to marshal Bitbucket web hook body: %w", err)
 			}
@@ -107,10 +109,7 @@ func (b *BitbucketClient) CreateWebHook(
 		return nil, fmt.Errorf("failed to create Bitbucket web hook: invalid response %s", r.Body)
 	}
 
-	return &WebHook{
-		ID:  *r.JSON201.Uuid,
-		URL: *r.JSON201.Url,
-	}, nil
+	return convertBitbucketWebhook(r.JSON201), nil
 }
 
 func (b *BitbucketClient) CreateWebHookIfNotExists(
@@ -151,10 +150,7 @@ func (b *BitbucketClient) GetWebHook(ctx context.Context, _, _, projectID, webHo
 		return nil, fmt.Errorf("failed to get Bitbucket web hook: invalid response %s", r.Body)
 	}
 
-	return &WebHook{
-		ID:  *r.JSON200.Uuid,
-		URL: *r.JSON200.Url,
-	}, nil
+	return convertBitbucketWebhook(r.JSON200), nil
 }
 
 func (b *BitbucketClient) GetWebHooks(ctx context.Context, _, _, projectID string) ([]*WebHook, error) {
@@ -183,15 +179,101 @@ func (b *BitbucketClient) GetWebHooks(ctx context.Context, _, _, projectID strin
 			return nil, fmt.Errorf("failed to get Bitbucket web hooks: invalid response %s", r.Body)
 		}
 
-		webHooks[i] = &WebHook{
-			ID:  *hook.Uuid,
-			URL: *hook.Url,
-		}
+		webHooks[i] = convertBitbucketWebhook(&hook)
 	}
 
 	return webHooks, nil
 }
 
+// UpdateWebHook sets the URL, secret, events and TLS verification of the webhook
+// and activates it.
+func (b *BitbucketClient) UpdateWebHook(
+	ctx context.Context,
+	_, _, projectID, webHookRef, webHookSecret, webHookURL string,
+	skipTLS bool,
+) (*WebHook, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(bitbucketWebHookBody(webHookSecret, webHookURL, skipTLS))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Bitbucket web hook body: %w", err)
+	}
+
+	r, err := b.client.PutRepositoriesWorkspaceRepoSlugHooksUidWithResponse(
+		ctx,
+		owner,
+		repo,
+		webHookRef,
+		func(_ context.Context, req *http.Request) error {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update Bitbucket web hook: %w", err)
+	}
+
+	if r.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to update Bitbucket web hook: %w", ErrWebHookNotFound)
+	}
+
+	if r.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to update Bitbucket web hook: %s %s", r.Status(), r.Body)
+	}
+
+	if r.JSON200 == nil || r.JSON200.Uuid == nil || r.JSON200.Url == nil {
+		return nil, fmt.Errorf("failed to update Bitbucket web hook: invalid response %s", r.Body)
+	}
+
+	return convertBitbucketWebhook(r.JSON200), nil
+}
+
+// GetWebHookDeliveries is not supported: the Bitbucket Cloud REST API has no endpoint for
+// the request history of webhooks, which is only available in the web UI.
+func (*BitbucketClient) GetWebHookDeliveries(
+	_ context.Context,
+	_, _, _, _ string,
+) ([]WebHookDelivery, error) {
+	return nil, ErrWebHookDeliveriesNotSupported
+}
+
+func bitbucketWebHookBody(webHookSecret, webHookURL string, skipTLS bool) map[string]interface{} {
+	return map[string]interface{}{
+		"url":                    webHookURL,
+		"active":                 true,
+		"secret":                 webHookSecret,
+		"skip_cert_verification": skipTLS,
+		"history_enabled":        true,
+		"events":                 WebHookEvents(codebaseApi.GitProviderBitbucket),
+	}
+}
+
+// convertBitbucketWebhook converts a webhook with a checked UUID and URL.
+func convertBitbucketWebhook(hook *generated.WebhookSubscription) *WebHook {
+	webHook := &WebHook{
+		ID:     *hook.Uuid,
+		URL:    *hook.Url,
+		Active: ptr.Deref(hook.Active, false),
+	}
+
+	if hook.Events != nil {
+		for _, event := range *hook.Events {
+			webHook.Events = append(webHook.Events, string(event))
+		}
+	}
+
+	// skip_cert_verification is not in the generated schema.
+	if skip, ok := hook.AdditionalProperties["skip_cert_verification"].(bool); ok {
+		webHook.SkipTLS = skip
+	}
+
+	return webHook
+}
+
 func (b *BitbucketClient) DeleteWebHook(ctx context.Context, _, _, projectID, webHookRef string) error {
 	owner, repo, err := parseProjectID(projectID)
 	if err != nil {
@@ -290,3 +372,175 @@ func (*BitbucketClient) SetDefaultBranch(_ context.Context, _, _, _, _ string) e
 func createObjectStatusOk(statusCode int) bool {
 	return statusCode == http.StatusOK || statusCode == http.StatusCreated
 }
+
+// bitbucketCommitStates maps the commit states to the states of Bitbucket build statuses.
+var bitbucketCommitStates = map[string]generated.CommitstatusState{
+	CommitStatePending: generated.CommitstatusStateINPROGRESS,
+	CommitStateSuccess: generated.CommitstatusStateSUCCESSFUL,
+	CommitStateFailure: generated.CommitstatusStateFAILED,
+	CommitStateError:   generated.CommitstatusStateSTOPPED,
+}
+
+// SetCommitStatus creates or updates the build status of the commit with the context as its key.
+func (b *BitbucketClient) SetCommitStatus(
+	ctx context.Context,
+	_, _, projectID, sha string,
+	status CommitStatus,
+) error {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	state, ok := bitbucketCommitStates[status.State]
+	if !ok {
+		return fmt.Errorf("unsupported commit state %q", status.State)
+	}
+
+	targetURL := status.TargetURL
+	if targetURL == "" {
+		targetURL = fmt.Sprintf("https://bitbucket.org/%s/commits/%s", projectID, sha)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"key":         status.Context,
+		"name":        status.Context,
+		"state":       string(state),
+		"description": truncate(status.Description, bitbucketStatusDescriptionLength),
+		"url":         targetURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Bitbucket commit status: %w", err)
+	}
+
+	r, err := b.client.PostRepositoriesWorkspaceRepoSlugCommitCommitStatusesBuildWithBodyWithResponse(
+		ctx,
+		owner,
+		repo,
+		sha,
+		"application/json",
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set Bitbucket commit status: %w", err)
+	}
+
+	if !createObjectStatusOk(r.StatusCode()) {
+		return fmt.Errorf("failed to set Bitbucket commit status: %s %s", r.Status(), r.Body)
+	}
+
+	return nil
+}
+
+// GetPullRequestCommits returns the commits of the pull request, newest first.
+func (b *BitbucketClient) GetPullRequestCommits(
+	ctx context.Context,
+	_, _, projectID string,
+	pullRequest int,
+) ([]Commit, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	get := func(editor generated.RequestEditorFn) (*http.Response, []byte, error) {
+		r, err := b.client.GetRepositoriesWorkspaceRepoSlugPullrequestsPullRequestIdCommitsWithResponse(
+			ctx, owner, repo, pullRequest, editor,
+		)
+		if err != nil {
+			return nil, nil, err //nolint:wrapcheck // the error is wrapped below
+		}
+
+		return r.HTTPResponse, r.Body, nil
+	}
+
+	commits, err := getBitbucketCommits(url.Values{}, get)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Bitbucket pull request commits: %w", err)
+	}
+
+	return commits, nil
+}
+
+// GetCommits returns the commits reachable from the commit to but not from the commit from, newest first.
+func (b *BitbucketClient) GetCommits(
+	ctx context.Context,
+	_, _, projectID, from, to string,
+) ([]Commit, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	get := func(editor generated.RequestEditorFn) (*http.Response, []byte, error) {
+		r, err := b.client.GetRepositoriesWorkspaceRepoSlugCommitsWithResponse(ctx, owner, repo, editor)
+		if err != nil {
+			return nil, nil, err //nolint:wrapcheck // the error is wrapped below
+		}
+
+		return r.HTTPResponse, r.Body, nil
+	}
+
+	commits, err := getBitbucketCommits(url.Values{"include": {to}, "exclude": {from}}, get)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Bitbucket commits: %w", err)
+	}
+
+	return commits, nil
+}
+
+// getBitbucketCommits returns the commits of all pages of a commit list. Bitbucket links
+// every page but the last to the next one.
+func getBitbucketCommits(
+	query url.Values,
+	get func(editor generated.RequestEditorFn) (*http.Response, []byte, error),
+) ([]Commit, error) {
+	query.Set("pagelen", strconv.Itoa(commitsPageSize))
+
+	var commits []Commit
+
+	for {
+		resp, body, err := get(func(_ context.Context, req *http.Request) error {
+			req.URL.RawQuery = query.Encode()
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s %s", resp.Status, body)
+		}
+
+		page := struct {
+			Values []struct {
+				Hash    string `json:"hash"`
+				Message string `json:"message"`
+				Parents []struct {
+					Hash string `json:"hash"`
+				} `json:"parents"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}{}
+
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode commits: %w", err)
+		}
+
+		for _, v := range page.Values {
+			commits = append(commits, Commit{SHA: v.Hash, Message: v.Message, Parents: len(v.Parents)})
+		}
+
+		if page.Next == "" {
+			return commits, nil
+		}
+
+		next, err := url.Parse(page.Next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next page link: %w", err)
+		}
+
+		query = next.Query()
+	}
+}
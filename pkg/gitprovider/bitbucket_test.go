@@ -2,6 +2,7 @@ package gitprovider
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -419,3 +420,178 @@ func TestBitbucketClient_SetDefaultBranch(t *testing.T) {
 		})
 	}
 }
+
+func TestBitbucketClient_UpdateWebHook(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if strings.Contains(r.URL.Path, "not-found") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+
+			_, _ = w.Write([]byte(`{"type": "error", "error": {"message": "Not Found"}}`))
+
+			return
+		}
+
+		if strings.Contains(r.URL.Path, "success") {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["secret"] != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{
+				"uuid": "123",
+				"url": "https://example.com",
+				"active": true,
+				"events": ["pullrequest:created"],
+				"skip_cert_verification": true
+			}`))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name      string
+		projectID string
+		want      *WebHook
+		wantErr   require.ErrorAssertionFunc
+		errIs     error
+	}{
+		{
+			name:      "success",
+			projectID: "owner/success",
+			want: &WebHook{
+				ID:      "123",
+				URL:     "https://example.com",
+				Events:  []string{"pullrequest:created"},
+				SkipTLS: true,
+				Active:  true,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:      "not found",
+			projectID: "owner/not-found",
+			wantErr:   require.Error,
+			errIs:     ErrWebHookNotFound,
+		},
+		{
+			name:      "failure",
+			projectID: "owner/failure",
+			wantErr:   require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := NewBitbucketClient("token", WithBitbucketClientUrl(server.URL))
+			require.NoError(t, err)
+
+			got, err := b.UpdateWebHook(
+				context.Background(), "", "", tt.projectID, "123", "secret", "https://example.com", true,
+			)
+
+			tt.wantErr(t, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBitbucketClient_GetWebHookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBitbucketClient("token")
+	require.NoError(t, err)
+
+	_, err = b.GetWebHookDeliveries(context.Background(), "", "", "owner/repo", "123")
+	require.ErrorIs(t, err, ErrWebHookDeliveriesNotSupported)
+}
+
+func TestBitbucketClient_Commits(t *testing.T) {
+	t.Parallel()
+
+	var statusBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /repositories/owner/repo/commit/abc123/statuses/build":
+			_ = json.NewDecoder(r.Body).Decode(&statusBody)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"type":"build"}`))
+		case "GET /repositories/owner/repo/pullrequests/5/commits":
+			if r.URL.Query().Get("page") == "" {
+				assert.Equal(t, "pagelen=100", r.URL.RawQuery)
+				_, _ = w.Write([]byte(`{"values":[{"hash":"b2","message":"WIP"}],` +
+					`"next":"https://api.bitbucket.org/2.0/repositories/owner/repo/pullrequests/5/commits?page=c2"}`))
+
+				return
+			}
+
+			assert.Equal(t, "c2", r.URL.Query().Get("page"))
+			_, _ = w.Write([]byte(`{"values":[{"hash":"a1","message":"[APP-1] Fix"}]}`))
+		case "GET /repositories/owner/repo/commits":
+			assert.Equal(t, "head", r.URL.Query().Get("include"))
+			assert.Equal(t, "base", r.URL.Query().Get("exclude"))
+			_, _ = w.Write([]byte(`{"values":[{"hash":"m1","message":"Sync","parents":[{"hash":"a1"},{"hash":"c3"}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	b, err := NewBitbucketClient("token", WithBitbucketClientUrl(server.URL))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	err = b.SetCommitStatus(ctx, "", "", "owner/repo", "abc123", CommitStatus{
+		State:       CommitStateSuccess,
+		Context:     "edp/commit-policy",
+		Description: "All commits follow the commit policy",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"key":         "edp/commit-policy",
+		"name":        "edp/commit-policy",
+		"state":       "SUCCESSFUL",
+		"description": "All commits follow the commit policy",
+		"url":         "https://bitbucket.org/owner/repo/commits/abc123",
+	}, statusBody)
+
+	commits, err := b.GetPullRequestCommits(ctx, "", "", "owner/repo", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []Commit{{SHA: "b2", Message: "WIP"}, {SHA: "a1", Message: "[APP-1] Fix"}}, commits)
+
+	commits, err = b.GetCommits(ctx, "", "", "owner/repo", "base", "head")
+	require.NoError(t, err)
+	assert.Equal(t, []Commit{{SHA: "m1", Message: "Sync", Parents: 2}}, commits)
+
+	_, err = b.GetPullRequestCommits(ctx, "", "", "owner/other", 5)
+	require.Error(t, err)
+}
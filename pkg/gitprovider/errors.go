@@ -5,4 +5,8 @@ import "errors"
 var (
 	ErrWebHookNotFound = errors.New("webhook not found")
 	ErrApiNotSupported = errors.New("api is not supported")
+
+	// ErrWebHookDeliveriesNotSupported is returned by git providers whose API does not expose
+	// the delivery history of webhooks.
+	ErrWebHookDeliveriesNotSupported = errors.New("webhook delivery history is not supported")
 )
@@ -6,21 +6,51 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
 )
 
 type gitHubWebHook struct {
-	ID     int `json:"id"`
+	ID     int      `json:"id"`
+	Active bool     `json:"active"`
+	Events []string `json:"events"`
 	Config struct {
-		URL string `json:"url"`
+		URL         string `json:"url"`
+		InsecureSSL string `json:"insecure_ssl"`
 	} `json:"config"`
 }
 
+type gitHubWebHookDelivery struct {
+	ID          int64     `json:"id"`
+	Event       string    `json:"event"`
+	Action      string    `json:"action"`
+	Status      string    `json:"status"`
+	StatusCode  int       `json:"status_code"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
 type gitHubOrganization struct {
 	Login string `json:"login"`
 }
 
+type gitHubMilestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+type gitHubCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+	} `json:"commit"`
+	Parents []struct {
+		SHA string `json:"sha"`
+	} `json:"parents"`
+}
+
 type GitHubClient struct {
 	restyClient *resty.Client
 }
@@ -28,17 +58,21 @@ type GitHubClient struct {
 const (
 	repoPathParam  = "repo"
 	ownerPathParam = "owner"
+	issuePathParam = "issue"
+	shaPathParam   = "sha"
+
+	// commitsPageSize is the number of commits requested per page, the largest page providers allow.
+	commitsPageSize = 100
+
+	// maxGitHubPullRequestCommits is the number of pull request commits GitHub lists at most.
+	maxGitHubPullRequestCommits = 250
+
+	// milestonesPageSize is the number of milestones requested per page, the largest page GitHub allows.
+	milestonesPageSize = 100
 )
 
 // NewGitHubClient creates a new GitHub client.
 func NewGitHubClient(restyClient *resty.Client) *GitHubClient {
-	restyClient.SetRetryCount(retryCount)
-	restyClient.AddRetryCondition(
-		func(response *resty.Response, err error) bool {
-			return response.IsError()
-		},
-	)
-
 	return &GitHubClient{restyClient: restyClient}
 }
 
@@ -59,27 +93,15 @@ func (c *GitHubClient) CreateWebHook(
 
 	c.restyClient.HostURL = githubURL
 	webHook := &gitHubWebHook{}
-	insecure := 0
 
-	if skipTLS {
-		insecure = 1
-	}
+	body := gitHubWebHookBody(webHookSecret, webHookURL, skipTLS)
+	body["name"] = "web"
 
 	resp, err := c.restyClient.
 		R().
 		SetContext(ctx).
 		SetAuthToken(token).
-		SetBody(map[string]interface{}{
-			"name":   "web",
-			"active": true,
-			"events": []string{"pull_request", "push", "issue_comment"},
-			"config": map[string]string{
-				"url":          webHookURL,
-				"content_type": "json",
-				"insecure_ssl": strconv.Itoa(insecure),
-				"secret":       webHookSecret,
-			},
-		}).
+		SetBody(body).
 		SetPathParams(map[string]string{
 			ownerPathParam: owner,
 			repoPathParam:  repo,
@@ -206,6 +228,133 @@ func (c *GitHubClient) GetWebHooks(
 	return webHooks, nil
 }
 
+// GetWebHookDeliveries returns the recent deliveries of the webhook, newest first.
+func (c *GitHubClient) GetWebHookDeliveries(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID,
+	webHookRef string,
+) ([]WebHookDelivery, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.restyClient.HostURL = githubURL
+
+	var gitHubDeliveries []gitHubWebHookDelivery
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParams(map[string]string{
+			ownerPathParam: owner,
+			repoPathParam:  repo,
+			"hook-id":      webHookRef,
+		}).
+		SetQueryParam("per_page", strconv.Itoa(webHookDeliveriesPageSize)).
+		SetResult(&gitHubDeliveries).
+		Get("/repos/{owner}/{repo}/hooks/{hook-id}/deliveries")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub web hook deliveries: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get GitHub web hook deliveries: %w", ErrWebHookNotFound)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get GitHub web hook deliveries: %s", resp.String())
+	}
+
+	deliveries := make([]WebHookDelivery, len(gitHubDeliveries))
+
+	for i, delivery := range gitHubDeliveries {
+		event := delivery.Event
+		if delivery.Action != "" {
+			event = event + "." + delivery.Action
+		}
+
+		deliveries[i] = WebHookDelivery{
+			ID:          strconv.FormatInt(delivery.ID, 10),
+			Event:       event,
+			StatusCode:  delivery.StatusCode,
+			Status:      delivery.Status,
+			DeliveredAt: delivery.DeliveredAt,
+		}
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebHook sets the URL, secret, events and TLS verification of the webhook
+// and activates it.
+func (c *GitHubClient) UpdateWebHook(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID,
+	webHookRef,
+	webHookSecret,
+	webHookURL string,
+	skipTLS bool,
+) (*WebHook, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.restyClient.HostURL = githubURL
+	webHook := &gitHubWebHook{}
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetBody(gitHubWebHookBody(webHookSecret, webHookURL, skipTLS)).
+		SetPathParams(map[string]string{
+			ownerPathParam: owner,
+			repoPathParam:  repo,
+			"hook-id":      webHookRef,
+		}).
+		SetResult(webHook).
+		Patch("/repos/{owner}/{repo}/hooks/{hook-id}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update GitHub web hook: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to update GitHub web hook: %w", ErrWebHookNotFound)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to update GitHub web hook: %s", resp.String())
+	}
+
+	return convertWebhook(webHook), nil
+}
+
+func gitHubWebHookBody(webHookSecret, webHookURL string, skipTLS bool) map[string]interface{} {
+	insecure := 0
+
+	if skipTLS {
+		insecure = 1
+	}
+
+	return map[string]interface{}{
+		"active": true,
+		"events": WebHookEvents(codebaseApi.GitProviderGithub),
+		"config": map[string]string{
+			"url":          webHookURL,
+			"content_type": "json",
+			"insecure_ssl": strconv.Itoa(insecure),
+			"secret":       webHookSecret,
+		},
+	}
+}
+
 // DeleteWebHook deletes webhook by ID for the given project.
 func (c *GitHubClient) DeleteWebHook(
 	ctx context.Context,
@@ -401,13 +550,443 @@ func (c *GitHubClient) isOwnerOrg(
 	return false, nil
 }
 
+// CreateIssueComment adds a comment to the issue.
+func (c *GitHubClient) CreateIssueComment(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID string,
+	issue int,
+	body string,
+) error {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	c.restyClient.HostURL = githubURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParams(map[string]string{
+			ownerPathParam: owner,
+			repoPathParam:  repo,
+			issuePathParam: strconv.Itoa(issue),
+		}).
+		SetBody(map[string]string{
+			"body": body,
+		}).
+		Post("/repos/{owner}/{repo}/issues/{issue}/comments")
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub issue comment: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to create GitHub issue comment: %s", resp.String())
+	}
+
+	return nil
+}
+
+// AddIssueLabels adds the labels to the issue. GitHub creates the labels that the repository does not have.
+func (c *GitHubClient) AddIssueLabels(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID string,
+	issue int,
+	labels []string,
+) error {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	c.restyClient.HostURL = githubURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParams(map[string]string{
+			ownerPathParam: owner,
+			repoPathParam:  repo,
+			issuePathParam: strconv.Itoa(issue),
+		}).
+		SetBody(map[string][]string{
+			"labels": labels,
+		}).
+		Post("/repos/{owner}/{repo}/issues/{issue}/labels")
+	if err != nil {
+		return fmt.Errorf("failed to add GitHub issue labels: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to add GitHub issue labels: %s", resp.String())
+	}
+
+	return nil
+}
+
+// SetIssueMilestone sets the milestone of the issue. The milestone is created if the repository does not have it.
+func (c *GitHubClient) SetIssueMilestone(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID string,
+	issue int,
+	milestone string,
+) error {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	number, err := c.getOrCreateMilestone(ctx, githubURL, token, owner, repo, milestone)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParams(map[string]string{
+			ownerPathParam: owner,
+			repoPathParam:  repo,
+			issuePathParam: strconv.Itoa(issue),
+		}).
+		SetBody(map[string]int{
+			"milestone": number,
+		}).
+		Patch("/repos/{owner}/{repo}/issues/{issue}")
+	if err != nil {
+		return fmt.Errorf("failed to set GitHub issue milestone: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to set GitHub issue milestone: %s", resp.String())
+	}
+
+	return nil
+}
+
+// getOrCreateMilestone returns the number of the milestone with the given title.
+func (c *GitHubClient) getOrCreateMilestone(
+	ctx context.Context,
+	githubURL,
+	token,
+	owner,
+	repo,
+	title string,
+) (int, error) {
+	c.restyClient.HostURL = githubURL
+
+	pathParams := map[string]string{
+		ownerPathParam: owner,
+		repoPathParam:  repo,
+	}
+
+	number, ok, err := c.findMilestone(ctx, token, pathParams, title)
+	if err != nil || ok {
+		return number, err
+	}
+
+	created := &gitHubMilestone{}
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParams(pathParams).
+		SetBody(map[string]string{
+			"title": title,
+		}).
+		SetResult(created).
+		Post("/repos/{owner}/{repo}/milestones")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create GitHub milestone: %w", err)
+	}
+
+	// The milestone was created in the meantime, e.g. by a build of another branch.
+	if resp.StatusCode() == http.StatusUnprocessableEntity {
+		if number, ok, err = c.findMilestone(ctx, token, pathParams, title); err != nil || ok {
+			return number, err
+		}
+	}
+
+	if resp.IsError() {
+		return 0, fmt.Errorf("failed to create GitHub milestone: %s", resp.String())
+	}
+
+	return created.Number, nil
+}
+
+// findMilestone returns the number of the milestone with the given title, and whether the
+// repository has it.
+func (c *GitHubClient) findMilestone(
+	ctx context.Context,
+	token string,
+	pathParams map[string]string,
+	title string,
+) (int, bool, error) {
+	for page := 1; ; page++ {
+		milestones := make([]gitHubMilestone, 0)
+
+		resp, err := c.restyClient.
+			R().
+			SetContext(ctx).
+			SetAuthToken(token).
+			SetPathParams(pathParams).
+			SetQueryParams(map[string]string{
+				"state":    "all",
+				"per_page": strconv.Itoa(milestonesPageSize),
+				"page":     strconv.Itoa(page),
+			}).
+			SetResult(&milestones).
+			Get("/repos/{owner}/{repo}/milestones")
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to get GitHub milestones: %w", err)
+		}
+
+		if resp.IsError() {
+			return 0, false, fmt.Errorf("failed to get GitHub milestones: %s", resp.String())
+		}
+
+		for _, m := range milestones {
+			if m.Title == title {
+				return m.Number, true, nil
+			}
+		}
+
+		if len(milestones) < milestonesPageSize {
+			return 0, false, nil
+		}
+	}
+}
+
+// IssueExists reports whether the repository has the issue. GitHub reports pull requests as issues too.
+func (c *GitHubClient) IssueExists(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID string,
+	issue int,
+) (bool, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return false, err
+	}
+
+	c.restyClient.HostURL = githubURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParams(map[string]string{
+			ownerPathParam: owner,
+			repoPathParam:  repo,
+			issuePathParam: strconv.Itoa(issue),
+		}).
+		Get("/repos/{owner}/{repo}/issues/{issue}")
+	if err != nil {
+		return false, fmt.Errorf("failed to get GitHub issue: %w", err)
+	}
+
+	// GitHub answers 410 Gone for deleted issues.
+	if resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusGone {
+		return false, nil
+	}
+
+	if resp.IsError() {
+		return false, fmt.Errorf("failed to get GitHub issue: %s", resp.String())
+	}
+
+	return true, nil
+}
+
+// SetCommitStatus creates a status of the commit. GitHub shows the latest status of each context.
+func (c *GitHubClient) SetCommitStatus(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID,
+	sha string,
+	status CommitStatus,
+) error {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	c.restyClient.HostURL = githubURL
+
+	body := map[string]string{
+		"state":       status.State,
+		"context":     status.Context,
+		"description": truncate(status.Description, gitHubStatusDescriptionLength),
+	}
+
+	if status.TargetURL != "" {
+		body["target_url"] = status.TargetURL
+	}
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetAuthToken(token).
+		SetPathParams(map[string]string{
+			ownerPathParam: owner,
+			repoPathParam:  repo,
+			shaPathParam:   sha,
+		}).
+		SetBody(body).
+		Post("/repos/{owner}/{repo}/statuses/{sha}")
+	if err != nil {
+		return fmt.Errorf("failed to set GitHub commit status: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to set GitHub commit status: %s", resp.String())
+	}
+
+	return nil
+}
+
+// GetPullRequestCommits returns the commits of the pull request, oldest first.
+// GitHub lists at most 250 commits of a pull request, so larger pull requests fail with an error.
+func (c *GitHubClient) GetPullRequestCommits(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID string,
+	pullRequest int,
+) ([]Commit, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.restyClient.HostURL = githubURL
+
+	var result []Commit
+
+	for page := 1; ; page++ {
+		var commits []gitHubCommit
+
+		resp, err := c.restyClient.
+			R().
+			SetContext(ctx).
+			SetAuthToken(token).
+			SetPathParams(map[string]string{
+				ownerPathParam: owner,
+				repoPathParam:  repo,
+				"pull":         strconv.Itoa(pullRequest),
+			}).
+			SetQueryParams(map[string]string{
+				"per_page": strconv.Itoa(commitsPageSize),
+				"page":     strconv.Itoa(page),
+			}).
+			SetResult(&commits).
+			Get("/repos/{owner}/{repo}/pulls/{pull}/commits")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub pull request commits: %w", err)
+		}
+
+		if resp.IsError() {
+			return nil, fmt.Errorf("failed to get GitHub pull request commits: %s", resp.String())
+		}
+
+		for i := range commits {
+			result = append(result, commits[i].toCommit())
+		}
+
+		if len(commits) < commitsPageSize {
+			break
+		}
+	}
+
+	if len(result) >= maxGitHubPullRequestCommits {
+		return nil, fmt.Errorf("GitHub lists only the first %d commits of the pull request", maxGitHubPullRequestCommits)
+	}
+
+	return result, nil
+}
+
+// GetCommits returns the commits reachable from the commit to but not from the commit from, oldest first.
+func (c *GitHubClient) GetCommits(
+	ctx context.Context,
+	githubURL,
+	token,
+	projectID,
+	from,
+	to string,
+) ([]Commit, error) {
+	owner, repo, err := parseProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.restyClient.HostURL = githubURL
+
+	var result []Commit
+
+	for page := 1; ; page++ {
+		comparison := struct {
+			TotalCommits int            `json:"total_commits"`
+			Commits      []gitHubCommit `json:"commits"`
+		}{}
+
+		resp, err := c.restyClient.
+			R().
+			SetContext(ctx).
+			SetAuthToken(token).
+			SetPathParams(map[string]string{
+				ownerPathParam: owner,
+				repoPathParam:  repo,
+				"basehead":     from + "..." + to,
+			}).
+			SetQueryParams(map[string]string{
+				"per_page": strconv.Itoa(commitsPageSize),
+				"page":     strconv.Itoa(page),
+			}).
+			SetResult(&comparison).
+			Get("/repos/{owner}/{repo}/compare/{basehead}")
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare GitHub commits: %w", err)
+		}
+
+		if resp.IsError() {
+			return nil, fmt.Errorf("failed to compare GitHub commits: %s", resp.String())
+		}
+
+		for i := range comparison.Commits {
+			result = append(result, comparison.Commits[i].toCommit())
+		}
+
+		if len(comparison.Commits) == 0 || len(result) >= comparison.TotalCommits {
+			return result, nil
+		}
+	}
+}
+
+func (c *gitHubCommit) toCommit() Commit {
+	return Commit{SHA: c.SHA, Message: c.Commit.Message, Parents: len(c.Parents)}
+}
+
 func convertWebhook(githubHook *gitHubWebHook) *WebHook {
 	if githubHook == nil {
 		return nil
 	}
 
 	return &WebHook{
-		ID:  strconv.Itoa(githubHook.ID),
-		URL: githubHook.Config.URL,
+		ID:      strconv.Itoa(githubHook.ID),
+		URL:     githubHook.Config.URL,
+		Events:  githubHook.Events,
+		SkipTLS: githubHook.Config.InsecureSSL == "1",
+		Active:  githubHook.Active,
 	}
 }
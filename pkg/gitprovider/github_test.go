@@ -3,9 +3,15 @@ package gitprovider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/jarcoal/httpmock"
@@ -641,3 +647,433 @@ func TestGitHubClient_SetDefaultBranch(t *testing.T) {
 		})
 	}
 }
+
+func TestGitHubClient_UpdateWebHook(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name        string
+		projectID   string
+		respStatus  int
+		resBody     map[string]interface{}
+		want        *WebHook
+		wantErr     require.ErrorAssertionFunc
+		errIs       error
+		errContains string
+	}{
+		{
+			name:       "success",
+			projectID:  "owner/repo",
+			respStatus: http.StatusOK,
+			resBody: map[string]interface{}{
+				"id":     1,
+				"active": true,
+				"events": []string{"pull_request", "push", "issue_comment"},
+				"config": map[string]string{"url": "https://example.com", "insecure_ssl": "1"},
+			},
+			want: &WebHook{
+				ID:      "1",
+				URL:     "https://example.com",
+				Events:  []string{"pull_request", "push", "issue_comment"},
+				SkipTLS: true,
+				Active:  true,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:        "invalid project ID",
+			projectID:   "owner-repo",
+			respStatus:  http.StatusOK,
+			resBody:     map[string]interface{}{},
+			wantErr:     require.Error,
+			errContains: "invalid project ID",
+		},
+		{
+			name:       "not found",
+			projectID:  "owner/repo",
+			respStatus: http.StatusNotFound,
+			resBody:    map[string]interface{}{"message": "not found"},
+			wantErr:    require.Error,
+			errIs:      ErrWebHookNotFound,
+		},
+		{
+			name:        "response failure",
+			projectID:   "owner/repo",
+			respStatus:  http.StatusUnprocessableEntity,
+			resBody:     map[string]interface{}{"message": "validation failed"},
+			wantErr:     require.Error,
+			errContains: "failed to update GitHub web hook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			var body map[string]interface{}
+
+			httpmock.RegisterResponder(
+				http.MethodPatch,
+				"https://api.github.com/repos/owner/repo/hooks/999",
+				func(req *http.Request) (*http.Response, error) {
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+					return httpmock.NewJsonResponse(tt.respStatus, tt.resBody)
+				},
+			)
+
+			c := NewGitHubClient(restyClient)
+
+			got, err := c.UpdateWebHook(
+				context.Background(), "https://api.github.com", "token", tt.projectID, "999", "secret",
+				"https://example.com", true,
+			)
+
+			tt.wantErr(t, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+
+			if tt.errContains != "" {
+				assert.Contains(t, err.Error(), tt.errContains)
+			}
+
+			assert.Equal(t, tt.want, got)
+
+			if err == nil {
+				assert.Equal(t, true, body["active"])
+				assert.Equal(t, []interface{}{"pull_request", "push", "issue_comment"}, body["events"])
+				assert.Equal(t, map[string]interface{}{
+					"url":          "https://example.com",
+					"content_type": "json",
+					"secret":       "secret",
+					"insecure_ssl": "1",
+				}, body["config"])
+			}
+		})
+	}
+}
+
+func TestGitHubClient_GetWebHookDeliveries(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	defer httpmock.DeactivateAndReset()
+
+	deliveredAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		respStatus int
+		resBody    interface{}
+		want       []WebHookDelivery
+		wantErr    require.ErrorAssertionFunc
+		errIs      error
+	}{
+		{
+			name:       "success",
+			respStatus: http.StatusOK,
+			resBody: []map[string]interface{}{
+				{
+					"id":           12,
+					"event":        "pull_request",
+					"action":       "opened",
+					"status":       "Invalid HTTP Response: 502",
+					"status_code":  502,
+					"delivered_at": "2026-10-19T12:00:00Z",
+				},
+				{"id": 11, "event": "push", "status": "OK", "status_code": 200, "delivered_at": "2026-10-19T12:00:00Z"},
+			},
+			want: []WebHookDelivery{
+				{
+					ID:          "12",
+					Event:       "pull_request.opened",
+					StatusCode:  502,
+					Status:      "Invalid HTTP Response: 502",
+					DeliveredAt: deliveredAt,
+				},
+				{ID: "11", Event: "push", StatusCode: 200, Status: "OK", DeliveredAt: deliveredAt},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:       "not found",
+			respStatus: http.StatusNotFound,
+			resBody:    map[string]interface{}{"message": "Not Found"},
+			wantErr:    require.Error,
+			errIs:      ErrWebHookNotFound,
+		},
+		{
+			name:       "failure",
+			respStatus: http.StatusForbidden,
+			resBody:    map[string]interface{}{"message": "Resource not accessible by integration"},
+			wantErr:    require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			responder, err := httpmock.NewJsonResponder(tt.respStatus, tt.resBody)
+			require.NoError(t, err)
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				"https://api.github.com/repos/owner/repo/hooks/1/deliveries?per_page=30",
+				responder,
+			)
+
+			c := NewGitHubClient(restyClient)
+
+			got, err := c.GetWebHookDeliveries(context.Background(), "https://api.github.com", "token", "owner/repo", "1")
+
+			tt.wantErr(t, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGitHubClient_Issues(t *testing.T) {
+	tests := []struct {
+		name         string
+		milestones   string
+		call         func(c *GitHubClient, url string) error
+		wantRequests []string
+		wantBody     string
+	}{
+		{
+			name: "create comment",
+			call: func(c *GitHubClient, url string) error {
+				return c.CreateIssueComment(context.Background(), url, "token", "owner/repo", 12, "Build 1.0.0")
+			},
+			wantRequests: []string{"POST /repos/owner/repo/issues/12/comments"},
+			wantBody:     `{"body":"Build 1.0.0"}`,
+		},
+		{
+			name: "add labels",
+			call: func(c *GitHubClient, url string) error {
+				return c.AddIssueLabels(context.Background(), url, "token", "owner/repo", 12, []string{"app", "qa"})
+			},
+			wantRequests: []string{"POST /repos/owner/repo/issues/12/labels"},
+			wantBody:     `{"labels":["app","qa"]}`,
+		},
+		{
+			name:       "set existing milestone",
+			milestones: `[{"number":3,"title":"1.0.0"}]`,
+			call: func(c *GitHubClient, url string) error {
+				return c.SetIssueMilestone(context.Background(), url, "token", "owner/repo", 12, "1.0.0")
+			},
+			wantRequests: []string{"GET /repos/owner/repo/milestones", "PATCH /repos/owner/repo/issues/12"},
+			wantBody:     `{"milestone":3}`,
+		},
+		{
+			name:       "create milestone",
+			milestones: `[{"number":3,"title":"0.9.0"}]`,
+			call: func(c *GitHubClient, url string) error {
+				return c.SetIssueMilestone(context.Background(), url, "token", "owner/repo", 12, "1.0.0")
+			},
+			wantRequests: []string{
+				"GET /repos/owner/repo/milestones",
+				"POST /repos/owner/repo/milestones",
+				"PATCH /repos/owner/repo/issues/12",
+			},
+			wantBody: `{"milestone":4}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				requests []string
+				lastBody string
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				requests = append(requests, r.Method+" "+r.URL.Path)
+
+				body, _ := io.ReadAll(r.Body)
+				lastBody = string(body)
+
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet:
+					_, _ = w.Write([]byte(tt.milestones))
+				case r.URL.Path == "/repos/owner/repo/milestones":
+					_, _ = w.Write([]byte(`{"number":4,"title":"1.0.0"}`))
+				default:
+					_, _ = w.Write([]byte(`{}`))
+				}
+			}))
+			defer server.Close()
+
+			require.NoError(t, tt.call(NewGitHubClient(resty.New()), server.URL))
+			assert.Equal(t, tt.wantRequests, requests)
+			assert.JSONEq(t, tt.wantBody, lastBody)
+		})
+	}
+}
+
+func TestGitHubClient_getOrCreateMilestone(t *testing.T) {
+	otherMilestones := make([]gitHubMilestone, 0, milestonesPageSize)
+	for i := range milestonesPageSize {
+		otherMilestones = append(otherMilestones, gitHubMilestone{Number: i + 1, Title: fmt.Sprintf("0.%d.0", i)})
+	}
+
+	tests := []struct {
+		name         string
+		title        string
+		createStatus int
+		want         int
+		wantRequests []string
+	}{
+		{
+			name:         "milestone on the second page",
+			title:        "0.100.0",
+			createStatus: http.StatusCreated,
+			want:         101,
+			wantRequests: []string{"GET 1", "GET 2"},
+		},
+		{
+			name:         "milestone created concurrently",
+			title:        "1.0.0",
+			createStatus: http.StatusUnprocessableEntity,
+			want:         102,
+			wantRequests: []string{"GET 1", "GET 2", "POST", "GET 1", "GET 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				requests []string
+				created  bool
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				if r.Method == http.MethodPost {
+					requests = append(requests, "POST")
+					created = true
+
+					w.WriteHeader(tt.createStatus)
+					_, _ = w.Write([]byte(`{"message":"Validation Failed","errors":[{"code":"already_exists"}]}`))
+
+					return
+				}
+
+				requests = append(requests, "GET "+r.URL.Query().Get("page"))
+
+				page := []gitHubMilestone{{Number: 101, Title: "0.100.0"}}
+				if created {
+					page = append(page, gitHubMilestone{Number: 102, Title: "1.0.0"})
+				}
+
+				if r.URL.Query().Get("page") == "1" {
+					page = otherMilestones
+				}
+
+				_ = json.NewEncoder(w).Encode(page)
+			}))
+			defer server.Close()
+
+			got, err := NewGitHubClient(resty.New()).
+				getOrCreateMilestone(context.Background(), server.URL, "token", "owner", "repo", tt.title)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
+
+func TestGitHubClient_Commits(t *testing.T) {
+	var statusBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /repos/owner/repo/statuses/abc123":
+			_ = json.NewDecoder(r.Body).Decode(&statusBody)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		case "GET /repos/owner/repo/pulls/5/commits":
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			_, _ = w.Write([]byte(`[{"sha":"a1","commit":{"message":"[APP-1] Fix"}},{"sha":"b2","commit":{"message":"WIP"}}]`))
+		case "GET /repos/owner/repo/pulls/6/commits":
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte("[" + strings.TrimSuffix(strings.Repeat(`{"sha":"a1"},`, commitsPageSize), ",") + "]"))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`[{"sha":"m1","commit":{"message":"Sync"},"parents":[{"sha":"a1"},{"sha":"c3"}]}]`))
+		case "GET /repos/owner/repo/compare/base...head":
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte(`{"total_commits":2,"commits":[{"sha":"a1","commit":{"message":"[APP-1] Fix"}}]}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"total_commits":2,"commits":[{"sha":"m1","parents":[{"sha":"a1"},{"sha":"c3"}]}]}`))
+		case "GET /repos/owner/repo/issues/12":
+			_, _ = w.Write([]byte(`{"number":12}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewGitHubClient(resty.New())
+	ctx := context.Background()
+
+	err := c.SetCommitStatus(ctx, server.URL, "token", "owner/repo", "abc123", CommitStatus{
+		State:       CommitStateFailure,
+		Context:     "edp/commit-policy",
+		Description: strings.Repeat("a", 200),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "failure", statusBody["state"])
+	assert.Equal(t, "edp/commit-policy", statusBody["context"])
+	assert.Len(t, []rune(statusBody["description"]), gitHubStatusDescriptionLength)
+	assert.NotContains(t, statusBody, "target_url")
+
+	commits, err := c.GetPullRequestCommits(ctx, server.URL, "token", "owner/repo", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []Commit{{SHA: "a1", Message: "[APP-1] Fix"}, {SHA: "b2", Message: "WIP"}}, commits)
+
+	commits, err = c.GetPullRequestCommits(ctx, server.URL, "token", "owner/repo", 6)
+	require.NoError(t, err)
+	require.Len(t, commits, commitsPageSize+1, "all pages must be listed")
+	assert.Equal(t, Commit{SHA: "m1", Message: "Sync", Parents: 2}, commits[commitsPageSize])
+
+	commits, err = c.GetCommits(ctx, server.URL, "token", "owner/repo", "base", "head")
+	require.NoError(t, err)
+	assert.Equal(t, []Commit{{SHA: "a1", Message: "[APP-1] Fix"}, {SHA: "m1", Parents: 2}}, commits)
+
+	exists, err := c.IssueExists(ctx, server.URL, "token", "owner/repo", 12)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = c.IssueExists(ctx, server.URL, "token", "owner/repo", 13)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = c.GetPullRequestCommits(ctx, server.URL, "token", "owner/other", 5)
+	require.Error(t, err)
+}
@@ -2,42 +2,79 @@ package gitprovider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
 type gitlabWebHook struct {
-	ID  int    `json:"id"`
-	URL string `json:"url"`
+	ID                    int    `json:"id"`
+	URL                   string `json:"url"`
+	PushEvents            bool   `json:"push_events"`
+	TagPushEvents         bool   `json:"tag_push_events"`
+	MergeRequestsEvents   bool   `json:"merge_requests_events"`
+	NoteEvents            bool   `json:"note_events"`
+	IssuesEvents          bool   `json:"issues_events"`
+	PipelineEvents        bool   `json:"pipeline_events"`
+	JobEvents             bool   `json:"job_events"`
+	ReleasesEvents        bool   `json:"releases_events"`
+	EnableSSLVerification bool   `json:"enable_ssl_verification"`
+	AlertStatus           string `json:"alert_status"`
+}
+
+// gitlabWebHookEvent is an entry of the log of a project hook. The response status is
+// the HTTP status code or an error description, e.g. "internal error".
+type gitlabWebHookEvent struct {
+	ID             int        `json:"id"`
+	Trigger        string     `json:"trigger"`
+	ResponseStatus string     `json:"response_status"`
+	CreatedAt      *time.Time `json:"created_at"`
+}
+
+// gitLabHookEventFlags are the event flags of a GitLab project hook the operator manages.
+var gitLabHookEventFlags = []string{
+	"push_events",
+	"tag_push_events",
+	"merge_requests_events",
+	"note_events",
+	"issues_events",
+	"pipeline_events",
+	"job_events",
+	"releases_events",
 }
 
 type gitlabNamespace struct {
 	ID int `json:"id"`
 }
 
+type gitlabCommit struct {
+	ID        string   `json:"id"`
+	Message   string   `json:"message"`
+	ParentIDs []string `json:"parent_ids"`
+}
+
+type gitlabMilestone struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
 type GitLabClient struct {
 	restyClient *resty.Client
 }
 
 const (
-	retryCount            = 3
 	gitLabTokenHeaderName = "PRIVATE-TOKEN"
 	projectIDPathParam    = "project-id"
 )
 
 // NewGitLabClient creates a new GitLab client.
 func NewGitLabClient(restyClient *resty.Client) *GitLabClient {
-	restyClient.SetRetryCount(retryCount)
-	restyClient.AddRetryCondition(
-		func(response *resty.Response, err error) bool {
-			return response.IsError()
-		},
-	)
-
 	return &GitLabClient{restyClient: restyClient}
 }
 
@@ -58,14 +95,7 @@ func (c *GitLabClient) CreateWebHook(
 		R().
 		SetContext(ctx).
 		SetHeader(gitLabTokenHeaderName, token).
-		SetBody(map[string]interface{}{
-			"url":                     webHookURL,
-			"merge_requests_events":   true,
-			"note_events":             true,
-			"push_events":             false,
-			"token":                   webHookSecret,
-			"enable_ssl_verification": !skipTLS,
-		}).
+		SetBody(gitLabWebHookBody(webHookSecret, webHookURL, skipTLS)).
 		SetPathParams(map[string]string{
 			projectIDPathParam: projectID,
 		}).
@@ -179,6 +209,120 @@ func (c *GitLabClient) GetWebHooks(
 	return hooks, nil
 }
 
+// GetWebHookDeliveries returns the recent entries of the log of the project hook, newest first.
+// The hook log is available since GitLab 17.3.
+func (c *GitLabClient) GetWebHookDeliveries(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID,
+	webHookRef string,
+) ([]WebHookDelivery, error) {
+	c.restyClient.HostURL = gitlabURL
+
+	var events []gitlabWebHookEvent
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetPathParams(map[string]string{
+			projectIDPathParam: projectID,
+			"hook-id":          webHookRef,
+		}).
+		SetQueryParam("per_page", strconv.Itoa(webHookDeliveriesPageSize)).
+		SetResult(&events).
+		Get("/api/v4/projects/{project-id}/hooks/{hook-id}/events")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab web hook events: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get GitLab web hook events: %w", ErrWebHookNotFound)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get GitLab web hook events: %s", resp.String())
+	}
+
+	deliveries := make([]WebHookDelivery, len(events))
+
+	for i, event := range events {
+		deliveries[i] = WebHookDelivery{
+			ID:    strconv.Itoa(event.ID),
+			Event: event.Trigger,
+		}
+
+		if code, convErr := strconv.Atoi(event.ResponseStatus); convErr == nil {
+			deliveries[i].StatusCode = code
+		} else {
+			deliveries[i].Status = event.ResponseStatus
+		}
+
+		if event.CreatedAt != nil {
+			deliveries[i].DeliveredAt = *event.CreatedAt
+		}
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebHook sets the URL, secret, events and TLS verification of the webhook.
+func (c *GitLabClient) UpdateWebHook(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID,
+	webHookRef,
+	webHookSecret,
+	webHookURL string,
+	skipTLS bool,
+) (*WebHook, error) {
+	c.restyClient.HostURL = gitlabURL
+	webHook := &gitlabWebHook{}
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetBody(gitLabWebHookBody(webHookSecret, webHookURL, skipTLS)).
+		SetPathParams(map[string]string{
+			projectIDPathParam: projectID,
+			"hook-id":          webHookRef,
+		}).
+		SetResult(webHook).
+		Put("/api/v4/projects/{project-id}/hooks/{hook-id}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to update GitLab web hook: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to update GitLab web hook: %w", ErrWebHookNotFound)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to update GitLab web hook: %s", resp.String())
+	}
+
+	return convertGitlabWebhook(webHook), nil
+}
+
+// gitLabWebHookBody sets every managed event flag explicitly, because GitLab enables
+// push events unless they are disabled.
+func gitLabWebHookBody(webHookSecret, webHookURL string, skipTLS bool) map[string]interface{} {
+	body := map[string]interface{}{
+		"url":                     webHookURL,
+		"token":                   webHookSecret,
+		"enable_ssl_verification": !skipTLS,
+	}
+
+	for _, flag := range gitLabHookEventFlags {
+		body[flag] = slices.Contains(gitLabWebHookEvents, flag)
+	}
+
+	return body
+}
+
 // DeleteWebHook deletes webhook by ID for the given project.
 func (c *GitLabClient) DeleteWebHook(
 	ctx context.Context,
@@ -346,6 +490,339 @@ func (c *GitLabClient) getNamespace(
 	return ns, nil
 }
 
+// CreateIssueComment adds a note to the issue.
+func (c *GitLabClient) CreateIssueComment(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID string,
+	issue int,
+	body string,
+) error {
+	c.restyClient.HostURL = gitlabURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetPathParams(map[string]string{
+			"projectID":    projectID,
+			issuePathParam: strconv.Itoa(issue),
+		}).
+		SetBody(map[string]string{
+			"body": body,
+		}).
+		Post("/api/v4/projects/{projectID}/issues/{issue}/notes")
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab issue note: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to create GitLab issue note: %s", resp.String())
+	}
+
+	return nil
+}
+
+// AddIssueLabels adds the labels to the issue. GitLab creates the labels that the project does not have.
+func (c *GitLabClient) AddIssueLabels(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID string,
+	issue int,
+	labels []string,
+) error {
+	return c.updateIssue(ctx, gitlabURL, token, projectID, issue, map[string]interface{}{
+		"add_labels": strings.Join(labels, ","),
+	})
+}
+
+// SetIssueMilestone sets the milestone of the issue. The milestone is created if the project does not have it.
+func (c *GitLabClient) SetIssueMilestone(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID string,
+	issue int,
+	milestone string,
+) error {
+	id, err := c.getOrCreateMilestone(ctx, gitlabURL, token, projectID, milestone)
+	if err != nil {
+		return err
+	}
+
+	return c.updateIssue(ctx, gitlabURL, token, projectID, issue, map[string]interface{}{
+		"milestone_id": id,
+	})
+}
+
+func (c *GitLabClient) updateIssue(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID string,
+	issue int,
+	body map[string]interface{},
+) error {
+	c.restyClient.HostURL = gitlabURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetPathParams(map[string]string{
+			"projectID":    projectID,
+			issuePathParam: strconv.Itoa(issue),
+		}).
+		SetBody(body).
+		Put("/api/v4/projects/{projectID}/issues/{issue}")
+	if err != nil {
+		return fmt.Errorf("failed to update GitLab issue: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to update GitLab issue: %s", resp.String())
+	}
+
+	return nil
+}
+
+// getOrCreateMilestone returns the ID of the project milestone with the given title.
+func (c *GitLabClient) getOrCreateMilestone(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID,
+	title string,
+) (int, error) {
+	c.restyClient.HostURL = gitlabURL
+
+	milestones := make([]gitlabMilestone, 0)
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetPathParams(map[string]string{
+			"projectID": projectID,
+		}).
+		SetQueryParam("title", title).
+		SetResult(&milestones).
+		Get("/api/v4/projects/{projectID}/milestones")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get GitLab milestones: %w", err)
+	}
+
+	if resp.IsError() {
+		return 0, fmt.Errorf("failed to get GitLab milestones: %s", resp.String())
+	}
+
+	if len(milestones) > 0 {
+		return milestones[0].ID, nil
+	}
+
+	created := &gitlabMilestone{}
+
+	resp, err = c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetPathParams(map[string]string{
+			"projectID": projectID,
+		}).
+		SetBody(map[string]string{
+			"title": title,
+		}).
+		SetResult(created).
+		Post("/api/v4/projects/{projectID}/milestones")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create GitLab milestone: %w", err)
+	}
+
+	if resp.IsError() {
+		return 0, fmt.Errorf("failed to create GitLab milestone: %s", resp.String())
+	}
+
+	return created.ID, nil
+}
+
+// IssueExists reports whether the project has the issue.
+func (c *GitLabClient) IssueExists(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID string,
+	issue int,
+) (bool, error) {
+	c.restyClient.HostURL = gitlabURL
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetPathParams(map[string]string{
+			"projectID":    projectID,
+			issuePathParam: strconv.Itoa(issue),
+		}).
+		Get("/api/v4/projects/{projectID}/issues/{issue}")
+	if err != nil {
+		return false, fmt.Errorf("failed to get GitLab issue: %w", err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.IsError() {
+		return false, fmt.Errorf("failed to get GitLab issue: %s", resp.String())
+	}
+
+	return true, nil
+}
+
+// SetCommitStatus sets the status of the commit with the context as its name.
+func (c *GitLabClient) SetCommitStatus(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID,
+	sha string,
+	status CommitStatus,
+) error {
+	c.restyClient.HostURL = gitlabURL
+
+	state := status.State
+
+	switch state {
+	case CommitStateFailure:
+		state = "failed"
+	case CommitStateError:
+		state = "canceled"
+	}
+
+	body := map[string]string{
+		"state":       state,
+		"name":        status.Context,
+		"description": truncate(status.Description, gitLabStatusDescriptionLength),
+	}
+
+	if status.TargetURL != "" {
+		body["target_url"] = status.TargetURL
+	}
+
+	resp, err := c.restyClient.
+		R().
+		SetContext(ctx).
+		SetHeader(gitLabTokenHeaderName, token).
+		SetPathParams(map[string]string{
+			"projectID":  projectID,
+			shaPathParam: sha,
+		}).
+		SetBody(body).
+		Post("/api/v4/projects/{projectID}/statuses/{sha}")
+	if err != nil {
+		return fmt.Errorf("failed to set GitLab commit status: %w", err)
+	}
+
+	// GitLab rejects setting the state the status already has.
+	if resp.StatusCode() == http.StatusBadRequest && strings.Contains(resp.String(), "Cannot transition status") {
+		return nil
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("failed to set GitLab commit status: %s", resp.String())
+	}
+
+	return nil
+}
+
+// GetPullRequestCommits returns the commits of the merge request, newest first.
+func (c *GitLabClient) GetPullRequestCommits(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID string,
+	pullRequest int,
+) ([]Commit, error) {
+	commits, err := c.getCommits(ctx, gitlabURL, token, map[string]string{
+		"projectID":    projectID,
+		"mergeRequest": strconv.Itoa(pullRequest),
+	}, nil, "/api/v4/projects/{projectID}/merge_requests/{mergeRequest}/commits")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab merge request commits: %w", err)
+	}
+
+	return commits, nil
+}
+
+// GetCommits returns the commits reachable from the commit to but not from the commit from, newest first.
+func (c *GitLabClient) GetCommits(
+	ctx context.Context,
+	gitlabURL,
+	token,
+	projectID,
+	from,
+	to string,
+) ([]Commit, error) {
+	commits, err := c.getCommits(ctx, gitlabURL, token, map[string]string{
+		"projectID": projectID,
+	}, map[string]string{
+		"ref_name": from + ".." + to,
+	}, "/api/v4/projects/{projectID}/repository/commits")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab commits: %w", err)
+	}
+
+	return commits, nil
+}
+
+// getCommits returns the commits of all pages of the commit list.
+func (c *GitLabClient) getCommits(
+	ctx context.Context,
+	gitlabURL,
+	token string,
+	pathParams,
+	queryParams map[string]string,
+	path string,
+) ([]Commit, error) {
+	c.restyClient.HostURL = gitlabURL
+
+	var result []Commit
+
+	for page := 1; ; page++ {
+		var commits []gitlabCommit
+
+		resp, err := c.restyClient.
+			R().
+			SetContext(ctx).
+			SetHeader(gitLabTokenHeaderName, token).
+			SetPathParams(pathParams).
+			SetQueryParams(queryParams).
+			SetQueryParams(map[string]string{
+				"per_page": strconv.Itoa(commitsPageSize),
+				"page":     strconv.Itoa(page),
+			}).
+			SetResult(&commits).
+			Get(path)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // the callers wrap the error
+		}
+
+		if resp.IsError() {
+			return nil, errors.New(resp.String())
+		}
+
+		for _, commit := range commits {
+			result = append(result, Commit{SHA: commit.ID, Message: commit.Message, Parents: len(commit.ParentIDs)})
+		}
+
+		if len(commits) < commitsPageSize {
+			return result, nil
+		}
+	}
+}
+
 func decodeProjectID(projectID string) (namespace, path string, err error) {
 	lastSlashIndex := strings.LastIndex(projectID, "/")
 	if lastSlashIndex == -1 || lastSlashIndex == len(projectID)-1 || lastSlashIndex == 0 {
@@ -360,8 +837,31 @@ func convertGitlabWebhook(hook *gitlabWebHook) *WebHook {
 		return nil
 	}
 
+	flags := map[string]bool{
+		"push_events":           hook.PushEvents,
+		"tag_push_events":       hook.TagPushEvents,
+		"merge_requests_events": hook.MergeRequestsEvents,
+		"note_events":           hook.NoteEvents,
+		"issues_events":         hook.IssuesEvents,
+		"pipeline_events":       hook.PipelineEvents,
+		"job_events":            hook.JobEvents,
+		"releases_events":       hook.ReleasesEvents,
+	}
+
+	var events []string
+
+	for _, flag := range gitLabHookEventFlags {
+		if flags[flag] {
+			events = append(events, flag)
+		}
+	}
+
 	return &WebHook{
-		ID:  strconv.Itoa(hook.ID),
-		URL: hook.URL,
+		ID:      strconv.Itoa(hook.ID),
+		URL:     hook.URL,
+		Events:  events,
+		SkipTLS: !hook.EnableSSLVerification,
+		// GitLab disables hooks that keep failing; there is no other way to deactivate one.
+		Active: hook.AlertStatus != "disabled" && hook.AlertStatus != "temporarily_disabled",
 	}
 }
@@ -3,9 +3,14 @@ package gitprovider
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/jarcoal/httpmock"
@@ -30,8 +35,8 @@ func TestGitLabClient_CreateWebHook(t *testing.T) {
 		{
 			name:       "success",
 			respStatus: http.StatusCreated,
-			resBody:    map[string]interface{}{"id": 1, "url": "https://example.com"},
-			want:       &WebHook{ID: "1", URL: "https://example.com"},
+			resBody:    map[string]interface{}{"id": 1, "url": "https://example.com", "enable_ssl_verification": true},
+			want:       &WebHook{ID: "1", URL: "https://example.com", Active: true},
 			wantErr:    assert.NoError,
 		},
 		{
@@ -81,8 +86,8 @@ func TestGitLabClient_GetWebHook(t *testing.T) {
 		{
 			name:       "success",
 			respStatus: http.StatusOK,
-			resBody:    map[string]interface{}{"id": 1, "url": "https://example.com"},
-			want:       &WebHook{ID: "1", URL: "https://example.com"},
+			resBody:    map[string]interface{}{"id": 1, "url": "https://example.com", "enable_ssl_verification": true},
+			want:       &WebHook{ID: "1", URL: "https://example.com", Active: true},
 			wantErr:    assert.NoError,
 		},
 		{
@@ -198,8 +203,8 @@ func TestGitLabClient_GetWebHooks(t *testing.T) {
 			name:       "success",
 			projectID:  "owner/repo",
 			respStatus: http.StatusOK,
-			resBody:    []map[string]interface{}{{"id": 1, "url": "https://example.com"}},
-			want:       []*WebHook{{ID: "1", URL: "https://example.com"}},
+			resBody:    []map[string]interface{}{{"id": 1, "url": "https://example.com", "enable_ssl_verification": true}},
+			want:       []*WebHook{{ID: "1", URL: "https://example.com", Active: true}},
 			wantErr:    require.NoError,
 		},
 		{
@@ -272,8 +277,8 @@ func TestGitLabClient_CreateWebHookIfNotExists(t *testing.T) {
 			GETRespStatus:  http.StatusOK,
 			GETResBody:     []map[string]interface{}{},
 			POSTRespStatus: http.StatusCreated,
-			POSTResBody:    map[string]interface{}{"id": 1, "url": "https://example.com"},
-			want:           &WebHook{ID: "1", URL: "https://example.com"},
+			POSTResBody:    map[string]interface{}{"id": 1, "url": "https://example.com", "enable_ssl_verification": true},
+			want:           &WebHook{ID: "1", URL: "https://example.com", Active: true},
 			wantErr:        require.NoError,
 		},
 		{
@@ -281,10 +286,10 @@ func TestGitLabClient_CreateWebHookIfNotExists(t *testing.T) {
 			projectID:      "owner/repo",
 			webHookURL:     "https://example.com",
 			GETRespStatus:  http.StatusOK,
-			GETResBody:     []map[string]interface{}{{"id": 1, "url": "https://example.com"}},
+			GETResBody:     []map[string]interface{}{{"id": 1, "url": "https://example.com", "enable_ssl_verification": true}},
 			POSTRespStatus: http.StatusCreated,
-			POSTResBody:    map[string]interface{}{"id": 2, "url": "https://provider.com"},
-			want:           &WebHook{ID: "1", URL: "https://example.com"},
+			POSTResBody:    map[string]interface{}{"id": 2, "url": "https://provider.com", "enable_ssl_verification": true},
+			want:           &WebHook{ID: "1", URL: "https://example.com", Active: true},
 			wantErr:        require.NoError,
 		},
 		{
@@ -292,10 +297,10 @@ func TestGitLabClient_CreateWebHookIfNotExists(t *testing.T) {
 			projectID:      "owner/repo",
 			GETRespStatus:  http.StatusOK,
 			webHookURL:     "https://example.com",
-			GETResBody:     []map[string]interface{}{{"id": 2, "url": "https://provider.com"}},
+			GETResBody:     []map[string]interface{}{{"id": 2, "url": "https://provider.com", "enable_ssl_verification": true}},
 			POSTRespStatus: http.StatusCreated,
-			POSTResBody:    map[string]interface{}{"id": 1, "url": "https://example.com"},
-			want:           &WebHook{ID: "1", URL: "https://example.com"},
+			POSTResBody:    map[string]interface{}{"id": 1, "url": "https://example.com", "enable_ssl_verification": true},
+			want:           &WebHook{ID: "1", URL: "https://example.com", Active: true},
 			wantErr:        require.NoError,
 		},
 		{
@@ -613,3 +618,339 @@ func Test_decodeProjectID(t *testing.T) {
 		})
 	}
 }
+
+func TestGitLabClient_UpdateWebHook(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name       string
+		respStatus int
+		resBody    map[string]interface{}
+		want       *WebHook
+		wantErr    require.ErrorAssertionFunc
+		errIs      error
+	}{
+		{
+			name:       "success",
+			respStatus: http.StatusOK,
+			resBody: map[string]interface{}{
+				"id":                      1,
+				"url":                     "https://example.com",
+				"merge_requests_events":   true,
+				"note_events":             true,
+				"enable_ssl_verification": true,
+			},
+			want: &WebHook{
+				ID:     "1",
+				URL:    "https://example.com",
+				Events: []string{"merge_requests_events", "note_events"},
+				Active: true,
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:       "not found",
+			respStatus: http.StatusNotFound,
+			resBody:    map[string]interface{}{"message": "404 Not found"},
+			wantErr:    require.Error,
+			errIs:      ErrWebHookNotFound,
+		},
+		{
+			name:       "failure",
+			respStatus: http.StatusBadRequest,
+			resBody:    map[string]interface{}{"message": "bad request"},
+			wantErr:    require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			var body map[string]interface{}
+
+			httpmock.RegisterResponder(
+				http.MethodPut,
+				"https://gitlab.example.com/api/v4/projects/group%2Fapp/hooks/1",
+				func(req *http.Request) (*http.Response, error) {
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+					return httpmock.NewJsonResponse(tt.respStatus, tt.resBody)
+				},
+			)
+
+			c := NewGitLabClient(restyClient)
+
+			got, err := c.UpdateWebHook(
+				context.Background(), "https://gitlab.example.com", "token", "group/app", "1", "secret",
+				"https://example.com", false,
+			)
+
+			tt.wantErr(t, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+
+			assert.Equal(t, tt.want, got)
+
+			// Push events are enabled by GitLab unless they are disabled explicitly.
+			assert.Equal(t, false, body["push_events"])
+			assert.Equal(t, true, body["merge_requests_events"])
+			assert.Equal(t, true, body["enable_ssl_verification"])
+			assert.Equal(t, "secret", body["token"])
+		})
+	}
+}
+
+func TestGitLabClient_GetWebHookDeliveries(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name       string
+		respStatus int
+		resBody    interface{}
+		want       []WebHookDelivery
+		wantErr    require.ErrorAssertionFunc
+		errIs      error
+	}{
+		{
+			name:       "success",
+			respStatus: http.StatusOK,
+			resBody: []map[string]interface{}{
+				{"id": 3, "trigger": "merge_request_hooks", "response_status": "internal error"},
+				{"id": 2, "trigger": "note_hooks", "response_status": "200", "created_at": "2026-10-19T12:00:00Z"},
+			},
+			want: []WebHookDelivery{
+				{ID: "3", Event: "merge_request_hooks", Status: "internal error"},
+				{
+					ID:          "2",
+					Event:       "note_hooks",
+					StatusCode:  200,
+					DeliveredAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:       "not found",
+			respStatus: http.StatusNotFound,
+			resBody:    map[string]interface{}{"message": "404 Not found"},
+			wantErr:    require.Error,
+			errIs:      ErrWebHookNotFound,
+		},
+		{
+			name:       "failure",
+			respStatus: http.StatusForbidden,
+			resBody:    map[string]interface{}{"message": "403 Forbidden"},
+			wantErr:    require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			responder, err := httpmock.NewJsonResponder(tt.respStatus, tt.resBody)
+			require.NoError(t, err)
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				"https://gitlab.example.com/api/v4/projects/group%2Fapp/hooks/1/events?per_page=30",
+				responder,
+			)
+
+			c := NewGitLabClient(restyClient)
+
+			got, err := c.GetWebHookDeliveries(context.Background(), "https://gitlab.example.com", "token", "group/app", "1")
+
+			tt.wantErr(t, err)
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGitLabClient_Issues(t *testing.T) {
+	tests := []struct {
+		name         string
+		milestones   string
+		call         func(c *GitLabClient, url string) error
+		wantRequests []string
+		wantBody     string
+	}{
+		{
+			name: "create comment",
+			call: func(c *GitLabClient, url string) error {
+				return c.CreateIssueComment(context.Background(), url, "token", "group/app", 12, "Build 1.0.0")
+			},
+			wantRequests: []string{"POST /api/v4/projects/group%2Fapp/issues/12/notes"},
+			wantBody:     `{"body":"Build 1.0.0"}`,
+		},
+		{
+			name: "add labels",
+			call: func(c *GitLabClient, url string) error {
+				return c.AddIssueLabels(context.Background(), url, "token", "group/app", 12, []string{"app", "qa"})
+			},
+			wantRequests: []string{"PUT /api/v4/projects/group%2Fapp/issues/12"},
+			wantBody:     `{"add_labels":"app,qa"}`,
+		},
+		{
+			name:       "set existing milestone",
+			milestones: `[{"id":7,"title":"1.0.0"}]`,
+			call: func(c *GitLabClient, url string) error {
+				return c.SetIssueMilestone(context.Background(), url, "token", "group/app", 12, "1.0.0")
+			},
+			wantRequests: []string{
+				"GET /api/v4/projects/group%2Fapp/milestones",
+				"PUT /api/v4/projects/group%2Fapp/issues/12",
+			},
+			wantBody: `{"milestone_id":7}`,
+		},
+		{
+			name:       "create milestone",
+			milestones: `[]`,
+			call: func(c *GitLabClient, url string) error {
+				return c.SetIssueMilestone(context.Background(), url, "token", "group/app", 12, "1.0.0")
+			},
+			wantRequests: []string{
+				"GET /api/v4/projects/group%2Fapp/milestones",
+				"POST /api/v4/projects/group%2Fapp/milestones",
+				"PUT /api/v4/projects/group%2Fapp/issues/12",
+			},
+			wantBody: `{"milestone_id":8}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				requests []string
+				lastBody string
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(gitLabTokenHeaderName) != "token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+				body, _ := io.ReadAll(r.Body)
+				lastBody = string(body)
+
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet:
+					_, _ = w.Write([]byte(tt.milestones))
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/milestones"):
+					_, _ = w.Write([]byte(`{"id":8,"title":"1.0.0"}`))
+				default:
+					_, _ = w.Write([]byte(`{}`))
+				}
+			}))
+			defer server.Close()
+
+			require.NoError(t, tt.call(NewGitLabClient(resty.New()), server.URL))
+			assert.Equal(t, tt.wantRequests, requests)
+			assert.JSONEq(t, tt.wantBody, lastBody)
+		})
+	}
+}
+
+func TestGitLabClient_Commits(t *testing.T) {
+	var (
+		statusBody   map[string]string
+		statusExists bool
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /api/v4/projects/group%2Fapp/statuses/abc123":
+			if statusExists {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"Cannot transition status via :drop from :failed"}`))
+
+				return
+			}
+
+			_ = json.NewDecoder(r.Body).Decode(&statusBody)
+			statusExists = true
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		case "GET /api/v4/projects/group%2Fapp/merge_requests/5/commits":
+			if r.URL.Query().Get("page") == "1" {
+				page := strings.Repeat(`{"id":"b2","message":"WIP"},`, commitsPageSize)
+				_, _ = w.Write([]byte("[" + strings.TrimSuffix(page, ",") + "]"))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`[{"id":"a1","message":"[APP-1] Fix"}]`))
+		case "GET /api/v4/projects/group%2Fapp/repository/commits":
+			assert.Equal(t, "base..head", r.URL.Query().Get("ref_name"))
+			_, _ = w.Write([]byte(`[{"id":"m1","message":"Sync","parent_ids":["a1","c3"]}]`))
+		case "GET /api/v4/projects/group%2Fapp/issues/12":
+			_, _ = w.Write([]byte(`{"iid":12}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewGitLabClient(resty.New())
+	ctx := context.Background()
+	status := CommitStatus{
+		State:       CommitStateFailure,
+		Context:     "edp/commit-policy",
+		Description: "Commit b2: message does not match",
+		TargetURL:   "https://docs.example.com/commit-policy",
+	}
+
+	require.NoError(t, c.SetCommitStatus(ctx, server.URL, "token", "group/app", "abc123", status))
+	assert.Equal(t, map[string]string{
+		"state":       "failed",
+		"name":        "edp/commit-policy",
+		"description": "Commit b2: message does not match",
+		"target_url":  "https://docs.example.com/commit-policy",
+	}, statusBody)
+
+	require.NoError(t, c.SetCommitStatus(ctx, server.URL, "token", "group/app", "abc123", status),
+		"setting the same state again must succeed")
+
+	statusExists = false
+	status.State = CommitStateError
+	require.NoError(t, c.SetCommitStatus(ctx, server.URL, "token", "group/app", "abc123", status))
+	assert.Equal(t, "canceled", statusBody["state"], "GitLab has no error state")
+
+	commits, err := c.GetPullRequestCommits(ctx, server.URL, "token", "group/app", 5)
+	require.NoError(t, err)
+	require.Len(t, commits, commitsPageSize+1, "all pages must be listed")
+	assert.Equal(t, Commit{SHA: "a1", Message: "[APP-1] Fix"}, commits[commitsPageSize])
+
+	commits, err = c.GetCommits(ctx, server.URL, "token", "group/app", "base", "head")
+	require.NoError(t, err)
+	assert.Equal(t, []Commit{{SHA: "m1", Message: "Sync", Parents: 2}}, commits)
+
+	exists, err := c.IssueExists(ctx, server.URL, "token", "group/app", 12)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = c.IssueExists(ctx, server.URL, "token", "group/app", 13)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
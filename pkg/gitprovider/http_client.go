@@ -0,0 +1,348 @@
+package gitprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/internal/httpretry"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitconnection"
+)
+
+const (
+	// DefaultRequestsPerSecond and DefaultRequestBurst throttle the API requests made for a
+	// GitServer, well below the limits of GitHub (5000 requests per hour per token for
+	// most endpoints, plus secondary limits on bursts) and GitLab.
+	DefaultRequestsPerSecond = 5
+	DefaultRequestBurst      = 10
+
+	defaultMaxRetries     = 3
+	defaultRetryBaseDelay = time.Second
+
+	// maxRateLimitWait is the longest a request waits for the rate limit to reset. When the
+	// reset is further away the request fails with ErrRateLimited at once, so that
+	// reconcilers requeue instead of blocking their workers.
+	maxRateLimitWait = time.Minute
+)
+
+// ErrRateLimited is returned when the git provider rate limit is exhausted for longer than
+// a request can wait.
+var ErrRateLimited = errors.New("git provider API rate limit is exceeded")
+
+// HTTPClientPool hands out one HTTP client per GitServer, shared by every git provider API
+// call made for it, so that throttling and the rate limits the provider reports apply to all
+// of them together. The clients connect with the proxy and TLS settings of the GitServer.
+// A nil pool hands out unshared clients with the default settings and without throttling.
+type HTTPClientPool struct {
+	reader         client.Reader
+	mu             sync.Mutex
+	clients        map[string]*pooledClient
+	limit          rate.Limit
+	burst          int
+	maxRetries     int
+	retryBaseDelay time.Duration
+	transport      http.RoundTripper
+}
+
+type pooledClient struct {
+	client      *http.Client
+	nonRetrying *http.Client
+	transport   *rateLimitTransport
+	fingerprint string
+}
+
+// HTTPClientPoolOption configures an HTTPClientPool.
+type HTTPClientPoolOption func(*HTTPClientPool)
+
+// WithRequestRate sets the number of requests per second, and the burst, allowed for a GitServer.
+func WithRequestRate(requestsPerSecond float64, burst int) HTTPClientPoolOption {
+	return func(p *HTTPClientPool) {
+		p.limit = rate.Limit(requestsPerSecond)
+		p.burst = burst
+	}
+}
+
+// WithRetries sets how many times a throttled or failed request is retried, and the first
+// backoff delay, which doubles with every retry unless the provider sends Retry-After.
+func WithRetries(maxRetries int, baseDelay time.Duration) HTTPClientPoolOption {
+	return func(p *HTTPClientPool) {
+		p.maxRetries = maxRetries
+		p.retryBaseDelay = baseDelay
+	}
+}
+
+// WithTransport sets the transport the clients send requests with. GitServers with proxy or
+// TLS settings need an *http.Transport, which is cloned with the settings applied.
+func WithTransport(transport http.RoundTripper) HTTPClientPoolOption {
+	return func(p *HTTPClientPool) {
+		p.transport = transport
+	}
+}
+
+// NewHTTPClientPool creates a new HTTPClientPool. The reader gets the Secrets the GitServer
+// TLS settings refer to.
+func NewHTTPClientPool(reader client.Reader, opts ...HTTPClientPoolOption) *HTTPClientPool {
+	p := &HTTPClientPool{
+		reader:         reader,
+		clients:        make(map[string]*pooledClient),
+		limit:          DefaultRequestsPerSecond,
+		burst:          DefaultRequestBurst,
+		maxRetries:     defaultMaxRetries,
+		retryBaseDelay: defaultRetryBaseDelay,
+		transport:      http.DefaultTransport,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Client returns the HTTP client of the GitServer.
+func (p *HTTPClientPool) Client(ctx context.Context, gitServer *codebaseApi.GitServer) (*http.Client, error) {
+	c, _, err := p.get(ctx, gitServer)
+
+	return c, err
+}
+
+// NonRetryingClient returns an HTTP client that connects with the proxy and TLS settings of
+// the GitServer but neither throttles nor retries requests, for requests that must not be
+// sent twice.
+func (p *HTTPClientPool) NonRetryingClient(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+) (*http.Client, error) {
+	_, c, err := p.get(ctx, gitServer)
+
+	return c, err
+}
+
+// get returns the retrying and the non-retrying HTTP clients of the GitServer.
+func (p *HTTPClientPool) get(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+) (retrying, nonRetrying *http.Client, err error) {
+	if p == nil {
+		return &http.Client{}, &http.Client{}, nil
+	}
+
+	settings, err := gitconnection.Load(ctx, p.reader, gitServer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load GitServer connection settings: %w", err)
+	}
+
+	// The host is part of the key so that a GitServer moved to another host starts afresh.
+	key := fmt.Sprintf("%s/%s/%s", gitServer.Namespace, gitServer.Name, gitServer.Spec.GitHost)
+	fingerprint := settings.Fingerprint()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pooled, ok := p.clients[key]
+	if ok && pooled.fingerprint == fingerprint {
+		return pooled.client, pooled.nonRetrying, nil
+	}
+
+	base, err := p.baseTransport(settings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Changed settings replace the transport underneath, keeping the throttling state.
+	if ok {
+		pooled.transport.SetBase(base, p.transport)
+		pooled.nonRetrying = &http.Client{Transport: base}
+		pooled.fingerprint = fingerprint
+
+		return pooled.client, pooled.nonRetrying, nil
+	}
+
+	transport := newRateLimitTransport(base, gitServer.Namespace+"/"+gitServer.Name, p)
+
+	p.clients[key] = &pooledClient{
+		client:      &http.Client{Transport: transport},
+		nonRetrying: &http.Client{Transport: base},
+		transport:   transport,
+		fingerprint: fingerprint,
+	}
+
+	return p.clients[key].client, p.clients[key].nonRetrying, nil
+}
+
+// RestyClient returns a resty client that sends requests with the HTTP client of the GitServer.
+func (p *HTTPClientPool) RestyClient(ctx context.Context, gitServer *codebaseApi.GitServer) (*resty.Client, error) {
+	c, err := p.Client(ctx, gitServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return resty.NewWithClient(c), nil
+}
+
+// NewGitProjectProvider creates a new Git project provider that uses the HTTP client of the GitServer.
+func (p *HTTPClientPool) NewGitProjectProvider(
+	ctx context.Context,
+	gitServer *codebaseApi.GitServer,
+	token string,
+) (GitProjectProvider, error) {
+	restyClient, err := p.RestyClient(ctx, gitServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGitProjectProvider(gitServer, restyClient, token)
+}
+
+// Evict drops the clients of the GitServer, which is deleted, and closes their idle
+// connections.
+func (p *HTTPClientPool) Evict(namespace, name string) {
+	if p == nil {
+		return
+	}
+
+	prefix := namespace + "/" + name + "/"
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, pooled := range p.clients {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		delete(p.clients, key)
+		pooled.transport.CloseIdleConnections(p.transport)
+	}
+}
+
+func (p *HTTPClientPool) baseTransport(settings gitconnection.Settings) (http.RoundTripper, error) {
+	if settings.IsZero() {
+		return p.transport, nil
+	}
+
+	base, ok := p.transport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("proxy and TLS settings need an HTTP transport")
+	}
+
+	transport, err := settings.Transport(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply GitServer connection settings: %w", err)
+	}
+
+	return transport, nil
+}
+
+// rateLimitTransport throttles the requests made for a GitServer, holds them back while the
+// provider reports the rate limit as exhausted, retries throttled and failed requests and
+// records metrics about them.
+type rateLimitTransport struct {
+	*httpretry.Transport
+
+	gitServer string
+}
+
+func newRateLimitTransport(base http.RoundTripper, gitServer string, p *HTTPClientPool) *rateLimitTransport {
+	t := &rateLimitTransport{gitServer: gitServer}
+
+	t.Transport = httpretry.New(base, httpretry.Options{
+		Limit:          p.limit,
+		Burst:          p.burst,
+		MaxRetries:     p.maxRetries,
+		RetryBaseDelay: p.retryBaseDelay,
+		MaxWait:        maxRateLimitWait,
+		ErrRateLimited: ErrRateLimited,
+		// GitHub answers with 403 when the rate limit is exhausted.
+		IsThrottled: func(resp *http.Response) bool {
+			return resp.StatusCode == http.StatusForbidden && isRateLimited(resp.Header)
+		},
+		RetryAfter: rateLimitReset,
+		OnResponse: t.observeResponse,
+		OnRetry: func(_ *http.Request, resp *http.Response) {
+			retriesTotal.WithLabelValues(t.gitServer, strconv.Itoa(resp.StatusCode)).Inc()
+		},
+	})
+
+	return t
+}
+
+func (t *rateLimitTransport) observeResponse(req *http.Request, resp *http.Response, err error, took time.Duration) {
+	if err != nil {
+		requestsTotal.WithLabelValues(t.gitServer, req.Method, "error").Inc()
+
+		return
+	}
+
+	requestDuration.WithLabelValues(t.gitServer, req.Method).Observe(took.Seconds())
+	requestsTotal.WithLabelValues(t.gitServer, req.Method, strconv.Itoa(resp.StatusCode)).Inc()
+
+	t.observeRateLimit(resp)
+}
+
+// observeRateLimit holds back further requests when the provider reports that no requests
+// are left until the rate limit resets. GitHub sends X-RateLimit-* headers, GitLab RateLimit-*.
+func (t *rateLimitTransport) observeRateLimit(resp *http.Response) {
+	remaining, reset, ok := parseRateLimitHeaders(resp.Header)
+	if !ok {
+		return
+	}
+
+	rateLimitRemaining.WithLabelValues(t.gitServer).Set(float64(remaining))
+
+	if remaining == 0 {
+		t.PauseUntil(reset)
+	}
+}
+
+// rateLimitReset returns how long until the rate limit resets when no requests are left.
+func rateLimitReset(resp *http.Response) (time.Duration, bool) {
+	remaining, reset, ok := parseRateLimitHeaders(resp.Header)
+	if !ok || remaining > 0 || reset.IsZero() {
+		return 0, false
+	}
+
+	return time.Until(reset), true
+}
+
+func parseRateLimitHeaders(header http.Header) (remaining int64, reset time.Time, ok bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		value := header.Get(prefix + "Remaining")
+		if value == "" {
+			continue
+		}
+
+		remaining, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, time.Time{}, false
+		}
+
+		if resetUnix, err := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64); err == nil {
+			reset = time.Unix(resetUnix, 0)
+		}
+
+		return remaining, reset, true
+	}
+
+	return 0, time.Time{}, false
+}
+
+func isRateLimited(header http.Header) bool {
+	if header.Get("Retry-After") != "" {
+		return true
+	}
+
+	remaining, _, ok := parseRateLimitHeaders(header)
+
+	return ok && remaining == 0
+}
@@ -0,0 +1,273 @@
+package gitprovider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+const testAPIURL = "https://api.example.com/resource"
+
+func newTestGitServer(name string) *codebaseApi.GitServer {
+	return &codebaseApi.GitServer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       codebaseApi.GitServerSpec{GitHost: "example.com"},
+	}
+}
+
+func TestHTTPClientPool_Client(t *testing.T) {
+	t.Parallel()
+
+	pool := NewHTTPClientPool(nil)
+
+	first := mustClient(t, pool, newTestGitServer("github"))
+
+	assert.Same(t, first, mustClient(t, pool, newTestGitServer("github")), "a GitServer must share its client")
+	assert.NotSame(t, first, mustClient(t, pool, newTestGitServer("gitlab")))
+
+	var nilPool *HTTPClientPool
+
+	assert.NotNil(t, mustClient(t, nilPool, newTestGitServer("github")))
+}
+
+func TestHTTPClientPool_Evict(t *testing.T) {
+	t.Parallel()
+
+	pool := NewHTTPClientPool(nil)
+
+	first := mustClient(t, pool, newTestGitServer("github"))
+	other := mustClient(t, pool, newTestGitServer("gitlab"))
+
+	pool.Evict("default", "github")
+
+	assert.NotSame(t, first, mustClient(t, pool, newTestGitServer("github")), "a deleted GitServer must start afresh")
+	assert.Same(t, other, mustClient(t, pool, newTestGitServer("gitlab")))
+
+	var nilPool *HTTPClientPool
+
+	nilPool.Evict("default", "github")
+}
+
+func TestHTTPClientPool_Client_ConnectionSettings(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "git-ca"},
+		Data:       map[string][]byte{"ca.crt": caBundle},
+	}).Build()
+
+	pool := NewHTTPClientPool(k8sClient)
+	gitServer := newTestGitServer("gitlab")
+
+	resp, err := mustClient(t, pool, gitServer).Get(server.URL) //nolint:noctx // test request
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+
+	require.Error(t, err, "the private CA must not be trusted by default")
+
+	gitServer.Spec.TLS = &codebaseApi.GitServerTLS{
+		CABundleSecretRef: &corev1.LocalObjectReference{Name: "git-ca"},
+	}
+
+	resp, err = mustClient(t, pool, gitServer).Get(server.URL) //nolint:noctx // test request
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	gitServer.Spec.TLS.CABundleSecretRef.Name = "missing"
+
+	_, err = pool.Client(context.Background(), gitServer)
+	require.ErrorContains(t, err, "failed to get secret missing")
+}
+
+func TestRateLimitTransport_Retries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		method    string
+		responses []*http.Response
+		wantCode  int
+		wantCalls int
+	}{
+		{
+			name:   "too many requests with Retry-After",
+			method: http.MethodPost,
+			responses: []*http.Response{
+				responseWithHeaders(http.StatusTooManyRequests, map[string]string{"Retry-After": "0"}),
+				httpmock.NewStringResponse(http.StatusCreated, ""),
+			},
+			wantCode:  http.StatusCreated,
+			wantCalls: 2,
+		},
+		{
+			name:   "GitHub rate limit",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				responseWithHeaders(http.StatusForbidden, map[string]string{
+					"X-RateLimit-Remaining": "0",
+					"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Unix(), 10),
+				}),
+				httpmock.NewStringResponse(http.StatusOK, ""),
+			},
+			wantCode:  http.StatusOK,
+			wantCalls: 2,
+		},
+		{
+			name:   "forbidden without rate limit",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				httpmock.NewStringResponse(http.StatusForbidden, ""),
+			},
+			wantCode:  http.StatusForbidden,
+			wantCalls: 1,
+		},
+		{
+			name:   "server error of idempotent request",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				httpmock.NewStringResponse(http.StatusBadGateway, ""),
+				httpmock.NewStringResponse(http.StatusBadGateway, ""),
+				httpmock.NewStringResponse(http.StatusBadGateway, ""),
+			},
+			wantCode:  http.StatusBadGateway,
+			wantCalls: 3,
+		},
+		{
+			name:   "server error of non-idempotent request",
+			method: http.MethodPost,
+			responses: []*http.Response{
+				httpmock.NewStringResponse(http.StatusInternalServerError, ""),
+			},
+			wantCode:  http.StatusInternalServerError,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := httpmock.NewMockTransport()
+			calls := 0
+
+			transport.RegisterResponder(tt.method, testAPIURL, func(req *http.Request) (*http.Response, error) {
+				resp := tt.responses[min(calls, len(tt.responses)-1)]
+				calls++
+
+				return resp, nil
+			})
+
+			pool := NewHTTPClientPool(nil, WithTransport(transport), WithRetries(2, time.Millisecond))
+
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, testAPIURL, strings.NewReader("{}"))
+			require.NoError(t, err)
+
+			resp, err := mustClient(t, pool, newTestGitServer("git")).Do(req)
+			require.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantCode, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestRateLimitTransport_RetriesRequestWithoutBody(t *testing.T) {
+	t.Parallel()
+
+	transport := httpmock.NewMockTransport()
+	calls := 0
+
+	transport.RegisterResponder(http.MethodGet, testAPIURL, func(*http.Request) (*http.Response, error) {
+		calls++
+
+		if calls == 1 {
+			return httpmock.NewStringResponse(http.StatusBadGateway, ""), nil
+		}
+
+		return httpmock.NewStringResponse(http.StatusOK, ""), nil
+	})
+
+	pool := NewHTTPClientPool(nil, WithTransport(transport), WithRetries(2, time.Millisecond))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, testAPIURL, http.NoBody)
+	require.NoError(t, err)
+	require.Nil(t, req.GetBody)
+
+	resp, err := mustClient(t, pool, newTestGitServer("git")).Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, calls)
+}
+
+func TestRateLimitTransport_ExhaustedRateLimit(t *testing.T) {
+	t.Parallel()
+
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder(http.MethodGet, testAPIURL, httpmock.ResponderFromResponse(
+		responseWithHeaders(http.StatusOK, map[string]string{
+			"RateLimit-Remaining": "0",
+			"RateLimit-Reset":     strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+		}),
+	))
+
+	client := mustClient(t, NewHTTPClientPool(nil, WithTransport(transport)), newTestGitServer("gitlab"))
+
+	resp, err := client.Get(testAPIURL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	_, err = client.Get(testAPIURL) //nolint:bodyclose // the request must fail
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 1, transport.GetTotalCallCount(), "requests must be held back until the rate limit resets")
+}
+
+func mustClient(t *testing.T, pool *HTTPClientPool, gitServer *codebaseApi.GitServer) *http.Client {
+	t.Helper()
+
+	c, err := pool.Client(context.Background(), gitServer)
+	require.NoError(t, err)
+
+	return c
+}
+
+func responseWithHeaders(code int, headers map[string]string) *http.Response {
+	resp := httpmock.NewStringResponse(code, "")
+
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+
+	return resp
+}
@@ -0,0 +1,33 @@
+package gitprovider
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "codebase_operator_git_provider_requests_total",
+		Help: "Number of git provider API requests by GitServer, method and response code.",
+	}, []string{"git_server", "method", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "codebase_operator_git_provider_request_duration_seconds",
+		Help:    "Duration of git provider API requests by GitServer and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"git_server", "method"})
+
+	retriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "codebase_operator_git_provider_retries_total",
+		Help: "Number of retried git provider API requests by GitServer and response code.",
+	}, []string{"git_server", "code"})
+
+	rateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "codebase_operator_git_provider_rate_limit_remaining",
+		Help: "Number of git provider API requests left in the current rate limit window by GitServer.",
+	}, []string{"git_server"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsTotal, requestDuration, retriesTotal, rateLimitRemaining)
+}
@@ -395,6 +395,92 @@ func (_c *MockGitWebHookProvider_GetWebHook_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// GetWebHookDeliveries provides a mock function for the type MockGitWebHookProvider
+func (_mock *MockGitWebHookProvider) GetWebHookDeliveries(ctx context.Context, gitProviderURL string, token string, projectID string, webHookRef string) ([]gitprovider.WebHookDelivery, error) {
+	ret := _mock.Called(ctx, gitProviderURL, token, projectID, webHookRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebHookDeliveries")
+	}
+
+	var r0 []gitprovider.WebHookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) ([]gitprovider.WebHookDelivery, error)); ok {
+		return returnFunc(ctx, gitProviderURL, token, projectID, webHookRef)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) []gitprovider.WebHookDelivery); ok {
+		r0 = returnFunc(ctx, gitProviderURL, token, projectID, webHookRef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gitprovider.WebHookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, gitProviderURL, token, projectID, webHookRef)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGitWebHookProvider_GetWebHookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebHookDeliveries'
+type MockGitWebHookProvider_GetWebHookDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetWebHookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - gitProviderURL string
+//   - token string
+//   - projectID string
+//   - webHookRef string
+func (_e *MockGitWebHookProvider_Expecter) GetWebHookDeliveries(ctx interface{}, gitProviderURL interface{}, token interface{}, projectID interface{}, webHookRef interface{}) *MockGitWebHookProvider_GetWebHookDeliveries_Call {
+	return &MockGitWebHookProvider_GetWebHookDeliveries_Call{Call: _e.mock.On("GetWebHookDeliveries", ctx, gitProviderURL, token, projectID, webHookRef)}
+}
+
+func (_c *MockGitWebHookProvider_GetWebHookDeliveries_Call) Run(run func(ctx context.Context, gitProviderURL string, token string, projectID string, webHookRef string)) *MockGitWebHookProvider_GetWebHookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitWebHookProvider_GetWebHookDeliveries_Call) Return(webHookDeliverys []gitprovider.WebHookDelivery, err error) *MockGitWebHookProvider_GetWebHookDeliveries_Call {
+	_c.Call.Return(webHookDeliverys, err)
+	return _c
+}
+
+func (_c *MockGitWebHookProvider_GetWebHookDeliveries_Call) RunAndReturn(run func(ctx context.Context, gitProviderURL string, token string, projectID string, webHookRef string) ([]gitprovider.WebHookDelivery, error)) *MockGitWebHookProvider_GetWebHookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetWebHooks provides a mock function for the type MockGitWebHookProvider
 func (_mock *MockGitWebHookProvider) GetWebHooks(ctx context.Context, githubURL string, token string, projectID string) ([]*gitprovider.WebHook, error) {
 	ret := _mock.Called(ctx, githubURL, token, projectID)
@@ -474,3 +560,107 @@ func (_c *MockGitWebHookProvider_GetWebHooks_Call) RunAndReturn(run func(ctx con
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateWebHook provides a mock function for the type MockGitWebHookProvider
+func (_mock *MockGitWebHookProvider) UpdateWebHook(ctx context.Context, gitProviderURL string, token string, projectID string, webHookRef string, webHookSecret string, webHookURL string, skipTLS bool) (*gitprovider.WebHook, error) {
+	ret := _mock.Called(ctx, gitProviderURL, token, projectID, webHookRef, webHookSecret, webHookURL, skipTLS)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebHook")
+	}
+
+	var r0 *gitprovider.WebHook
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string, bool) (*gitprovider.WebHook, error)); ok {
+		return returnFunc(ctx, gitProviderURL, token, projectID, webHookRef, webHookSecret, webHookURL, skipTLS)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, string, bool) *gitprovider.WebHook); ok {
+		r0 = returnFunc(ctx, gitProviderURL, token, projectID, webHookRef, webHookSecret, webHookURL, skipTLS)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gitprovider.WebHook)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, string, bool) error); ok {
+		r1 = returnFunc(ctx, gitProviderURL, token, projectID, webHookRef, webHookSecret, webHookURL, skipTLS)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGitWebHookProvider_UpdateWebHook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebHook'
+type MockGitWebHookProvider_UpdateWebHook_Call struct {
+	*mock.Call
+}
+
+// UpdateWebHook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - gitProviderURL string
+//   - token string
+//   - projectID string
+//   - webHookRef string
+//   - webHookSecret string
+//   - webHookURL string
+//   - skipTLS bool
+func (_e *MockGitWebHookProvider_Expecter) UpdateWebHook(ctx interface{}, gitProviderURL interface{}, token interface{}, projectID interface{}, webHookRef interface{}, webHookSecret interface{}, webHookURL interface{}, skipTLS interface{}) *MockGitWebHookProvider_UpdateWebHook_Call {
+	return &MockGitWebHookProvider_UpdateWebHook_Call{Call: _e.mock.On("UpdateWebHook", ctx, gitProviderURL, token, projectID, webHookRef, webHookSecret, webHookURL, skipTLS)}
+}
+
+func (_c *MockGitWebHookProvider_UpdateWebHook_Call) Run(run func(ctx context.Context, gitProviderURL string, token string, projectID string, webHookRef string, webHookSecret string, webHookURL string, skipTLS bool)) *MockGitWebHookProvider_UpdateWebHook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
+		}
+		var arg7 bool
+		if args[7] != nil {
+			arg7 = args[7].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+			arg7,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGitWebHookProvider_UpdateWebHook_Call) Return(webHook *gitprovider.WebHook, err error) *MockGitWebHookProvider_UpdateWebHook_Call {
+	_c.Call.Return(webHook, err)
+	return _c
+}
+
+func (_c *MockGitWebHookProvider_UpdateWebHook_Call) RunAndReturn(run func(ctx context.Context, gitProviderURL string, token string, projectID string, webHookRef string, webHookSecret string, webHookURL string, skipTLS bool) (*gitprovider.WebHook, error)) *MockGitWebHookProvider_UpdateWebHook_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -2,8 +2,13 @@ package gitprovider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 
@@ -44,6 +49,23 @@ type GitWebHookProvider interface {
 		token,
 		projectID string,
 	) ([]*WebHook, error)
+	UpdateWebHook(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID,
+		webHookRef,
+		webHookSecret,
+		webHookURL string,
+		skipTLS bool,
+	) (*WebHook, error)
+	GetWebHookDeliveries(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID,
+		webHookRef string,
+	) ([]WebHookDelivery, error)
 	DeleteWebHook(
 		ctx context.Context,
 		gitProviderURL,
@@ -77,6 +99,110 @@ type GitProjectProvider interface {
 	) error
 }
 
+// GitIssueProvider is an interface for the issues of a Git project.
+// Issues are referenced by their number in the project.
+type GitIssueProvider interface {
+	CreateIssueComment(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID string,
+		issue int,
+		body string,
+	) error
+	AddIssueLabels(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID string,
+		issue int,
+		labels []string,
+	) error
+	// SetIssueMilestone sets the milestone of the issue. The milestone is created if the project does not have it.
+	SetIssueMilestone(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID string,
+		issue int,
+		milestone string,
+	) error
+	// IssueExists reports whether the project has the issue.
+	IssueExists(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID string,
+		issue int,
+	) (bool, error)
+}
+
+// The longest status descriptions the git providers accept.
+const (
+	gitHubStatusDescriptionLength    = 140
+	gitLabStatusDescriptionLength    = 255
+	bitbucketStatusDescriptionLength = 255
+)
+
+// Commit states of a CommitStatus. CommitStateError reports a check that could not be
+// completed; GitLab and Bitbucket, which have no such state, show it as canceled and stopped.
+const (
+	CommitStatePending = "pending"
+	CommitStateSuccess = "success"
+	CommitStateFailure = "failure"
+	CommitStateError   = "error"
+)
+
+// CommitStatus is a status of a commit, shown by the git provider on the commit and on the
+// pull requests whose head it is.
+type CommitStatus struct {
+	// State is CommitStatePending, CommitStateSuccess, CommitStateFailure or CommitStateError.
+	State string
+	// Context identifies the status among the statuses of the commit, e.g. edp/commit-policy.
+	Context     string
+	Description string
+	// TargetURL is the link of the status. Bitbucket requires it, and links to the commit if it is empty.
+	TargetURL string
+}
+
+// Commit is a commit of a Git project.
+type Commit struct {
+	SHA     string
+	Message string
+	// Parents is the number of parents of the commit. Merge commits have more than one.
+	Parents int
+}
+
+// GitCommitProvider is an interface for the commits of a Git project.
+type GitCommitProvider interface {
+	// SetCommitStatus creates or updates the status of the commit with the same context.
+	SetCommitStatus(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID,
+		sha string,
+		status CommitStatus,
+	) error
+	// GetPullRequestCommits returns the commits of the pull request.
+	GetPullRequestCommits(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID string,
+		pullRequest int,
+	) ([]Commit, error)
+	// GetCommits returns the commits reachable from the commit to but not from the commit from.
+	GetCommits(
+		ctx context.Context,
+		gitProviderURL,
+		token,
+		projectID,
+		from,
+		to string,
+	) ([]Commit, error)
+}
+
 type RepositorySettings struct {
 	IsPrivate bool
 }
@@ -102,15 +228,51 @@ func NewProvider(gitServer *codebaseApi.GitServer, restyClient *resty.Client, to
 	case codebaseApi.GitProviderGitlab:
 		return NewGitLabClient(restyClient), nil
 	case codebaseApi.GitProviderBitbucket:
-		return NewBitbucketClient(token)
+		return NewBitbucketClient(token, WithBitbucketHTTPClient(restyClient.GetClient()))
 	default:
 		return nil, fmt.Errorf("unsupported git provider %s", gitServer.Spec.GitProvider)
 	}
 }
 
+// NewGitIssueProvider creates a new Git issue provider based on gitServer.
+func NewGitIssueProvider(gitServer *codebaseApi.GitServer, restyClient *resty.Client) (GitIssueProvider, error) {
+	switch gitServer.Spec.GitProvider {
+	case codebaseApi.GitProviderGithub:
+		return NewGitHubClient(restyClient), nil
+	case codebaseApi.GitProviderGitlab:
+		return NewGitLabClient(restyClient), nil
+	default:
+		return nil, fmt.Errorf("git provider %s does not support issues", gitServer.Spec.GitProvider)
+	}
+}
+
+// NewGitCommitProvider creates a new Git commit provider based on gitServer.
+func NewGitCommitProvider(
+	gitServer *codebaseApi.GitServer,
+	restyClient *resty.Client,
+	token string,
+) (GitCommitProvider, error) {
+	switch gitServer.Spec.GitProvider {
+	case codebaseApi.GitProviderGithub:
+		return NewGitHubClient(restyClient), nil
+	case codebaseApi.GitProviderGitlab:
+		return NewGitLabClient(restyClient), nil
+	case codebaseApi.GitProviderBitbucket:
+		return NewBitbucketClient(token, WithBitbucketHTTPClient(restyClient.GetClient()))
+	default:
+		return nil, fmt.Errorf("git provider %s does not support commit statuses", gitServer.Spec.GitProvider)
+	}
+}
+
 // NewGitProjectProvider creates a new Git project provider based on gitServer.
-func NewGitProjectProvider(gitServer *codebaseApi.GitServer, token string) (GitProjectProvider, error) {
-	return NewProvider(gitServer, resty.New(), token)
+// The restyClient should send requests with the HTTP client of the GitServer,
+// see HTTPClientPool.NewGitProjectProvider.
+func NewGitProjectProvider(
+	gitServer *codebaseApi.GitServer,
+	restyClient *resty.Client,
+	token string,
+) (GitProjectProvider, error) {
+	return NewProvider(gitServer, restyClient, token)
 }
 
 // GetGitProviderAPIURL returns git server url with protocol.
@@ -139,6 +301,16 @@ func GetGitProviderAPIURL(gitServer *codebaseApi.GitServer) string {
 	return url
 }
 
+// truncate shortens the text to the length, in runes, git providers accept for status descriptions.
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	return string(runes[:length-1]) + "…"
+}
+
 func parseProjectID(projectID string) (owner, repo string, err error) {
 	parts := strings.Split(projectID, "/")
 	if len(parts) != 2 {
@@ -151,4 +323,119 @@ func parseProjectID(projectID string) (owner, repo string, err error) {
 type WebHook struct {
 	ID  string `json:"id"`
 	URL string `json:"url"`
+
+	// Events are the events the webhook is subscribed to, in the notation of the git provider.
+	Events []string `json:"events,omitempty"`
+
+	// SkipTLS is true if the git provider does not verify the TLS certificate of the URL.
+	SkipTLS bool `json:"skipTLS,omitempty"`
+
+	// Active is false if the webhook is disabled in the git provider.
+	Active bool `json:"active"`
+}
+
+// webHookDeliveriesPageSize is the number of recent deliveries requested from git providers.
+const webHookDeliveriesPageSize = 30
+
+// WebHookDelivery is the result of a delivery of a webhook.
+type WebHookDelivery struct {
+	ID string
+
+	// Event is the event type, in the notation of the git provider.
+	Event string
+
+	// StatusCode is the HTTP status code the receiver responded with,
+	// 0 if the git provider could not connect to it.
+	StatusCode int
+
+	// Status is the description of the result by the git provider, if any.
+	Status string
+
+	// DeliveredAt is zero if the git provider does not report the time.
+	DeliveredAt time.Time
+}
+
+// Succeeded reports whether the receiver accepted the delivery.
+func (d *WebHookDelivery) Succeeded() bool {
+	return d.StatusCode >= http.StatusOK && d.StatusCode < http.StatusMultipleChoices
+}
+
+// Webhook settings that can drift from the ones the operator configures.
+const (
+	WebHookDriftMissing  = "missing"
+	WebHookDriftURL      = "url"
+	WebHookDriftEvents   = "events"
+	WebHookDriftSSL      = "sslVerification"
+	WebHookDriftInactive = "inactive"
+	WebHookDriftSecret   = "secret"
+)
+
+var (
+	gitHubWebHookEvents = []string{"pull_request", "push", "issue_comment"}
+	gitLabWebHookEvents = []string{"merge_requests_events", "note_events"}
+
+	bitbucketWebHookEvents = []string{
+		"pullrequest:created",
+		"pullrequest:updated",
+		"pullrequest:fulfilled",
+		"pullrequest:comment_created",
+		"pullrequest:comment_updated",
+	}
+)
+
+// WebHookEvents returns the events the operator subscribes webhooks to,
+// in the notation of the git provider.
+func WebHookEvents(gitProvider string) []string {
+	switch gitProvider {
+	case codebaseApi.GitProviderGithub:
+		return slices.Clone(gitHubWebHookEvents)
+	case codebaseApi.GitProviderGitlab:
+		return slices.Clone(gitLabWebHookEvents)
+	case codebaseApi.GitProviderBitbucket:
+		return slices.Clone(bitbucketWebHookEvents)
+	default:
+		return nil
+	}
+}
+
+// WebHookDrift returns the settings of the webhook that differ from the ones the operator
+// configures. The secret is not returned by git providers and is not compared.
+func WebHookDrift(gitProvider string, webHook *WebHook, webHookURL string, skipTLS bool) []string {
+	if webHook == nil {
+		return []string{WebHookDriftMissing}
+	}
+
+	var drift []string
+
+	if webHook.URL != webHookURL {
+		drift = append(drift, WebHookDriftURL)
+	}
+
+	events := slices.Clone(webHook.Events)
+	slices.Sort(events)
+
+	wantEvents := WebHookEvents(gitProvider)
+	slices.Sort(wantEvents)
+
+	if !slices.Equal(events, wantEvents) {
+		drift = append(drift, WebHookDriftEvents)
+	}
+
+	if webHook.SkipTLS != skipTLS {
+		drift = append(drift, WebHookDriftSSL)
+	}
+
+	if !webHook.Active {
+		drift = append(drift, WebHookDriftInactive)
+	}
+
+	return drift
+}
+
+// WebHookSecretHash returns a hash of the webhook secret, so that a change of the secret
+// can be detected without storing the secret itself.
+func WebHookSecretHash(webHookSecret string) string {
+	sum := sha256.Sum256([]byte(webHookSecret))
+
+	return hex.EncodeToString(sum[:8])
 }
@@ -125,3 +125,53 @@ func Test_getGitProviderAPIURL(t *testing.T) {
 		})
 	}
 }
+
+func TestWebHookDrift(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		webHook *WebHook
+		want    []string
+	}{
+		{
+			name: "in sync",
+			webHook: &WebHook{
+				URL:    "https://el.example.com",
+				Events: []string{"note_events", "merge_requests_events"},
+				Active: true,
+			},
+			want: nil,
+		},
+		{
+			name:    "missing",
+			webHook: nil,
+			want:    []string{WebHookDriftMissing},
+		},
+		{
+			name: "everything drifted",
+			webHook: &WebHook{
+				URL:     "https://old.example.com",
+				Events:  []string{"push_events", "merge_requests_events"},
+				SkipTLS: true,
+			},
+			want: []string{WebHookDriftURL, WebHookDriftEvents, WebHookDriftSSL, WebHookDriftInactive},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, WebHookDrift(codebaseApi.GitProviderGitlab, tt.webHook, "https://el.example.com", false))
+		})
+	}
+}
+
+func TestWebHookSecretHash(t *testing.T) {
+	t.Parallel()
+
+	assert.Len(t, WebHookSecretHash("secret"), 16)
+	assert.Equal(t, WebHookSecretHash("secret"), WebHookSecretHash("secret"))
+	assert.NotEqual(t, WebHookSecretHash("secret"), WebHookSecretHash("rotated"))
+}
@@ -0,0 +1,120 @@
+package issuetracker
+
+import (
+	"context"
+	"fmt"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+	"github.com/epam/edp-codebase-operator/v2/pkg/util"
+)
+
+// Factory creates the issue trackers of codebases.
+type Factory struct {
+	client      client.Client
+	httpClients *gitprovider.HTTPClientPool
+	jiraClients *jira.ClientPool
+}
+
+func NewFactory(
+	k8sClient client.Client,
+	httpClients *gitprovider.HTTPClientPool,
+	jiraClients *jira.ClientPool,
+) *Factory {
+	return &Factory{
+		client:      k8sClient,
+		httpClients: httpClients,
+		jiraClients: jiraClients,
+	}
+}
+
+// New returns the issue tracker of the codebase.
+// GitHub and GitLab trackers use the credentials of the GitServer of the codebase.
+func (f *Factory) New(ctx context.Context, codebase *codebaseApi.Codebase) (Tracker, error) {
+	switch codebase.Spec.GetIssueTracker() {
+	case codebaseApi.IssueTrackerJira:
+		return f.newJiraTracker(ctx, codebase)
+	case codebaseApi.IssueTrackerGitHub, codebaseApi.IssueTrackerGitLab:
+		return f.newGitTracker(ctx, codebase)
+	case "":
+		return nil, ErrNoIssueTracker
+	default:
+		return nil, fmt.Errorf("unsupported issue tracker %q", codebase.Spec.IssueTracker)
+	}
+}
+
+func (f *Factory) newJiraTracker(ctx context.Context, codebase *codebaseApi.Codebase) (Tracker, error) {
+	if codebase.Spec.JiraServer == nil || *codebase.Spec.JiraServer == "" {
+		return nil, fmt.Errorf("codebase %s uses jira issue tracker without jiraServer", codebase.Name)
+	}
+
+	js := &codebaseApi.JiraServer{}
+	if err := f.client.Get(ctx, types.NamespacedName{
+		Namespace: codebase.Namespace,
+		Name:      *codebase.Spec.JiraServer,
+	}, js); err != nil {
+		return nil, fmt.Errorf("failed to get JiraServer: %w", err)
+	}
+
+	server, err := jira.ServerFromSecret(ctx, f.client, js)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Jira credentials: %w", err)
+	}
+
+	jc, err := f.jiraClients.Client(js, server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Jira client: %w", err)
+	}
+
+	return NewJiraTracker(jc), nil
+}
+
+func (f *Factory) newGitTracker(ctx context.Context, codebase *codebaseApi.Codebase) (Tracker, error) {
+	gitServer := &codebaseApi.GitServer{}
+	if err := f.client.Get(ctx, types.NamespacedName{
+		Namespace: codebase.Namespace,
+		Name:      codebase.Spec.GitServer,
+	}, gitServer); err != nil {
+		return nil, fmt.Errorf("failed to get GitServer: %w", err)
+	}
+
+	if gitServer.Spec.GitProvider != codebase.Spec.IssueTracker {
+		return nil, fmt.Errorf("issue tracker %s does not match git provider %s of GitServer %s",
+			codebase.Spec.IssueTracker, gitServer.Spec.GitProvider, gitServer.Name)
+	}
+
+	secret := &coreV1.Secret{}
+	if err := f.client.Get(ctx, types.NamespacedName{
+		Namespace: codebase.Namespace,
+		Name:      gitServer.Spec.NameSshKeySecret,
+	}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get GitServer secret: %w", err)
+	}
+
+	token := string(secret.Data[util.GitServerSecretTokenField])
+	if token == "" {
+		return nil, fmt.Errorf("no %s key in secret %s", util.GitServerSecretTokenField, secret.Name)
+	}
+
+	restyClient, err := f.httpClients.RestyClient(ctx, gitServer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git provider HTTP client: %w", err)
+	}
+
+	provider, err := gitprovider.NewGitIssueProvider(gitServer, restyClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGitTracker(
+		provider,
+		gitprovider.GetGitProviderAPIURL(gitServer),
+		token,
+		codebase.Spec.GetProjectID(),
+	), nil
+}
@@ -0,0 +1,115 @@
+package issuetracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+)
+
+func TestFactory_New(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, coreV1.AddToScheme(scheme))
+
+	gitServer := &codebaseApi.GitServer{
+		ObjectMeta: metaV1.ObjectMeta{Name: "github", Namespace: "default"},
+		Spec: codebaseApi.GitServerSpec{
+			GitHost:          "github.com",
+			GitProvider:      codebaseApi.GitProviderGithub,
+			NameSshKeySecret: "github-secret",
+		},
+	}
+
+	codebase := func(issueTracker string, token string) (*codebaseApi.Codebase, *coreV1.Secret) {
+		return &codebaseApi.Codebase{
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: codebaseApi.CodebaseSpec{
+				GitServer:    "github",
+				GitUrlPath:   "/owner/app",
+				IssueTracker: issueTracker,
+			},
+		}, &coreV1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{Name: "github-secret", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte(token)},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		issueTracker string
+		token        string
+		want         Tracker
+		wantErr      string
+	}{
+		{
+			name:         "github issues",
+			issueTracker: codebaseApi.IssueTrackerGitHub,
+			token:        "token",
+			want: &GitTracker{
+				apiURL:    "https://api.github.com",
+				token:     "token",
+				projectID: "owner/app",
+			},
+		},
+		{
+			name:         "issue tracker does not match git provider",
+			issueTracker: codebaseApi.IssueTrackerGitLab,
+			token:        "token",
+			wantErr:      "issue tracker gitlab does not match git provider github of GitServer github",
+		},
+		{
+			name:         "secret without token",
+			issueTracker: codebaseApi.IssueTrackerGitHub,
+			wantErr:      "no token key in secret github-secret",
+		},
+		{
+			name:    "no issue tracker",
+			wantErr: ErrNoIssueTracker.Error(),
+		},
+		{
+			name:         "jira without jira server",
+			issueTracker: codebaseApi.IssueTrackerJira,
+			wantErr:      "codebase app uses jira issue tracker without jiraServer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cb, secret := codebase(tt.issueTracker, tt.token)
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitServer, secret).Build()
+
+			factory := NewFactory(k8sClient, gitprovider.NewHTTPClientPool(k8sClient), jira.NewClientPool())
+
+			got, err := factory.New(context.Background(), cb)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			gitTracker, ok := got.(*GitTracker)
+			require.True(t, ok)
+			assert.NotNil(t, gitTracker.provider)
+
+			gitTracker.provider = nil
+			assert.Equal(t, tt.want, gitTracker)
+		})
+	}
+}
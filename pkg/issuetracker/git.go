@@ -0,0 +1,66 @@
+package issuetracker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/epam/edp-codebase-operator/v2/pkg/gitprovider"
+)
+
+// GitTracker updates the GitHub or GitLab issues of a repository.
+type GitTracker struct {
+	provider  gitprovider.GitIssueProvider
+	apiURL    string
+	token     string
+	projectID string
+}
+
+func NewGitTracker(provider gitprovider.GitIssueProvider, apiURL, token, projectID string) *GitTracker {
+	return &GitTracker{
+		provider:  provider,
+		apiURL:    apiURL,
+		token:     token,
+		projectID: projectID,
+	}
+}
+
+// LinkBuild comments the link to the build on the issue, because GitHub and GitLab issues have no remote links.
+func (t *GitTracker) LinkBuild(ctx context.Context, issue, title, url string) error {
+	return t.Comment(ctx, issue, fmt.Sprintf("[%s](%s)", title, url))
+}
+
+func (t *GitTracker) SetMilestone(ctx context.Context, issue, milestone string) error {
+	number, err := IssueNumber(issue)
+	if err != nil {
+		return err
+	}
+
+	return t.provider.SetIssueMilestone(ctx, t.apiURL, t.token, t.projectID, number, milestone)
+}
+
+func (t *GitTracker) AddLabels(ctx context.Context, issue string, labels []string) error {
+	number, err := IssueNumber(issue)
+	if err != nil {
+		return err
+	}
+
+	return t.provider.AddIssueLabels(ctx, t.apiURL, t.token, t.projectID, number, labels)
+}
+
+func (t *GitTracker) Comment(ctx context.Context, issue, body string) error {
+	number, err := IssueNumber(issue)
+	if err != nil {
+		return err
+	}
+
+	return t.provider.CreateIssueComment(ctx, t.apiURL, t.token, t.projectID, number, body)
+}
+
+func (t *GitTracker) IssueExists(ctx context.Context, issue string) (bool, error) {
+	number, err := IssueNumber(issue)
+	if err != nil {
+		return false, err
+	}
+
+	return t.provider.IssueExists(ctx, t.apiURL, t.token, t.projectID, number)
+}
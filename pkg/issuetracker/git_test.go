@@ -0,0 +1,41 @@
+package issuetracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	gitproviderMocks "github.com/epam/edp-codebase-operator/v2/pkg/gitprovider/mocks"
+)
+
+func TestGitTracker(t *testing.T) {
+	t.Parallel()
+
+	const (
+		apiURL    = "https://api.github.com"
+		token     = "token"
+		projectID = "owner/repo"
+	)
+
+	provider := gitproviderMocks.NewMockGitIssueProvider(t)
+	provider.On("CreateIssueComment", mock.Anything, apiURL, token, projectID, 12, "[Build 1.0.0](https://ci/1)").
+		Return(nil)
+	provider.On("AddIssueLabels", mock.Anything, apiURL, token, projectID, 12, []string{"app"}).Return(nil)
+	provider.On("SetIssueMilestone", mock.Anything, apiURL, token, projectID, 12, "1.0.0").Return(nil)
+	provider.On("IssueExists", mock.Anything, apiURL, token, projectID, 12).Return(true, nil)
+
+	tr := NewGitTracker(provider, apiURL, token, projectID)
+	ctx := context.Background()
+
+	require.NoError(t, tr.LinkBuild(ctx, "#12", "Build 1.0.0", "https://ci/1"))
+	require.NoError(t, tr.AddLabels(ctx, "#12", []string{"app"}))
+	require.NoError(t, tr.SetMilestone(ctx, "#12", "1.0.0"))
+
+	exists, err := tr.IssueExists(ctx, "#12")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.Error(t, tr.Comment(ctx, "APP-12", "Deployed"))
+}
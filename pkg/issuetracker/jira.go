@@ -0,0 +1,105 @@
+package issuetracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+)
+
+// JiraTracker updates Jira issues.
+type JiraTracker struct {
+	client jira.Client
+}
+
+func NewJiraTracker(client jira.Client) *JiraTracker {
+	return &JiraTracker{client: client}
+}
+
+// LinkBuild adds a remote link to the issue.
+func (t *JiraTracker) LinkBuild(_ context.Context, issue, title, url string) error {
+	if err := t.client.CreateIssueLink(issue, title, url); err != nil {
+		return fmt.Errorf("failed to link build to jira issue %s: %w", issue, err)
+	}
+
+	return nil
+}
+
+// SetMilestone adds the fix version to the issue. The fix version is created if the project does not have it.
+func (t *JiraTracker) SetMilestone(ctx context.Context, issue, milestone string) error {
+	update := updateRequest("fixVersions", map[string]string{"name": milestone})
+
+	// Jira rejects fix versions that the project does not have, so the fix version is created on failure.
+	if err := t.client.ApplyTagsToIssue(issue, update); err == nil {
+		return nil
+	}
+
+	project, err := t.client.GetProjectInfo(issue)
+	if err != nil {
+		return fmt.Errorf("failed to get project of jira issue %s: %w", issue, err)
+	}
+
+	projectID, err := strconv.Atoi(project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to parse jira project id %s: %w", project.ID, err)
+	}
+
+	if err = t.client.CreateFixVersionValue(ctx, projectID, milestone); err != nil {
+		return fmt.Errorf("failed to create jira fix version %s: %w", milestone, err)
+	}
+
+	if err = t.client.ApplyTagsToIssue(issue, update); err != nil {
+		return fmt.Errorf("failed to set fix version of jira issue %s: %w", issue, err)
+	}
+
+	return nil
+}
+
+// AddLabels adds the labels to the issue.
+func (t *JiraTracker) AddLabels(_ context.Context, issue string, labels []string) error {
+	values := make([]interface{}, 0, len(labels))
+	for _, label := range labels {
+		values = append(values, label)
+	}
+
+	if err := t.client.ApplyTagsToIssue(issue, updateRequest("labels", values...)); err != nil {
+		return fmt.Errorf("failed to add labels to jira issue %s: %w", issue, err)
+	}
+
+	return nil
+}
+
+// Comment adds a comment to the issue.
+func (t *JiraTracker) Comment(ctx context.Context, issue, body string) error {
+	return t.client.AddComment(ctx, issue, body)
+}
+
+// IssueExists reports whether Jira has the issue.
+func (t *JiraTracker) IssueExists(ctx context.Context, issue string) (bool, error) {
+	_, err := t.client.GetIssue(ctx, issue)
+	if errors.Is(err, jira.ErrNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get jira issue %s: %w", issue, err)
+	}
+
+	return true, nil
+}
+
+// updateRequest returns the body of a Jira issue update that adds the values to the field.
+func updateRequest(field string, values ...interface{}) map[string]interface{} {
+	operations := make([]map[string]interface{}, 0, len(values))
+	for _, v := range values {
+		operations = append(operations, map[string]interface{}{"add": v})
+	}
+
+	return map[string]interface{}{
+		"update": map[string]interface{}{
+			field: operations,
+		},
+	}
+}
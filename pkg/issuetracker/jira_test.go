@@ -0,0 +1,130 @@
+package issuetracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	goJira "github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/epam/edp-codebase-operator/v2/pkg/client/jira"
+	jiraMocks "github.com/epam/edp-codebase-operator/v2/pkg/client/jira/mocks"
+)
+
+func TestJiraTracker(t *testing.T) {
+	t.Parallel()
+
+	fixVersion := map[string]interface{}{
+		"update": map[string]interface{}{
+			"fixVersions": []map[string]interface{}{{"add": map[string]string{"name": "1.0.0"}}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		call   func(tr *JiraTracker) error
+		client func(t *testing.T) *jiraMocks.MockClient
+	}{
+		{
+			name: "link build",
+			call: func(tr *JiraTracker) error {
+				return tr.LinkBuild(context.Background(), "APP-1", "Build", "https://ci/1")
+			},
+			client: func(t *testing.T) *jiraMocks.MockClient {
+				m := jiraMocks.NewMockClient(t)
+				m.On("CreateIssueLink", "APP-1", "Build", "https://ci/1").Return(nil)
+
+				return m
+			},
+		},
+		{
+			name: "add labels",
+			call: func(tr *JiraTracker) error {
+				return tr.AddLabels(context.Background(), "APP-1", []string{"app", "qa"})
+			},
+			client: func(t *testing.T) *jiraMocks.MockClient {
+				m := jiraMocks.NewMockClient(t)
+				m.On("ApplyTagsToIssue", "APP-1", map[string]interface{}{
+					"update": map[string]interface{}{
+						"labels": []map[string]interface{}{{"add": "app"}, {"add": "qa"}},
+					},
+				}).Return(nil)
+
+				return m
+			},
+		},
+		{
+			name: "set existing fix version",
+			call: func(tr *JiraTracker) error {
+				return tr.SetMilestone(context.Background(), "APP-1", "1.0.0")
+			},
+			client: func(t *testing.T) *jiraMocks.MockClient {
+				m := jiraMocks.NewMockClient(t)
+				m.On("ApplyTagsToIssue", "APP-1", fixVersion).Return(nil)
+
+				return m
+			},
+		},
+		{
+			name: "create fix version",
+			call: func(tr *JiraTracker) error {
+				return tr.SetMilestone(context.Background(), "APP-1", "1.0.0")
+			},
+			client: func(t *testing.T) *jiraMocks.MockClient {
+				m := jiraMocks.NewMockClient(t)
+				m.On("ApplyTagsToIssue", "APP-1", fixVersion).Return(errors.New("version is not valid")).Once()
+				m.On("GetProjectInfo", "APP-1").Return(&goJira.Project{ID: "100"}, nil)
+				m.On("CreateFixVersionValue", mock.Anything, 100, "1.0.0").Return(nil)
+				m.On("ApplyTagsToIssue", "APP-1", fixVersion).Return(nil).Once()
+
+				return m
+			},
+		},
+		{
+			name: "comment",
+			call: func(tr *JiraTracker) error {
+				return tr.Comment(context.Background(), "APP-1", "Deployed")
+			},
+			client: func(t *testing.T) *jiraMocks.MockClient {
+				m := jiraMocks.NewMockClient(t)
+				m.On("AddComment", mock.Anything, "APP-1", "Deployed").Return(nil)
+
+				return m
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.NoError(t, tt.call(NewJiraTracker(tt.client(t))))
+		})
+	}
+}
+
+func TestJiraTracker_IssueExists(t *testing.T) {
+	t.Parallel()
+
+	m := jiraMocks.NewMockClient(t)
+	m.On("GetIssue", mock.Anything, "APP-1").Return(&goJira.Issue{Key: "APP-1"}, nil)
+	m.On("GetIssue", mock.Anything, "APP-2").Return(nil, fmt.Errorf("jira issue APP-2: %w", jira.ErrNotFound))
+	m.On("GetIssue", mock.Anything, "APP-3").Return(nil, errors.New("unauthorized"))
+
+	tr := NewJiraTracker(m)
+	ctx := context.Background()
+
+	exists, err := tr.IssueExists(ctx, "APP-1")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = tr.IssueExists(ctx, "APP-2")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = tr.IssueExists(ctx, "APP-3")
+	require.Error(t, err)
+}
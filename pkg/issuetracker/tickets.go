@@ -0,0 +1,369 @@
+package issuetracker
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+const (
+	jiraTicketPattern = `[A-Z][A-Z0-9_]+-[0-9]+`
+	gitTicketPattern  = `#[0-9]+`
+)
+
+// TicketNamePattern returns the pattern of the tickets of the codebase.
+// It is the ticketNamePattern of the codebase or the default pattern of its issue tracker.
+func TicketNamePattern(codebase *codebaseApi.Codebase) string {
+	if codebase.Spec.TicketNamePattern != nil && *codebase.Spec.TicketNamePattern != "" {
+		return *codebase.Spec.TicketNamePattern
+	}
+
+	switch codebase.Spec.GetIssueTracker() {
+	case codebaseApi.IssueTrackerGitHub, codebaseApi.IssueTrackerGitLab:
+		return gitTicketPattern
+	default:
+		return jiraTicketPattern
+	}
+}
+
+// FindTickets returns the tickets in the text that match the pattern, without duplicates.
+func FindTickets(pattern, text string) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket name pattern %q: %w", pattern, err)
+	}
+
+	var tickets []string
+
+	for _, ticket := range re.FindAllString(text, -1) {
+		if !slices.Contains(tickets, ticket) {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	return tickets, nil
+}
+
+// projectKeyPattern matches Jira project keys in the literal text of ticket name patterns.
+var projectKeyPattern = regexp.MustCompile(`[A-Z][A-Z0-9_]+`)
+
+// maxExpansions bounds the literal strings a part of a ticket name pattern is spelled out to.
+const maxExpansions = 1024
+
+// JiraProjectKeys returns the Jira project keys spelled out in the ticket name pattern,
+// e.g. APP in APP-\d+ and APP and WEB in (APP|WEB)-\d+. Only literal text and alternations
+// of it count: keys that character classes or repetitions take part in, such as the keys
+// of [AB]PP-\d+ or APP[0-9]?-\d+, are not guessed.
+// Patterns that match the tickets of any project, such as the default one, have no project keys.
+func JiraProjectKeys(pattern string) ([]string, error) {
+	if _, err := syntax.Parse(pattern, syntax.Perl); err != nil {
+		return nil, fmt.Errorf("invalid ticket name pattern %q: %w", pattern, err)
+	}
+
+	p := &keyParser{pattern: pattern}
+
+	if texts, ok := p.alternation(true); ok {
+		p.collect(texts, true, true)
+	}
+
+	return p.keys, nil
+}
+
+// keyParser reads the literal text of a valid ticket name pattern. The regexp/syntax parser
+// cannot be used for it: it factors alternations, e.g. (APP|API) into AP[IP], which cannot
+// be told apart from a character class.
+type keyParser struct {
+	pattern string
+	pos     int
+	depth   int
+	keys    []string
+}
+
+// alternation reads the branches up to the end of the group and returns the strings they
+// match, if they match literal text only. leftClosed reports whether nothing but the start
+// of the text can precede the branches.
+func (p *keyParser) alternation(leftClosed bool) ([]string, bool) {
+	var texts []string
+
+	literal := true
+
+	for {
+		branch, ok := p.sequence(leftClosed)
+		if ok {
+			texts = appendUnique(texts, branch...)
+		} else {
+			literal = false
+		}
+
+		if p.pos >= len(p.pattern) || p.pattern[p.pos] != '|' {
+			break
+		}
+
+		p.pos++
+	}
+
+	if !literal {
+		p.collect(texts, leftClosed, p.depth == 0)
+
+		return nil, false
+	}
+
+	return texts, true
+}
+
+// sequence reads the items of a branch, joining the literal ones. The keys of literal text
+// next to other items are collected right away.
+func (p *keyParser) sequence(leftClosed bool) ([]string, bool) {
+	run, runClosed, literal := []string{""}, leftClosed, true
+
+	for p.pos < len(p.pattern) && p.pattern[p.pos] != '|' && p.pattern[p.pos] != ')' {
+		texts, kind := p.item(runClosed && len(run) == 1 && run[0] == "")
+
+		if kind == literalItem && len(run)*len(texts) <= maxExpansions {
+			run = join(run, texts)
+
+			continue
+		}
+
+		// Anchors and word boundaries let nothing else touch the literal text.
+		p.collect(run, runClosed, kind == boundaryItem)
+
+		run, runClosed, literal = []string{""}, kind == boundaryItem, false
+
+		if kind == literalItem {
+			run = texts
+		}
+	}
+
+	if !literal {
+		p.collect(run, runClosed, p.depth == 0)
+
+		return nil, false
+	}
+
+	return run, true
+}
+
+type itemKind int
+
+const (
+	literalItem itemKind = iota
+	boundaryItem
+	otherItem
+)
+
+// item reads an item of a branch with its repetition and returns the strings it matches.
+func (p *keyParser) item(leftClosed bool) ([]string, itemKind) {
+	var (
+		texts []string
+		kind  = otherItem
+	)
+
+	switch c := p.pattern[p.pos]; c {
+	case '(':
+		p.pos++
+
+		if p.groupFlags() {
+			return nil, boundaryItem
+		}
+
+		p.depth++
+		texts, ok := p.alternation(leftClosed)
+		p.depth--
+		p.pos++
+
+		if ok {
+			kind = literalItem
+		}
+
+		return p.repeated(texts, kind, leftClosed)
+	case '[':
+		p.skipCharClass()
+	case '\\':
+		texts, kind = p.escape()
+	case '^', '$':
+		p.pos++
+
+		return nil, boundaryItem
+	case '.':
+		p.pos++
+	default:
+		r, size := utf8.DecodeRuneInString(p.pattern[p.pos:])
+		p.pos += size
+		texts, kind = []string{string(r)}, literalItem
+	}
+
+	return p.repeated(texts, kind, leftClosed)
+}
+
+// repeated reads the repetition of an item, whose literal text then no longer stands for itself.
+func (p *keyParser) repeated(texts []string, kind itemKind, leftClosed bool) ([]string, itemKind) {
+	if kind == boundaryItem {
+		return texts, kind
+	}
+
+	rest := p.pattern[p.pos:]
+
+	n := 0
+	if rest != "" && strings.ContainsRune("*+?", rune(rest[0])) {
+		n = 1
+	} else if m := repetitionPattern.FindString(rest); m != "" {
+		n = len(m)
+	}
+
+	if n == 0 {
+		return texts, kind
+	}
+
+	p.pos += n
+
+	if p.pos < len(p.pattern) && p.pattern[p.pos] == '?' {
+		p.pos++
+	}
+
+	if kind == literalItem {
+		p.collect(texts, leftClosed, false)
+	}
+
+	return nil, otherItem
+}
+
+var repetitionPattern = regexp.MustCompile(`^\{[0-9]+(,[0-9]*)?\}`)
+
+// groupFlags skips the flags and the name at the start of a group. It reports whether the
+// group only sets flags, such as (?i).
+func (p *keyParser) groupFlags() bool {
+	if p.pos >= len(p.pattern) || p.pattern[p.pos] != '?' {
+		return false
+	}
+
+	end := strings.IndexAny(p.pattern[p.pos:], ":)>")
+	p.pos += end + 1
+
+	return p.pattern[p.pos-1] == ')'
+}
+
+func (p *keyParser) skipCharClass() {
+	p.pos++
+
+	if strings.HasPrefix(p.pattern[p.pos:], "^") {
+		p.pos++
+	}
+
+	// A closing bracket right after the opening one is a literal.
+	if strings.HasPrefix(p.pattern[p.pos:], "]") {
+		p.pos++
+	}
+
+	for p.pos < len(p.pattern) && p.pattern[p.pos] != ']' {
+		switch {
+		case p.pattern[p.pos] == '\\':
+			p.pos += 2
+		case strings.HasPrefix(p.pattern[p.pos:], "[:"):
+			p.pos += strings.Index(p.pattern[p.pos:], ":]") + 2
+		default:
+			p.pos++
+		}
+	}
+
+	p.pos++
+}
+
+// escape reads an escape sequence. Escaped punctuation is literal text.
+func (p *keyParser) escape() ([]string, itemKind) {
+	c := p.pattern[p.pos+1]
+	p.pos += 2
+
+	switch {
+	case c == 'Q':
+		end := strings.Index(p.pattern[p.pos:], `\E`)
+		if end < 0 {
+			end = len(p.pattern) - p.pos
+		}
+
+		text := p.pattern[p.pos : p.pos+end]
+		p.pos = min(p.pos+end+2, len(p.pattern))
+
+		return []string{text}, literalItem
+	case c == 'A' || c == 'z' || c == 'b':
+		return nil, boundaryItem
+	case c == 'x' || c == 'p' || c == 'P':
+		if strings.HasPrefix(p.pattern[p.pos:], "{") {
+			p.pos += strings.Index(p.pattern[p.pos:], "}") + 1
+		} else if c == 'x' {
+			p.pos += 2
+		} else {
+			p.pos++
+		}
+
+		return nil, otherItem
+	case c >= '0' && c <= '7':
+		for i := 0; i < 2 && p.pos < len(p.pattern) && p.pattern[p.pos] >= '0' && p.pattern[p.pos] <= '7'; i++ {
+			p.pos++
+		}
+
+		return nil, otherItem
+	case c < utf8.RuneSelf && !unicode.IsLetter(rune(c)) && !unicode.IsDigit(rune(c)):
+		return []string{string(c)}, literalItem
+	default:
+		return nil, otherItem
+	}
+}
+
+// collect adds the project keys of the literal text. A key at the start or the end of the
+// text counts only if the text is closed there, so that no other text can extend it.
+func (p *keyParser) collect(texts []string, leftClosed, rightClosed bool) {
+	for _, text := range texts {
+		for _, loc := range projectKeyPattern.FindAllStringIndex(text, -1) {
+			if (loc[0] == 0 && !leftClosed) || (loc[0] > 0 && isKeyByte(text[loc[0]-1])) ||
+				(loc[1] == len(text) && !rightClosed) {
+				continue
+			}
+
+			p.keys = appendUnique(p.keys, text[loc[0]:loc[1]])
+		}
+	}
+}
+
+func isKeyByte(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+func join(prefixes, suffixes []string) []string {
+	var joined []string
+
+	for _, prefix := range prefixes {
+		for _, suffix := range suffixes {
+			joined = appendUnique(joined, prefix+suffix)
+		}
+	}
+
+	return joined
+}
+
+func appendUnique(values []string, add ...string) []string {
+	for _, v := range add {
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// IssueNumber returns the number of the GitHub or GitLab issue referenced as #123 or 123.
+func IssueNumber(ticket string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(ticket), "#"))
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid issue reference %q", ticket)
+	}
+
+	return number, nil
+}
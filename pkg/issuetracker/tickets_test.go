@@ -0,0 +1,116 @@
+package issuetracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestTicketNamePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		spec codebaseApi.CodebaseSpec
+		want string
+	}{
+		{
+			name: "pattern of the codebase",
+			spec: codebaseApi.CodebaseSpec{TicketNamePattern: ptr.To(`EPMDEDP-\d+`), JiraServer: ptr.To("jira")},
+			want: `EPMDEDP-\d+`,
+		},
+		{
+			name: "jira",
+			spec: codebaseApi.CodebaseSpec{JiraServer: ptr.To("jira")},
+			want: jiraTicketPattern,
+		},
+		{
+			name: "github",
+			spec: codebaseApi.CodebaseSpec{IssueTracker: codebaseApi.IssueTrackerGitHub},
+			want: gitTicketPattern,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, TicketNamePattern(&codebaseApi.Codebase{Spec: tt.spec}))
+		})
+	}
+}
+
+func TestFindTickets(t *testing.T) {
+	t.Parallel()
+
+	tickets, err := FindTickets(gitTicketPattern, "Fix login (#12), closes #7 and #12")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#12", "#7"}, tickets)
+
+	tickets, err = FindTickets(jiraTicketPattern, "[APP-1] Fix login for APP-22")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"APP-1", "APP-22"}, tickets)
+
+	_, err = FindTickets("[", "text")
+	require.Error(t, err)
+}
+
+func TestJiraProjectKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{pattern: `EPMDEDP-\d+`, want: []string{"EPMDEDP"}},
+		{pattern: `(APP|WEB_UI)-[0-9]+`, want: []string{"APP", "WEB_UI"}},
+		{pattern: `(APP|API)-\d+`, want: []string{"API", "APP"}},
+		{pattern: `APP-\d+|API-\d+`, want: []string{"API", "APP"}},
+		{pattern: `(CORE|CORP)_(UI|API)-\d+`, want: []string{"CORE_API", "CORE_UI", "CORP_API", "CORP_UI"}},
+		{pattern: `\[(OPS|OPT)\]-?\d+`, want: []string{"OPS", "OPT"}},
+		{pattern: `^(APP-\d+|WEB-\d+)$`, want: []string{"APP", "WEB"}},
+		{pattern: `(?:APP|API)-\d+`, want: []string{"API", "APP"}},
+		{pattern: `APP[0-9]?-\d+`, want: nil},
+		{pattern: `[AB]PP-\d+`, want: nil},
+		{pattern: `AP[IP]-\d+`, want: nil},
+		{pattern: `(APP)+-\d+`, want: nil},
+		{pattern: `X(APP|API)`, want: []string{"XAPP", "XAPI"}},
+		{pattern: jiraTicketPattern, want: nil},
+		{pattern: `(?i)app-\d+`, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			keys, err := JiraProjectKeys(tt.pattern)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, keys)
+		})
+	}
+
+	_, err := JiraProjectKeys("[")
+	require.Error(t, err)
+}
+
+func TestIssueNumber(t *testing.T) {
+	t.Parallel()
+
+	number, err := IssueNumber("#42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, number)
+
+	number, err = IssueNumber("7")
+	require.NoError(t, err)
+	assert.Equal(t, 7, number)
+
+	_, err = IssueNumber("APP-1")
+	require.Error(t, err)
+
+	_, err = IssueNumber("#0")
+	require.Error(t, err)
+}
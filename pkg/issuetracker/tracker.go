@@ -0,0 +1,29 @@
+package issuetracker
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoIssueTracker is returned for codebases without an issue tracker.
+var ErrNoIssueTracker = errors.New("codebase has no issue tracker")
+
+// Tracker updates the issues of the issue tracker of a codebase.
+// Issues are referenced by their ticket, e.g. APP-123 in Jira or #123 in GitHub and GitLab.
+type Tracker interface {
+	// LinkBuild links the build or commit with the given URL to the issue.
+	LinkBuild(ctx context.Context, issue, title, url string) error
+
+	// SetMilestone sets the milestone of the issue, the fix version in Jira.
+	// The milestone is created if it does not exist.
+	SetMilestone(ctx context.Context, issue, milestone string) error
+
+	// AddLabels adds the labels to the issue.
+	AddLabels(ctx context.Context, issue string, labels []string) error
+
+	// Comment adds a comment to the issue.
+	Comment(ctx context.Context, issue, body string) error
+
+	// IssueExists reports whether the issue tracker has the issue.
+	IssueExists(ctx context.Context, issue string) (bool, error)
+}
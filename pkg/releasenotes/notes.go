@@ -0,0 +1,119 @@
+package releasenotes
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OtherIssueType is the group of the issues whose type is unknown, e.g. deleted issues.
+const OtherIssueType = "Other"
+
+// Issue is a Jira issue in the release notes.
+type Issue struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary,omitempty"`
+	Status  string `json:"status,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// Group contains the issues of one issue type.
+type Group struct {
+	Type   string  `json:"type"`
+	Issues []Issue `json:"issues"`
+}
+
+// Notes are the release notes of a version of a CodebaseBranch.
+type Notes struct {
+	Codebase   string    `json:"codebase"`
+	Branch     string    `json:"branch"`
+	Version    string    `json:"version"`
+	ReleasedAt time.Time `json:"releasedAt"`
+	Groups     []Group   `json:"groups"`
+	Commits    []string  `json:"commits,omitempty"`
+}
+
+// AddIssue adds the issue to the group of its type.
+// Groups are sorted by type, with issues of an unknown type last.
+func (n *Notes) AddIssue(issueType string, issue Issue) {
+	if issueType == "" {
+		issueType = OtherIssueType
+	}
+
+	i := slices.IndexFunc(n.Groups, func(g Group) bool {
+		return g.Type == issueType
+	})
+	if i >= 0 {
+		n.Groups[i].Issues = append(n.Groups[i].Issues, issue)
+
+		return
+	}
+
+	n.Groups = append(n.Groups, Group{Type: issueType, Issues: []Issue{issue}})
+
+	slices.SortStableFunc(n.Groups, func(a, b Group) int {
+		if (a.Type == OtherIssueType) != (b.Type == OtherIssueType) {
+			if a.Type == OtherIssueType {
+				return 1
+			}
+
+			return -1
+		}
+
+		return strings.Compare(a.Type, b.Type)
+	})
+}
+
+// Markdown renders the release notes as Markdown.
+func (n *Notes) Markdown() string {
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "# %s %s\n\n", n.Codebase, n.Version)
+	fmt.Fprintf(b, "Released %s from branch %s.\n", n.ReleasedAt.UTC().Format(time.DateOnly), n.Branch)
+
+	if len(n.Groups) == 0 && len(n.Commits) == 0 {
+		b.WriteString("\nNo changes.\n")
+
+		return b.String()
+	}
+
+	for _, g := range n.Groups {
+		fmt.Fprintf(b, "\n## %s\n\n", g.Type)
+
+		for _, issue := range g.Issues {
+			key := issue.Key
+			if issue.URL != "" {
+				key = fmt.Sprintf("[%s](%s)", issue.Key, issue.URL)
+			}
+
+			if issue.Summary == "" {
+				fmt.Fprintf(b, "- %s\n", key)
+				continue
+			}
+
+			fmt.Fprintf(b, "- %s %s\n", key, issue.Summary)
+		}
+	}
+
+	if len(n.Commits) > 0 {
+		b.WriteString("\n## Commits\n\n")
+
+		for _, commit := range n.Commits {
+			fmt.Fprintf(b, "- %s\n", commit)
+		}
+	}
+
+	return b.String()
+}
+
+// JSON renders the release notes as JSON.
+func (n *Notes) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal release notes: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,64 @@
+package releasenotes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotes(t *testing.T) {
+	t.Parallel()
+
+	notes := &Notes{
+		Codebase:   "app",
+		Branch:     "main",
+		Version:    "1.0.0",
+		ReleasedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Commits:    []string{"abc123"},
+	}
+
+	notes.AddIssue("Story", Issue{Key: "APP-2", Summary: "Add login", URL: "https://jira.example.com/browse/APP-2"})
+	notes.AddIssue("", Issue{Key: "APP-9"})
+	notes.AddIssue("Bug", Issue{Key: "APP-1", Summary: "Fix crash", URL: "https://jira.example.com/browse/APP-1"})
+	notes.AddIssue("Story", Issue{Key: "APP-3", Summary: "Add logout"})
+
+	assert.Equal(t, `# app 1.0.0
+
+Released 2024-05-01 from branch main.
+
+## Bug
+
+- [APP-1](https://jira.example.com/browse/APP-1) Fix crash
+
+## Story
+
+- [APP-2](https://jira.example.com/browse/APP-2) Add login
+- APP-3 Add logout
+
+## Other
+
+- APP-9
+
+## Commits
+
+- abc123
+`, notes.Markdown())
+
+	data, err := notes.JSON()
+	require.NoError(t, err)
+
+	got := &Notes{}
+	require.NoError(t, json.Unmarshal(data, got))
+	assert.Equal(t, notes, got)
+}
+
+func TestNotes_Empty(t *testing.T) {
+	t.Parallel()
+
+	notes := &Notes{Codebase: "app", Branch: "main", Version: "1.0.0"}
+
+	assert.Contains(t, notes.Markdown(), "No changes.")
+}
@@ -0,0 +1,281 @@
+package releasenotes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+const (
+	configMapSuffix = "-release-notes"
+
+	changesKeySuffix  = ".changes.json"
+	markdownKeySuffix = ".md"
+	jsonKeySuffix     = ".json"
+)
+
+// invalidKeyChars matches the characters that are not allowed in ConfigMap keys, e.g. + in semver build metadata.
+var invalidKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// Changes are the tickets and commits of the builds of a version.
+type Changes struct {
+	Tickets []string `json:"tickets,omitempty"`
+	Commits []string `json:"commits,omitempty"`
+}
+
+// ConfigMapName returns the name of the ConfigMap with the changes and release notes of the CodebaseBranch.
+func ConfigMapName(codebaseBranchName string) string {
+	return codebaseBranchName + configMapSuffix
+}
+
+// MarkdownKey returns the ConfigMap key of the Markdown release notes of the version.
+func MarkdownKey(version string) string {
+	return versionKey(version) + markdownKeySuffix
+}
+
+// JSONKey returns the ConfigMap key of the JSON release notes of the version.
+func JSONKey(version string) string {
+	return versionKey(version) + jsonKeySuffix
+}
+
+func changesKey(version string) string {
+	return versionKey(version) + changesKeySuffix
+}
+
+func versionKey(version string) string {
+	return invalidKeyChars.ReplaceAllString(version, "_")
+}
+
+// keyVersion returns the version part of a ConfigMap key.
+func keyVersion(key string) string {
+	for _, suffix := range []string{changesKeySuffix, markdownKeySuffix, jsonKeySuffix} {
+		if version, ok := strings.CutSuffix(key, suffix); ok {
+			return version
+		}
+	}
+
+	return key
+}
+
+// RecordChanges adds the tickets and commits to the changes of the version.
+// The ConfigMap is created on the first call and is owned by the CodebaseBranch.
+func RecordChanges(
+	ctx context.Context,
+	c client.Client,
+	codebaseBranch *codebaseApi.CodebaseBranch,
+	version string,
+	tickets, commits []string,
+) error {
+	return updateConfigMap(ctx, c, codebaseBranch, func(cm *coreV1.ConfigMap) error {
+		changes, err := parseChanges(cm, version)
+		if err != nil {
+			return err
+		}
+
+		changes.Tickets = appendUnique(changes.Tickets, tickets...)
+		changes.Commits = appendUnique(changes.Commits, commits...)
+
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal changes: %w", err)
+		}
+
+		cm.Data[changesKey(version)] = string(data)
+
+		return nil
+	})
+}
+
+// StoreNotes stores the Markdown and JSON release notes of the version.
+func StoreNotes(ctx context.Context, c client.Client, codebaseBranch *codebaseApi.CodebaseBranch, notes *Notes) error {
+	data, err := notes.JSON()
+	if err != nil {
+		return err
+	}
+
+	return updateConfigMap(ctx, c, codebaseBranch, func(cm *coreV1.ConfigMap) error {
+		cm.Data[MarkdownKey(notes.Version)] = notes.Markdown()
+		cm.Data[JSONKey(notes.Version)] = string(data)
+
+		return nil
+	})
+}
+
+// TrimVersions removes the changes and release notes of the versions that are not kept from the
+// ConfigMap, so that it does not grow with every version of a long-lived branch.
+func TrimVersions(
+	ctx context.Context,
+	c client.Client,
+	codebaseBranch *codebaseApi.CodebaseBranch,
+	keep []string,
+) error {
+	keepKeys := make([]string, 0, len(keep))
+	for _, version := range keep {
+		keepKeys = append(keepKeys, versionKey(version))
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := GetConfigMap(ctx, c, codebaseBranch)
+		if err != nil || cm == nil {
+			return err
+		}
+
+		trimmed := false
+
+		for key := range cm.Data {
+			if !slices.Contains(keepKeys, keyVersion(key)) {
+				delete(cm.Data, key)
+
+				trimmed = true
+			}
+		}
+
+		if !trimmed {
+			return nil
+		}
+
+		return c.Update(ctx, cm)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to trim release notes ConfigMap: %w", err)
+	}
+
+	return nil
+}
+
+// GetConfigMap returns the ConfigMap of the CodebaseBranch or nil if there is none yet.
+func GetConfigMap(
+	ctx context.Context,
+	c client.Client,
+	codebaseBranch *codebaseApi.CodebaseBranch,
+) (*coreV1.ConfigMap, error) {
+	cm := &coreV1.ConfigMap{}
+
+	err := c.Get(ctx, types.NamespacedName{
+		Namespace: codebaseBranch.Namespace,
+		Name:      ConfigMapName(codebaseBranch.Name),
+	}, cm)
+	if k8sErrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release notes ConfigMap: %w", err)
+	}
+
+	return cm, nil
+}
+
+// GetChanges returns the changes of the version from the ConfigMap and whether any are recorded.
+func GetChanges(cm *coreV1.ConfigMap, version string) (*Changes, bool, error) {
+	if cm == nil {
+		return &Changes{}, false, nil
+	}
+
+	_, ok := cm.Data[changesKey(version)]
+
+	changes, err := parseChanges(cm, version)
+
+	return changes, ok, err
+}
+
+// HasNotes reports whether the release notes of the version are stored in the ConfigMap.
+func HasNotes(cm *coreV1.ConfigMap, version string) bool {
+	if cm == nil {
+		return false
+	}
+
+	_, ok := cm.Data[MarkdownKey(version)]
+
+	return ok
+}
+
+func parseChanges(cm *coreV1.ConfigMap, version string) (*Changes, error) {
+	changes := &Changes{}
+
+	data, ok := cm.Data[changesKey(version)]
+	if !ok {
+		return changes, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), changes); err != nil {
+		return nil, fmt.Errorf("failed to parse changes of version %s: %w", version, err)
+	}
+
+	return changes, nil
+}
+
+func updateConfigMap(
+	ctx context.Context,
+	c client.Client,
+	codebaseBranch *codebaseApi.CodebaseBranch,
+	update func(cm *coreV1.ConfigMap) error,
+) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := GetConfigMap(ctx, c, codebaseBranch)
+		if err != nil {
+			return err
+		}
+
+		if cm == nil {
+			cm = &coreV1.ConfigMap{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      ConfigMapName(codebaseBranch.Name),
+					Namespace: codebaseBranch.Namespace,
+					Labels: map[string]string{
+						codebaseApi.CodebaseBranchLabel: codebaseBranch.Name,
+					},
+				},
+				Data: map[string]string{},
+			}
+
+			if err = controllerutil.SetControllerReference(codebaseBranch, cm, c.Scheme()); err != nil {
+				return fmt.Errorf("failed to set controller reference: %w", err)
+			}
+
+			if err = update(cm); err != nil {
+				return err
+			}
+
+			return c.Create(ctx, cm)
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+
+		if err = update(cm); err != nil {
+			return err
+		}
+
+		return c.Update(ctx, cm)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update release notes ConfigMap: %w", err)
+	}
+
+	return nil
+}
+
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		if item != "" && !slices.Contains(values, item) {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,107 @@
+package releasenotes
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+)
+
+func TestRecordChanges(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, coreV1.AddToScheme(scheme))
+
+	cb := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app-main", Namespace: "default", UID: "uid"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cb).Build()
+	ctx := context.Background()
+
+	require.NoError(t, RecordChanges(ctx, k8sClient, cb, "1.0.0+1", []string{"APP-1"}, []string{"abc"}))
+	require.NoError(t, RecordChanges(ctx, k8sClient, cb, "1.0.0+1", []string{"APP-1", "APP-2"}, nil))
+
+	cm, err := GetConfigMap(ctx, k8sClient, cb)
+	require.NoError(t, err)
+	require.NotNil(t, cm)
+	assert.Equal(t, "app-main-release-notes", cm.Name)
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, "app-main", cm.OwnerReferences[0].Name)
+	assert.Contains(t, cm.Data, "1.0.0_1.changes.json")
+
+	changes, ok, err := GetChanges(cm, "1.0.0+1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, &Changes{Tickets: []string{"APP-1", "APP-2"}, Commits: []string{"abc"}}, changes)
+
+	_, ok, err = GetChanges(cm, "2.0.0")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, HasNotes(cm, "1.0.0+1"))
+
+	notes := &Notes{Codebase: "app", Branch: "main", Version: "1.0.0+1", ReleasedAt: time.Now()}
+	notes.AddIssue("Bug", Issue{Key: "APP-1"})
+	require.NoError(t, StoreNotes(ctx, k8sClient, cb, notes))
+
+	cm, err = GetConfigMap(ctx, k8sClient, cb)
+	require.NoError(t, err)
+	assert.True(t, HasNotes(cm, "1.0.0+1"))
+	assert.Contains(t, cm.Data[MarkdownKey("1.0.0+1")], "- APP-1")
+	assert.Contains(t, cm.Data[JSONKey("1.0.0+1")], `"key": "APP-1"`)
+}
+
+func TestTrimVersions(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, coreV1.AddToScheme(scheme))
+
+	cb := &codebaseApi.CodebaseBranch{
+		ObjectMeta: metaV1.ObjectMeta{Name: "app-main", Namespace: "default", UID: "uid"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cb).Build()
+	ctx := context.Background()
+
+	require.NoError(t, TrimVersions(ctx, k8sClient, cb, nil), "a missing ConfigMap must not be created")
+
+	cm, err := GetConfigMap(ctx, k8sClient, cb)
+	require.NoError(t, err)
+	assert.Nil(t, cm)
+
+	for _, version := range []string{"0.1.0", "0.2.0", "0.3.0+1"} {
+		require.NoError(t, RecordChanges(ctx, k8sClient, cb, version, []string{"APP-1"}, nil))
+		require.NoError(t, StoreNotes(ctx, k8sClient, cb, &Notes{Version: version, ReleasedAt: time.Now()}))
+	}
+
+	require.NoError(t, TrimVersions(ctx, k8sClient, cb, []string{"0.2.0", "0.3.0+1"}))
+
+	cm, err = GetConfigMap(ctx, k8sClient, cb)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"0.2.0.changes.json", "0.2.0.md", "0.2.0.json",
+		"0.3.0_1.changes.json", "0.3.0_1.md", "0.3.0_1.json",
+	}, slices.Collect(maps.Keys(cm.Data)))
+}
+
+func TestGetConfigMap_NotFound(t *testing.T) {
+	t.Parallel()
+
+	cb := &codebaseApi.CodebaseBranch{ObjectMeta: metaV1.ObjectMeta{Name: "app-main", Namespace: "default"}}
+
+	cm, err := GetConfigMap(context.Background(), fake.NewClientBuilder().Build(), cb)
+	require.NoError(t, err)
+	assert.Nil(t, cm)
+}
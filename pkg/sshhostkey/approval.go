@@ -0,0 +1,77 @@
+package sshhostkey
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"slices"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// UnknownHostKeyError carries the key presented by a host that has no entry in
+// known_hosts, so that it can be offered to an administrator for approval.
+// It wraps the original knownhosts.KeyError, so Enrich and IsVerificationError
+// treat it as any other verification failure.
+type UnknownHostKeyError struct {
+	HostPort string
+	Key      ssh.PublicKey
+	Err      error
+}
+
+func (e *UnknownHostKeyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnknownHostKeyError) Unwrap() error {
+	return e.Err
+}
+
+// recordUnknownHost reports the key of a host absent from known_hosts as an
+// UnknownHostKeyError. Mismatches and revoked keys are returned unchanged: they
+// must never be offered for approval.
+func recordUnknownHost(callback ssh.HostKeyCallback) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return &UnknownHostKeyError{HostPort: hostname, Key: key, Err: err}
+		}
+
+		return err
+	}
+}
+
+// Fingerprint returns the SHA256 fingerprint of the key in the format of ssh-keygen -l.
+func Fingerprint(key ssh.PublicKey) string {
+	return ssh.FingerprintSHA256(key)
+}
+
+// Line returns a known_hosts line that pins the key for the given host:port addresses.
+func Line(hostPorts []string, key ssh.PublicKey) string {
+	return knownhosts.Line(hostPorts, key)
+}
+
+// Contains reports whether the known_hosts data pins the key for the host:port address.
+// Only plain host patterns are matched, as written by Line.
+func Contains(knownHosts []byte, hostPort string, key ssh.PublicKey) bool {
+	want := knownhosts.Normalize(hostPort)
+	rest := knownHosts
+
+	for len(rest) > 0 {
+		_, hosts, pubKey, _, next, err := ssh.ParseKnownHosts(rest)
+		if err != nil {
+			return false
+		}
+
+		if bytes.Equal(pubKey.Marshal(), key.Marshal()) && slices.Contains(hosts, want) {
+			return true
+		}
+
+		rest = next
+	}
+
+	return false
+}
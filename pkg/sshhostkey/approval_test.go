@@ -0,0 +1,50 @@
+package sshhostkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	key := newHostKey(t)
+
+	assert.Equal(t, ssh.FingerprintSHA256(key), Fingerprint(key))
+	assert.Contains(t, Fingerprint(key), "SHA256:")
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+
+	key := newHostKey(t)
+	other := newHostKey(t)
+
+	knownHosts := []byte("# comment\n" +
+		Line([]string{HostPort("other.example.com", 22)}, other) + "\n" +
+		Line([]string{HostPort(testHost, testPort), HostPort(testHost, 22)}, key) + "\n")
+
+	tests := []struct {
+		name       string
+		knownHosts []byte
+		hostPort   string
+		key        ssh.PublicKey
+		want       bool
+	}{
+		{name: "pinned with port", knownHosts: knownHosts, hostPort: HostPort(testHost, testPort), key: key, want: true},
+		{name: "pinned on default port", knownHosts: knownHosts, hostPort: HostPort(testHost, 22), key: key, want: true},
+		{name: "other key", knownHosts: knownHosts, hostPort: HostPort(testHost, testPort), key: other},
+		{name: "other port", knownHosts: knownHosts, hostPort: HostPort(testHost, 2022), key: key},
+		{name: "empty", knownHosts: nil, hostPort: HostPort(testHost, testPort), key: key},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Contains(tt.knownHosts, tt.hostPort, tt.key))
+		})
+	}
+}
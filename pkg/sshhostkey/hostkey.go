@@ -1,9 +1,11 @@
 // Package sshhostkey centralises SSH host key verification for every outbound
 // SSH connection the operator makes.
 //
-// Verification is mandatory. The known_hosts source is a single file shared by
-// the whole process, located by SSH_KNOWN_HOSTS and re-read on every
-// connection, so entries added to it apply to connections already in flight.
+// Verification is mandatory. The known_hosts sources are shared by the whole
+// process, listed colon-separated in SSH_KNOWN_HOSTS and re-read on every
+// connection, so entries added to them apply to connections already in flight.
+// Next to the administrator's file, the list holds the host keys approved on
+// GitServers.
 package sshhostkey
 
 import (
@@ -25,7 +27,8 @@ import (
 const knownHostsEnvVar = "SSH_KNOWN_HOSTS"
 
 // ClientConfig returns the host key callback and the host key algorithms to set
-// on a golang.org/x/crypto/ssh.ClientConfig.
+// on a golang.org/x/crypto/ssh.ClientConfig. The callback reports a host absent
+// from known_hosts with an UnknownHostKeyError.
 //
 // Both values must be applied together. Restricting HostKeyAlgorithms to the
 // types actually recorded for the host prevents the server from offering a key
@@ -40,7 +43,7 @@ func ClientConfig(host string, port int32) (ssh.HostKeyCallback, []string, error
 		return nil, nil, fmt.Errorf("failed to load SSH known_hosts (%s): %w", Source(), err)
 	}
 
-	return db.HostKeyCallback(), db.HostKeyAlgorithms(HostPort(host, int(port))), nil
+	return recordUnknownHost(db.HostKeyCallback()), db.HostKeyAlgorithms(HostPort(host, int(port))), nil
 }
 
 func HostPort(host string, port int) string {
@@ -95,7 +98,8 @@ func Enrich(err error, hostPort string) error {
 
 	return fmt.Errorf(
 		"SSH host key for %s is not present in known_hosts (%s). "+
-			"Add it to the operator's ssh-known-hosts ConfigMap, for example: ssh-keyscan -p %s %s: %w",
+			"Approve the key offered in the GitServer status.pendingHostKey, or add it to the operator's "+
+			"ssh-known-hosts ConfigMap, for example: ssh-keyscan -p %s %s: %w",
 		hostPort, Source(), portOf(hostPort), hostOf(hostPort), err,
 	)
 }
@@ -96,6 +96,10 @@ func TestClientConfig_RejectsUnpinnedKey(t *testing.T) {
 
 	require.ErrorAs(t, err, &keyErr)
 	assert.NotEmpty(t, keyErr.Want, "a pinned host presenting a new key must report the expected keys")
+
+	var unknownErr *UnknownHostKeyError
+
+	assert.False(t, errors.As(err, &unknownErr), "a changed key must never be offered for approval")
 }
 
 func TestClientConfig_RejectsUnknownHost(t *testing.T) {
@@ -113,6 +117,12 @@ func TestClientConfig_RejectsUnknownHost(t *testing.T) {
 
 	require.ErrorAs(t, err, &keyErr)
 	assert.Empty(t, keyErr.Want, "an unpinned host has no expected keys")
+
+	var unknownErr *UnknownHostKeyError
+
+	require.ErrorAs(t, err, &unknownErr)
+	assert.Equal(t, "other.example.com:22", unknownErr.HostPort)
+	assert.True(t, IsVerificationError(err))
 }
 
 func TestClientConfig_MissingKnownHostsFile(t *testing.T) {
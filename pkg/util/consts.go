@@ -27,6 +27,15 @@ const (
 	GitServerSecretWebhookSecretField = "secretString"
 	// GitServerSecretUserNameField is a field in secret created for the git server that stores username.
 	GitServerSecretUserNameField = "username"
+	// GitServerSecretGitHubAppIDField is a field in secret created for the git server that stores
+	// the id of the GitHub App the operator mints the token with.
+	GitServerSecretGitHubAppIDField = "githubAppId"
+	// GitServerSecretGitHubAppInstallationIDField is a field in secret created for the git server
+	// that stores the id of the GitHub App installation.
+	GitServerSecretGitHubAppInstallationIDField = "githubAppInstallationId"
+	// GitServerSecretGitHubAppPrivateKeyField is a field in secret created for the git server that
+	// stores the PEM-encoded private key of the GitHub App.
+	GitServerSecretGitHubAppPrivateKeyField = "githubAppPrivateKey"
 
 	ImportStrategy     = "import"
 	CloneStrategy      = "clone"
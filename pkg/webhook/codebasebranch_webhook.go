@@ -2,7 +2,10 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"strings"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -73,7 +76,7 @@ func (r *CodebaseBranchValidationWebhook) ValidateCreate(
 		return nil, fmt.Errorf("CodebaseBranch CR with the same codebase name and branch name already exists")
 	}
 
-	return nil, nil
+	return r.pipelineWarnings(ctx, createdCodebaseBranch), nil
 }
 
 // ValidateUpdate is a webhook for validating the updating of the CodebaseBranch CR.
@@ -85,7 +88,60 @@ func (r *CodebaseBranchValidationWebhook) ValidateUpdate(
 		return nil, err
 	}
 
-	return nil, nil
+	oldCodebaseBranch, ok := oldObj.(*v1.CodebaseBranch)
+	if !ok {
+		return nil, nil
+	}
+
+	updatedCodebaseBranch, ok := newObj.(*v1.CodebaseBranch)
+	if !ok {
+		return nil, nil
+	}
+
+	if maps.Equal(oldCodebaseBranch.Spec.Pipelines, updatedCodebaseBranch.Spec.Pipelines) {
+		return nil, nil
+	}
+
+	return r.pipelineWarnings(ctx, updatedCodebaseBranch), nil
+}
+
+// pipelineWarnings warns about pipeline bindings that reference missing Tekton Pipelines.
+// They are warnings rather than denials: the operator defaults the bindings by naming
+// convention, and pipelines are often installed after the branches that use them; the
+// controller reports the bindings in the PipelinesResolved condition once they resolve.
+func (r *CodebaseBranchValidationWebhook) pipelineWarnings(
+	ctx context.Context,
+	branch *v1.CodebaseBranch,
+) admission.Warnings {
+	if len(branch.Spec.Pipelines) == 0 {
+		return nil
+	}
+
+	codebase := &v1.Codebase{}
+	if err := r.client.Get(
+		ctx,
+		client.ObjectKey{Namespace: branch.Namespace, Name: branch.Spec.CodebaseName},
+		codebase,
+	); err != nil || !codebasebranch.UsesTektonPipelines(codebase) {
+		return nil
+	}
+
+	missing, err := codebasebranch.FindMissingPipelines(ctx, r.client, branch.Namespace, branch.Spec.Pipelines)
+	if err != nil {
+		if !errors.Is(err, codebasebranch.ErrPipelineAPIUnavailable) {
+			r.log.Error(err, "Failed to check pipeline bindings", "codebasebranch", branch.Name)
+		}
+
+		return nil
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return admission.Warnings{
+		fmt.Sprintf("Tekton Pipelines referenced by spec.pipelines do not exist: %s", strings.Join(missing, ", ")),
+	}
 }
 
 // ValidateDelete is a webhook for validating the deleting of the CodebaseBranch CR.
@@ -6,6 +6,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	tektonpipelineApi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -256,3 +257,52 @@ func TestCodebaseBranchValidationWebhook_ValidateDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestCodebaseBranchValidationWebhook_WarnsAboutMissingPipelines(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, codebaseApi.AddToScheme(scheme))
+	require.NoError(t, tektonpipelineApi.AddToScheme(scheme))
+
+	codebase := &codebaseApi.Codebase{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-codebase"},
+	}
+	buildPipeline := &tektonpipelineApi.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "github-go-gin-app-build-semver"},
+	}
+
+	branch := func(pipelines map[string]string) *codebaseApi.CodebaseBranch {
+		return &codebaseApi.CodebaseBranch{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-codebase-main"},
+			Spec: codebaseApi.CodebaseBranchSpec{
+				CodebaseName: "test-codebase",
+				BranchName:   "main",
+				Pipelines:    pipelines,
+			},
+		}
+	}
+
+	resolved := map[string]string{"build": "github-go-gin-app-build-semver"}
+	withTypo := map[string]string{"build": "github-go-gin-app-build-semver", "review": "github-go-gin-app-reveiw"}
+
+	r := NewCodebaseBranchValidationWebhook(
+		fake.NewClientBuilder().WithScheme(scheme).WithObjects(codebase, buildPipeline).Build(),
+		logr.Discard(),
+	)
+
+	warnings, err := r.ValidateCreate(t.Context(), branch(resolved))
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	warnings, err = r.ValidateCreate(t.Context(), branch(withTypo))
+	require.NoError(t, err, "missing pipelines must not block the request")
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "review: github-go-gin-app-reveiw")
+
+	warnings, err = r.ValidateUpdate(t.Context(), branch(resolved), branch(withTypo))
+	require.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	warnings, err = r.ValidateUpdate(t.Context(), branch(withTypo), branch(withTypo))
+	require.NoError(t, err)
+	assert.Empty(t, warnings, "unchanged bindings must not be checked again")
+}
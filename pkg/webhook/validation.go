@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	codebaseApi "github.com/epam/edp-codebase-operator/v2/api/v1"
+	"github.com/epam/edp-codebase-operator/v2/pkg/codebasebranch"
 )
 
 var allowedCodebaseSettings = map[string][]string{
@@ -50,6 +51,18 @@ func IsCodebaseValid(codebase *codebaseApi.Codebase) error {
 		return fmt.Errorf("gitUrlPath should not end with space")
 	}
 
+	if _, err := codebasebranch.NewDiscoveryPatterns(codebase); err != nil {
+		return err
+	}
+
+	if _, err := codebasebranch.NewPreviewSettings(codebase); err != nil {
+		return err
+	}
+
+	if _, err := codebasebranch.NewHistoryRetention(codebase); err != nil {
+		return err
+	}
+
 	return nil
 }
 
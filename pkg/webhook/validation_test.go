@@ -113,6 +113,51 @@ func TestIsCodebaseValid(t *testing.T) {
 				require.ErrorContains(t, err, "gitUrlPath should not end with space")
 			},
 		},
+		{
+			name: "should fail on malformed branch discovery pattern",
+			args: args{
+				cr: &codebaseApi.Codebase{
+					ObjectMeta: metaV1.ObjectMeta{
+						Annotations: map[string]string{
+							codebaseApi.BranchDiscoveryIncludeAnnotation: "feature/[",
+						},
+					},
+					Spec: codebaseApi.CodebaseSpec{
+						Lang:     "go",
+						Strategy: "create",
+						Versioning: codebaseApi.Versioning{
+							Type: codebaseApi.VersioningTypDefault,
+						},
+					},
+				},
+			},
+			want: func(t require.TestingT, err error, i ...any) {
+				require.ErrorContains(t, err, codebaseApi.BranchDiscoveryIncludeAnnotation)
+			},
+		},
+		{
+			name: "should fail on malformed preview ttl",
+			args: args{
+				cr: &codebaseApi.Codebase{
+					ObjectMeta: metaV1.ObjectMeta{
+						Annotations: map[string]string{
+							codebaseApi.PreviewEnvironmentsAnnotation: "true",
+							codebaseApi.PreviewTTLAnnotation:          "3 days",
+						},
+					},
+					Spec: codebaseApi.CodebaseSpec{
+						Lang:     "go",
+						Strategy: "create",
+						Versioning: codebaseApi.Versioning{
+							Type: codebaseApi.VersioningTypDefault,
+						},
+					},
+				},
+			},
+			want: func(t require.TestingT, err error, i ...any) {
+				require.ErrorContains(t, err, codebaseApi.PreviewTTLAnnotation)
+			},
+		},
 	}
 
 	for _, tt := range tests {